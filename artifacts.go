@@ -0,0 +1,150 @@
+package migris
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/dialect"
+)
+
+const (
+	directionUp   = "up"
+	directionDown = "down"
+)
+
+// sqlArtifacts records the statements executed by Go migrations in a table.
+type sqlArtifacts struct {
+	dialect   dialect.Dialect
+	table     string
+	clock     Clock
+	encryptor Encryptor
+}
+
+func (m *Migrate) newSQLArtifacts() *sqlArtifacts {
+	if m.sqlArtifacts == "" {
+		return nil
+	}
+	return &sqlArtifacts{dialect: m.dialect, table: m.sqlArtifacts, clock: m.clock, encryptor: m.encryptor}
+}
+
+func (a *sqlArtifacts) createTableSQL() string {
+	if a.dialect == dialect.MySQL {
+		return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY, "+
+			"version_id BIGINT NOT NULL, direction VARCHAR(4) NOT NULL, statements LONGBLOB NOT NULL, "+
+			"applied_at DATETIME NOT NULL)", a.table)
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id BIGSERIAL PRIMARY KEY, "+
+		"version_id BIGINT NOT NULL, direction VARCHAR(4) NOT NULL, statements BYTEA NOT NULL, "+
+		"applied_at TIMESTAMP NOT NULL)", a.table)
+}
+
+func (a *sqlArtifacts) insertSQL() string {
+	if a.dialect == dialect.MySQL {
+		return fmt.Sprintf("INSERT INTO %s (version_id, direction, statements, applied_at) VALUES (?, ?, ?, ?)", a.table)
+	}
+	return fmt.Sprintf("INSERT INTO %s (version_id, direction, statements, applied_at) VALUES ($1, $2, $3, $4)", a.table)
+}
+
+func (a *sqlArtifacts) selectSQL() string {
+	placeholder := "$1"
+	if a.dialect == dialect.MySQL {
+		placeholder = "?"
+	}
+	return fmt.Sprintf("SELECT statements FROM %s WHERE version_id = %s AND direction = '%s' ORDER BY id DESC LIMIT 1",
+		a.table, placeholder, directionUp)
+}
+
+// record stores the statements of a migration run in the artifacts table.
+func (a *sqlArtifacts) record(
+	ctx context.Context,
+	conn migrationConn,
+	version int64,
+	direction string,
+	statements []string,
+) error {
+	compressed, err := compressStatements(statements)
+	if err != nil {
+		return err
+	}
+	if a.encryptor != nil {
+		if compressed, err = a.encryptor.Encrypt(ctx, compressed); err != nil {
+			return fmt.Errorf("failed to encrypt SQL artifacts of version %d: %w", version, err)
+		}
+	}
+	if _, err = conn.ExecContext(ctx, a.createTableSQL()); err != nil {
+		return fmt.Errorf("failed to create SQL artifacts table: %w", err)
+	}
+	if _, err = conn.ExecContext(ctx, a.insertSQL(), version, direction, compressed, a.clock.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record SQL artifacts of version %d: %w", version, err)
+	}
+	return nil
+}
+
+// AppliedSQL returns the SQL executed by the latest up run of the migration with the given
+// version, as recorded with WithSQLArtifacts.
+func (m *Migrate) AppliedSQL(ctx context.Context, version int64) (string, error) {
+	artifacts := m.newSQLArtifacts()
+	if artifacts == nil {
+		return "", errors.New("SQL artifacts are not enabled, please call WithSQLArtifacts option")
+	}
+	if m.db == nil {
+		return "", errors.New("database connection is not set, please call WithDB option")
+	}
+
+	var compressed []byte
+	err := m.db.QueryRowContext(ctx, artifacts.selectSQL(), version).Scan(&compressed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("no SQL artifacts recorded for version %d", version)
+	}
+	if err != nil {
+		return "", err
+	}
+	if artifacts.encryptor != nil {
+		if compressed, err = artifacts.encryptor.Decrypt(ctx, compressed); err != nil {
+			return "", fmt.Errorf("failed to decrypt SQL artifacts of version %d: %w", version, err)
+		}
+	}
+	return decompressStatements(compressed)
+}
+
+// formatArtifactStatement formats an executed statement, appending its arguments as a comment.
+func formatArtifactStatement(query string, args []any) string {
+	if len(args) == 0 {
+		return query
+	}
+	return fmt.Sprintf("%s -- args: %v", query, args)
+}
+
+func compressStatements(statements []string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	for _, statement := range statements {
+		if _, err := fmt.Fprintf(zw, "%s;\n", statement); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressStatements(compressed []byte) (string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	var sb strings.Builder
+	if _, err = io.Copy(&sb, zr); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
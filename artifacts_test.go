@@ -0,0 +1,45 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akfaiz/migris/internal/dialect"
+)
+
+func TestSQLArtifactsStatements(t *testing.T) {
+	compressed, err := compressStatements([]string{
+		"CREATE TABLE users (id BIGINT)",
+		formatArtifactStatement("INSERT INTO users (id) VALUES ($1)", []any{1}),
+	})
+	require.NoError(t, err)
+
+	statements, err := decompressStatements(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE users (id BIGINT);\n"+
+		"INSERT INTO users (id) VALUES ($1) -- args: [1];\n", statements)
+}
+
+func TestSQLArtifactsQueries(t *testing.T) {
+	pg := &sqlArtifacts{dialect: dialect.Postgres, table: "sql_artifacts"}
+	assert.Contains(t, pg.createTableSQL(), "statements BYTEA NOT NULL")
+	assert.Equal(t, "INSERT INTO sql_artifacts (version_id, direction, statements, applied_at) VALUES ($1, $2, $3, $4)",
+		pg.insertSQL())
+	assert.Equal(t, "SELECT statements FROM sql_artifacts WHERE version_id = $1 AND direction = 'up' ORDER BY id DESC LIMIT 1",
+		pg.selectSQL())
+
+	mysql := &sqlArtifacts{dialect: dialect.MySQL, table: "sql_artifacts"}
+	assert.Contains(t, mysql.createTableSQL(), "statements LONGBLOB NOT NULL")
+	assert.Equal(t, "INSERT INTO sql_artifacts (version_id, direction, statements, applied_at) VALUES (?, ?, ?, ?)",
+		mysql.insertSQL())
+}
+
+func TestAppliedSQLRequiresOption(t *testing.T) {
+	m, err := New("pgx")
+	require.NoError(t, err)
+
+	_, err = m.AppliedSQL(t.Context(), 1)
+	require.Error(t, err)
+}
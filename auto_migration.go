@@ -0,0 +1,163 @@
+package migris
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/internal/parser"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/akfaiz/migris/schema"
+	"github.com/akfaiz/migris/schema/diff"
+)
+
+// WithDeclaredSchema sets the schema the database should have, declared with the schema builder
+// as if every table were created from scratch. CreateAuto generates the migrations that converge
+// the database to it.
+//
+// Example:
+//
+//	migris.WithDeclaredSchema(func(c schema.Context) error {
+//	    return schema.Create(c, "users", func(table *schema.Blueprint) {
+//	        table.ID()
+//	        table.String("email").Unique()
+//	    })
+//	})
+func WithDeclaredSchema(fn func(c schema.Context) error) Option {
+	return func(m *Migrate) {
+		m.declaredSchema = fn
+	}
+}
+
+// CreateAuto creates a SQL migration with the changes that converge the database to the schema
+// declared with WithDeclaredSchema, computed with the schema/diff package. The up file applies
+// the changes and the down file reverts them. The version table and the other tables maintained
+// by migris are ignored. When the database already has the declared schema, no file is created.
+//
+// The generated statements should be reviewed before the migration is run: a renamed table or
+// column is dropped and added again, which loses its data.
+func (m *Migrate) CreateAuto(ctx context.Context, name string) error {
+	if m.db == nil {
+		return errors.New("database connection is not set, please call WithDB option")
+	}
+	if m.declaredSchema == nil {
+		return errors.New("declared schema is not set, please call WithDeclaredSchema option")
+	}
+	if err := m.checkCreateName(name); err != nil {
+		return err
+	}
+
+	declared, err := diff.Declare(m.dialect.String(), m.declaredSchema)
+	if err != nil {
+		return fmt.Errorf("failed to declare schema: %w", err)
+	}
+	current, err := m.takeSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = createAuto(ctx, m.migrationDir, m.NextVersion(), name, m.dialect.String(), current, declared)
+	return err
+}
+
+// takeSnapshot reads the snapshot of the current schema, without the tables maintained by migris.
+func (m *Migrate) takeSnapshot(ctx context.Context) (*diff.Snapshot, error) {
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	snapshot, err := diff.Take(schema.NewContext(ctx, tx), m.managedTables()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	return snapshot, nil
+}
+
+// createAuto writes the up and down SQL files of the migration of the given version converging
+// current to declared and returns the path of the up file. It returns an empty path when there is
+// nothing to change.
+func createAuto(
+	ctx context.Context,
+	dir, version, name string,
+	dialect string,
+	current, declared *diff.Snapshot,
+) (string, error) {
+	up, err := diffSQL(ctx, dialect, current, declared)
+	if err != nil {
+		return "", err
+	}
+	if len(up) == 0 {
+		logger.InfoMsg(logger.MessageSchemaUpToDate)
+		return "", nil
+	}
+	down, err := diffSQL(ctx, dialect, declared, current)
+	if err != nil {
+		return "", err
+	}
+
+	stem := fmt.Sprintf("%s_%s", version, parser.SnakeCase(name))
+	for _, filename := range []string{stem + ".go", stem + ".sql", stem + ".up.sql"} {
+		exists, existsErr := pathutil.ExistsFold(dir, filename)
+		if existsErr != nil {
+			return "", fmt.Errorf("failed to create migration file: %w", existsErr)
+		}
+		if exists {
+			return "", fmt.Errorf("failed to create migration file: %s already exists", pathutil.Join(dir, filename))
+		}
+	}
+
+	upPath := pathutil.Join(dir, stem+".up.sql")
+	if err = writeStatements(upPath, up); err != nil {
+		return "", err
+	}
+	if err = writeStatements(pathutil.Join(dir, stem+".down.sql"), down); err != nil {
+		return "", err
+	}
+	return upPath, nil
+}
+
+// diffSQL compiles the changes converging from to to for the dialect.
+func diffSQL(ctx context.Context, dialect string, from, to *diff.Snapshot) ([]string, error) {
+	changes, err := diff.Compare(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare schemas: %w", err)
+	}
+	if changes.Empty() {
+		return nil, nil
+	}
+	statements, err := changes.SQL(ctx, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema changes: %w", err)
+	}
+	return statements, nil
+}
+
+// writeStatements writes the statements to a new SQL file, each terminated by a semicolon.
+func writeStatements(path string, statements []string) error {
+	var b strings.Builder
+	for _, statement := range statements {
+		b.WriteString(strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+		b.WriteString(";\n")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create migration file: %w", err)
+	}
+	_, err = f.WriteString(b.String())
+	// A failed close can lose the written statements, e.g. on a full disk.
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write migration file: %w", err)
+	}
+	logger.InfoMsg(logger.MessageCreatedFile, path)
+	return nil
+}
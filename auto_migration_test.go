@@ -0,0 +1,59 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/akfaiz/migris/schema/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAuto(t *testing.T) {
+	current, err := diff.Declare("pgx", func(c schema.Context) error {
+		return schema.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name")
+		})
+	})
+	require.NoError(t, err)
+	declared, err := diff.Declare("pgx", func(c schema.Context) error {
+		return schema.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name")
+			table.String("email").Unique()
+		})
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	version := nextVersion(fixedClock())
+	path, err := createAuto(t.Context(), dir, version, "add_email_to_users", "pgx", current, declared)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250904164848_add_email_to_users.up.sql"), path)
+
+	up, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE users ADD COLUMN email VARCHAR(255) NOT NULL;\n"+
+		"ALTER TABLE users ADD CONSTRAINT uk_users_email UNIQUE (email);\n", string(up))
+	down, err := os.ReadFile(filepath.Join(dir, "20250904164848_add_email_to_users.down.sql"))
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE users DROP CONSTRAINT uk_users_email;\n"+
+		"ALTER TABLE users DROP COLUMN email;\n", string(down))
+
+	_, err = createAuto(t.Context(), dir, version, "add_email_to_users", "pgx", current, declared)
+	require.Error(t, err, "creating the same migration twice should fail")
+
+	path, err = createAuto(t.Context(), t.TempDir(), version, "noop", "pgx", declared, declared)
+	require.NoError(t, err)
+	assert.Empty(t, path, "no migration is created for equal schemas")
+}
+
+func TestMigrate_CreateAutoRequiresDB(t *testing.T) {
+	m, err := New("pgx")
+	require.NoError(t, err)
+	require.EqualError(t, m.CreateAuto(t.Context(), "sync"),
+		"database connection is not set, please call WithDB option")
+}
@@ -0,0 +1,365 @@
+package migris
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/akfaiz/migris/schema"
+	"github.com/go-sql-driver/mysql"
+	"github.com/pressly/goose/v3"
+)
+
+// defaultCanarySampleRows is the number of rows Canary copies when the config does not set one.
+const defaultCanarySampleRows = 10000
+
+// CanaryConfig configures Canary.
+type CanaryConfig struct {
+	Table      string // Table the pending migrations change
+	SampleRows int64  // Number of rows copied into the sample table, defaults to 10000
+}
+
+// CanaryReport is the result of Canary.
+type CanaryReport struct {
+	Table      string            `json:"table"`
+	TableRows  int64             `json:"table_rows"` // estimated from the statistics of the database, 0 if unknown
+	SampleRows int64             `json:"sample_rows"`
+	Statements []CanaryStatement `json:"statements"`
+}
+
+// CanaryStatement is a pending statement changing the table, as it ran against the sample.
+type CanaryStatement struct {
+	Migration string        `json:"migration"`
+	SQL       string        `json:"sql"`
+	Duration  time.Duration `json:"duration"` // duration against the sample
+	Estimate  time.Duration `json:"estimate"` // duration extrapolated to the rows of the table
+	Lock      string        `json:"lock"`     // strongest lock the statement took on the table
+	Error     string        `json:"error,omitempty"`
+}
+
+// pendingStatement is a statement of a pending up migration.
+type pendingStatement struct {
+	version   int64
+	migration string
+	sql       string
+}
+
+// canaryUnsafePatterns match the statements that would move the sample table out of the way of
+// its cleanup. They are not run.
+var canaryUnsafePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bRENAME\s+(TO|AS)\b`),
+	regexp.MustCompile(`(?i)\bSET\s+SCHEMA\b`),
+}
+
+// mysqlLockClause matches an explicit LOCK clause of a MySQL ALTER TABLE or index statement.
+var mysqlLockClause = regexp.MustCompile(`(?i)\bLOCK\s*=`)
+
+// Canary predicts how long the pending migrations take on a large table and which locks they
+// take, before they run against it. It copies a sample of the rows of the table into a new table
+// named after it with a _canary suffix, runs the pending statements that alter the table or
+// create or drop its indexes against the sample, and extrapolates their durations linearly to the
+// number of rows the database estimates for the table. The sample table is dropped afterwards.
+//
+// On PostgreSQL every statement runs in its own transaction, and the lock is the strongest one
+// pg_locks shows on the sample table before it commits; CONCURRENTLY statements run outside of a
+// transaction and take a SHARE UPDATE EXCLUSIVE lock. On MySQL every statement is first tried with
+// LOCK=NONE, then with LOCK=SHARED, and the lock is the weakest one the server accepts.
+//
+// The statements of Go migrations are captured like in dry-run mode and SQL migrations are split
+// at semicolons. Statements renaming the table or moving it to another schema are not run, and a
+// failing statement is reported without stopping the canary. The sample is far smaller and
+// colder than the table, so treat the estimates as a lower bound.
+func (m *Migrate) Canary(ctx context.Context, cfg CanaryConfig) (*CanaryReport, error) {
+	if m.db == nil {
+		return nil, errors.New("database connection is not set, please call WithDB option")
+	}
+	if cfg.Table == "" {
+		return nil, errors.New("table name cannot be empty")
+	}
+	if cfg.SampleRows <= 0 {
+		cfg.SampleRows = defaultCanarySampleRows
+	}
+
+	provider, err := m.newProvider()
+	if err != nil {
+		return nil, err
+	}
+	currentVersion, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current database version: %w", err)
+	}
+	pending, err := m.pendingStatements(ctx, provider.ListSources(), currentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CanaryReport{Table: cfg.Table}
+	if report.TableRows, err = m.estimateRows(ctx, cfg.Table); err != nil {
+		return nil, err
+	}
+	sample := cfg.Table + "_canary"
+	var statements []pendingStatement
+	for _, statement := range pending {
+		if rewritten, ok := rewriteForCanary(statement.sql, cfg.Table, sample); ok {
+			statements = append(statements, pendingStatement{migration: statement.migration, sql: rewritten})
+		}
+	}
+	if len(statements) == 0 {
+		return report, nil
+	}
+
+	if report.SampleRows, err = m.copySample(ctx, cfg.Table, sample, cfg.SampleRows); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = m.db.ExecContext(context.WithoutCancel(ctx), "DROP TABLE IF EXISTS "+sample)
+	}()
+
+	for _, statement := range statements {
+		result := CanaryStatement{Migration: statement.migration, SQL: statement.sql}
+		var err error
+		if m.dialect == dialect.MySQL {
+			result.Duration, result.Lock, err = m.runMySQLCanary(ctx, statement.sql)
+		} else {
+			result.Duration, result.Lock, err = m.runPostgresCanary(ctx, statement.sql, sample)
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		result.Estimate = extrapolate(result.Duration, report.SampleRows, report.TableRows)
+		report.Statements = append(report.Statements, result)
+	}
+	return report, nil
+}
+
+// pendingStatements returns the statements of the pending up migrations, in version order.
+func (m *Migrate) pendingStatements(
+	ctx context.Context,
+	sources []*goose.Source,
+	currentVersion int64,
+) ([]pendingStatement, error) {
+	var statements []pendingStatement
+	for _, migration := range m.determineMigrationsToApply(goose.MaxVersion, currentVersion) {
+		fn := migration.upFunc(m.dependencies)
+		if fn == nil {
+			continue
+		}
+		dryRunCtx := schema.NewDryRunContext(ctx)
+		if err := fn(dryRunCtx); err != nil {
+			return nil, fmt.Errorf("failed to render migration %s: %w", migration.source, err)
+		}
+		for _, statement := range dryRunCtx.GetCapturedSQL() {
+			statements = append(statements, pendingStatement{
+				version:   migration.version,
+				migration: pathutil.Base(migration.source),
+				sql:       statement,
+			})
+		}
+	}
+
+	fsys := m.migrationsFS()
+	for _, source := range sources {
+		if source.Type != goose.TypeSQL || source.Version <= currentVersion {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, source.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, statement := range upStatements(content) {
+			statements = append(statements, pendingStatement{
+				version:   source.Version,
+				migration: pathutil.Base(source.Path),
+				sql:       statement,
+			})
+		}
+	}
+
+	slices.SortStableFunc(statements, func(a, b pendingStatement) int {
+		return cmp.Compare(a.version, b.version)
+	})
+	return statements, nil
+}
+
+// rewriteForCanary returns the statement with the table replaced by the sample table, and
+// whether the statement changes the table and can run against the sample.
+func rewriteForCanary(statement, table, sample string) (string, bool) {
+	for _, pattern := range canaryUnsafePatterns {
+		if pattern.MatchString(statement) {
+			return "", false
+		}
+	}
+	name := regexp.QuoteMeta(table)
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`(?is)^(ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?)` + name + `(\s|$)`),
+		regexp.MustCompile(`(?is)^(CREATE\s+(?:UNIQUE\s+|FULLTEXT\s+|SPATIAL\s+)?INDEX\s.*?\bON\s+(?:ONLY\s+)?)` +
+			name + `(\s|\(|$)`),
+		regexp.MustCompile(`(?is)^(DROP\s+INDEX\s+\S+\s+ON\s+)` + name + `(\s|$)`),
+	}
+	statement = strings.TrimSpace(statement)
+	for _, pattern := range patterns {
+		if loc := pattern.FindStringSubmatchIndex(statement); loc != nil {
+			return statement[:loc[3]] + sample + statement[loc[4]:], true
+		}
+	}
+	return "", false
+}
+
+// estimateRows returns the number of rows of the table the statistics of the database estimate,
+// or 0 if the table has not been analyzed yet.
+func (m *Migrate) estimateRows(ctx context.Context, table string) (int64, error) {
+	var query string
+	switch m.dialect {
+	case dialect.Postgres:
+		query = "SELECT reltuples::bigint FROM pg_class WHERE oid = $1::regclass"
+	case dialect.MySQL:
+		query = "SELECT COALESCE(TABLE_ROWS, 0) FROM information_schema.TABLES " +
+			"WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+	case dialect.Unknown:
+		return 0, errors.New("unknown database dialect")
+	default:
+		return 0, errors.New("unknown database dialect")
+	}
+	var rows int64
+	if err := m.db.QueryRowContext(ctx, query, table).Scan(&rows); err != nil {
+		return 0, fmt.Errorf("cannot estimate the rows of table %s: %w", table, err)
+	}
+	return max(rows, 0), nil
+}
+
+// copySample creates the sample table with the structure of the table and copies up to limit rows
+// into it. It returns the number of copied rows.
+func (m *Migrate) copySample(ctx context.Context, table, sample string, limit int64) (int64, error) {
+	create := fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", sample, table)
+	insert := fmt.Sprintf("INSERT INTO %s OVERRIDING SYSTEM VALUE SELECT * FROM %s LIMIT %d", sample, table, limit)
+	if m.dialect == dialect.MySQL {
+		create = fmt.Sprintf("CREATE TABLE %s LIKE %s", sample, table)
+		insert = fmt.Sprintf("INSERT INTO %s SELECT * FROM %s LIMIT %d", sample, table, limit)
+	}
+	if _, err := m.db.ExecContext(ctx, create); err != nil {
+		return 0, fmt.Errorf("cannot create sample table %s: %w", sample, err)
+	}
+	result, err := m.db.ExecContext(ctx, insert)
+	if err == nil {
+		var copied int64
+		if copied, err = result.RowsAffected(); err == nil {
+			return copied, nil
+		}
+	}
+	_, _ = m.db.ExecContext(context.WithoutCancel(ctx), "DROP TABLE IF EXISTS "+sample)
+	return 0, fmt.Errorf("cannot copy the rows of table %s: %w", table, err)
+}
+
+// runPostgresCanary runs the statement against the sample table in a transaction and returns its
+// duration and the strongest lock it took on the sample table.
+func (m *Migrate) runPostgresCanary(ctx context.Context, statement, sample string) (time.Duration, string, error) {
+	if isNonTransactional(statement) {
+		start := time.Now()
+		_, err := m.db.ExecContext(ctx, statement)
+		return time.Since(start), "ShareUpdateExclusiveLock", err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	start := time.Now()
+	if _, err = tx.ExecContext(ctx, statement); err != nil {
+		return time.Since(start), "", err
+	}
+	duration := time.Since(start)
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT mode FROM pg_locks WHERE pid = pg_backend_pid() AND granted AND relation = $1::regclass", sample)
+	if err != nil {
+		return duration, "", err
+	}
+	var modes []string
+	for rows.Next() {
+		var mode string
+		if err = rows.Scan(&mode); err != nil {
+			_ = rows.Close()
+			return duration, "", err
+		}
+		modes = append(modes, mode)
+	}
+	if err = rows.Close(); err != nil {
+		return duration, "", err
+	}
+	return duration, strongestLock(modes), tx.Commit()
+}
+
+// postgresLockModes are the table lock modes of PostgreSQL, from the weakest to the strongest.
+var postgresLockModes = []string{
+	"AccessShareLock",
+	"RowShareLock",
+	"RowExclusiveLock",
+	"ShareUpdateExclusiveLock",
+	"ShareLock",
+	"ShareRowExclusiveLock",
+	"ExclusiveLock",
+	"AccessExclusiveLock",
+}
+
+// strongestLock returns the strongest of the PostgreSQL lock modes.
+func strongestLock(modes []string) string {
+	strongest := ""
+	for _, mode := range modes {
+		if slices.Index(postgresLockModes, mode) > slices.Index(postgresLockModes, strongest) {
+			strongest = mode
+		}
+	}
+	return strongest
+}
+
+// runMySQLCanary runs the statement against the sample table with the weakest lock the server
+// accepts for it, and returns its duration and the lock.
+func (m *Migrate) runMySQLCanary(ctx context.Context, statement string) (time.Duration, string, error) {
+	if mysqlLockClause.MatchString(statement) {
+		start := time.Now()
+		_, err := m.db.ExecContext(ctx, statement)
+		return time.Since(start), "", err
+	}
+	separator := " "
+	if strings.HasPrefix(strings.ToUpper(statement), "ALTER") {
+		separator = ", "
+	}
+	for _, lock := range []string{"NONE", "SHARED", "EXCLUSIVE"} {
+		query := statement + separator + "LOCK=" + lock
+		start := time.Now()
+		_, err := m.db.ExecContext(ctx, query)
+		if err == nil || !isUnsupportedLock(err) {
+			return time.Since(start), lock, err
+		}
+	}
+	return 0, "", errors.New("no lock level is supported")
+}
+
+// isUnsupportedLock reports whether MySQL rejected the LOCK clause of the statement.
+func isUnsupportedLock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		// ER_ALTER_OPERATION_NOT_SUPPORTED and ER_ALTER_OPERATION_NOT_SUPPORTED_REASON
+		return mysqlErr.Number == 1845 || mysqlErr.Number == 1846
+	}
+	return false
+}
+
+// extrapolate scales the duration of a statement against the sample to the rows of the table.
+func extrapolate(duration time.Duration, sampleRows, tableRows int64) time.Duration {
+	if sampleRows <= 0 || tableRows <= 0 {
+		return 0
+	}
+	return time.Duration(float64(duration) * float64(tableRows) / float64(sampleRows))
+}
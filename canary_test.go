@@ -0,0 +1,123 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteForCanary(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      string
+		wantOK    bool
+	}{
+		{
+			name:      "alter table",
+			statement: "ALTER TABLE orders ADD COLUMN note TEXT NULL",
+			want:      "ALTER TABLE orders_canary ADD COLUMN note TEXT NULL",
+			wantOK:    true,
+		},
+		{
+			name:      "alter table if exists only",
+			statement: "alter table if exists only orders drop column note",
+			want:      "alter table if exists only orders_canary drop column note",
+			wantOK:    true,
+		},
+		{
+			name:      "create index",
+			statement: "CREATE UNIQUE INDEX CONCURRENTLY uq_orders_number ON orders (number)",
+			want:      "CREATE UNIQUE INDEX CONCURRENTLY uq_orders_number ON orders_canary (number)",
+			wantOK:    true,
+		},
+		{
+			name:      "create index without a space before the columns",
+			statement: "CREATE INDEX idx_orders_customer_id ON orders(customer_id)",
+			want:      "CREATE INDEX idx_orders_customer_id ON orders_canary(customer_id)",
+			wantOK:    true,
+		},
+		{
+			name:      "mysql drop index",
+			statement: "DROP INDEX idx_orders_customer_id ON orders",
+			want:      "DROP INDEX idx_orders_customer_id ON orders_canary",
+			wantOK:    true,
+		},
+		{name: "other table", statement: "ALTER TABLE orders_archive ADD COLUMN note TEXT"},
+		{name: "index of another table", statement: "CREATE INDEX idx_users_email ON users (email)"},
+		{name: "rename", statement: "ALTER TABLE orders RENAME TO purchases"},
+		{name: "set schema", statement: "ALTER TABLE orders SET SCHEMA archive"},
+		{name: "data change", statement: "UPDATE orders SET note = ''"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rewriteForCanary(tt.statement, "orders", "orders_canary")
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMigrate_PendingStatements(t *testing.T) {
+	addNote, err := newMigration("20250102000000_add_note.go", func(c schema.Context) error {
+		return schema.Table(c, "orders", func(table *schema.Blueprint) {
+			table.Text("note").Nullable()
+		})
+	}, nil)
+	require.NoError(t, err)
+	applied, err := newMigration("20250101000000_create_orders.go", nil, nil)
+	require.NoError(t, err)
+	withRegisteredMigrations(t, applied, addNote)
+
+	fsys := fstest.MapFS{
+		"20250103000000_index_orders.sql": {Data: []byte(
+			"-- +goose Up\nCREATE INDEX idx_orders_note ON orders (note);\n" +
+				"-- +goose Down\nDROP INDEX idx_orders_note;\n")},
+	}
+	m, err := New("pgx", WithFS(fsys))
+	require.NoError(t, err)
+
+	sources := []*goose.Source{
+		{Type: goose.TypeGo, Version: 20250102000000},
+		{Type: goose.TypeSQL, Path: "20250103000000_index_orders.sql", Version: 20250103000000},
+	}
+	got, err := m.pendingStatements(t.Context(), sources, 20250101000000)
+	require.NoError(t, err)
+	assert.Equal(t, []pendingStatement{
+		{
+			version:   20250102000000,
+			migration: "20250102000000_add_note.go",
+			sql:       "ALTER TABLE orders ADD COLUMN note TEXT NULL",
+		},
+		{
+			version:   20250103000000,
+			migration: "20250103000000_index_orders.sql",
+			sql:       "CREATE INDEX idx_orders_note ON orders (note)",
+		},
+	}, got)
+}
+
+func TestStrongestLock(t *testing.T) {
+	assert.Equal(t, "AccessExclusiveLock",
+		strongestLock([]string{"ShareLock", "AccessExclusiveLock", "AccessShareLock"}))
+	assert.Equal(t, "ShareUpdateExclusiveLock", strongestLock([]string{"ShareUpdateExclusiveLock"}))
+	assert.Empty(t, strongestLock(nil))
+}
+
+func TestExtrapolate(t *testing.T) {
+	assert.Equal(t, 50*time.Second, extrapolate(100*time.Millisecond, 10000, 5000000))
+	assert.Zero(t, extrapolate(100*time.Millisecond, 10000, 0), "unknown table rows")
+	assert.Zero(t, extrapolate(100*time.Millisecond, 0, 5000000), "empty sample")
+}
+
+func TestMigrate_Canary(t *testing.T) {
+	m, err := New("pgx")
+	require.NoError(t, err)
+	_, err = m.Canary(t.Context(), CanaryConfig{Table: "orders"})
+	require.EqualError(t, err, "database connection is not set, please call WithDB option")
+}
@@ -0,0 +1,209 @@
+package migris
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/database"
+)
+
+const checksumColumn = "checksum"
+
+// ErrChecksumMismatch is returned by Validate when the file of an applied migration has changed
+// since the migration ran.
+var ErrChecksumMismatch = errors.New("migration changed after it was applied")
+
+// checksumStore wraps the goose store of the version table and records the checksum of each
+// applied migration in an additional checksum column.
+type checksumStore struct {
+	database.Store
+
+	dialect   dialect.Dialect
+	checksums map[int64]string
+}
+
+var _ database.StoreExtender = (*checksumStore)(nil)
+
+// TableExists reports whether the version table exists, adding the checksum column to a version
+// table created by an earlier release.
+func (s *checksumStore) TableExists(ctx context.Context, db database.DBTxConn) (bool, error) {
+	extender, ok := s.Store.(database.StoreExtender)
+	if !ok {
+		return false, errors.ErrUnsupported
+	}
+	exists, err := extender.TableExists(ctx, db)
+	if err != nil || !exists {
+		return exists, err
+	}
+	return true, s.ensureChecksumColumn(ctx, db)
+}
+
+func (s *checksumStore) CreateVersionTable(ctx context.Context, db database.DBTxConn) error {
+	if err := s.Store.CreateVersionTable(ctx, db); err != nil {
+		return err
+	}
+	return s.ensureChecksumColumn(ctx, db)
+}
+
+func (s *checksumStore) Insert(ctx context.Context, db database.DBTxConn, req database.InsertRequest) error {
+	if err := s.Store.Insert(ctx, db, req); err != nil {
+		return err
+	}
+	checksum, ok := s.checksums[req.Version]
+	if !ok {
+		return nil
+	}
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE version_id = %s",
+		s.Tablename(), checksumColumn, s.placeholder(1), s.placeholder(2))
+	if _, err := db.ExecContext(ctx, query, checksum, req.Version); err != nil {
+		return fmt.Errorf("failed to record checksum of version %d: %w", req.Version, err)
+	}
+	return nil
+}
+
+func (s *checksumStore) ensureChecksumColumn(ctx context.Context, db database.DBTxConn) error {
+	query, args := s.checksumColumnQuery()
+	var count int
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check checksum column: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	query = fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s VARCHAR(64) NULL", s.Tablename(), checksumColumn)
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to add checksum column: %w", err)
+	}
+	return nil
+}
+
+// checksumColumnQuery returns the query counting the checksum column of the version table and its
+// arguments. A version table qualified by a schema, e.g. tenant.schema_migrations, is looked up in
+// that schema, otherwise in the current one.
+func (s *checksumStore) checksumColumnQuery() (string, []any) {
+	tableSchema, table, qualified := strings.Cut(s.Tablename(), ".")
+	if !qualified {
+		tableSchema, table = "", tableSchema
+	}
+	args := []any{table, checksumColumn}
+	schemaCondition := "table_schema = current_schema()"
+	if s.dialect == dialect.MySQL {
+		schemaCondition = "table_schema = DATABASE()"
+	}
+	if tableSchema != "" {
+		args = append(args, tableSchema)
+		schemaCondition = "table_schema = " + s.placeholder(3)
+	}
+	query := "SELECT COUNT(*) FROM information_schema.columns WHERE table_name = " + s.placeholder(1) +
+		" AND column_name = " + s.placeholder(2) + " AND " + schemaCondition
+	return query, args
+}
+
+func (s *checksumStore) placeholder(n int) string {
+	if s.dialect == dialect.MySQL {
+		return "?"
+	}
+	return fmt.Sprintf("$%d", n)
+}
+
+// migrationChecksums returns the SHA-256 checksum of the file of each migration. SQL migrations
+// are read from the migrations file system; Go migrations from the migrations directory, or else
+// from the path they were compiled from. Migrations whose file cannot be read have no checksum.
+func migrationChecksums(sources []*goose.Source, fsys fs.FS) map[int64]string {
+	checksums := make(map[int64]string, len(sources))
+	for _, source := range sources {
+		var content []byte
+		var err error
+		switch source.Type {
+		case goose.TypeSQL:
+			content, err = fs.ReadFile(fsys, source.Path)
+		case goose.TypeGo:
+			path := registeredVersions[source.Version]
+			content, err = fs.ReadFile(fsys, pathutil.Base(path))
+			if err != nil {
+				content, err = os.ReadFile(path)
+			}
+		}
+		if err != nil || content == nil {
+			continue
+		}
+		sum := sha256.Sum256(content)
+		checksums[source.Version] = hex.EncodeToString(sum[:])
+	}
+	return checksums
+}
+
+// Validate checks that the files of the applied migrations have not changed since they ran, by
+// comparing their checksums with the ones recorded in the version table. Every changed migration
+// is reported with an error wrapping ErrChecksumMismatch.
+//
+// Migrations applied before checksums were recorded, and migrations whose file can no longer be
+// read, are skipped.
+func (m *Migrate) Validate(ctx context.Context) error {
+	provider, err := m.newProvider()
+	if err != nil {
+		return err
+	}
+	checksums := migrationChecksums(provider.ListSources(), m.migrationsFS())
+
+	recorded, err := m.recordedChecksums(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, version := range slices.Sorted(maps.Keys(recorded)) {
+		if current, ok := checksums[version]; ok && current != recorded[version] {
+			errs = append(errs, fmt.Errorf("%w: version %d", ErrChecksumMismatch, version))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recordedChecksums returns the checksums recorded in the version table by version. Migrations
+// applied before checksums were recorded are not included.
+func (m *Migrate) recordedChecksums(ctx context.Context) (map[int64]string, error) {
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	c := schema.NewContext(ctx, tx)
+	exists, err := schema.HasColumn(c, m.tableName, checksumColumn)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	rows, err := c.Query(fmt.Sprintf("SELECT version_id, %s FROM %s WHERE %s IS NOT NULL",
+		checksumColumn, m.tableName, checksumColumn))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checksums := make(map[int64]string)
+	for rows.Next() {
+		var version int64
+		var checksum string
+		if err = rows.Scan(&version, &checksum); err != nil {
+			return nil, err
+		}
+		checksums[version] = checksum
+	}
+	return checksums, rows.Err()
+}
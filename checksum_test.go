@@ -0,0 +1,135 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"testing/fstest"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/sqlfile"
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationChecksums(t *testing.T) {
+	checksum := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+	fsys := fstest.MapFS{
+		"20250101000000_create_users.sql": {Data: []byte("-- +goose Up\nCREATE TABLE users (id bigint);\n")},
+		"20250102000000_add_posts.go":     {Data: []byte("package migrations\n")},
+	}
+	registeredVersions[20250102000000] = "/build/migrations/20250102000000_add_posts.go"
+	registeredVersions[20250103000000] = "/build/migrations/20250103000000_missing.go"
+	t.Cleanup(func() {
+		delete(registeredVersions, 20250102000000)
+		delete(registeredVersions, 20250103000000)
+	})
+
+	checksums := migrationChecksums([]*goose.Source{
+		{Type: goose.TypeSQL, Path: "20250101000000_create_users.sql", Version: 20250101000000},
+		{Type: goose.TypeGo, Version: 20250102000000},
+		{Type: goose.TypeGo, Version: 20250103000000},
+	}, fsys)
+
+	assert.Equal(t, map[int64]string{
+		20250101000000: checksum("-- +goose Up\nCREATE TABLE users (id bigint);\n"),
+		20250102000000: checksum("package migrations\n"),
+	}, checksums)
+}
+
+func TestMigrationChecksums_CRLF(t *testing.T) {
+	sources := []*goose.Source{{Type: goose.TypeSQL, Path: "20250101000000_create_users.sql", Version: 20250101000000}}
+	lf := migrationChecksums(sources, sqlfile.New(fstest.MapFS{
+		"20250101000000_create_users.up.sql": {Data: []byte("CREATE TABLE users (id bigint);\n")},
+	}))
+	crlf := migrationChecksums(sources, sqlfile.New(fstest.MapFS{
+		"20250101000000_create_users.up.sql": {Data: []byte("CREATE TABLE users (id bigint);\r\n")},
+	}))
+	assert.Equal(t, lf, crlf, "a migration checked out with CRLF line endings has the same checksum")
+}
+
+func TestMigrationChecksums_EnumFrom(t *testing.T) {
+	content := "package migrations\n\n" +
+		"func up(c schema.Context) error {\n" +
+		"\treturn schema.Table(c, \"orders\", func(table *schema.Blueprint) {\n" +
+		"\t\ttable.EnumFrom(\"status\", \"checksum_order_status\").Change()\n" +
+		"\t})\n" +
+		"}\n"
+	fsys := fstest.MapFS{"20250101000000_change_status.go": {Data: []byte(content)}}
+	registeredVersions[20250101000000] = "/build/migrations/20250101000000_change_status.go"
+	t.Cleanup(func() { delete(registeredVersions, 20250101000000) })
+	sources := []*goose.Source{{Type: goose.TypeGo, Version: 20250101000000}}
+
+	sum := sha256.Sum256([]byte(content))
+	schema.RegisterEnum("checksum_order_status", "pending", "paid")
+	before := migrationChecksums(sources, fsys)
+	assert.Equal(t, map[int64]string{20250101000000: hex.EncodeToString(sum[:])}, before)
+
+	// A new value is registered before the migration changing the column is added, which must
+	// not change the checksum of the applied migrations using the enum.
+	schema.RegisterEnum("checksum_order_status", "pending", "paid", "shipped")
+	assert.Equal(t, before, migrationChecksums(sources, fsys))
+}
+
+func TestChecksumStore_ChecksumColumnQuery(t *testing.T) {
+	newStore := func(t *testing.T, val dialect.Dialect, table string) *checksumStore {
+		t.Helper()
+		store, err := database.NewStore(val.GooseDialect(), table)
+		require.NoError(t, err)
+		return &checksumStore{Store: store, dialect: val}
+	}
+
+	tests := []struct {
+		name      string
+		dialect   dialect.Dialect
+		table     string
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:    "postgres",
+			dialect: dialect.Postgres,
+			table:   "schema_migrations",
+			wantQuery: "SELECT COUNT(*) FROM information_schema.columns " +
+				"WHERE table_name = $1 AND column_name = $2 AND table_schema = current_schema()",
+			wantArgs: []any{"schema_migrations", "checksum"},
+		},
+		{
+			name:    "postgres qualified",
+			dialect: dialect.Postgres,
+			table:   "tenant.schema_migrations",
+			wantQuery: "SELECT COUNT(*) FROM information_schema.columns " +
+				"WHERE table_name = $1 AND column_name = $2 AND table_schema = $3",
+			wantArgs: []any{"schema_migrations", "checksum", "tenant"},
+		},
+		{
+			name:    "mysql",
+			dialect: dialect.MySQL,
+			table:   "schema_migrations",
+			wantQuery: "SELECT COUNT(*) FROM information_schema.columns " +
+				"WHERE table_name = ? AND column_name = ? AND table_schema = DATABASE()",
+			wantArgs: []any{"schema_migrations", "checksum"},
+		},
+		{
+			name:    "mysql qualified",
+			dialect: dialect.MySQL,
+			table:   "tenant.schema_migrations",
+			wantQuery: "SELECT COUNT(*) FROM information_schema.columns " +
+				"WHERE table_name = ? AND column_name = ? AND table_schema = ?",
+			wantArgs: []any{"schema_migrations", "checksum", "tenant"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := newStore(t, tt.dialect, tt.table).checksumColumnQuery()
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
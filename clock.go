@@ -0,0 +1,24 @@
+package migris
+
+import "time"
+
+// Clock is the time source used by the migrator, e.g. for the version of newly created
+// migration files and for measuring execution durations. Tests can provide a fixed clock to
+// make the output deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to allow the use of ordinary functions as a Clock.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
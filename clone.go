@@ -0,0 +1,57 @@
+package migris
+
+import (
+	"context"
+	"errors"
+
+	"github.com/akfaiz/migris/schema"
+)
+
+// CloneSchema creates the target schema with a copy of every table of the source schema, for a
+// blue-green deployment of risky changes: clone the live schema, run the pending migrations
+// against the clone, e.g. through a connection whose search_path is the clone, then promote it
+// with SwapSchemas. Without data only the rows of the version table are copied, so only the
+// pending migrations run against the clone. The clone is created in a single transaction.
+//
+// It is only supported by PostgreSQL; see schema.CloneSchema for what is cloned.
+func (m *Migrate) CloneSchema(ctx context.Context, source, target string, withData bool) error {
+	options := schema.CloneOptions{WithData: withData}
+	if !withData {
+		options.DataTables = []string{m.tableName}
+	}
+	return m.inTransaction(ctx, "clone schema", func(c schema.Context) error {
+		return schema.CloneSchema(c, source, target, options)
+	})
+}
+
+// SwapSchemas exchanges the names of the live schema and a candidate schema, e.g. a clone
+// migrated after CloneSchema, in a single transaction. Afterwards the candidate is live and the
+// previous live schema has the name of the candidate, so swapping again rolls the release back.
+//
+// It is only supported by PostgreSQL.
+func (m *Migrate) SwapSchemas(ctx context.Context, live, candidate string) error {
+	m.warnTarget()
+	return m.inTransaction(ctx, "swap schemas", func(c schema.Context) error {
+		return schema.SwapSchemas(c, live, candidate)
+	})
+}
+
+// inTransaction runs fn in a transaction that is committed if fn succeeds.
+func (m *Migrate) inTransaction(ctx context.Context, operation string, fn func(c schema.Context) error) error {
+	if m.dryRun {
+		return errors.New(operation + " is not supported in dry-run mode")
+	}
+	if m.db == nil {
+		return errors.New("database connection is not set, please call WithDB option")
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(schema.NewContext(ctx, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,18 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_CloneSchemaDryRun(t *testing.T) {
+	m, err := New("pgx", WithDryRun(true))
+	require.NoError(t, err)
+
+	require.EqualError(t, m.CloneSchema(context.Background(), "public", "green", false),
+		"clone schema is not supported in dry-run mode")
+	require.EqualError(t, m.SwapSchemas(context.Background(), "public", "green"),
+		"swap schemas is not supported in dry-run mode")
+}
@@ -0,0 +1,43 @@
+package migris
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+
+	"github.com/akfaiz/migris/schema"
+)
+
+// GenerateGo writes a Go source file with package name pkg to w that declares constants for the
+// names of the tables and columns in the current schema; see schema.GenerateGo. The version
+// table and the tables maintained by migris, such as the rename log, are skipped.
+//
+// Regenerate the file after running the migrations, so query code that still uses a renamed or
+// dropped column no longer compiles.
+func (m *Migrate) GenerateGo(ctx context.Context, w io.Writer, pkg string) error {
+	if m.db == nil {
+		return errors.New("database connection is not set, please call WithDB option")
+	}
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	return schema.GenerateGo(schema.NewContext(ctx, tx), w, pkg, m.managedTables()...)
+}
+
+// managedTables returns the version table and the other tables maintained by migris.
+func (m *Migrate) managedTables() []string {
+	tables := []string{m.tableName}
+	for _, table := range []string{m.renameLog, m.sqlArtifacts, m.tableSnapshots} {
+		if table != "" {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
@@ -1,21 +1,85 @@
 package migris
 
 import (
+	"fmt"
+	"os"
 	"text/template"
 
+	"github.com/akfaiz/migris/internal/logger"
 	"github.com/akfaiz/migris/internal/parser"
-	"github.com/pressly/goose/v3"
+	"github.com/akfaiz/migris/internal/pathutil"
 )
 
+// versionFormat is the layout of the timestamp used as the version of new migration files.
+const versionFormat = "20060102150405"
+
+type templateVars struct {
+	Version   string
+	CamelName string
+}
+
 // Create creates a new migration file with the given name in the specified directory.
 func Create(dir, name string) error {
-	tmpl := getMigrationTemplate(name)
-	return goose.CreateWithTemplate(nil, dir, tmpl, name, "go")
+	_, err := create(dir, name, systemClock{})
+	return err
 }
 
 // Create creates a new migration file with the given name in the specified directory.
 func (m *Migrate) Create(name string) error {
-	return Create(m.migrationDir, name)
+	if err := m.checkCreateName(name); err != nil {
+		return err
+	}
+	_, err := create(m.migrationDir, name, m.clock)
+	return err
+}
+
+// NextVersion returns the version Create would give a migration file created now, e.g. for
+// release tooling that derives artifact names from it.
+func NextVersion() string {
+	return nextVersion(systemClock{})
+}
+
+// NextVersion returns the version Create would give a migration file created now, according
+// to the clock of the migrator.
+func (m *Migrate) NextVersion() string {
+	return nextVersion(m.clock)
+}
+
+// nextVersion returns the version of a migration file created at the current time of the clock.
+func nextVersion(clock Clock) string {
+	return clock.Now().UTC().Format(versionFormat)
+}
+
+// create writes a new migration file versioned with the current time of the clock
+// and returns its path.
+func create(dir, name string, clock Clock) (string, error) {
+	version := nextVersion(clock)
+	filename := fmt.Sprintf("%s_%s.go", version, parser.SnakeCase(name))
+	path := pathutil.Join(dir, filename)
+	exists, err := pathutil.ExistsFold(dir, filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to create migration file: %w", err)
+	}
+	if exists {
+		return "", fmt.Errorf("failed to create migration file: %s already exists", path)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create migration file: %w", err)
+	}
+	defer f.Close()
+
+	vars := templateVars{
+		Version:   version,
+		CamelName: parser.CamelCase(name),
+	}
+	if err = getMigrationTemplate(name).Execute(f, vars); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	logger.InfoMsg(logger.MessageCreatedFile, path)
+	return path, nil
 }
 
 func getMigrationTemplate(name string) *template.Template {
@@ -0,0 +1,75 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixedClock() Clock {
+	return ClockFunc(func() time.Time {
+		return time.Date(2025, 9, 4, 16, 48, 48, 0, time.UTC)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := create(dir, "create_users_table", fixedClock())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250904164848_create_users_table.go"), path)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "migris.AddMigrationContext(upCreateUsersTable, downCreateUsersTable)")
+	assert.Contains(t, string(content), `schema.Create(c, "users"`)
+
+	_, err = create(dir, "create_users_table", fixedClock())
+	require.Error(t, err, "creating the same migration twice should fail")
+}
+
+func TestMigrate_CreateUsesClock(t *testing.T) {
+	dir := t.TempDir()
+	m, err := New("postgres", WithMigrationDir(dir), WithClock(fixedClock()))
+	require.NoError(t, err)
+
+	require.NoError(t, m.Create("add_email_to_users"))
+	assert.FileExists(t, filepath.Join(dir, "20250904164848_add_email_to_users.go"))
+}
+
+func TestCreate_NormalizesPaths(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := create(dir+`\`, `users\add email`, fixedClock())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250904164848_users_add_email.go"), path)
+	assert.FileExists(t, path)
+}
+
+func TestMigrate_NextVersion(t *testing.T) {
+	m, err := New("postgres", WithClock(fixedClock()))
+	require.NoError(t, err)
+	assert.Equal(t, "20250904164848", m.NextVersion())
+	assert.Len(t, NextVersion(), len(versionFormat))
+}
+
+func TestMigrate_CreateChecksNamePattern(t *testing.T) {
+	dir := t.TempDir()
+	m, err := New("postgres", WithMigrationDir(dir), WithClock(fixedClock()),
+		WithLintPolicy(LintPolicy{NamePattern: regexp.MustCompile(`^(create|add|drop|alter)_[a-z0-9_]+$`)}))
+	require.NoError(t, err)
+
+	err = m.Create("fix stuff")
+	require.ErrorIs(t, err, ErrLintViolation)
+	require.EqualError(t, err,
+		"migration violates the lint policy: name fix_stuff does not match ^(create|add|drop|alter)_[a-z0-9_]+$")
+	assert.NoFileExists(t, filepath.Join(dir, "20250904164848_fix_stuff.go"))
+
+	require.NoError(t, m.Create("add email to users"))
+	assert.FileExists(t, filepath.Join(dir, "20250904164848_add_email_to_users.go"))
+}
@@ -0,0 +1,134 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/internal/pathutil"
+)
+
+// ErrDataLossNotAcknowledged is returned when rolling back migrations whose down migration loses
+// data was not acknowledged.
+var ErrDataLossNotAcknowledged = errors.New("rollback loses data and was not acknowledged")
+
+// LossyMigration is a migration declared with LossyDown that is about to be rolled back.
+type LossyMigration struct {
+	Version int64
+	Name    string
+	Reason  string
+}
+
+// DataLossAcknowledger is asked before migrations declared with LossyDown are rolled back, e.g.
+// by prompting the operator. The rollback only runs if it returns true.
+type DataLossAcknowledger func(ctx context.Context, migrations []LossyMigration) (bool, error)
+
+// LossyDown declares that the down migration loses data, e.g. because it drops a column that the
+// up migration added and that has been written to since. The reason is shown to the operator,
+// who has to acknowledge the loss before the migration is rolled back; see
+// WithDataLossAcknowledger.
+//
+// Example:
+//
+//	func init() {
+//	    migris.AddMigrationContext(upAddNickname, downAddNickname,
+//	        migris.LossyDown("drops users.nickname"))
+//	}
+func LossyDown(reason string) MigrationOption {
+	return func(m *Migration) {
+		lossy := true
+		m.lossyDown = &lossy
+		m.lossReason = reason
+	}
+}
+
+// LosslessDown declares that the down migration restores the previous schema without losing data.
+// In safe mode every Go migration has to declare either LossyDown or LosslessDown.
+func LosslessDown() MigrationOption {
+	return func(m *Migration) {
+		lossy := false
+		m.lossyDown = &lossy
+		m.lossReason = ""
+	}
+}
+
+// WithSafeMode enables or disables safe mode. In safe mode a Go migration can only be rolled back
+// if it declares whether its down migration loses data with LossyDown or LosslessDown, and a
+// lossy rollback fails unless it is acknowledged with WithDataLossAcknowledger. SQL migrations
+// carry no such declaration and are not checked.
+func WithSafeMode(enabled bool) Option {
+	return func(m *Migrate) {
+		m.safeMode = enabled
+	}
+}
+
+// WithDataLossAcknowledger sets the function that is asked before migrations declared with
+// LossyDown are rolled back. Without it, a lossy rollback only prints a warning, or fails in safe
+// mode.
+func WithDataLossAcknowledger(acknowledger DataLossAcknowledger) Option {
+	return func(m *Migrate) {
+		m.dataLossAcknowledger = acknowledger
+	}
+}
+
+// acknowledgeDataLoss checks the declarations of the Go migrations with the given versions before
+// they are rolled back, lists the lossy ones and asks for their acknowledgement.
+func (m *Migrate) acknowledgeDataLoss(ctx context.Context, versions []int64) error {
+	var lossy []LossyMigration
+	for _, migration := range registeredMigrations {
+		if !slices.Contains(versions, migration.version) {
+			continue
+		}
+		name := pathutil.Base(migration.source)
+		if migration.lossyDown == nil {
+			if m.safeMode {
+				return fmt.Errorf("migration %s does not declare whether its down migration loses data, "+
+					"use migris.LossyDown or migris.LosslessDown", name)
+			}
+			continue
+		}
+		if *migration.lossyDown {
+			lossy = append(lossy, LossyMigration{Version: migration.version, Name: name, Reason: migration.lossReason})
+		}
+	}
+	if len(lossy) == 0 {
+		return nil
+	}
+	slices.SortFunc(lossy, func(a, b LossyMigration) int {
+		return compareVersionsDesc(a.Version, b.Version)
+	})
+
+	losses := make([]logger.DataLoss, len(lossy))
+	for i, migration := range lossy {
+		losses[i] = logger.DataLoss{Migration: migration.Name, Reason: migration.Reason}
+	}
+	logger.DataLossBanner(losses)
+	if m.dataLossAcknowledger == nil {
+		if m.safeMode {
+			return ErrDataLossNotAcknowledged
+		}
+		return nil
+	}
+	acknowledged, err := m.dataLossAcknowledger(ctx, lossy)
+	if err != nil {
+		return err
+	}
+	if !acknowledged {
+		return ErrDataLossNotAcknowledged
+	}
+	return nil
+}
+
+// compareVersionsDesc orders migrations in the order they are rolled back.
+func compareVersionsDesc(a, b int64) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,71 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withRegisteredMigrations(t *testing.T, migrations ...*Migration) {
+	t.Helper()
+	saved := registeredMigrations
+	registeredMigrations = migrations
+	t.Cleanup(func() { registeredMigrations = saved })
+}
+
+func TestAcknowledgeDataLoss(t *testing.T) {
+	lossless, err := newMigration("20250101000000_create_users.go", nil, nil, LosslessDown())
+	require.NoError(t, err)
+	lossy, err := newMigration("20250102000000_add_nickname.go", nil, nil, LossyDown("drops users.nickname"))
+	require.NoError(t, err)
+	undeclared, err := newMigration("20250103000000_backfill.go", nil, nil)
+	require.NoError(t, err)
+	withRegisteredMigrations(t, lossless, lossy, undeclared)
+
+	t.Run("asks for the lossy migrations", func(t *testing.T) {
+		var asked []LossyMigration
+		m := &Migrate{dataLossAcknowledger: func(_ context.Context, migrations []LossyMigration) (bool, error) {
+			asked = migrations
+			return true, nil
+		}}
+		require.NoError(t, m.acknowledgeDataLoss(t.Context(), []int64{20250102000000, 20250101000000}))
+		assert.Equal(t, []LossyMigration{
+			{Version: 20250102000000, Name: "20250102000000_add_nickname.go", Reason: "drops users.nickname"},
+		}, asked)
+	})
+
+	t.Run("fails when the loss is declined", func(t *testing.T) {
+		m := &Migrate{dataLossAcknowledger: func(context.Context, []LossyMigration) (bool, error) {
+			return false, nil
+		}}
+		err := m.acknowledgeDataLoss(t.Context(), []int64{20250102000000})
+		require.ErrorIs(t, err, ErrDataLossNotAcknowledged)
+	})
+
+	t.Run("does not ask for lossless migrations", func(t *testing.T) {
+		m := &Migrate{safeMode: true, dataLossAcknowledger: func(context.Context, []LossyMigration) (bool, error) {
+			t.Fatal("acknowledger must not be called")
+			return false, nil
+		}}
+		require.NoError(t, m.acknowledgeDataLoss(t.Context(), []int64{20250101000000}))
+	})
+
+	t.Run("warns without an acknowledger", func(t *testing.T) {
+		m := &Migrate{}
+		require.NoError(t, m.acknowledgeDataLoss(t.Context(), []int64{20250102000000, 20250103000000}))
+	})
+
+	t.Run("requires acknowledgement in safe mode", func(t *testing.T) {
+		m := &Migrate{safeMode: true}
+		err := m.acknowledgeDataLoss(t.Context(), []int64{20250102000000})
+		require.ErrorIs(t, err, ErrDataLossNotAcknowledged)
+	})
+
+	t.Run("requires a declaration in safe mode", func(t *testing.T) {
+		m := &Migrate{safeMode: true}
+		err := m.acknowledgeDataLoss(t.Context(), []int64{20250103000000})
+		require.ErrorContains(t, err, "migration 20250103000000_backfill.go does not declare")
+	})
+}
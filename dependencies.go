@@ -0,0 +1,98 @@
+package migris
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Dependencies holds application services (configuration, feature flags, clocks, ...) that are
+// injected into migration providers registered with AddMigrationProvider.
+type Dependencies struct {
+	values map[string]any
+	clock  Clock
+	random io.Reader
+}
+
+func newDependencies() *Dependencies {
+	return &Dependencies{values: make(map[string]any)}
+}
+
+func (d *Dependencies) set(name string, value any) {
+	d.values[name] = value
+}
+
+// Get returns the dependency registered under the given name.
+func (d *Dependencies) Get(name string) (any, bool) {
+	if d == nil {
+		return nil, false
+	}
+	value, ok := d.values[name]
+	return value, ok
+}
+
+// Has reports whether a dependency is registered under the given name.
+func (d *Dependencies) Has(name string) bool {
+	_, ok := d.Get(name)
+	return ok
+}
+
+// Clock returns the time source of the migrator, as set with WithClock.
+// Data migrations should use it for created_at/updated_at values instead of time.Now, so
+// fixtures produced with a fixed clock are reproducible.
+func (d *Dependencies) Clock() Clock {
+	if d == nil || d.clock == nil {
+		return systemClock{}
+	}
+	return d.clock
+}
+
+// Now returns the current time of the migrator's clock.
+//
+// Example:
+//
+//	now := deps.Now()
+//	_, err := c.Exec("UPDATE plans SET created_at = $1, updated_at = $1 WHERE created_at IS NULL", now)
+func (d *Dependencies) Now() time.Time {
+	return d.Clock().Now()
+}
+
+// Random returns the source of random bytes of the migrator, as set with WithRandom.
+func (d *Dependencies) Random() io.Reader {
+	if d == nil || d.random == nil {
+		return rand.Reader
+	}
+	return d.random
+}
+
+// NewUUID returns a random (version 4) UUID read from the random source of the migrator. Data
+// migrations should use it for generated keys instead of a UUID package, so fixtures produced
+// with a fixed random source are reproducible.
+//
+// Example:
+//
+//	id, err := deps.NewUUID()
+//	_, err = c.Exec("INSERT INTO plans (id, name) VALUES ($1, $2)", id, "free")
+func (d *Dependencies) NewUUID() (string, error) {
+	return newUUID(d.Random())
+}
+
+// Resolve returns the dependency registered under the given name as type T.
+// It returns an error if the dependency is missing or has a different type.
+//
+// Example:
+//
+//	flags, err := migris.Resolve[*FeatureFlags](deps, "flags")
+func Resolve[T any](deps *Dependencies, name string) (T, error) {
+	var zero T
+	value, ok := deps.Get(name)
+	if !ok {
+		return zero, fmt.Errorf("dependency %q is not registered", name)
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("dependency %q has type %T, expected %T", name, value, zero)
+	}
+	return typed, nil
+}
@@ -0,0 +1,94 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type featureFlags struct {
+	backfill bool
+}
+
+type backfillProvider struct {
+	ran bool
+}
+
+func (p *backfillProvider) Up(_ schema.Context, deps *Dependencies) error {
+	flags, err := Resolve[*featureFlags](deps, "flags")
+	if err != nil {
+		return err
+	}
+	p.ran = flags.backfill
+	return nil
+}
+
+func (p *backfillProvider) Down(_ schema.Context, _ *Dependencies) error {
+	return nil
+}
+
+func TestResolve(t *testing.T) {
+	m, err := New("postgres", WithDependency("flags", &featureFlags{backfill: true}))
+	require.NoError(t, err)
+
+	flags, err := Resolve[*featureFlags](m.dependencies, "flags")
+	require.NoError(t, err)
+	assert.True(t, flags.backfill)
+
+	_, err = Resolve[string](m.dependencies, "flags")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected string")
+
+	_, err = Resolve[string](m.dependencies, "config")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+
+	_, err = Resolve[string](nil, "config")
+	require.Error(t, err)
+}
+
+func TestMigrationProvider(t *testing.T) {
+	m, err := New("postgres", WithDependency("flags", &featureFlags{backfill: true}))
+	require.NoError(t, err)
+
+	provider := &backfillProvider{}
+	migration := &Migration{version: 1, source: "00001_backfill.go", provider: provider}
+
+	err = migration.upFunc(m.dependencies)(schema.NewDryRunContext(t.Context()))
+	require.NoError(t, err)
+	assert.True(t, provider.ran)
+}
+
+func TestDependencies_Clock(t *testing.T) {
+	m, err := New("postgres", WithClock(fixedClock()))
+	require.NoError(t, err)
+
+	assert.Equal(t, fixedClock().Now(), m.dependencies.Now())
+	assert.Equal(t, fixedClock().Now(), m.dependencies.Clock().Now())
+
+	var deps *Dependencies
+	assert.Equal(t, systemClock{}, deps.Clock())
+}
+
+func TestDependencies_NewUUID(t *testing.T) {
+	m, err := New("postgres", WithRandom(bytes.NewReader(bytes.Repeat([]byte{0xff}, 32))))
+	require.NoError(t, err)
+
+	id, err := m.dependencies.NewUUID()
+	require.NoError(t, err)
+	assert.Equal(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", id)
+	id, err = m.dependencies.NewUUID()
+	require.NoError(t, err)
+	assert.Equal(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", id, "the same bytes give the same uuid")
+
+	_, err = m.dependencies.NewUUID()
+	require.Error(t, err, "the random source is exhausted")
+
+	var deps *Dependencies
+	id, err = deps.NewUUID()
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+}
@@ -31,11 +31,12 @@ func (m *Migrate) DownContext(ctx context.Context) error {
 		return err
 	}
 	if currentVersion == 0 {
-		logger.Info("Nothing to rollback.")
+		logger.InfoMsg(logger.MessageNothingToRollback)
 		return nil
 	}
-	logger.Info("Rolling back migrations.\n")
-	result, err := provider.Down(ctx)
+	m.warnTarget()
+	logger.InfoMsg(logger.MessageRollingBack)
+	results, err := m.downTo(ctx, provider, -1)
 	if err != nil {
 		var partialErr *goose.PartialError
 		if errors.As(err, &partialErr) {
@@ -43,10 +44,11 @@ func (m *Migrate) DownContext(ctx context.Context) error {
 		}
 		return err
 	}
-	if result != nil {
-		logger.PrintResult(result)
+	if len(results) == 0 {
+		return nil
 	}
-	return nil
+	logger.PrintResults(results)
+	return m.runPostMigrationHooks(ctx)
 }
 
 // DownTo rolls back the migrations to the specified version.
@@ -70,12 +72,13 @@ func (m *Migrate) DownToContext(ctx context.Context, version int64) error {
 	if err != nil {
 		return err
 	}
-	if currentVersion == 0 {
-		logger.Info("Nothing to rollback.")
+	if currentVersion == 0 || version >= currentVersion {
+		logger.InfoMsg(logger.MessageNothingToRollback)
 		return nil
 	}
-	logger.Info("Rolling back migrations.\n")
-	results, err := provider.DownTo(ctx, version)
+	m.warnTarget()
+	logger.InfoMsg(logger.MessageRollingBack)
+	results, err := m.downTo(ctx, provider, version)
 	if err != nil {
 		var partialErr *goose.PartialError
 		if errors.As(err, &partialErr) {
@@ -85,7 +88,45 @@ func (m *Migrate) DownToContext(ctx context.Context, version int64) error {
 		return err
 	}
 	logger.PrintResults(results)
-	return nil
+	return m.runPostMigrationHooks(ctx)
+}
+
+// DownSteps rolls back the last n applied migrations.
+func (m *Migrate) DownSteps(n int) error {
+	ctx := context.Background()
+	return m.DownStepsContext(ctx, n)
+}
+
+// DownStepsContext rolls back the last n applied migrations.
+func (m *Migrate) DownStepsContext(ctx context.Context, n int) error {
+	if n < 1 {
+		return errors.New("steps must be greater than 0")
+	}
+
+	provider, err := m.newProvider()
+	if err != nil {
+		return err
+	}
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return err
+	}
+	return m.DownToContext(ctx, stepsTargetVersion(statuses, n))
+}
+
+// stepsTargetVersion returns the version to roll back to so that the last n applied migrations
+// are rolled back, or 0 when fewer than n+1 migrations are applied.
+func stepsTargetVersion(statuses []*goose.MigrationStatus, n int) int64 {
+	var applied []int64
+	for _, status := range statuses {
+		if status.State == goose.StateApplied {
+			applied = append(applied, status.Source.Version)
+		}
+	}
+	if n >= len(applied) {
+		return 0
+	}
+	return applied[len(applied)-n-1]
 }
 
 // executeDryRunDown executes migrations in dry-run mode for down operations.
@@ -103,7 +144,7 @@ func (m *Migrate) executeDryRunDown(ctx context.Context, version int64) error {
 	}
 
 	if currentVersion == 0 {
-		logger.Info("Nothing to rollback.")
+		logger.InfoMsg(logger.MessageNothingToRollback)
 		return nil
 	}
 
@@ -112,7 +153,7 @@ func (m *Migrate) executeDryRunDown(ctx context.Context, version int64) error {
 	// Determine which migrations to rollback
 	migrationsToRollback := m.determineMigrationsToRollback(version, currentVersion)
 	if len(migrationsToRollback) == 0 {
-		logger.Info("Nothing to rollback.")
+		logger.InfoMsg(logger.MessageNothingToRollback)
 		return nil
 	}
 
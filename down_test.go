@@ -0,0 +1,48 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepsTargetVersion(t *testing.T) {
+	status := func(version int64, state goose.State) *goose.MigrationStatus {
+		return &goose.MigrationStatus{Source: &goose.Source{Version: version}, State: state}
+	}
+	statuses := []*goose.MigrationStatus{
+		status(1, goose.StateApplied),
+		status(2, goose.StateApplied),
+		status(3, goose.StateApplied),
+		status(4, goose.StatePending),
+	}
+
+	tests := []struct {
+		name  string
+		steps int
+		want  int64
+	}{
+		{name: "last migration", steps: 1, want: 2},
+		{name: "last two migrations", steps: 2, want: 1},
+		{name: "all applied migrations", steps: 3, want: 0},
+		{name: "more steps than applied migrations", steps: 10, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, stepsTargetVersion(statuses, tt.steps))
+		})
+	}
+}
+
+func TestMigrate_DownToReachedTarget(t *testing.T) {
+	for _, target := range []int64{2, 3} {
+		t.Run(fmt.Sprintf("target %d", target), func(t *testing.T) {
+			m, buf := newVersionMigrate(t, 2)
+			require.NoError(t, m.DownToContext(t.Context(), target))
+			assert.Contains(t, buf.String(), `msg="Nothing to rollback."`)
+		})
+	}
+}
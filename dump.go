@@ -0,0 +1,158 @@
+package migris
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/schema"
+)
+
+const schemaDumpHeader = "-- Schema dump generated by migris. Load it with LoadSchema instead of replaying the migrations."
+
+// DumpSchema writes the structure of every table in the current schema to w as a canonical SQL
+// file, followed by the contents of the version table. Loading the file with LoadSchema gives
+// the same database as running all applied migrations, e.g. for a fast CI database setup.
+//
+// Only the structure is dumped; the data of the other tables is not included.
+func (m *Migrate) DumpSchema(ctx context.Context, w io.Writer) error {
+	if m.db == nil {
+		return errors.New("database connection is not set, please call WithDB option")
+	}
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	c := schema.NewContext(ctx, tx)
+	statements, err := schema.GetSchemaDDL(c)
+	if err != nil {
+		return err
+	}
+	versions, err := m.dumpVersions(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintln(w, schemaDumpHeader); err != nil {
+		return err
+	}
+	for _, statement := range append(statements, versions...) {
+		if _, err = fmt.Fprintf(w, "\n%s;\n", statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpVersions returns the statements that restore the rows of the version table.
+func (m *Migrate) dumpVersions(c schema.Context) ([]string, error) {
+	exists, err := schema.HasTable(c, m.tableName)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	withChecksum, err := schema.HasColumn(c, m.tableName, checksumColumn)
+	if err != nil {
+		return nil, err
+	}
+	checksumSelect := "NULL"
+	if withChecksum {
+		checksumSelect = checksumColumn
+	}
+
+	rows, err := c.Query(fmt.Sprintf("SELECT version_id, is_applied, %s FROM %s ORDER BY id", checksumSelect, m.tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var version int64
+		var applied bool
+		var checksum sql.NullString
+		if err = rows.Scan(&version, &applied, &checksum); err != nil {
+			return nil, err
+		}
+		if checksum.Valid {
+			statements = append(statements, fmt.Sprintf("INSERT INTO %s (version_id, is_applied, %s) VALUES (%d, %t, '%s')",
+				m.tableName, checksumColumn, version, applied, checksum.String))
+			continue
+		}
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s (version_id, is_applied) VALUES (%d, %t)",
+			m.tableName, version, applied))
+	}
+	return statements, rows.Err()
+}
+
+// LoadSchema restores a schema dump written by DumpSchema into an empty database. The
+// statements run in a single transaction; afterwards only the migrations created after the
+// dump are pending.
+func (m *Migrate) LoadSchema(ctx context.Context, r io.Reader) error {
+	if m.dryRun {
+		return errors.New("load schema is not supported in dry-run mode")
+	}
+	if m.db == nil {
+		return errors.New("database connection is not set, please call WithDB option")
+	}
+
+	statements, err := parseSchemaDump(r)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err = tx.ExecContext(ctx, statement); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to load schema: %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	logger.InfoMsg(logger.MessageLoadedSchema, len(statements))
+
+	return nil
+}
+
+// parseSchemaDump splits a schema dump into its statements. A statement ends with a line that
+// ends with a semicolon outside a string literal; comment lines between statements are skipped.
+func parseSchemaDump(r io.Reader) ([]string, error) {
+	var statements, current []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(current) == 0 && (strings.TrimSpace(line) == "" || strings.HasPrefix(line, "--")) {
+			continue
+		}
+		current = append(current, line)
+		statement := strings.TrimRight(strings.Join(current, "\n"), " \t")
+		// A semicolon inside a string literal, e.g. in a comment, does not end the statement.
+		if strings.HasSuffix(statement, ";") && strings.Count(statement, "'")%2 == 0 {
+			statements = append(statements, strings.TrimSuffix(statement, ";"))
+			current = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(current) > 0 {
+		return nil, errors.New("schema dump ends with an unterminated statement")
+	}
+	return statements, nil
+}
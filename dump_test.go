@@ -0,0 +1,33 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchemaDump(t *testing.T) {
+	dump := schemaDumpHeader + "\n" +
+		"\n" +
+		"CREATE TABLE public.users (\n" +
+		"    id bigint NOT NULL\n" +
+		");\n" +
+		"\n" +
+		"COMMENT ON TABLE public.users IS 'Registered;\n" +
+		"users';\n" +
+		"\n" +
+		"INSERT INTO schema_migrations (version_id, is_applied) VALUES (0, true);\n"
+
+	statements, err := parseSchemaDump(strings.NewReader(dump))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE public.users (\n    id bigint NOT NULL\n)",
+		"COMMENT ON TABLE public.users IS 'Registered;\nusers'",
+		"INSERT INTO schema_migrations (version_id, is_applied) VALUES (0, true)",
+	}, statements)
+
+	_, err = parseSchemaDump(strings.NewReader("CREATE TABLE users (\n    id bigint NOT NULL\n)"))
+	require.Error(t, err)
+}
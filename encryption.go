@@ -0,0 +1,52 @@
+package migris
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+)
+
+// Encryptor encrypts data stored by the migrator, such as the statements recorded with
+// WithSQLArtifacts. Implement it to delegate to a key management service; NewAESEncryptor
+// returns an implementation for a local key.
+type Encryptor interface {
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+type aesEncryptor struct {
+	aead cipher.AEAD
+}
+
+// NewAESEncryptor returns an Encryptor that uses AES-GCM with the given key, which must be 16,
+// 24 or 32 bytes long to select AES-128, AES-192 or AES-256. A random nonce is prepended to
+// every ciphertext.
+func NewAESEncryptor(key []byte) (Encryptor, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesEncryptor{aead: aead}, nil
+}
+
+func (e *aesEncryptor) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return e.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (e *aesEncryptor) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < e.aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:e.aead.NonceSize()], ciphertext[e.aead.NonceSize():]
+	return e.aead.Open(nil, nonce, sealed, nil)
+}
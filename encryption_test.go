@@ -0,0 +1,30 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESEncryptor(t *testing.T) {
+	enc, err := NewAESEncryptor(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	ciphertext, err := enc.Encrypt(t.Context(), []byte("ALTER TABLE patients ADD COLUMN ssn VARCHAR(11)"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "patients")
+
+	plaintext, err := enc.Decrypt(t.Context(), ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE patients ADD COLUMN ssn VARCHAR(11)", string(plaintext))
+
+	other, err := NewAESEncryptor(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = other.Decrypt(t.Context(), ciphertext)
+	require.Error(t, err)
+
+	_, err = NewAESEncryptor([]byte("short"))
+	require.Error(t, err)
+}
@@ -1,18 +1,81 @@
 package migriscli
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
 	"github.com/urfave/cli/v3"
 )
 
+// defaultSchemaFile is the path of the schema file used by the schema-dump and schema-load commands.
+const defaultSchemaFile = "schema.sql"
+
+// defaultCodegenFile is the path of the Go file written by the codegen command.
+const defaultCodegenFile = "tables_gen.go"
+
+// defaultTenantReportFile is the path of the report written by the tenants commands.
+const defaultTenantReportFile = "tenants.json"
+
 // Config holds the configuration for the migris CLI commands.
 type Config struct {
 	DB            *sql.DB // Database connection
 	Dialect       string  // Database dialect (e.g., "pgx", "mysql", etc.)
 	MigrationsDir string  // Directory where migration files are stored
+
+	// DSN is the data source name DB was opened with. It is only used to show the target host and
+	// database before destructive commands and to detect production databases.
+	DSN string
+	// Environment labels the environment the commands change, e.g. "staging".
+	Environment string
+	// ProductionHosts overrides the host patterns of production databases.
+	ProductionHosts []string
+	// SafeMode requires Go migrations to declare whether their down migration loses data; see
+	// migris.WithSafeMode.
+	SafeMode bool
+	// LockWait sets how the statements of Go migrations wait for locks; see migris.WithLockWait.
+	LockWait migris.LockWait
+	// LockDiagnostics logs the sessions holding the locks statements of Go migrations wait for;
+	// see migris.WithLockDiagnostics.
+	LockDiagnostics bool
+	// TransactionPolicy checks pending PostgreSQL migrations for statements that cannot run in a
+	// transaction; see migris.WithTransactionPolicy.
+	TransactionPolicy migris.TransactionPolicy
+	// LintPolicy holds the conventions checked by the lint command and by create for the names of
+	// new migrations; see migris.WithLintPolicy.
+	LintPolicy migris.LintPolicy
+	// SQLFormatter formats the statements printed with --dry-run, e.g. migris.PrettySQL{}; see
+	// migris.WithSQLFormatter.
+	SQLFormatter migris.SQLFormatter
+	// PreRunSQL and PostRunSQL are executed before and after the migrations of every up, down,
+	// reset and fresh run, e.g. to pause and re-enable event triggers; see migris.WithPreRunSQL.
+	PreRunSQL  []string
+	PostRunSQL []string
+
+	// Tenants returns the tenants migrated by the tenants commands, e.g. the names of the tenant
+	// schemas.
+	Tenants func(ctx context.Context) ([]string, error)
+	// TenantDB opens the connection of a tenant for the tenants commands, e.g. with the schema of
+	// the tenant as its search_path. It is closed once the tenant has been migrated.
+	TenantDB func(ctx context.Context, tenant string) (*sql.DB, error)
+
+	// DeclaredSchema declares the schema the database should have with the schema builder. The
+	// create command with --auto generates a SQL migration converging the database to it; see
+	// migris.WithDeclaredSchema.
+	DeclaredSchema func(c schema.Context) error
+
+	// Messages overrides the text of operator-facing messages, e.g. to localize the output.
+	Messages map[migris.Message]string
 }
 
 // NewCLI creates a new CLI interface for migris with subcommands.
@@ -20,6 +83,17 @@ func NewCLI(cfg Config) *cli.Command {
 	cmd := &cli.Command{
 		Name:  "migrate",
 		Usage: "Database migration CLI tool",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "quiet",
+				Aliases: []string{"q"},
+				Usage:   "Suppress informational and decorative output",
+			},
+			&cli.BoolFlag{
+				Name:  "no-color",
+				Usage: "Disable colored output",
+			},
+		},
 		Commands: []*cli.Command{
 			{
 				Name:  "create",
@@ -31,9 +105,32 @@ func NewCLI(cfg Config) *cli.Command {
 						Usage:    "Name of the migration",
 						Required: true,
 					},
+					&cli.BoolFlag{
+						Name:  "auto",
+						Usage: "Generate a SQL migration converging the database to the declared schema",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					if c.Bool("auto") {
+						return migrator.CreateAuto(ctx, c.String("name"))
+					}
+					return migrator.Create(c.String("name"))
 				},
+			},
+			{
+				Name:  "next-version",
+				Usage: "Print the version the next created migration file would get",
 				Action: func(ctx context.Context, c *cli.Command) error {
-					return migris.Create(cfg.MigrationsDir, c.String("name"))
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					fmt.Println(migrator.NextVersion())
+					return nil
 				},
 			},
 			{
@@ -44,6 +141,10 @@ func NewCLI(cfg Config) *cli.Command {
 						Name:  "dry-run",
 						Usage: "Simulate the migration without applying changes",
 					},
+					&cli.StringFlag{
+						Name:  "class",
+						Usage: "Migration classes to apply: schema-first, schema-only or data-only",
+					},
 				},
 				Action: func(ctx context.Context, c *cli.Command) error {
 					migrator, err := createMigrator(c, cfg.DB, cfg)
@@ -61,6 +162,10 @@ func NewCLI(cfg Config) *cli.Command {
 						Name:  "dry-run",
 						Usage: "Simulate the migration without applying changes",
 					},
+					&cli.StringFlag{
+						Name:  "class",
+						Usage: "Migration classes to apply: schema-first, schema-only or data-only",
+					},
 					&cli.Int64Flag{
 						Name:     "version",
 						Aliases:  []string{"v"},
@@ -84,12 +189,24 @@ func NewCLI(cfg Config) *cli.Command {
 						Name:  "dry-run",
 						Usage: "Simulate the migration without applying changes",
 					},
+					&cli.BoolFlag{
+						Name:  "accept-data-loss",
+						Usage: "Roll back migrations that lose data without asking",
+					},
+					&cli.IntFlag{
+						Name:  "steps",
+						Usage: "Number of migrations to rollback",
+						Value: 1,
+					},
 				},
 				Action: func(ctx context.Context, c *cli.Command) error {
 					migrator, err := createMigrator(c, cfg.DB, cfg)
 					if err != nil {
 						return err
 					}
+					if steps := c.Int("steps"); steps != 1 {
+						return migrator.DownStepsContext(ctx, steps)
+					}
 					return migrator.DownContext(ctx)
 				},
 			},
@@ -101,6 +218,10 @@ func NewCLI(cfg Config) *cli.Command {
 						Name:  "dry-run",
 						Usage: "Simulate the migration without applying changes",
 					},
+					&cli.BoolFlag{
+						Name:  "accept-data-loss",
+						Usage: "Roll back migrations that lose data without asking",
+					},
 					&cli.Int64Flag{
 						Name:     "version",
 						Aliases:  []string{"v"},
@@ -124,6 +245,10 @@ func NewCLI(cfg Config) *cli.Command {
 						Name:  "dry-run",
 						Usage: "Simulate the migration without applying changes",
 					},
+					&cli.BoolFlag{
+						Name:  "accept-data-loss",
+						Usage: "Roll back migrations that lose data without asking",
+					},
 				},
 				Action: func(ctx context.Context, c *cli.Command) error {
 					migrator, err := createMigrator(c, cfg.DB, cfg)
@@ -133,15 +258,226 @@ func NewCLI(cfg Config) *cli.Command {
 					return migrator.ResetContext(ctx)
 				},
 			},
+			{
+				Name:  "fresh",
+				Usage: "Drop all tables and re-run all migrations",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					return migrator.FreshContext(ctx)
+				},
+			},
+			{
+				Name:  "schema-dump",
+				Usage: "Dump the database schema to a SQL file",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Path of the schema file",
+						Value:   defaultSchemaFile,
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					f, err := os.Create(c.String("file"))
+					if err != nil {
+						return err
+					}
+					if err = migrator.DumpSchema(ctx, f); err != nil {
+						_ = f.Close()
+						return err
+					}
+					return f.Close()
+				},
+			},
+			{
+				Name:  "schema-load",
+				Usage: "Load the database schema from a SQL file",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Path of the schema file",
+						Value:   defaultSchemaFile,
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					f, err := os.Open(c.String("file"))
+					if err != nil {
+						return err
+					}
+					defer f.Close()
+					return migrator.LoadSchema(ctx, f)
+				},
+			},
+			{
+				Name:  "schema-import",
+				Usage: "Write a baseline migration of the existing database and record it as applied",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					_, err = migrator.ImportSchema(ctx)
+					return err
+				},
+			},
+			{
+				Name:  "schema-clone",
+				Usage: "Clone a schema for a blue-green deployment",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "source",
+						Usage: "Schema to clone",
+						Value: "public",
+					},
+					&cli.StringFlag{
+						Name:     "target",
+						Usage:    "Name of the new schema",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "with-data",
+						Usage: "Copy the rows of every table",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					return migrator.CloneSchema(ctx, c.String("source"), c.String("target"), c.Bool("with-data"))
+				},
+			},
+			{
+				Name:  "schema-swap",
+				Usage: "Swap the names of the live schema and a candidate schema",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "live",
+						Usage: "Live schema",
+						Value: "public",
+					},
+					&cli.StringFlag{
+						Name:     "candidate",
+						Usage:    "Schema to promote",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					return migrator.SwapSchemas(ctx, c.String("live"), c.String("candidate"))
+				},
+			},
+			{
+				Name:  "codegen",
+				Usage: "Generate Go constants for the table and column names",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Path of the generated Go file",
+						Value:   defaultCodegenFile,
+					},
+					&cli.StringFlag{
+						Name:     "package",
+						Aliases:  []string{"p"},
+						Usage:    "Package name of the generated Go file",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					f, err := os.Create(c.String("file"))
+					if err != nil {
+						return err
+					}
+					if err = migrator.GenerateGo(ctx, f, c.String("package")); err != nil {
+						_ = f.Close()
+						return err
+					}
+					return f.Close()
+				},
+			},
 			{
 				Name:  "status",
 				Usage: "Show the status of migrations",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Output format: text or json",
+						Value: "text",
+					},
+					&cli.BoolFlag{
+						Name:  "pending-only",
+						Usage: "Show only the migrations that have not been applied yet",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					pendingOnly := c.Bool("pending-only")
+					switch c.String("format") {
+					case "text":
+						if pendingOnly {
+							return migrator.PendingStatusContext(ctx)
+						}
+						return migrator.StatusContext(ctx)
+					case "json":
+						statuses, err := migrator.StatusInfo(ctx)
+						if err != nil {
+							return err
+						}
+						if pendingOnly {
+							statuses = migris.PendingStatuses(statuses)
+						}
+						return writeJSON(statuses)
+					default:
+						return fmt.Errorf("unknown format %q, expected text or json", c.String("format"))
+					}
+				},
+			},
+			inspectCommand(cfg),
+			canaryCommand(cfg),
+			tenantsCommand(cfg),
+			{
+				Name:  "validate",
+				Usage: "Check that applied migrations have not changed",
 				Action: func(ctx context.Context, c *cli.Command) error {
 					migrator, err := createMigrator(c, cfg.DB, cfg)
 					if err != nil {
 						return err
 					}
-					return migrator.StatusContext(ctx)
+					return migrator.Validate(ctx)
+				},
+			},
+			{
+				Name:  "lint",
+				Usage: "Check migrations against the lint policy",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					migrator, err := createMigrator(c, cfg.DB, cfg)
+					if err != nil {
+						return err
+					}
+					return migrator.Lint(ctx)
 				},
 			},
 		},
@@ -150,6 +486,354 @@ func NewCLI(cfg Config) *cli.Command {
 	return cmd
 }
 
+// inspectCommand returns the read-only inspect command with its tables, columns and indexes
+// subcommands.
+func inspectCommand(cfg Config) *cli.Command {
+	return &cli.Command{
+		Name:  "inspect",
+		Usage: "Show the tables, columns and indexes of a database without changing it",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dsn",
+				Usage: "Inspect the database at this DSN, opened with the driver named after the dialect",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: text or json",
+				Value: "text",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "tables",
+				Usage: "List the tables",
+				Action: inspectAction(cfg, func(ctx context.Context, m *migris.Migrate, _ *cli.Command) (any, error) {
+					return m.InspectTables(ctx)
+				}),
+			},
+			{
+				Name:      "columns",
+				Usage:     "List the columns of a table",
+				ArgsUsage: "<table>",
+				Action: inspectAction(cfg, func(ctx context.Context, m *migris.Migrate, c *cli.Command) (any, error) {
+					table, err := tableArg(c)
+					if err != nil {
+						return nil, err
+					}
+					return m.InspectColumns(ctx, table)
+				}),
+			},
+			{
+				Name:      "indexes",
+				Usage:     "List the indexes of a table",
+				ArgsUsage: "<table>",
+				Action: inspectAction(cfg, func(ctx context.Context, m *migris.Migrate, c *cli.Command) (any, error) {
+					table, err := tableArg(c)
+					if err != nil {
+						return nil, err
+					}
+					return m.InspectIndexes(ctx, table)
+				}),
+			},
+		},
+	}
+}
+
+// canaryCommand returns the canary command, which runs the pending migrations against a sample of
+// a table to estimate their duration and locks.
+func canaryCommand(cfg Config) *cli.Command {
+	return &cli.Command{
+		Name:      "canary",
+		Usage:     "Estimate the duration and locks of the pending migrations on a sample of a table",
+		ArgsUsage: "<table>",
+		Flags: []cli.Flag{
+			&cli.Int64Flag{
+				Name:  "rows",
+				Usage: "Number of rows copied into the sample table",
+				Value: 10000,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: text or json",
+				Value: "text",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			format := c.String("format")
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unknown format %q, expected text or json", format)
+			}
+			table, err := tableArg(c)
+			if err != nil {
+				return err
+			}
+			migrator, err := createMigrator(c, cfg.DB, cfg)
+			if err != nil {
+				return err
+			}
+			report, err := migrator.Canary(ctx, migris.CanaryConfig{Table: table, SampleRows: c.Int64("rows")})
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				return writeJSON(report)
+			}
+			return writeCanaryReport(os.Stdout, report)
+		},
+	}
+}
+
+// tenantsCommand returns the tenants command, which applies the pending migrations of every tenant
+// and resumes the tenants that did not finish.
+func tenantsCommand(cfg Config) *cli.Command {
+	reportFlag := &cli.StringFlag{
+		Name:  "report",
+		Usage: "Path of the report of the tenant runs",
+		Value: defaultTenantReportFile,
+	}
+	concurrencyFlag := &cli.IntFlag{
+		Name:  "concurrency",
+		Usage: "Number of tenants migrated at once",
+		Value: 1,
+	}
+	return &cli.Command{
+		Name:  "tenants",
+		Usage: "Apply the migrations of every tenant",
+		Commands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "Apply all up migrations of every tenant, continuing after failed tenants",
+				Flags: []cli.Flag{reportFlag, concurrencyFlag},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					if cfg.Tenants == nil {
+						return errors.New("tenants are not configured, please set Config.Tenants")
+					}
+					tenants, err := cfg.Tenants(ctx)
+					if err != nil {
+						return err
+					}
+					runner, err := tenantRunner(c, cfg, len(tenants))
+					if err != nil {
+						return err
+					}
+					report, err := runner.Up(ctx, tenants)
+					if err != nil {
+						return err
+					}
+					return finishTenantRun(c.String("report"), report)
+				},
+			},
+			{
+				Name:  "resume",
+				Usage: "Apply the migrations of the tenants that failed or did not run in the last report",
+				Flags: []cli.Flag{reportFlag, concurrencyFlag},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					previous, err := readTenantReport(c.String("report"))
+					if err != nil {
+						return err
+					}
+					runner, err := tenantRunner(c, cfg, len(previous.Unfinished()))
+					if err != nil {
+						return err
+					}
+					report, err := runner.Resume(ctx, previous)
+					if err != nil {
+						return err
+					}
+					return finishTenantRun(c.String("report"), report)
+				},
+			},
+		},
+	}
+}
+
+// tenantRunner returns a runner migrating total tenants with the connections of Config.TenantDB,
+// printing a line for every tenant that has run.
+func tenantRunner(c *cli.Command, cfg Config, total int) (*migris.TenantRunner, error) {
+	if cfg.TenantDB == nil {
+		return nil, errors.New("tenant connections are not configured, please set Config.TenantDB")
+	}
+	done := 0
+	return &migris.TenantRunner{
+		Migrator: func(ctx context.Context, tenant string) (*migris.Migrate, func(), error) {
+			db, err := cfg.TenantDB(ctx, tenant)
+			if err != nil {
+				return nil, nil, err
+			}
+			release := func() { _ = db.Close() }
+			migrator, err := createMigrator(c, db, cfg)
+			return migrator, release, err
+		},
+		Concurrency: int(c.Int("concurrency")),
+		Progress: func(result migris.TenantResult) {
+			done++
+			fmt.Printf("[%d/%d] %s %s (%s)", done, total, result.Tenant, result.Status,
+				result.Duration.Round(time.Millisecond))
+			if result.Error != "" {
+				fmt.Printf(": %s", result.Error)
+			}
+			fmt.Println()
+		},
+	}, nil
+}
+
+// finishTenantRun writes the report of a tenant run and returns an error if a tenant did not
+// finish.
+func finishTenantRun(path string, report *migris.TenantReport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("%d of %d tenants succeeded, report written to %s\n",
+		report.Count(migris.TenantSucceeded), len(report.Results), path)
+	if err = report.Err(); err != nil {
+		return fmt.Errorf("%w, run tenants resume to retry them", err)
+	}
+	return nil
+}
+
+func readTenantReport(path string) (*migris.TenantReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report migris.TenantReport
+	if err = json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid tenant report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// writeCanaryReport writes the statements of a canary report as an aligned table.
+func writeCanaryReport(w io.Writer, report *migris.CanaryReport) error {
+	fmt.Fprintf(w, "Sampled %d of about %d rows of %s\n\n", report.SampleRows, report.TableRows, report.Table)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MIGRATION\tSTATEMENT\tSAMPLE\tESTIMATE\tLOCK\tERROR")
+	for _, statement := range report.Statements {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", statement.Migration, statement.SQL,
+			statement.Duration.Round(time.Millisecond), statement.Estimate.Round(time.Second),
+			statement.Lock, statement.Error)
+	}
+	return tw.Flush()
+}
+
+// inspectAction returns the action of an inspect subcommand that prints the result of inspect
+// in the selected format.
+func inspectAction(
+	cfg Config,
+	inspect func(ctx context.Context, migrator *migris.Migrate, c *cli.Command) (any, error),
+) cli.ActionFunc {
+	return func(ctx context.Context, c *cli.Command) error {
+		format := c.String("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q, expected text or json", format)
+		}
+		db := cfg.DB
+		if dsn := c.String("dsn"); dsn != "" {
+			var err error
+			if db, err = sql.Open(cfg.Dialect, dsn); err != nil {
+				return err
+			}
+			defer db.Close()
+			cfg.DSN = dsn
+		}
+		migrator, err := createMigrator(c, db, cfg)
+		if err != nil {
+			return err
+		}
+		result, err := inspect(ctx, migrator, c)
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return writeJSON(result)
+		}
+		return writeInspection(os.Stdout, result)
+	}
+}
+
+func tableArg(c *cli.Command) (string, error) {
+	if c.Args().Len() != 1 {
+		return "", fmt.Errorf("expected exactly one table name, got %d arguments", c.Args().Len())
+	}
+	return c.Args().First(), nil
+}
+
+// writeInspection writes the result of an inspect subcommand as an aligned table.
+func writeInspection(w io.Writer, result any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	switch rows := result.(type) {
+	case []migris.TableOverview:
+		fmt.Fprintln(tw, "NAME\tSCHEMA\tSIZE\tCOMMENT")
+		for _, table := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", table.Name, table.Schema, table.Size, optional(table.Comment))
+		}
+	case []migris.ColumnSnapshot:
+		fmt.Fprintln(tw, "NAME\tTYPE\tNULLABLE\tDEFAULT\tCOMMENT")
+		for _, column := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n",
+				column.Name, column.Type, column.Nullable, optional(column.Default), optional(column.Comment))
+		}
+	case []migris.IndexSnapshot:
+		fmt.Fprintln(tw, "NAME\tCOLUMNS\tUNIQUE\tPRIMARY")
+		for _, index := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%t\n",
+				index.Name, strings.Join(index.Columns, ", "), index.Unique, index.Primary)
+		}
+	default:
+		return fmt.Errorf("cannot print %T", result)
+	}
+	return tw.Flush()
+}
+
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func writeJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// confirmDataLoss asks the operator to type yes before migrations that lose data are rolled back,
+// unless the loss was accepted with a flag.
+func confirmDataLoss(accepted bool, in io.Reader) migris.DataLossAcknowledger {
+	return func(_ context.Context, _ []migris.LossyMigration) (bool, error) {
+		if accepted {
+			return true, nil
+		}
+		fmt.Fprint(os.Stderr, `Type "yes" to roll back and lose this data: `)
+		answer, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return false, err
+		}
+		return strings.TrimSpace(answer) == "yes", nil
+	}
+}
+
+// classPolicy parses the value of the --class flag.
+func classPolicy(value string) (migris.ClassPolicy, error) {
+	switch policy := migris.ClassPolicy(value); policy {
+	case migris.ClassPolicySchemaFirst, migris.ClassPolicySchemaOnly, migris.ClassPolicyDataOnly:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown class %q, expected schema-first, schema-only or data-only", value)
+	}
+}
+
 func createMigrator(c *cli.Command, db *sql.DB, cfg Config) (*migris.Migrate, error) {
 	options := []migris.Option{
 		migris.WithDB(db),
@@ -159,6 +843,39 @@ func createMigrator(c *cli.Command, db *sql.DB, cfg Config) (*migris.Migrate, er
 	if c.Bool("dry-run") {
 		options = append(options, migris.WithDryRun(true))
 	}
+	if class := c.String("class"); class != "" {
+		policy, err := classPolicy(class)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, migris.WithClassPolicy(policy))
+	}
+	options = append(options,
+		migris.WithQuiet(c.Bool("quiet")),
+		migris.WithNoColor(c.Bool("no-color")),
+	)
+	if cfg.Messages != nil {
+		options = append(options, migris.WithMessages(cfg.Messages))
+	}
+	options = append(options, migris.WithDSN(cfg.DSN), migris.WithEnvironmentLabel(cfg.Environment))
+	if cfg.ProductionHosts != nil {
+		options = append(options, migris.WithProductionHosts(cfg.ProductionHosts...))
+	}
+	options = append(options,
+		migris.WithSafeMode(cfg.SafeMode),
+		migris.WithLockWait(cfg.LockWait),
+		migris.WithLockDiagnostics(cfg.LockDiagnostics),
+		migris.WithTransactionPolicy(cfg.TransactionPolicy),
+		migris.WithLintPolicy(cfg.LintPolicy),
+		migris.WithDataLossAcknowledger(confirmDataLoss(c.Bool("accept-data-loss"), os.Stdin)),
+	)
+	if cfg.SQLFormatter != nil {
+		options = append(options, migris.WithSQLFormatter(cfg.SQLFormatter))
+	}
+	options = append(options, migris.WithPreRunSQL(cfg.PreRunSQL...), migris.WithPostRunSQL(cfg.PostRunSQL...))
+	if cfg.DeclaredSchema != nil {
+		options = append(options, migris.WithDeclaredSchema(cfg.DeclaredSchema))
+	}
 
 	migrator, err := migris.New(cfg.Dialect, options...)
 	if err != nil {
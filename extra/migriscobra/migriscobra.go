@@ -1,18 +1,81 @@
 package migriscobra
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
 	"github.com/spf13/cobra"
 )
 
+// defaultSchemaFile is the path of the schema file used by the schema-dump and schema-load commands.
+const defaultSchemaFile = "schema.sql"
+
+// defaultCodegenFile is the path of the Go file written by the codegen command.
+const defaultCodegenFile = "tables_gen.go"
+
+// defaultTenantReportFile is the path of the report written by the tenants commands.
+const defaultTenantReportFile = "tenants.json"
+
 // Config holds the configuration for the migris CLI commands.
 type Config struct {
 	DB            *sql.DB // Database connection
 	Dialect       string  // Database dialect (e.g., "pgx", "mysql", etc.)
 	MigrationsDir string  // Directory where migration files are stored
+
+	// DSN is the data source name DB was opened with. It is only used to show the target host and
+	// database before destructive commands and to detect production databases.
+	DSN string
+	// Environment labels the environment the commands change, e.g. "staging".
+	Environment string
+	// ProductionHosts overrides the host patterns of production databases.
+	ProductionHosts []string
+	// SafeMode requires Go migrations to declare whether their down migration loses data; see
+	// migris.WithSafeMode.
+	SafeMode bool
+	// LockWait sets how the statements of Go migrations wait for locks; see migris.WithLockWait.
+	LockWait migris.LockWait
+	// LockDiagnostics logs the sessions holding the locks statements of Go migrations wait for;
+	// see migris.WithLockDiagnostics.
+	LockDiagnostics bool
+	// TransactionPolicy checks pending PostgreSQL migrations for statements that cannot run in a
+	// transaction; see migris.WithTransactionPolicy.
+	TransactionPolicy migris.TransactionPolicy
+	// LintPolicy holds the conventions checked by the lint command and by create for the names of
+	// new migrations; see migris.WithLintPolicy.
+	LintPolicy migris.LintPolicy
+	// SQLFormatter formats the statements printed with --dry-run, e.g. migris.PrettySQL{}; see
+	// migris.WithSQLFormatter.
+	SQLFormatter migris.SQLFormatter
+	// PreRunSQL and PostRunSQL are executed before and after the migrations of every up, down,
+	// reset and fresh run, e.g. to pause and re-enable event triggers; see migris.WithPreRunSQL.
+	PreRunSQL  []string
+	PostRunSQL []string
+
+	// Tenants returns the tenants migrated by the tenants commands, e.g. the names of the tenant
+	// schemas.
+	Tenants func(ctx context.Context) ([]string, error)
+	// TenantDB opens the connection of a tenant for the tenants commands, e.g. with the schema of
+	// the tenant as its search_path. It is closed once the tenant has been migrated.
+	TenantDB func(ctx context.Context, tenant string) (*sql.DB, error)
+
+	// DeclaredSchema declares the schema the database should have with the schema builder. The
+	// create command with --auto generates a SQL migration converging the database to it; see
+	// migris.WithDeclaredSchema.
+	DeclaredSchema func(c schema.Context) error
+
+	// Messages overrides the text of operator-facing messages, e.g. to localize the output.
+	Messages map[migris.Message]string
 }
 
 // NewCLI creates a new CLI interface for migris with subcommands using Cobra.
@@ -22,16 +85,31 @@ func NewCLI(cfg Config) *cobra.Command {
 		Short: "Database migration CLI tool",
 		Long:  "A powerful database migration tool powered by migris",
 	}
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress informational and decorative output")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
 
 	// Add subcommands
 	rootCmd.AddCommand(
 		createCreateCommand(cfg),
+		createNextVersionCommand(cfg),
 		createUpCommand(cfg),
 		createUpToCommand(cfg),
 		createDownCommand(cfg),
 		createDownToCommand(cfg),
 		createResetCommand(cfg),
+		createFreshCommand(cfg),
+		createSchemaDumpCommand(cfg),
+		createSchemaLoadCommand(cfg),
+		createSchemaImportCommand(cfg),
+		createSchemaCloneCommand(cfg),
+		createSchemaSwapCommand(cfg),
+		createCodegenCommand(cfg),
 		createStatusCommand(cfg),
+		createInspectCommand(cfg),
+		createCanaryCommand(cfg),
+		createTenantsCommand(cfg),
+		createValidateCommand(cfg),
+		createLintCommand(cfg),
 	)
 
 	return rootCmd
@@ -46,14 +124,38 @@ func createCreateCommand(cfg Config) *cobra.Command {
 			if name == "" {
 				return cmd.Help()
 			}
-			return migris.Create(cfg.MigrationsDir, name)
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			if auto, _ := cmd.Flags().GetBool("auto"); auto {
+				return migrator.CreateAuto(context.Background(), name)
+			}
+			return migrator.Create(name)
 		},
 	}
 	cmd.Flags().StringP("name", "n", "", "Name of the migration (required)")
+	cmd.Flags().Bool("auto", false,
+		"Generate a SQL migration converging the database to the declared schema")
 	cmd.MarkFlagRequired("name")
 	return cmd
 }
 
+func createNextVersionCommand(cfg Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "next-version",
+		Short: "Print the version the next created migration file would get",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), migrator.NextVersion())
+			return nil
+		},
+	}
+}
+
 func createUpCommand(cfg Config) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "up",
@@ -67,6 +169,7 @@ func createUpCommand(cfg Config) *cobra.Command {
 		},
 	}
 	cmd.Flags().Bool("dry-run", false, "Simulate the migration without applying changes")
+	cmd.Flags().String("class", "", "Migration classes to apply: schema-first, schema-only or data-only")
 	return cmd
 }
 
@@ -84,6 +187,7 @@ func createUpToCommand(cfg Config) *cobra.Command {
 		},
 	}
 	cmd.Flags().Bool("dry-run", false, "Simulate the migration without applying changes")
+	cmd.Flags().String("class", "", "Migration classes to apply: schema-first, schema-only or data-only")
 	cmd.Flags().Int64P("version", "v", 0, "Target version to migrate up to (required)")
 	cmd.MarkFlagRequired("version")
 	return cmd
@@ -94,14 +198,20 @@ func createDownCommand(cfg Config) *cobra.Command {
 		Use:   "down",
 		Short: "Rollback the last migration",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, _ := cmd.Flags().GetInt("steps")
 			migrator, err := createMigrator(cmd, cfg)
 			if err != nil {
 				return err
 			}
+			if steps != 1 {
+				return migrator.DownStepsContext(context.Background(), steps)
+			}
 			return migrator.DownContext(context.Background())
 		},
 	}
 	cmd.Flags().Bool("dry-run", false, "Simulate the migration without applying changes")
+	cmd.Flags().Bool("accept-data-loss", false, "Roll back migrations that lose data without asking")
+	cmd.Flags().Int("steps", 1, "Number of migrations to rollback")
 	return cmd
 }
 
@@ -119,6 +229,7 @@ func createDownToCommand(cfg Config) *cobra.Command {
 		},
 	}
 	cmd.Flags().Bool("dry-run", false, "Simulate the migration without applying changes")
+	cmd.Flags().Bool("accept-data-loss", false, "Roll back migrations that lose data without asking")
 	cmd.Flags().Int64P("version", "v", 0, "Target version to migrate down to (required)")
 	cmd.MarkFlagRequired("version")
 	return cmd
@@ -137,6 +248,154 @@ func createResetCommand(cfg Config) *cobra.Command {
 		},
 	}
 	cmd.Flags().Bool("dry-run", false, "Simulate the migration without applying changes")
+	cmd.Flags().Bool("accept-data-loss", false, "Roll back migrations that lose data without asking")
+	return cmd
+}
+
+func createFreshCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fresh",
+		Short: "Drop all tables and re-run all migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			return migrator.FreshContext(context.Background())
+		},
+	}
+	return cmd
+}
+
+func createSchemaDumpCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema-dump",
+		Short: "Dump the database schema to a SQL file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, _ := cmd.Flags().GetString("file")
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			f, err := os.Create(file)
+			if err != nil {
+				return err
+			}
+			if err = migrator.DumpSchema(context.Background(), f); err != nil {
+				_ = f.Close()
+				return err
+			}
+			return f.Close()
+		},
+	}
+	cmd.Flags().StringP("file", "f", defaultSchemaFile, "Path of the schema file")
+	return cmd
+}
+
+func createSchemaLoadCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema-load",
+		Short: "Load the database schema from a SQL file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, _ := cmd.Flags().GetString("file")
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return migrator.LoadSchema(context.Background(), f)
+		},
+	}
+	cmd.Flags().StringP("file", "f", defaultSchemaFile, "Path of the schema file")
+	return cmd
+}
+
+func createSchemaImportCommand(cfg Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema-import",
+		Short: "Write a baseline migration of the existing database and record it as applied",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			_, err = migrator.ImportSchema(context.Background())
+			return err
+		},
+	}
+}
+
+func createSchemaCloneCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema-clone",
+		Short: "Clone a schema for a blue-green deployment",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			target, _ := cmd.Flags().GetString("target")
+			withData, _ := cmd.Flags().GetBool("with-data")
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			return migrator.CloneSchema(context.Background(), source, target, withData)
+		},
+	}
+	cmd.Flags().String("source", "public", "Schema to clone")
+	cmd.Flags().String("target", "", "Name of the new schema")
+	cmd.Flags().Bool("with-data", false, "Copy the rows of every table")
+	cmd.MarkFlagRequired("target")
+	return cmd
+}
+
+func createSchemaSwapCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema-swap",
+		Short: "Swap the names of the live schema and a candidate schema",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			live, _ := cmd.Flags().GetString("live")
+			candidate, _ := cmd.Flags().GetString("candidate")
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			return migrator.SwapSchemas(context.Background(), live, candidate)
+		},
+	}
+	cmd.Flags().String("live", "public", "Live schema")
+	cmd.Flags().String("candidate", "", "Schema to promote")
+	cmd.MarkFlagRequired("candidate")
+	return cmd
+}
+
+func createCodegenCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "codegen",
+		Short: "Generate Go constants for the table and column names",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, _ := cmd.Flags().GetString("file")
+			pkg, _ := cmd.Flags().GetString("package")
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			f, err := os.Create(file)
+			if err != nil {
+				return err
+			}
+			if err = migrator.GenerateGo(context.Background(), f, pkg); err != nil {
+				_ = f.Close()
+				return err
+			}
+			return f.Close()
+		},
+	}
+	cmd.Flags().StringP("file", "f", defaultCodegenFile, "Path of the generated Go file")
+	cmd.Flags().StringP("package", "p", "", "Package name of the generated Go file")
+	cmd.MarkFlagRequired("package")
 	return cmd
 }
 
@@ -149,12 +408,369 @@ func createStatusCommand(cfg Config) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return migrator.StatusContext(context.Background())
+			format, _ := cmd.Flags().GetString("format")
+			pendingOnly, _ := cmd.Flags().GetBool("pending-only")
+			switch format {
+			case "text":
+				if pendingOnly {
+					return migrator.PendingStatusContext(context.Background())
+				}
+				return migrator.StatusContext(context.Background())
+			case "json":
+				statuses, err := migrator.StatusInfo(context.Background())
+				if err != nil {
+					return err
+				}
+				if pendingOnly {
+					statuses = migris.PendingStatuses(statuses)
+				}
+				return writeJSON(cmd.OutOrStdout(), statuses)
+			default:
+				return fmt.Errorf("unknown format %q, expected text or json", format)
+			}
+		},
+	}
+	cmd.Flags().String("format", "text", "Output format: text or json")
+	cmd.Flags().Bool("pending-only", false, "Show only the migrations that have not been applied yet")
+	return cmd
+}
+
+func createInspectCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Show the tables, columns and indexes of a database without changing it",
+	}
+	cmd.PersistentFlags().String("dsn", "",
+		"Inspect the database at this DSN, opened with the driver named after the dialect")
+	cmd.PersistentFlags().String("format", "text", "Output format: text or json")
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "tables",
+			Short: "List the tables",
+			Args:  cobra.NoArgs,
+			RunE: inspectRunE(cfg, func(ctx context.Context, m *migris.Migrate, _ []string) (any, error) {
+				return m.InspectTables(ctx)
+			}),
+		},
+		&cobra.Command{
+			Use:   "columns <table>",
+			Short: "List the columns of a table",
+			Args:  cobra.ExactArgs(1),
+			RunE: inspectRunE(cfg, func(ctx context.Context, m *migris.Migrate, args []string) (any, error) {
+				return m.InspectColumns(ctx, args[0])
+			}),
+		},
+		&cobra.Command{
+			Use:   "indexes <table>",
+			Short: "List the indexes of a table",
+			Args:  cobra.ExactArgs(1),
+			RunE: inspectRunE(cfg, func(ctx context.Context, m *migris.Migrate, args []string) (any, error) {
+				return m.InspectIndexes(ctx, args[0])
+			}),
+		},
+	)
+	return cmd
+}
+
+// inspectRunE returns the run function of an inspect subcommand that prints the result of
+// inspect in the selected format.
+func inspectRunE(
+	cfg Config,
+	inspect func(ctx context.Context, m *migris.Migrate, args []string) (any, error),
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q, expected text or json", format)
+		}
+		if dsn, _ := cmd.Flags().GetString("dsn"); dsn != "" {
+			db, err := sql.Open(cfg.Dialect, dsn)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cfg.DB, cfg.DSN = db, dsn
+		}
+		migrator, err := createMigrator(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		result, err := inspect(context.Background(), migrator, args)
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return writeJSON(cmd.OutOrStdout(), result)
+		}
+		return writeInspection(cmd.OutOrStdout(), result)
+	}
+}
+
+// writeInspection writes the result of an inspect subcommand as an aligned table.
+func writeInspection(w io.Writer, result any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	switch rows := result.(type) {
+	case []migris.TableOverview:
+		fmt.Fprintln(tw, "NAME\tSCHEMA\tSIZE\tCOMMENT")
+		for _, table := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", table.Name, table.Schema, table.Size, optional(table.Comment))
+		}
+	case []migris.ColumnSnapshot:
+		fmt.Fprintln(tw, "NAME\tTYPE\tNULLABLE\tDEFAULT\tCOMMENT")
+		for _, column := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n",
+				column.Name, column.Type, column.Nullable, optional(column.Default), optional(column.Comment))
+		}
+	case []migris.IndexSnapshot:
+		fmt.Fprintln(tw, "NAME\tCOLUMNS\tUNIQUE\tPRIMARY")
+		for _, index := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%t\n",
+				index.Name, strings.Join(index.Columns, ", "), index.Unique, index.Primary)
+		}
+	default:
+		return fmt.Errorf("cannot print %T", result)
+	}
+	return tw.Flush()
+}
+
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func createCanaryCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "canary <table>",
+		Short: "Estimate the duration and locks of the pending migrations on a sample of a table",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unknown format %q, expected text or json", format)
+			}
+			rows, _ := cmd.Flags().GetInt64("rows")
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			report, err := migrator.Canary(context.Background(), migris.CanaryConfig{Table: args[0], SampleRows: rows})
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				return writeJSON(cmd.OutOrStdout(), report)
+			}
+			return writeCanaryReport(cmd.OutOrStdout(), report)
+		},
+	}
+	cmd.Flags().Int64("rows", 10000, "Number of rows copied into the sample table")
+	cmd.Flags().String("format", "text", "Output format: text or json")
+	return cmd
+}
+
+// writeCanaryReport writes the statements of a canary report as an aligned table.
+func writeCanaryReport(w io.Writer, report *migris.CanaryReport) error {
+	fmt.Fprintf(w, "Sampled %d of about %d rows of %s\n\n", report.SampleRows, report.TableRows, report.Table)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MIGRATION\tSTATEMENT\tSAMPLE\tESTIMATE\tLOCK\tERROR")
+	for _, statement := range report.Statements {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", statement.Migration, statement.SQL,
+			statement.Duration.Round(time.Millisecond), statement.Estimate.Round(time.Second),
+			statement.Lock, statement.Error)
+	}
+	return tw.Flush()
+}
+
+func createTenantsCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenants",
+		Short: "Apply the migrations of every tenant",
+	}
+	cmd.PersistentFlags().String("report", defaultTenantReportFile, "Path of the report of the tenant runs")
+	cmd.PersistentFlags().Int("concurrency", 1, "Number of tenants migrated at once")
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all up migrations of every tenant, continuing after failed tenants",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				if cfg.Tenants == nil {
+					return errors.New("tenants are not configured, please set Config.Tenants")
+				}
+				ctx := context.Background()
+				tenants, err := cfg.Tenants(ctx)
+				if err != nil {
+					return err
+				}
+				runner, err := tenantRunner(cmd, cfg, len(tenants))
+				if err != nil {
+					return err
+				}
+				report, err := runner.Up(ctx, tenants)
+				if err != nil {
+					return err
+				}
+				return finishTenantRun(cmd, report)
+			},
+		},
+		&cobra.Command{
+			Use:   "resume",
+			Short: "Apply the migrations of the tenants that failed or did not run in the last report",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				path, _ := cmd.Flags().GetString("report")
+				previous, err := readTenantReport(path)
+				if err != nil {
+					return err
+				}
+				runner, err := tenantRunner(cmd, cfg, len(previous.Unfinished()))
+				if err != nil {
+					return err
+				}
+				report, err := runner.Resume(context.Background(), previous)
+				if err != nil {
+					return err
+				}
+				return finishTenantRun(cmd, report)
+			},
+		},
+	)
+	return cmd
+}
+
+// tenantRunner returns a runner migrating total tenants with the connections of Config.TenantDB,
+// printing a line for every tenant that has run.
+func tenantRunner(cmd *cobra.Command, cfg Config, total int) (*migris.TenantRunner, error) {
+	if cfg.TenantDB == nil {
+		return nil, errors.New("tenant connections are not configured, please set Config.TenantDB")
+	}
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	done := 0
+	return &migris.TenantRunner{
+		Migrator: func(ctx context.Context, tenant string) (*migris.Migrate, func(), error) {
+			db, err := cfg.TenantDB(ctx, tenant)
+			if err != nil {
+				return nil, nil, err
+			}
+			release := func() { _ = db.Close() }
+			tenantCfg := cfg
+			tenantCfg.DB = db
+			migrator, err := createMigrator(cmd, tenantCfg)
+			return migrator, release, err
+		},
+		Concurrency: concurrency,
+		Progress: func(result migris.TenantResult) {
+			done++
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "[%d/%d] %s %s (%s)", done, total, result.Tenant, result.Status,
+				result.Duration.Round(time.Millisecond))
+			if result.Error != "" {
+				fmt.Fprintf(w, ": %s", result.Error)
+			}
+			fmt.Fprintln(w)
+		},
+	}, nil
+}
+
+// finishTenantRun writes the report of a tenant run and returns an error if a tenant did not
+// finish.
+func finishTenantRun(cmd *cobra.Command, report *migris.TenantReport) error {
+	path, _ := cmd.Flags().GetString("report")
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = writeJSON(file, report); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%d of %d tenants succeeded, report written to %s\n",
+		report.Count(migris.TenantSucceeded), len(report.Results), path)
+	if err = report.Err(); err != nil {
+		return fmt.Errorf("%w, run tenants resume to retry them", err)
+	}
+	return nil
+}
+
+func readTenantReport(path string) (*migris.TenantReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report migris.TenantReport
+	if err = json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid tenant report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+func createValidateCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check that applied migrations have not changed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			return migrator.Validate(context.Background())
 		},
 	}
 	return cmd
 }
 
+func createLintCommand(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check migrations against the lint policy",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := createMigrator(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			return migrator.Lint(context.Background())
+		},
+	}
+	return cmd
+}
+
+// confirmDataLoss asks the operator to type yes before migrations that lose data are rolled back,
+// unless the loss was accepted with a flag.
+func confirmDataLoss(accepted bool, in io.Reader) migris.DataLossAcknowledger {
+	return func(_ context.Context, _ []migris.LossyMigration) (bool, error) {
+		if accepted {
+			return true, nil
+		}
+		fmt.Fprint(os.Stderr, `Type "yes" to roll back and lose this data: `)
+		answer, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return false, err
+		}
+		return strings.TrimSpace(answer) == "yes", nil
+	}
+}
+
+// classPolicy parses the value of the --class flag.
+func classPolicy(value string) (migris.ClassPolicy, error) {
+	switch policy := migris.ClassPolicy(value); policy {
+	case migris.ClassPolicySchemaFirst, migris.ClassPolicySchemaOnly, migris.ClassPolicyDataOnly:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown class %q, expected schema-first, schema-only or data-only", value)
+	}
+}
+
 func createMigrator(cmd *cobra.Command, cfg Config) (*migris.Migrate, error) {
 	options := []migris.Option{
 		migris.WithDB(cfg.DB),
@@ -164,6 +780,39 @@ func createMigrator(cmd *cobra.Command, cfg Config) (*migris.Migrate, error) {
 	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
 		options = append(options, migris.WithDryRun(true))
 	}
+	if class, _ := cmd.Flags().GetString("class"); class != "" {
+		policy, err := classPolicy(class)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, migris.WithClassPolicy(policy))
+	}
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	options = append(options, migris.WithQuiet(quiet), migris.WithNoColor(noColor))
+	if cfg.Messages != nil {
+		options = append(options, migris.WithMessages(cfg.Messages))
+	}
+	options = append(options, migris.WithDSN(cfg.DSN), migris.WithEnvironmentLabel(cfg.Environment))
+	if cfg.ProductionHosts != nil {
+		options = append(options, migris.WithProductionHosts(cfg.ProductionHosts...))
+	}
+	acceptDataLoss, _ := cmd.Flags().GetBool("accept-data-loss")
+	options = append(options,
+		migris.WithSafeMode(cfg.SafeMode),
+		migris.WithLockWait(cfg.LockWait),
+		migris.WithLockDiagnostics(cfg.LockDiagnostics),
+		migris.WithTransactionPolicy(cfg.TransactionPolicy),
+		migris.WithLintPolicy(cfg.LintPolicy),
+		migris.WithDataLossAcknowledger(confirmDataLoss(acceptDataLoss, cmd.InOrStdin())),
+	)
+	if cfg.SQLFormatter != nil {
+		options = append(options, migris.WithSQLFormatter(cfg.SQLFormatter))
+	}
+	options = append(options, migris.WithPreRunSQL(cfg.PreRunSQL...), migris.WithPostRunSQL(cfg.PostRunSQL...))
+	if cfg.DeclaredSchema != nil {
+		options = append(options, migris.WithDeclaredSchema(cfg.DeclaredSchema))
+	}
 
 	migrator, err := migris.New(cfg.Dialect, options...)
 	if err != nil {
@@ -0,0 +1,37 @@
+// Package migriszap adapts a zap logger to the migris.Logger interface.
+package migriszap
+
+import (
+	"github.com/akfaiz/migris"
+	"go.uber.org/zap"
+)
+
+type logger struct {
+	sugar *zap.SugaredLogger
+}
+
+// New returns a migris.Logger that writes to the given zap logger. The args of every entry are
+// logged as alternating keys and values.
+//
+// Example:
+//
+//	migrator, err := migris.New("pgx", migris.WithDB(db), migris.WithLogger(migriszap.New(zapLogger)))
+func New(l *zap.Logger) migris.Logger {
+	return &logger{sugar: l.Sugar()}
+}
+
+func (l *logger) Debug(msg string, args ...any) {
+	l.sugar.Debugw(msg, args...)
+}
+
+func (l *logger) Info(msg string, args ...any) {
+	l.sugar.Infow(msg, args...)
+}
+
+func (l *logger) Warn(msg string, args ...any) {
+	l.sugar.Warnw(msg, args...)
+}
+
+func (l *logger) Error(msg string, args ...any) {
+	l.sugar.Errorw(msg, args...)
+}
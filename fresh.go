@@ -0,0 +1,42 @@
+package migris
+
+import (
+	"context"
+	"errors"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/schema"
+)
+
+// Fresh drops all tables in the current schema and runs all migrations from scratch.
+func (m *Migrate) Fresh() error {
+	ctx := context.Background()
+	return m.FreshContext(ctx)
+}
+
+// FreshContext drops all tables in the current schema and runs all migrations from scratch.
+// The down migrations are not run, so it also works when they are incomplete.
+func (m *Migrate) FreshContext(ctx context.Context) error {
+	if m.dryRun {
+		return errors.New("fresh is not supported in dry-run mode")
+	}
+	if m.db == nil {
+		return errors.New("database connection is not set, please call WithDB option")
+	}
+
+	m.warnTarget()
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = schema.DropAllTables(schema.NewContext(ctx, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	logger.InfoMsg(logger.MessageDroppedAllTables)
+
+	return m.UpContext(ctx)
+}
@@ -0,0 +1,81 @@
+package migris
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/schema"
+)
+
+// PostMigrationHook is called after a run that applied or rolled back at least one migration,
+// with the tables created or altered through the schema builder by the Go migrations of the run.
+// Tables dropped or renamed through the schema builder later in the run are not included.
+type PostMigrationHook func(ctx context.Context, db *sql.DB, tables []string) error
+
+// AnalyzeTables is a PostMigrationHook that refreshes the planner statistics of the tables
+// changed by the run, so queries do not regress to bad plans right after large column or index
+// changes. It runs ANALYZE on PostgreSQL and ANALYZE TABLE on MySQL.
+//
+// Example:
+//
+//	migrator, err := migris.New("pgx", migris.WithDB(db), migris.WithPostMigrationHook(migris.AnalyzeTables))
+func AnalyzeTables(ctx context.Context, db *sql.DB, tables []string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = schema.Analyze(schema.NewContext(ctx, tx), tables...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrationRun holds the state shared by the Go migrations run by a single provider.
+type migrationRun struct {
+	dialect   dialect.Dialect
+	timeout   time.Duration // statement timeout of the migrator
+	artifacts *sqlArtifacts
+	snapshots *tableSnapshots
+	tables    []string // tables changed through the schema builder, in order of first change
+
+	lockWait    LockWait
+	diagnostics *sql.DB // pool the lock holders are queried from, nil without lock diagnostics
+
+	noTransaction map[int64]bool // versions run outside of a transaction by the transaction policy
+}
+
+func (r *migrationRun) addTables(tables []string) {
+	for _, table := range tables {
+		if !slices.Contains(r.tables, table) {
+			r.tables = append(r.tables, table)
+		}
+	}
+}
+
+// removeTables removes the tables dropped or renamed by a migration, which no longer exist.
+func (r *migrationRun) removeTables(tables []string) {
+	r.tables = slices.DeleteFunc(r.tables, func(table string) bool {
+		return slices.Contains(tables, table)
+	})
+}
+
+// runPostMigrationHooks calls the post-migration hooks with the tables changed by the last run,
+// then publishes the resulting schema.
+func (m *Migrate) runPostMigrationHooks(ctx context.Context) error {
+	if m.run == nil {
+		return nil
+	}
+	for _, hook := range m.postMigrationHooks {
+		if err := hook(ctx, m.db, m.run.tables); err != nil {
+			return err
+		}
+	}
+	return m.publishSchema(ctx)
+}
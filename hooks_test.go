@@ -0,0 +1,101 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPostMigrationHooks(t *testing.T) {
+	var calls []string
+	hook := func(name string, err error) PostMigrationHook {
+		return func(_ context.Context, _ *sql.DB, tables []string) error {
+			assert.Equal(t, []string{"users", "orders"}, tables)
+			calls = append(calls, name)
+			return err
+		}
+	}
+
+	m, err := New("pgx",
+		WithPostMigrationHook(hook("first", nil)),
+		WithPostMigrationHook(hook("second", errors.New("boom"))),
+		WithPostMigrationHook(hook("third", nil)),
+	)
+	require.NoError(t, err)
+	require.NoError(t, m.runPostMigrationHooks(t.Context()), "no run, no hooks")
+
+	m.run = &migrationRun{}
+	m.run.addTables([]string{"users", "orders"})
+	m.run.addTables([]string{"orders"})
+
+	require.EqualError(t, m.runPostMigrationHooks(t.Context()), "boom")
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+// execDB is a database/sql driver accepting every statement without running it.
+type execDB struct{}
+
+func (d execDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d execDB) Driver() driver.Driver                        { return d }
+func (d execDB) Open(string) (driver.Conn, error)             { return d, nil }
+func (d execDB) Close() error                                 { return nil }
+
+func (d execDB) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("unexpected prepared statement")
+}
+
+func (d execDB) Begin() (driver.Tx, error) {
+	return nil, errors.New("unexpected transaction")
+}
+
+func (d execDB) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func TestGoMigrationTrackedTables(t *testing.T) {
+	_, err := New("pgx")
+	require.NoError(t, err)
+	db := sql.OpenDB(execDB{})
+	t.Cleanup(func() { db.Close() })
+
+	fn := func(c schema.Context) error {
+		addColumn := func(table *schema.Blueprint) { table.String("title") }
+		if err := schema.Create(c, "drafts", addColumn); err != nil {
+			return err
+		}
+		if err := schema.Drop(c, "drafts"); err != nil {
+			return err
+		}
+		if err := schema.Table(c, "posts", addColumn); err != nil {
+			return err
+		}
+		if err := schema.Rename(c, "posts", "articles"); err != nil {
+			return err
+		}
+		if err := schema.DropIfExists(c, "comments"); err != nil {
+			return err
+		}
+		return schema.Create(c, "comments", addColumn)
+	}
+	run := &migrationRun{}
+	run.addTables([]string{"users", "posts", "tags"})
+	f := &goMigrationFunc{fn: fn, source: "1_posts.go", version: 1, run: run}
+	require.NoError(t, f.runDB(t.Context(), db))
+
+	assert.Equal(t, []string{"users", "tags", "articles", "comments"}, run.tables)
+
+	require.NoError(t, (&goMigrationFunc{fn: func(c schema.Context) error {
+		return schema.Drop(c, "users")
+	}, source: "2_users.go", version: 2, run: run}).runDB(t.Context(), db))
+	assert.Equal(t, []string{"tags", "articles", "comments"}, run.tables)
+}
+
+func TestAnalyzeTablesWithoutTables(t *testing.T) {
+	require.NoError(t, AnalyzeTables(t.Context(), nil, nil))
+}
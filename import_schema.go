@@ -0,0 +1,80 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/akfaiz/migris/schema/diff"
+	"github.com/pressly/goose/v3/database"
+)
+
+// importSchemaName is the name of the baseline migration written by ImportSchema.
+const importSchemaName = "import_schema"
+
+// ImportSchema onboards an existing database: it writes a baseline SQL migration that creates
+// its tables with their columns, indexes and foreign keys, and records the migration as applied
+// in the version table, so the database is not migrated again while a new database gets the same
+// schema from it. The down file drops the tables. The version table and the other tables
+// maintained by migris are skipped, as are views, functions and other objects the schema/diff
+// package does not compare.
+//
+// The baseline is always a SQL migration holding the statements compiled by the schema/diff
+// package, also in projects whose other migrations are Go migrations; Go output is not
+// supported. It returns the path of the up file of the migration.
+func (m *Migrate) ImportSchema(ctx context.Context) (string, error) {
+	if m.db == nil {
+		return "", errors.New("database connection is not set, please call WithDB option")
+	}
+	current, err := m.takeSnapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(current.Tables) == 0 {
+		return "", errors.New("database has no tables to import")
+	}
+
+	return m.importSnapshot(ctx, m.NextVersion(), current)
+}
+
+// importSnapshot writes the baseline migration of version creating the tables of the snapshot
+// and records it as applied. The files are removed again if the migration cannot be recorded, so
+// that a later run does not apply it to the database it was imported from.
+func (m *Migrate) importSnapshot(ctx context.Context, version string, current *diff.Snapshot) (string, error) {
+	versionID, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	path, err := createAuto(ctx, m.migrationDir, version, importSchemaName, m.dialect.String(),
+		&diff.Snapshot{}, current)
+	if err != nil {
+		return "", err
+	}
+	if err = m.markApplied(ctx, versionID); err != nil {
+		removeErr := errors.Join(os.Remove(path), os.Remove(strings.TrimSuffix(path, ".up.sql")+".down.sql"))
+		return "", errors.Join(fmt.Errorf("failed to record baseline migration %s as applied: %w", path, err),
+			removeErr)
+	}
+	return path, nil
+}
+
+// markApplied records the migration of version as applied without running it, creating the
+// version table if needed.
+func (m *Migrate) markApplied(ctx context.Context, version int64) error {
+	provider, err := m.newProvider()
+	if err != nil {
+		return err
+	}
+	if _, err = provider.GetDBVersion(ctx); err != nil {
+		return err
+	}
+	store, err := m.newStore(m.dialect)
+	if err != nil {
+		return err
+	}
+	store.checksums = migrationChecksums(provider.ListSources(), m.migrationsFS())
+	return store.Insert(ctx, m.db, database.InsertRequest{Version: version})
+}
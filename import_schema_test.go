@@ -0,0 +1,108 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/akfaiz/migris/schema/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportSchema_Baseline(t *testing.T) {
+	current, err := diff.Declare("pgx", func(c schema.Context) error {
+		if err := schema.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("email").Unique()
+		}); err != nil {
+			return err
+		}
+		return schema.Create(c, "posts", func(table *schema.Blueprint) {
+			table.ID()
+			table.BigInteger("user_id")
+			table.Foreign("user_id").References("id").On("users")
+		})
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path, err := createAuto(t.Context(), dir, "20250904164848", importSchemaName, "pgx", &diff.Snapshot{}, current)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250904164848_import_schema.up.sql"), path)
+
+	up, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE posts (id BIGSERIAL NOT NULL, user_id BIGINT NOT NULL, "+
+		"CONSTRAINT pk_posts PRIMARY KEY (id));\n"+
+		"CREATE TABLE users (id BIGSERIAL NOT NULL, email VARCHAR(255) NOT NULL, "+
+		"CONSTRAINT pk_users PRIMARY KEY (id));\n"+
+		"ALTER TABLE users ADD CONSTRAINT uk_users_email UNIQUE (email);\n"+
+		"ALTER TABLE posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id);\n", string(up))
+	down, err := os.ReadFile(filepath.Join(dir, "20250904164848_import_schema.down.sql"))
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE posts DROP CONSTRAINT fk_posts_users;\n"+
+		"DROP TABLE users;\n"+
+		"DROP TABLE posts;\n", string(down))
+}
+
+func TestImportSchema_CompositeForeignKey(t *testing.T) {
+	current, err := diff.Declare("pgx", func(c schema.Context) error {
+		if err := schema.Create(c, "orders", func(table *schema.Blueprint) {
+			table.BigInteger("id")
+			table.BigInteger("tenant_id")
+			table.Primary("id", "tenant_id")
+		}); err != nil {
+			return err
+		}
+		return schema.Create(c, "order_lines", func(table *schema.Blueprint) {
+			table.BigInteger("order_id")
+			table.BigInteger("tenant_id")
+		})
+	})
+	require.NoError(t, err)
+	current.Table("order_lines").ForeignKeys = []*schema.ForeignKey{{
+		Name: "fk_order_lines_orders", Columns: []string{"order_id", "tenant_id"}, ForeignTable: "orders",
+		ForeignColumns: []string{"id", "tenant_id"},
+	}}
+
+	path, err := createAuto(t.Context(), t.TempDir(), "20250904164848", importSchemaName, "pgx",
+		&diff.Snapshot{}, current)
+	require.NoError(t, err)
+	up, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(up), "ALTER TABLE order_lines ADD CONSTRAINT fk_order_lines_orders "+
+		"FOREIGN KEY (order_id, tenant_id) REFERENCES orders (id, tenant_id);\n")
+}
+
+func TestMigrate_ImportSchemaRequiresDB(t *testing.T) {
+	m, err := New("pgx")
+	require.NoError(t, err)
+	_, err = m.ImportSchema(t.Context())
+	require.EqualError(t, err, "database connection is not set, please call WithDB option")
+}
+
+func TestMigrate_ImportSnapshotRemovesFilesOnFailure(t *testing.T) {
+	current, err := diff.Declare("pgx", func(c schema.Context) error {
+		return schema.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+		})
+	})
+	require.NoError(t, err)
+
+	// Nothing listens on the port, so the migration cannot be recorded.
+	db, err := sql.Open("pgx", "postgres://localhost:1/migris?connect_timeout=1")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	dir := t.TempDir()
+	m, err := New("pgx", WithDB(db), WithMigrationDir(dir))
+	require.NoError(t, err)
+
+	_, err = m.importSnapshot(t.Context(), "20250904164848", current)
+	require.ErrorContains(t, err, "failed to record baseline migration")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the files of the unrecorded migration are removed")
+}
@@ -0,0 +1,105 @@
+package migris
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/akfaiz/migris/schema"
+)
+
+// TableOverview is a table of the database as listed by InspectTables.
+type TableOverview struct {
+	Name    string  `json:"name"`
+	Schema  string  `json:"schema,omitempty"`
+	Size    int64   `json:"size"` // size in bytes, including indexes where the database reports them
+	Comment *string `json:"comment,omitempty"`
+}
+
+// InspectTables lists every table of the database, including the version table, in a read-only
+// transaction. Together with InspectColumns and InspectIndexes it makes the introspection of the
+// schema package available to operators, e.g. through the inspect commands of the CLIs.
+func (m *Migrate) InspectTables(ctx context.Context) ([]TableOverview, error) {
+	var tables []TableOverview
+	err := m.readOnly(ctx, func(c schema.Context) error {
+		var err error
+		tables, err = inspectTables(c)
+		return err
+	})
+	return tables, err
+}
+
+// InspectColumns lists the columns of the table in their ordinal order, in a read-only
+// transaction. It returns an error if the table does not exist.
+func (m *Migrate) InspectColumns(ctx context.Context, table string) ([]ColumnSnapshot, error) {
+	var columns []ColumnSnapshot
+	err := m.readOnly(ctx, func(c schema.Context) error {
+		if err := requireTable(c, table); err != nil {
+			return err
+		}
+		var err error
+		columns, err = columnSnapshots(c, table)
+		return err
+	})
+	return columns, err
+}
+
+// InspectIndexes lists the indexes of the table sorted by name, in a read-only transaction. It
+// returns an error if the table does not exist.
+func (m *Migrate) InspectIndexes(ctx context.Context, table string) ([]IndexSnapshot, error) {
+	var indexes []IndexSnapshot
+	err := m.readOnly(ctx, func(c schema.Context) error {
+		if err := requireTable(c, table); err != nil {
+			return err
+		}
+		var err error
+		indexes, err = indexSnapshots(c, table)
+		return err
+	})
+	return indexes, err
+}
+
+// readOnly runs fn in a read-only transaction that is always rolled back.
+func (m *Migrate) readOnly(ctx context.Context, fn func(c schema.Context) error) error {
+	if m.db == nil {
+		return errors.New("database connection is not set, please call WithDB option")
+	}
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	return fn(schema.NewContext(ctx, tx))
+}
+
+func inspectTables(c schema.Context) ([]TableOverview, error) {
+	tables, err := schema.GetTables(c)
+	if err != nil {
+		return nil, err
+	}
+	overviews := make([]TableOverview, 0, len(tables))
+	for _, table := range tables {
+		overviews = append(overviews, TableOverview{
+			Name:    table.Name,
+			Schema:  table.Schema,
+			Size:    table.Size,
+			Comment: nullStringPtr(table.Comment),
+		})
+	}
+	return overviews, nil
+}
+
+func requireTable(c schema.Context, table string) error {
+	exists, err := schema.HasTable(c, table)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("table %s does not exist", table)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectTables(t *testing.T) {
+	fake, err := schema.NewFake("pgx")
+	require.NoError(t, err)
+	for _, name := range []string{"users", "posts"} {
+		require.NoError(t, schema.Create(fake, name, func(table *schema.Blueprint) {
+			table.ID()
+		}))
+	}
+
+	got, err := inspectTables(fake)
+	require.NoError(t, err)
+	assert.Equal(t, []TableOverview{{Name: "posts"}, {Name: "users"}}, got)
+}
+
+func TestRequireTable(t *testing.T) {
+	fake, err := schema.NewFake("pgx")
+	require.NoError(t, err)
+	require.NoError(t, schema.Create(fake, "users", func(table *schema.Blueprint) {
+		table.ID()
+	}))
+
+	require.NoError(t, requireTable(fake, "users"))
+	require.EqualError(t, requireTable(fake, "posts"), "table posts does not exist")
+}
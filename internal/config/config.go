@@ -8,6 +8,13 @@ import (
 
 type Config struct {
 	Dialect dialect.Dialect
+	Strict  bool
+
+	InlineIndexes   bool
+	IdentityColumns bool
+
+	RenameLogTable string
+	ObjectOwner    string
 }
 
 var config = atomic.Pointer[Config]{}
@@ -19,11 +26,61 @@ func init() {
 }
 
 func SetDialect(dialect dialect.Dialect) {
-	cfg := config.Load()
+	cfg := *config.Load()
 	cfg.Dialect = dialect
-	config.Store(cfg)
+	config.Store(&cfg)
 }
 
 func GetDialect() dialect.Dialect {
 	return config.Load().Dialect
 }
+
+func SetStrict(strict bool) {
+	cfg := *config.Load()
+	cfg.Strict = strict
+	config.Store(&cfg)
+}
+
+func IsStrict() bool {
+	return config.Load().Strict
+}
+
+func SetInlineIndexes(inline bool) {
+	cfg := *config.Load()
+	cfg.InlineIndexes = inline
+	config.Store(&cfg)
+}
+
+func IsInlineIndexes() bool {
+	return config.Load().InlineIndexes
+}
+
+func SetIdentityColumns(identity bool) {
+	cfg := *config.Load()
+	cfg.IdentityColumns = identity
+	config.Store(&cfg)
+}
+
+func IsIdentityColumns() bool {
+	return config.Load().IdentityColumns
+}
+
+func SetRenameLogTable(table string) {
+	cfg := *config.Load()
+	cfg.RenameLogTable = table
+	config.Store(&cfg)
+}
+
+func GetRenameLogTable() string {
+	return config.Load().RenameLogTable
+}
+
+func SetObjectOwner(role string) {
+	cfg := *config.Load()
+	cfg.ObjectOwner = role
+	config.Store(&cfg)
+}
+
+func GetObjectOwner() string {
+	return config.Load().ObjectOwner
+}
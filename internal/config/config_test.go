@@ -19,3 +19,24 @@ func TestSetGetDialect(t *testing.T) {
 	result = config.GetDialect()
 	assert.Equal(t, dialect.MySQL, result)
 }
+
+func TestSetIsStrict(t *testing.T) {
+	t.Cleanup(func() { config.SetStrict(false) })
+
+	assert.False(t, config.IsStrict())
+
+	config.SetStrict(true)
+	assert.True(t, config.IsStrict())
+
+	config.SetStrict(false)
+	assert.False(t, config.IsStrict())
+}
+
+func TestSetIsIdentityColumns(t *testing.T) {
+	t.Cleanup(func() { config.SetIdentityColumns(false) })
+
+	assert.False(t, config.IsIdentityColumns())
+
+	config.SetIdentityColumns(true)
+	assert.True(t, config.IsIdentityColumns())
+}
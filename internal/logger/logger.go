@@ -5,6 +5,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/pressly/goose/v3"
@@ -25,11 +27,26 @@ var (
 	redBold    = color.New(color.FgRed, color.Bold).SprintFunc()
 
 	// Badge colors.
-	whiteBgBlue  = color.New(color.FgWhite, color.BgBlue).SprintFunc()
-	whiteBgGreen = color.New(color.FgWhite, color.BgGreen).SprintFunc()
-	whiteBgRed   = color.New(color.FgWhite, color.BgRed).SprintFunc()
+	whiteBgBlue   = color.New(color.FgWhite, color.BgBlue).SprintFunc()
+	blackBgYellow = color.New(color.FgBlack, color.BgYellow).SprintFunc()
+	whiteBgGreen  = color.New(color.FgWhite, color.BgGreen).SprintFunc()
+	whiteBgRed    = color.New(color.FgWhite, color.BgRed).SprintFunc()
 )
 
+// quiet suppresses informational and decorative output.
+var quiet atomic.Bool
+
+// SetQuiet enables or disables quiet mode. In quiet mode only migration results, statuses and
+// generated SQL are printed; informational messages, banners and summaries are suppressed.
+func SetQuiet(enabled bool) {
+	quiet.Store(enabled)
+}
+
+// SetNoColor disables or enables colored output.
+func SetNoColor(disabled bool) {
+	color.NoColor = disabled
+}
+
 // Helper functions
 
 // getTerminalWidth returns the terminal width with a fallback.
@@ -56,6 +73,11 @@ func formatDuration(ms float64) string {
 	return fmt.Sprintf(" %.2fms", ms)
 }
 
+// badge formats the catalog text of the key as a badge.
+func badge(key Message, colorFunc func(...interface{}) string) string {
+	return colorFunc(" " + Msg(key) + " ")
+}
+
 // printBulletPoint prints a formatted bullet point with colored text.
 func printBulletPoint(label, value string, colorFunc func(...interface{}) string) {
 	fmt.Printf("%s %s: %s\n", grey(BulletChar), label, colorFunc(value))
@@ -66,8 +88,118 @@ func Info(msg string) {
 }
 
 func Infof(format string, args ...any) {
+	if quiet.Load() && current() == nil {
+		return
+	}
 	msg := fmt.Sprintf(format, args...)
-	fmt.Printf("%s %s\n", whiteBgBlue(" INFO "), msg)
+	if l := current(); l != nil {
+		l.Info(text(msg))
+		return
+	}
+	fmt.Printf("%s %s\n", badge(MessageInfoBadge, whiteBgBlue), msg)
+}
+
+// InfoMsg prints the catalog message of the key as an informational message.
+func InfoMsg(key Message, args ...any) {
+	Info(Msg(key, args...))
+}
+
+// Warn prints a warning message to stderr. Warnings are not suppressed in quiet mode.
+func Warn(msg string) {
+	if l := current(); l != nil {
+		l.Warn(msg)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s %s\n", badge(MessageWarnBadge, blackBgYellow), msg)
+}
+
+// WarnMsg prints the catalog message of the key as a warning.
+func WarnMsg(key Message, args ...any) {
+	Warn(Msg(key, args...))
+}
+
+// TargetBanner prints a banner with the environment, host and database a destructive command is
+// about to change. The banner is printed to stderr and is not suppressed in quiet mode. Empty
+// values are omitted.
+func TargetBanner(environment, host, database string, production bool) {
+	if l := current(); l != nil {
+		msg := Msg(MessageTargetWarning)
+		if production {
+			msg = Msg(MessageProductionWarning)
+		}
+		l.Warn(msg, "environment", environment, "host", host, "database", database, "production", production)
+		return
+	}
+	if production {
+		warning := redBold(Msg(MessageProductionWarning))
+		fmt.Fprintf(os.Stderr, "%s %s\n", badge(MessageProductionBadge, whiteBgRed), warning)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s %s\n", badge(MessageTargetBadge, blackBgYellow), Msg(MessageTargetWarning))
+	}
+	for _, item := range []struct {
+		key   Message
+		value string
+	}{
+		{MessageTargetEnvironment, environment},
+		{MessageTargetHost, host},
+		{MessageTargetDatabase, database},
+	} {
+		if item.value != "" {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", grey(BulletChar), Msg(item.key), yellowBold(item.value))
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// DataLoss is a migration whose rollback loses data, as listed by DataLossBanner.
+type DataLoss struct {
+	Migration string
+	Reason    string
+}
+
+// DataLossBanner lists the migrations whose rollback loses data. Like the target banner it is
+// written to stderr and not suppressed in quiet mode.
+func DataLossBanner(losses []DataLoss) {
+	if l := current(); l != nil {
+		for _, loss := range losses {
+			l.Warn(Msg(MessageDataLossWarning), "migration", loss.Migration, "reason", loss.Reason)
+		}
+		return
+	}
+	warning := redBold(Msg(MessageDataLossWarning))
+	fmt.Fprintf(os.Stderr, "%s %s\n", badge(MessageDataLossBadge, whiteBgRed), warning)
+	for _, loss := range losses {
+		fmt.Fprintf(os.Stderr, "%s %s: %s\n", grey(BulletChar), loss.Migration, yellowBold(loss.Reason))
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// LockHolder is a session holding a lock a statement waits for, as listed by LockWaitBanner.
+type LockHolder struct {
+	ID       int64
+	User     string
+	State    string
+	Duration time.Duration
+	Query    string
+}
+
+// LockWaitBanner lists the sessions holding the lock a statement has been waiting for. Like the
+// target banner it is written to stderr and not suppressed in quiet mode.
+func LockWaitBanner(statement string, waited time.Duration, holders []LockHolder) {
+	if l := current(); l != nil {
+		for _, h := range holders {
+			l.Warn(Msg(MessageLockWaitWarning, waited, statement),
+				"session", h.ID, "user", h.User, "state", h.State, "duration", h.Duration, "query", h.Query)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s %s\n", badge(MessageLockWaitBadge, blackBgYellow),
+		Msg(MessageLockWaitWarning, waited, statement))
+	for _, h := range holders {
+		fmt.Fprintf(os.Stderr, "%s session %d (%s, %s for %s): %s\n",
+			grey(BulletChar), h.ID, h.User, h.State, h.Duration, yellowBold(h.Query))
+	}
+	fmt.Fprintln(os.Stderr)
 }
 
 func PrintResults(results []*goose.MigrationResult) {
@@ -77,10 +209,19 @@ func PrintResults(results []*goose.MigrationResult) {
 }
 
 func PrintResult(result *goose.MigrationResult) {
+	if l := current(); l != nil {
+		args := []any{"source", result.Source.Path, "direction", result.Direction, "duration", result.Duration}
+		if result.Error != nil {
+			l.Error("migration failed", append(args, "error", result.Error)...)
+		} else {
+			l.Info("migration done", args...)
+		}
+		return
+	}
 	durText := formatDuration(result.Duration.Seconds() * 1000)
-	statusText := " DONE"
+	statusText := " " + Msg(MessageStatusDone)
 	if result.Error != nil {
-		statusText = " FAIL"
+		statusText = " " + Msg(MessageStatusFail)
 	}
 
 	name := result.Source.Path
@@ -102,11 +243,19 @@ func PrintStatuses(statuses []*goose.MigrationStatus) {
 }
 
 func PrintStatus(status *goose.MigrationStatus) {
+	if l := current(); l != nil {
+		args := []any{"source", status.Source.Path, "state", string(status.State)}
+		if status.State == goose.StateApplied {
+			args = append(args, "applied_at", status.AppliedAt)
+		}
+		l.Info("migration status", args...)
+		return
+	}
 	var statusText string
 	if status.State == goose.StateApplied {
-		statusText = " Applied"
+		statusText = " " + Msg(MessageStatusApplied)
 	} else {
-		statusText = " Pending"
+		statusText = " " + Msg(MessageStatusPending)
 	}
 
 	name := status.Source.Path
@@ -123,53 +272,119 @@ func PrintStatus(status *goose.MigrationStatus) {
 // DryRun specific logger functions
 
 func DryRunStart(version int64) {
-	fmt.Printf("%s Starting DRY RUN migration (UP) to version %d\n", whiteBgBlue(" DRY RUN "), version)
-	fmt.Printf("%s Mode: DRY RUN - No actual database changes will be made\n\n", grey("📍"))
+	if l := current(); l != nil {
+		l.Info(Msg(MessageDryRunUpStart, version), "dry_run", true)
+		return
+	}
+	if quiet.Load() {
+		return
+	}
+	fmt.Printf("%s %s\n", badge(MessageDryRunBadge, whiteBgBlue), Msg(MessageDryRunUpStart, version))
+	fmt.Printf("%s %s\n\n", grey("📍"), Msg(MessageDryRunMode))
 }
 
 func DryRunMigrationStart(source string, version int64) {
-	fmt.Printf("%s %s (version %d)\n", yellowBold("PROCESSING"), source, version)
+	if l := current(); l != nil {
+		l.Debug("processing migration", "source", source, "version", version, "dry_run", true)
+		return
+	}
+	if quiet.Load() {
+		return
+	}
+	fmt.Printf("%s %s %s\n", yellowBold(Msg(MessageDryRunProcessing)), source, Msg(MessageDryRunVersionSuffix, version))
 }
 
 func DryRunMigrationComplete(source string, duration float64) {
+	if l := current(); l != nil {
+		l.Info("migration done", "source", source, "duration_ms", duration, "dry_run", true)
+		return
+	}
+	if quiet.Load() {
+		return
+	}
 	durText := formatDuration(duration)
-	statusText := " DRY RUN"
+	statusText := " " + Msg(MessageDryRunBadge)
 	dots := createDottedLine(source, durText, statusText)
 
 	fmt.Printf("%s %s%s%s\n", source, grey(dots), grey(durText), greenBold(statusText))
 }
 
 func DryRunSQL(query string, args ...any) {
+	if l := current(); l != nil {
+		l.Info("statement", "sql", query, "args", args, "dry_run", true)
+		return
+	}
+	if quiet.Load() {
+		fmt.Println(query)
+		return
+	}
 	fmt.Printf("%s %s\n", whiteBgGreen(" SQL "), query)
 	if len(args) > 0 {
-		fmt.Printf("%s Arguments: %v\n", grey("   "), args)
+		fmt.Printf("%s %s\n", grey("   "), Msg(MessageDryRunArguments, args))
 	}
 	fmt.Println()
 }
 
 func DryRunSummary(totalMigrations, totalStatements int, duration float64) {
-	fmt.Printf("%s DRY RUN Summary:\n", whiteBgBlue(" SUMMARY "))
-	printBulletPoint("Total migrations processed", strconv.Itoa(totalMigrations), greenBold)
-	printBulletPoint("Total SQL statements generated", strconv.Itoa(totalStatements), greenBold)
-	printBulletPoint("Total execution time", fmt.Sprintf("%.2fms", duration), greenBold)
-	printBulletPoint("Mode", "DRY RUN (no changes applied to database)", yellowBold)
+	if l := current(); l != nil {
+		logSummary(l, Msg(MessageDryRunSummary), totalMigrations, totalStatements, duration)
+		return
+	}
+	if quiet.Load() {
+		return
+	}
+	fmt.Printf("%s %s\n", badge(MessageSummaryBadge, whiteBgBlue), Msg(MessageDryRunSummary))
+	printSummary(totalMigrations, totalStatements, duration)
 }
 
 // DryRun DOWN specific logger functions
 
 func DryRunDownStart(version int64) {
+	if l := current(); l != nil {
+		if version == 0 {
+			l.Info(Msg(MessageDryRunResetStart), "dry_run", true)
+		} else {
+			l.Info(Msg(MessageDryRunDownStart, version), "dry_run", true)
+		}
+		return
+	}
+	if quiet.Load() {
+		return
+	}
 	if version == 0 {
-		fmt.Printf("%s Starting DRY RUN migration (RESET) - Rolling back all migrations\n", whiteBgRed(" DRY RUN "))
+		fmt.Printf("%s %s\n", badge(MessageDryRunBadge, whiteBgRed), Msg(MessageDryRunResetStart))
 	} else {
-		fmt.Printf("%s Starting DRY RUN migration (DOWN) to version %d\n", whiteBgRed(" DRY RUN "), version)
+		fmt.Printf("%s %s\n", badge(MessageDryRunBadge, whiteBgRed), Msg(MessageDryRunDownStart, version))
 	}
-	fmt.Printf("%s Mode: DRY RUN - No actual database changes will be made\n\n", grey("🔍"))
+	fmt.Printf("%s %s\n\n", grey("🔍"), Msg(MessageDryRunMode))
 }
 
 func DryRunDownSummary(totalMigrations, totalStatements int, duration float64, operation string) {
-	fmt.Printf("%s DRY RUN %s Summary:\n", whiteBgRed(" SUMMARY "), operation)
-	printBulletPoint("Total migrations processed", strconv.Itoa(totalMigrations), greenBold)
-	printBulletPoint("Total SQL statements generated", strconv.Itoa(totalStatements), greenBold)
-	printBulletPoint("Total execution time", fmt.Sprintf("%.2fms", duration), greenBold)
-	printBulletPoint("Mode", "DRY RUN (no changes applied to database)", yellowBold)
+	if l := current(); l != nil {
+		logSummary(l, Msg(MessageDryRunDownSummary, operation), totalMigrations, totalStatements, duration)
+		return
+	}
+	if quiet.Load() {
+		return
+	}
+	fmt.Printf("%s %s\n", badge(MessageSummaryBadge, whiteBgRed), Msg(MessageDryRunDownSummary, operation))
+	printSummary(totalMigrations, totalStatements, duration)
+}
+
+// printSummary prints the bullet points shared by the dry-run summaries.
+func printSummary(totalMigrations, totalStatements int, duration float64) {
+	printBulletPoint(Msg(MessageSummaryMigrations), strconv.Itoa(totalMigrations), greenBold)
+	printBulletPoint(Msg(MessageSummaryStatements), strconv.Itoa(totalStatements), greenBold)
+	printBulletPoint(Msg(MessageSummaryDuration), fmt.Sprintf("%.2fms", duration), greenBold)
+	printBulletPoint(Msg(MessageSummaryMode), Msg(MessageSummaryModeDryRun), yellowBold)
+}
+
+// logSummary logs a dry-run summary to a structured logger.
+func logSummary(l Logger, msg string, totalMigrations, totalStatements int, duration float64) {
+	l.Info(text(msg),
+		"migrations", totalMigrations,
+		"statements", totalStatements,
+		"duration_ms", duration,
+		"dry_run", true,
+	)
 }
@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// Message identifies an operator-facing message in the message catalog.
+type Message string
+
+// Message keys of the catalog. The default (English) text is defined in defaultMessages.
+const (
+	MessageInfoBadge           Message = "info_badge"
+	MessageWarnBadge           Message = "warn_badge"
+	MessageIgnoredFeature      Message = "ignored_feature"
+	MessageNothingToMigrate    Message = "nothing_to_migrate"
+	MessageNothingToRollback   Message = "nothing_to_rollback"
+	MessageRunningMigrations   Message = "running_migrations"
+	MessageRollingBack         Message = "rolling_back"
+	MessageDroppedAllTables    Message = "dropped_all_tables"
+	MessageLoadedSchema        Message = "loaded_schema"
+	MessageCreatedFile         Message = "created_file"
+	MessageSchemaUpToDate      Message = "schema_up_to_date"
+	MessageStatusDone          Message = "status_done"
+	MessageStatusFail          Message = "status_fail"
+	MessageStatusApplied       Message = "status_applied"
+	MessageStatusPending       Message = "status_pending"
+	MessageDryRunBadge         Message = "dry_run_badge"
+	MessageDryRunUpStart       Message = "dry_run_up_start"
+	MessageDryRunDownStart     Message = "dry_run_down_start"
+	MessageDryRunResetStart    Message = "dry_run_reset_start"
+	MessageDryRunMode          Message = "dry_run_mode"
+	MessageDryRunProcessing    Message = "dry_run_processing"
+	MessageDryRunVersionSuffix Message = "dry_run_version_suffix"
+	MessageDryRunArguments     Message = "dry_run_arguments"
+	MessageDryRunSummary       Message = "dry_run_summary"
+	MessageDryRunDownSummary   Message = "dry_run_down_summary"
+	MessageSummaryBadge        Message = "summary_badge"
+	MessageSummaryMigrations   Message = "summary_migrations"
+	MessageSummaryStatements   Message = "summary_statements"
+	MessageSummaryDuration     Message = "summary_duration"
+	MessageSummaryMode         Message = "summary_mode"
+	MessageSummaryModeDryRun   Message = "summary_mode_dry_run"
+	MessageTargetBadge         Message = "target_badge"
+	MessageProductionBadge     Message = "production_badge"
+	MessageTargetWarning       Message = "target_warning"
+	MessageProductionWarning   Message = "production_warning"
+	MessageTargetEnvironment   Message = "target_environment"
+	MessageTargetHost          Message = "target_host"
+	MessageTargetDatabase      Message = "target_database"
+	MessageDataLossBadge       Message = "data_loss_badge"
+	MessageDataLossWarning     Message = "data_loss_warning"
+	MessageLockWaitBadge       Message = "lock_wait_badge"
+	MessageLockWaitWarning     Message = "lock_wait_warning"
+	MessageLockRetry           Message = "lock_retry"
+	MessageNoTransactionRouted Message = "no_transaction_routed"
+)
+
+var defaultMessages = map[Message]string{
+	MessageInfoBadge:           "INFO",
+	MessageWarnBadge:           "WARN",
+	MessageIgnoredFeature:      "%s has no effect on table %s and was ignored",
+	MessageNothingToMigrate:    "Nothing to migrate.",
+	MessageNothingToRollback:   "Nothing to rollback.",
+	MessageRunningMigrations:   "Running migrations.\n",
+	MessageRollingBack:         "Rolling back migrations.\n",
+	MessageDroppedAllTables:    "Dropped all tables.",
+	MessageLoadedSchema:        "Loaded schema (%d statements).",
+	MessageCreatedFile:         "Created new file: %s",
+	MessageSchemaUpToDate:      "Schema is up to date, no migration created.",
+	MessageStatusDone:          "DONE",
+	MessageStatusFail:          "FAIL",
+	MessageStatusApplied:       "Applied",
+	MessageStatusPending:       "Pending",
+	MessageDryRunBadge:         "DRY RUN",
+	MessageDryRunUpStart:       "Starting DRY RUN migration (UP) to version %d",
+	MessageDryRunDownStart:     "Starting DRY RUN migration (DOWN) to version %d",
+	MessageDryRunResetStart:    "Starting DRY RUN migration (RESET) - Rolling back all migrations",
+	MessageDryRunMode:          "Mode: DRY RUN - No actual database changes will be made",
+	MessageDryRunProcessing:    "PROCESSING",
+	MessageDryRunVersionSuffix: "(version %d)",
+	MessageDryRunArguments:     "Arguments: %v",
+	MessageDryRunSummary:       "DRY RUN Summary:",
+	MessageDryRunDownSummary:   "DRY RUN %s Summary:",
+	MessageSummaryBadge:        "SUMMARY",
+	MessageSummaryMigrations:   "Total migrations processed",
+	MessageSummaryStatements:   "Total SQL statements generated",
+	MessageSummaryDuration:     "Total execution time",
+	MessageSummaryMode:         "Mode",
+	MessageSummaryModeDryRun:   "DRY RUN (no changes applied to database)",
+	MessageTargetBadge:         "TARGET",
+	MessageProductionBadge:     "PRODUCTION",
+	MessageTargetWarning:       "This command changes the database below and may lose data.",
+	MessageProductionWarning:   "This command changes a PRODUCTION database and may lose data.",
+	MessageTargetEnvironment:   "Environment",
+	MessageTargetHost:          "Host",
+	MessageTargetDatabase:      "Database",
+	MessageDataLossBadge:       "DATA LOSS",
+	MessageDataLossWarning:     "Rolling back these migrations loses data:",
+	MessageLockWaitBadge:       "LOCK WAIT",
+	MessageLockWaitWarning:     "Waiting %s for a lock held by other sessions: %s",
+	MessageLockRetry:           "Timed out waiting for a lock, retrying in %s (attempt %d of %d): %s",
+	MessageNoTransactionRouted: "Running %s outside of a transaction, as it cannot run in one: %s",
+}
+
+var (
+	messagesMu sync.RWMutex
+	messages   = maps.Clone(defaultMessages)
+)
+
+// SetMessages overrides entries of the message catalog, e.g. to localize the output.
+// Keys that are not present in overrides keep their default text.
+func SetMessages(overrides map[Message]string) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	messages = maps.Clone(defaultMessages)
+	maps.Copy(messages, overrides)
+}
+
+// Msg returns the catalog text for the key formatted with the given arguments.
+func Msg(key Message, args ...any) string {
+	messagesMu.RLock()
+	format, ok := messages[key]
+	messagesMu.RUnlock()
+	if !ok {
+		format = string(key)
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,25 @@
+package logger_test
+
+import (
+	"testing"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsg(t *testing.T) {
+	t.Cleanup(func() { logger.SetMessages(nil) })
+
+	assert.Equal(t, "Nothing to migrate.", logger.Msg(logger.MessageNothingToMigrate))
+	assert.Equal(t, "Created new file: 001.go", logger.Msg(logger.MessageCreatedFile, "001.go"))
+	assert.Equal(t, "unknown_key", logger.Msg(logger.Message("unknown_key")))
+
+	logger.SetMessages(map[logger.Message]string{
+		logger.MessageNothingToMigrate: "Tidak ada migrasi.",
+	})
+	assert.Equal(t, "Tidak ada migrasi.", logger.Msg(logger.MessageNothingToMigrate))
+	assert.Equal(t, "Nothing to rollback.", logger.Msg(logger.MessageNothingToRollback))
+
+	logger.SetMessages(nil)
+	assert.Equal(t, "Nothing to migrate.", logger.Msg(logger.MessageNothingToMigrate))
+}
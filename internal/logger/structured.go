@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Logger receives the output of the migrator as structured log entries instead of the colored
+// console output. The args are alternating keys and values, as accepted by log/slog.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+type loggerHolder struct {
+	logger Logger
+}
+
+var structured atomic.Pointer[loggerHolder]
+
+// SetLogger routes the output to the given logger. A nil logger restores the console output.
+func SetLogger(l Logger) {
+	if l == nil {
+		structured.Store(nil)
+		return
+	}
+	structured.Store(&loggerHolder{logger: l})
+}
+
+// current returns the configured structured logger, or nil for the console output.
+func current() Logger {
+	if h := structured.Load(); h != nil {
+		return h.logger
+	}
+	return nil
+}
+
+// Debug logs a diagnostic message, e.g. every executed statement. It is only written to a
+// structured logger; the console output omits it.
+func Debug(msg string, args ...any) {
+	if l := current(); l != nil {
+		l.Debug(msg, args...)
+	}
+}
+
+// text returns the message without the trailing newlines used to separate console output.
+func text(msg string) string {
+	return strings.TrimSpace(msg)
+}
@@ -0,0 +1,49 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructuredLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+	t.Cleanup(func() { logger.SetLogger(nil) })
+
+	logger.InfoMsg(logger.MessageRunningMigrations)
+	logger.WarnMsg(logger.MessageIgnoredFeature, "table engine", "users")
+	logger.Debug("executing statement", "table", "users", "sql", "DROP TABLE users")
+	logger.PrintResult(&goose.MigrationResult{
+		Source:    &goose.Source{Path: "20250101000000_create_users.go"},
+		Direction: "up",
+		Duration:  2 * time.Millisecond,
+		Error:     errors.New("boom"),
+	})
+	logger.TargetBanner("production", "db.prod.example.com:5432", "app", true)
+	logger.LockWaitBanner("ALTER TABLE users", 10*time.Second, []logger.LockHolder{
+		{ID: 42, User: "app@10.0.0.1", State: "Sleep", Duration: 2 * time.Minute, Query: "SELECT 1"},
+	})
+
+	assert.Equal(t, `level=INFO msg="Running migrations."
+level=WARN msg="table engine has no effect on table users and was ignored"
+level=DEBUG msg="executing statement" table=users sql="DROP TABLE users"
+level=ERROR msg="migration failed" source=20250101000000_create_users.go direction=up duration=2ms error=boom
+level=WARN msg="This command changes a PRODUCTION database and may lose data." environment=production host=db.prod.example.com:5432 database=app production=true
+level=WARN msg="Waiting 10s for a lock held by other sessions: ALTER TABLE users" session=42 user=app@10.0.0.1 state=Sleep duration=2m0s query="SELECT 1"
+`, buf.String())
+}
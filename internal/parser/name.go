@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// words splits the string into words separated by any non-alphanumeric character.
+func words(str string) []string {
+	return strings.FieldsFunc(str, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// SnakeCase converts the migration name to lower snake case, e.g. "Create Users" -> "create_users".
+func SnakeCase(str string) string {
+	parts := words(str)
+	for i, part := range parts {
+		parts[i] = strings.ToLower(part)
+	}
+	return strings.Join(parts, "_")
+}
+
+// CamelCase converts the migration name to camel case, e.g. "create_users" -> "CreateUsers".
+func CamelCase(str string) string {
+	var b strings.Builder
+	for _, part := range words(str) {
+		runes := []rune(strings.ToLower(part))
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/akfaiz/migris/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "create_users_table", want: "create_users_table"},
+		{input: "Create Users", want: "create_users"},
+		{input: "add-email-to-users", want: "add_email_to_users"},
+		{input: "__drop__posts__", want: "drop_posts"},
+		{input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, parser.SnakeCase(tt.input))
+		})
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "create_users_table", want: "CreateUsersTable"},
+		{input: "Create Users", want: "CreateUsers"},
+		{input: "add_email_v2", want: "AddEmailV2"},
+		{input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, parser.CamelCase(tt.input))
+		})
+	}
+}
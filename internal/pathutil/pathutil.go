@@ -0,0 +1,51 @@
+// Package pathutil provides OS-agnostic helpers for migration file paths.
+//
+// Migration sources may come from runtime.Caller (always forward slashes), from user input
+// (either separator on Windows) or from the file system. These helpers accept both separators
+// so the same path is interpreted identically on every OS.
+package pathutil
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ToSlash converts both Windows and Unix separators in p to forward slashes.
+func ToSlash(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
+
+// Base returns the last element of p, treating both `/` and `\` as separators.
+func Base(p string) string {
+	return path.Base(ToSlash(p))
+}
+
+// Clean returns the shortest equivalent of p using the separator of the current OS.
+func Clean(p string) string {
+	return filepath.Clean(filepath.FromSlash(ToSlash(p)))
+}
+
+// Join joins the directory and the file name using the separator of the current OS.
+func Join(dir, name string) string {
+	return filepath.Join(Clean(dir), name)
+}
+
+// ExistsFold reports whether dir contains an entry whose name equals name under
+// case-folding, mirroring the behavior of case-insensitive file systems.
+func ExistsFold(dir, name string) (bool, error) {
+	entries, err := os.ReadDir(Clean(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
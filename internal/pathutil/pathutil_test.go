@@ -0,0 +1,52 @@
+package pathutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "migrations/20250904164848_create_users_table.go", want: "20250904164848_create_users_table.go"},
+		{input: `C:\app\migrations\20250904164848_create_users_table.go`, want: "20250904164848_create_users_table.go"},
+		{input: `db\migrations/00001_init.sql`, want: "00001_init.sql"},
+		{input: "00001_init.sql", want: "00001_init.sql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, pathutil.Base(tt.input))
+		})
+	}
+}
+
+func TestClean(t *testing.T) {
+	assert.Equal(t, filepath.Join("db", "migrations"), pathutil.Clean(`db\migrations\`))
+	assert.Equal(t, filepath.Join("db", "migrations"), pathutil.Clean("db/./migrations/"))
+	assert.Equal(t, filepath.Join("db", "migrations", "x.go"), pathutil.Join(`db\migrations`, "x.go"))
+}
+
+func TestExistsFold(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00001_Create_Users.go"), nil, 0o600))
+
+	exists, err := pathutil.ExistsFold(dir, "00001_create_users.go")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = pathutil.ExistsFold(dir, "00002_create_posts.go")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = pathutil.ExistsFold(filepath.Join(dir, "missing"), "00001_create_users.go")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
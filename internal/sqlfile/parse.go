@@ -0,0 +1,86 @@
+package sqlfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Migration is a goose SQL migration split into its statements.
+type Migration struct {
+	Up            []string
+	Down          []string
+	NoTransaction bool
+}
+
+// Parse splits a goose SQL migration into the statements of its up and down sections the way
+// goose executes them: a statement ends at a line ending with a semicolon, unless it is enclosed
+// in -- +goose StatementBegin and -- +goose StatementEnd, e.g. a function body. Comment lines
+// outside of such blocks are dropped. The statements are returned without their final semicolon.
+func Parse(content []byte) (*Migration, error) {
+	var (
+		migration Migration
+		section   *[]string
+		buf       strings.Builder
+		block     bool
+	)
+	flush := func() {
+		statement := strings.TrimSuffix(strings.TrimSpace(buf.String()), ";")
+		if statement = strings.TrimSpace(statement); statement != "" {
+			*section = append(*section, statement)
+		}
+		buf.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(normalizeNewlines(content)))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(content)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if fields := strings.Fields(strings.TrimPrefix(trimmed, "--")); strings.HasPrefix(trimmed, "--") &&
+			len(fields) > 0 && fields[0] == "+goose" {
+			switch strings.ToLower(strings.Join(fields[1:], " ")) {
+			case "up":
+				section = &migration.Up
+			case "down":
+				section = &migration.Down
+			case "statementbegin":
+				block = true
+			case "statementend":
+				if section != nil {
+					flush()
+				}
+				block = false
+			case "no transaction":
+				migration.NoTransaction = true
+			case "envsub on", "envsub off":
+				return nil, fmt.Errorf("unsupported annotation %q", trimmed)
+			default:
+				return nil, fmt.Errorf("unknown annotation %q", trimmed)
+			}
+			continue
+		}
+		if section == nil {
+			if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+				continue
+			}
+			return nil, fmt.Errorf("statement %q outside of a -- +goose Up or Down section", trimmed)
+		}
+		if !block && (strings.HasPrefix(trimmed, "--") || (trimmed == "" && buf.Len() == 0)) {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+		if !block && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if section != nil && strings.TrimSpace(buf.String()) != "" {
+		return nil, fmt.Errorf("statement %q is not terminated by a semicolon", strings.TrimSpace(buf.String()))
+	}
+	return &migration, nil
+}
@@ -0,0 +1,57 @@
+package sqlfile_test
+
+import (
+	"testing"
+
+	"github.com/akfaiz/migris/internal/sqlfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	migration, err := sqlfile.Parse([]byte(`-- +goose NO TRANSACTION
+-- +goose Up
+-- Create the users table.
+CREATE TABLE users (
+  id bigint
+);
+CREATE INDEX CONCURRENTLY idx_users_id ON users (id);
+
+-- +goose StatementBegin
+CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION touch();
+DROP TABLE users;
+`))
+	require.NoError(t, err)
+	assert.Equal(t, &sqlfile.Migration{
+		Up: []string{
+			"CREATE TABLE users (\n  id bigint\n)",
+			"CREATE INDEX CONCURRENTLY idx_users_id ON users (id)",
+			"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+		},
+		Down:          []string{"DROP FUNCTION touch()", "DROP TABLE users"},
+		NoTransaction: true,
+	}, migration)
+
+	crlf, err := sqlfile.Parse([]byte("-- +goose Up\r\nSELECT 1;\r\n-- +goose Down\r\nSELECT 2;\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &sqlfile.Migration{Up: []string{"SELECT 1"}, Down: []string{"SELECT 2"}}, crlf)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := sqlfile.Parse([]byte("SELECT 1;\n"))
+	require.EqualError(t, err, `statement "SELECT 1;" outside of a -- +goose Up or Down section`)
+
+	_, err = sqlfile.Parse([]byte("-- +goose Up\nSELECT 1\n"))
+	require.EqualError(t, err, `statement "SELECT 1" is not terminated by a semicolon`)
+
+	_, err = sqlfile.Parse([]byte("-- +goose Up\n-- +goose ENVSUB ON\nSELECT '${NAME}';\n"))
+	require.EqualError(t, err, `unsupported annotation "-- +goose ENVSUB ON"`)
+}
@@ -0,0 +1,163 @@
+// Package sqlfile adds support for migrations split into an up and a down SQL file, e.g.
+// 0001_create_users.up.sql and 0001_create_users.down.sql.
+//
+// Goose only understands a single SQL file per migration, annotated with -- +goose Up and
+// -- +goose Down. FS presents every pair of files as such a file, so the pair is parsed and
+// executed by goose with the same versioning as the other migrations.
+//
+// The line endings of every SQL migration are normalized to \n, so files checked out with CRLF
+// line endings on Windows are annotated, split and checksummed like their LF counterparts.
+package sqlfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+// FS wraps a migrations file system so that up and down SQL files are merged into a single
+// goose SQL migration. Only the root directory of the file system is considered.
+type FS struct {
+	fsys fs.FS
+}
+
+// New returns a file system that merges the up and down SQL files of fsys.
+func New(fsys fs.FS) *FS {
+	return &FS{fsys: fsys}
+}
+
+// Open opens the named file. Up and down SQL files are hidden; the merged migration is
+// available under the name without the .up/.down part. SQL files of the root directory are
+// returned with normalized line endings.
+func (f *FS) Open(name string) (fs.File, error) {
+	if isSplitFile(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if path.Dir(name) == "." && strings.HasSuffix(name, ".sql") {
+		content, ok, err := f.merge(strings.TrimSuffix(name, ".sql"))
+		if err == nil && ok {
+			if _, statErr := fs.Stat(f.fsys, name); statErr == nil {
+				err = errors.New("migration conflicts with its .up.sql/.down.sql files")
+			}
+		}
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		if !ok {
+			if content, err = fs.ReadFile(f.fsys, name); err != nil {
+				return nil, err
+			}
+			content = normalizeNewlines(content)
+		}
+		return &file{name: name, Reader: bytes.NewReader(content), size: int64(len(content))}, nil
+	}
+	return f.fsys.Open(name)
+}
+
+// ReadDir reads the named directory, replacing the up and down SQL files of the root directory
+// with the merged migrations. Invalid pairs are reported when the merged migration is opened.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(f.fsys, name)
+	if err != nil || name != "." {
+		return entries, err
+	}
+
+	result := make([]fs.DirEntry, 0, len(entries))
+	existing := make(map[string]bool)
+	merged := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() || !isSplitFile(entry.Name()) {
+			result = append(result, entry)
+			existing[entry.Name()] = true
+			continue
+		}
+		merged[stem(entry.Name())+".sql"] = true
+	}
+	for mergedName := range merged {
+		if existing[mergedName] {
+			continue
+		}
+		content, _, _ := f.merge(strings.TrimSuffix(mergedName, ".sql"))
+		result = append(result, fs.FileInfoToDirEntry(&fileInfo{name: mergedName, size: int64(len(content))}))
+	}
+	slices.SortFunc(result, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return result, nil
+}
+
+// merge builds the goose SQL migration of the given stem. It reports false when the file system
+// has no up or down file for the stem.
+func (f *FS) merge(stem string) ([]byte, bool, error) {
+	up, upErr := fs.ReadFile(f.fsys, stem+upSuffix)
+	down, downErr := fs.ReadFile(f.fsys, stem+downSuffix)
+	if upErr != nil && downErr != nil {
+		return nil, false, nil
+	}
+	if upErr != nil {
+		return nil, true, fmt.Errorf("migration %s has no %s file", stem+downSuffix, upSuffix)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("-- +goose Up\n")
+	buf.Write(normalizeNewlines(up))
+	if downErr == nil {
+		buf.WriteString("\n-- +goose Down\n")
+		buf.Write(normalizeNewlines(down))
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), true, nil
+}
+
+// normalizeNewlines replaces CRLF line endings with LF.
+func normalizeNewlines(content []byte) []byte {
+	return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+}
+
+func isSplitFile(name string) bool {
+	return strings.HasSuffix(name, upSuffix) || strings.HasSuffix(name, downSuffix)
+}
+
+func stem(name string) string {
+	if s, ok := strings.CutSuffix(name, upSuffix); ok {
+		return s
+	}
+	return strings.TrimSuffix(name, downSuffix)
+}
+
+type file struct {
+	*bytes.Reader
+
+	name string
+	size int64
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return &fileInfo{name: f.name, size: f.size}, nil
+}
+
+func (f *file) Close() error {
+	return nil
+}
+
+type fileInfo struct {
+	name string
+	size int64
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() fs.FileMode  { return 0o444 }
+func (fi *fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi *fileInfo) IsDir() bool        { return false }
+func (fi *fileInfo) Sys() any           { return nil }
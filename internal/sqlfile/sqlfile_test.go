@@ -0,0 +1,87 @@
+package sqlfile_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/akfaiz/migris/internal/sqlfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFS(t *testing.T) {
+	fsys := sqlfile.New(fstest.MapFS{
+		"00001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id bigint);")},
+		"00001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"00002_add_index.up.sql":      {Data: []byte("CREATE INDEX idx_users_id ON users (id);")},
+		"00003_seed.sql":              {Data: []byte("-- +goose Up\nSELECT 1;\n")},
+		"00004_backfill.go":           {Data: []byte("package migrations\n")},
+	})
+
+	t.Run("lists merged migrations", func(t *testing.T) {
+		files, err := fs.Glob(fsys, "*.sql")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"00001_create_users.sql", "00002_add_index.sql", "00003_seed.sql"}, files)
+
+		files, err = fs.Glob(fsys, "*.go")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"00004_backfill.go"}, files)
+	})
+
+	t.Run("merges up and down files", func(t *testing.T) {
+		content, err := fs.ReadFile(fsys, "00001_create_users.sql")
+		require.NoError(t, err)
+		assert.Equal(t, "-- +goose Up\nCREATE TABLE users (id bigint);\n-- +goose Down\nDROP TABLE users;\n", string(content))
+
+		content, err = fs.ReadFile(fsys, "00002_add_index.sql")
+		require.NoError(t, err)
+		assert.Equal(t, "-- +goose Up\nCREATE INDEX idx_users_id ON users (id);\n", string(content))
+	})
+
+	t.Run("keeps goose sql files", func(t *testing.T) {
+		content, err := fs.ReadFile(fsys, "00003_seed.sql")
+		require.NoError(t, err)
+		assert.Equal(t, "-- +goose Up\nSELECT 1;\n", string(content))
+	})
+
+	t.Run("hides up and down files", func(t *testing.T) {
+		_, err := fsys.Open("00001_create_users.up.sql")
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestFS_InvalidPairs(t *testing.T) {
+	fsys := sqlfile.New(fstest.MapFS{
+		"00001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"00002_create_posts.up.sql":   {Data: []byte("CREATE TABLE posts (id bigint);")},
+		"00002_create_posts.sql":      {Data: []byte("-- +goose Up\nSELECT 1;\n")},
+	})
+
+	files, err := fs.Glob(fsys, "*.sql")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"00001_create_users.sql", "00002_create_posts.sql"}, files)
+
+	_, err = fs.ReadFile(fsys, "00001_create_users.sql")
+	require.ErrorContains(t, err, "has no .up.sql file")
+
+	_, err = fs.ReadFile(fsys, "00002_create_posts.sql")
+	require.ErrorContains(t, err, "conflicts")
+}
+
+func TestFS_CRLF(t *testing.T) {
+	fsys := sqlfile.New(fstest.MapFS{
+		"00001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (\r\n  id bigint\r\n);\r\n")},
+		"00001_create_users.down.sql": {Data: []byte("DROP TABLE users;\r\n")},
+		"00002_seed.sql":              {Data: []byte("-- +goose Up\r\nSELECT 1;\r\n-- +goose Down\r\nSELECT 2;\r\n")},
+	})
+
+	content, err := fs.ReadFile(fsys, "00001_create_users.sql")
+	require.NoError(t, err)
+	assert.Equal(t, "-- +goose Up\nCREATE TABLE users (\n  id bigint\n);\n\n-- +goose Down\nDROP TABLE users;\n\n",
+		string(content))
+
+	content, err = fs.ReadFile(fsys, "00002_seed.sql")
+	require.NoError(t, err)
+	assert.Equal(t, "-- +goose Up\nSELECT 1;\n-- +goose Down\nSELECT 2;\n", string(content))
+}
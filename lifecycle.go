@@ -0,0 +1,228 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/pressly/goose/v3"
+)
+
+// Hooks are called around the migrations of a run, e.g. to emit metrics, send notifications or
+// record audit logs. Every hook is optional. Hooks are not called in dry-run mode.
+type Hooks struct {
+	// BeforeMigration is called before a migration is applied or rolled back.
+	BeforeMigration func(ctx context.Context, event MigrationEvent)
+	// AfterMigration is called after a migration was applied or rolled back successfully.
+	AfterMigration func(ctx context.Context, event MigrationEvent)
+	// OnError is called when a migration fails; the run stops afterwards.
+	OnError func(ctx context.Context, event MigrationEvent)
+	// BeforeBatch is called before the migrations of a run.
+	BeforeBatch func(ctx context.Context, event BatchEvent)
+	// AfterBatch is called after the migrations of a run, also when one of them failed.
+	AfterBatch func(ctx context.Context, event BatchEvent)
+}
+
+// MigrationEvent describes a single migration passed to the lifecycle hooks.
+type MigrationEvent struct {
+	Version   int64
+	Name      string
+	Direction string        // "up" or "down"
+	Duration  time.Duration // zero for BeforeMigration
+	Err       error         // set for OnError
+	Author    string        // see Author and the -- author: annotation
+	Ticket    string        // see Ticket and the -- ticket: annotation
+}
+
+// BatchEvent describes a run of migrations passed to the lifecycle hooks.
+type BatchEvent struct {
+	Direction  string        // "up" or "down"
+	Migrations int           // migrations to run for BeforeBatch, migrations run successfully for AfterBatch
+	Duration   time.Duration // zero for BeforeBatch
+	Err        error         // the error that stopped the run, if any
+}
+
+func (h Hooks) beforeMigration(ctx context.Context, event MigrationEvent) {
+	if h.BeforeMigration != nil {
+		h.BeforeMigration(ctx, event)
+	}
+}
+
+func (h Hooks) afterMigration(ctx context.Context, event MigrationEvent) {
+	if h.AfterMigration != nil {
+		h.AfterMigration(ctx, event)
+	}
+}
+
+func (h Hooks) onError(ctx context.Context, event MigrationEvent) {
+	if h.OnError != nil {
+		h.OnError(ctx, event)
+	}
+}
+
+func (h Hooks) beforeBatch(ctx context.Context, event BatchEvent) {
+	if h.BeforeBatch != nil {
+		h.BeforeBatch(ctx, event)
+	}
+}
+
+func (h Hooks) afterBatch(ctx context.Context, event BatchEvent) {
+	if h.AfterBatch != nil {
+		h.AfterBatch(ctx, event)
+	}
+}
+
+// upTo applies the pending migrations one at a time in the given order, calling the lifecycle
+// hooks around each of them and the pre-run and post-run statements around all of them.
+func (m *Migrate) upTo(
+	ctx context.Context,
+	provider *goose.Provider,
+	pending []*goose.Source,
+) (_ []*goose.MigrationResult, err error) {
+	session, err := m.startRunSQL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer session.finish(ctx, &err)
+
+	b := m.startBatch(ctx, directionUp, len(pending))
+	for _, source := range pending {
+		m.hooks.beforeMigration(ctx, m.migrationEvent(source, directionUp))
+		migrationCtx, cancel := m.migrationContext(ctx, source)
+		var result *goose.MigrationResult
+		var err error
+		if m.classPolicy == ClassPolicyInterleaved {
+			result, err = provider.UpByOne(migrationCtx)
+		} else {
+			result, err = provider.ApplyVersion(migrationCtx, source.Version, true)
+		}
+		cancel()
+		if err != nil {
+			return b.fail(source, err)
+		}
+		b.succeed(result)
+	}
+	return b.finish()
+}
+
+// downTo rolls back the applied migrations newer than version one at a time, calling the
+// lifecycle hooks around each of them and the pre-run and post-run statements around all of
+// them. A version of -1 rolls back only the last migration.
+func (m *Migrate) downTo(
+	ctx context.Context,
+	provider *goose.Provider,
+	version int64,
+) (_ []*goose.MigrationResult, err error) {
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[int64]*goose.Source, len(statuses))
+	var rollback []int64
+	for _, status := range statuses {
+		sources[status.Source.Version] = status.Source
+		if status.State == goose.StateApplied && status.Source.Version > version {
+			rollback = append(rollback, status.Source.Version)
+		}
+	}
+	slices.SortFunc(rollback, compareVersionsDesc)
+	if version < 0 {
+		rollback = rollback[:min(len(rollback), 1)]
+	}
+	planned := len(rollback)
+	if err = m.acknowledgeDataLoss(ctx, rollback); err != nil {
+		return nil, err
+	}
+	session, err := m.startRunSQL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer session.finish(ctx, &err)
+
+	b := m.startBatch(ctx, directionDown, planned)
+	for range planned {
+		current, err := provider.GetDBVersion(ctx)
+		if err != nil {
+			return b.fail(nil, err)
+		}
+		source, ok := sources[current]
+		if !ok {
+			source = &goose.Source{Type: goose.TypeGo, Version: current}
+		}
+		m.hooks.beforeMigration(ctx, m.migrationEvent(source, directionDown))
+		migrationCtx, cancel := m.migrationContext(ctx, source)
+		result, err := provider.Down(migrationCtx)
+		cancel()
+		if err != nil {
+			return b.fail(source, err)
+		}
+		b.succeed(result)
+	}
+	return b.finish()
+}
+
+// batch tracks a run of migrations for the lifecycle hooks.
+type batch struct {
+	m         *Migrate
+	ctx       context.Context
+	direction string
+	start     time.Time
+	results   []*goose.MigrationResult
+}
+
+func (m *Migrate) startBatch(ctx context.Context, direction string, migrations int) *batch {
+	m.hooks.beforeBatch(ctx, BatchEvent{Direction: direction, Migrations: migrations})
+	return &batch{m: m, ctx: ctx, direction: direction, start: m.clock.Now()}
+}
+
+func (b *batch) succeed(result *goose.MigrationResult) {
+	b.results = append(b.results, result)
+	event := b.m.migrationEvent(result.Source, b.direction)
+	event.Duration = result.Duration
+	b.m.hooks.afterMigration(b.ctx, event)
+}
+
+// fail reports the failed migration and the end of the batch. The error is returned as a
+// goose.PartialError with the migrations applied before it, like goose does for a whole run.
+func (b *batch) fail(source *goose.Source, err error) ([]*goose.MigrationResult, error) {
+	var partialErr *goose.PartialError
+	if errors.As(err, &partialErr) {
+		err = &goose.PartialError{Applied: b.results, Failed: partialErr.Failed, Err: partialErr.Err}
+	}
+	if source != nil {
+		event := b.m.migrationEvent(source, b.direction)
+		event.Err = err
+		if partialErr != nil && partialErr.Failed != nil {
+			event.Duration = partialErr.Failed.Duration
+		}
+		b.m.hooks.onError(b.ctx, event)
+	}
+	b.m.hooks.afterBatch(b.ctx, b.event(err))
+	return b.results, err
+}
+
+func (b *batch) finish() ([]*goose.MigrationResult, error) {
+	b.m.hooks.afterBatch(b.ctx, b.event(nil))
+	return b.results, nil
+}
+
+func (b *batch) event(err error) BatchEvent {
+	return BatchEvent{
+		Direction:  b.direction,
+		Migrations: len(b.results),
+		Duration:   b.m.clock.Now().Sub(b.start),
+		Err:        err,
+	}
+}
+
+func (m *Migrate) migrationEvent(source *goose.Source, direction string) MigrationEvent {
+	md := sourceMetadata(source, m.migrationsFS())
+	return MigrationEvent{
+		Version:   source.Version,
+		Name:      migrationName(source),
+		Direction: direction,
+		Author:    md.author,
+		Ticket:    md.ticket,
+	}
+}
@@ -0,0 +1,66 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchHooks(t *testing.T) {
+	var events []string
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m, err := New("pgx",
+		WithClock(ClockFunc(func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		})),
+		WithHooks(Hooks{
+			BeforeMigration: func(_ context.Context, e MigrationEvent) {
+				events = append(events, fmt.Sprintf("before %s %d %s", e.Direction, e.Version, e.Name))
+			},
+			AfterMigration: func(_ context.Context, e MigrationEvent) {
+				events = append(events, fmt.Sprintf("after %s %d %s", e.Direction, e.Version, e.Duration))
+			},
+			OnError: func(_ context.Context, e MigrationEvent) {
+				events = append(events, fmt.Sprintf("error %s %d %s %v", e.Direction, e.Version, e.Duration, e.Err))
+			},
+			BeforeBatch: func(_ context.Context, e BatchEvent) {
+				events = append(events, fmt.Sprintf("before batch %s %d", e.Direction, e.Migrations))
+			},
+			AfterBatch: func(_ context.Context, e BatchEvent) {
+				events = append(events, fmt.Sprintf("after batch %s %d %s %v", e.Direction, e.Migrations, e.Duration, e.Err))
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	createUsers := &goose.Source{Type: goose.TypeSQL, Path: "20250101000000_create_users.sql", Version: 20250101000000}
+	createPosts := &goose.Source{Type: goose.TypeSQL, Path: "20250102000000_create_posts.sql", Version: 20250102000000}
+	failed := &goose.MigrationResult{Source: createPosts, Duration: 3 * time.Millisecond}
+
+	b := m.startBatch(t.Context(), directionUp, 2)
+	m.hooks.beforeMigration(t.Context(), m.migrationEvent(createUsers, directionUp))
+	b.succeed(&goose.MigrationResult{Source: createUsers, Duration: 2 * time.Millisecond})
+	m.hooks.beforeMigration(t.Context(), m.migrationEvent(createPosts, directionUp))
+	results, err := b.fail(createPosts, &goose.PartialError{Failed: failed, Err: errors.New("boom")})
+
+	require.Len(t, results, 1)
+	var partialErr *goose.PartialError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Len(t, partialErr.Applied, 1)
+	assert.Same(t, failed, partialErr.Failed)
+	assert.Equal(t, []string{
+		"before batch up 2",
+		"before up 20250101000000 20250101000000_create_users.sql",
+		"after up 20250101000000 2ms",
+		"before up 20250102000000 20250102000000_create_posts.sql",
+		"error up 20250102000000 3ms " + err.Error(),
+		"after batch up 1 1s " + err.Error(),
+	}, events)
+}
@@ -0,0 +1,207 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/parser"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+)
+
+// ErrLintViolation is returned by Lint for every migration that violates the lint policy, and by
+// Create for a name that does not match it.
+var ErrLintViolation = errors.New("migration violates the lint policy")
+
+// LintPolicy holds the conventions migrations have to follow, so they are enforced by Create and
+// Lint instead of in code review. Every check is disabled by default.
+type LintPolicy struct {
+	// RequireDown requires every migration to have a down migration. Go migrations without one are
+	// added with NoDown, SQL migrations are annotated with -- +migris NO DOWN.
+	RequireDown bool
+	// NamePattern is the pattern the names of migrations have to match, e.g.
+	// ^(create|add|drop|alter)_[a-z0-9_]+$. The name is the snake case part of the file name after
+	// the version, without the extension.
+	NamePattern *regexp.Regexp
+	// SingleTable allows every migration to create, alter, drop or index only one table.
+	SingleTable bool
+	// Dialects compiles the Go migrations for each of the given dialects, e.g. "postgres" and
+	// "mysql", and reports the migrations that fail for any of them, annotated with the dialect.
+	// It catches portability breaks of migrations meant to run on several databases in CI without
+	// a database of each.
+	Dialects []string
+}
+
+// noDownAnnotation declares that a SQL migration has no down migration on purpose.
+var noDownAnnotation = regexp.MustCompile(`(?mi)^--\s*\+migris\s+NO\s+DOWN\b`)
+
+// tableStatementPatterns match the DDL statements of a table and capture its name.
+var tableStatementPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)^(?:CREATE\s+(?:TEMPORARY\s+)?TABLE|ALTER\s+TABLE|DROP\s+TABLE|TRUNCATE(?:\s+TABLE)?)` +
+		`\s+(?:IF\s+(?:NOT\s+)?EXISTS\s+)?(?:ONLY\s+)?([\w.\x60"]+)`),
+	regexp.MustCompile(`(?is)^CREATE\s+(?:UNIQUE\s+|FULLTEXT\s+|SPATIAL\s+)?INDEX\s.*?\bON\s+` +
+		`(?:ONLY\s+)?([\w.\x60"]+)`),
+	regexp.MustCompile(`(?is)^DROP\s+INDEX\s+\S+\s+ON\s+([\w.\x60"]+)`),
+	regexp.MustCompile(`(?is)^COMMENT\s+ON\s+TABLE\s+([\w.\x60"]+)`),
+}
+
+// WithLintPolicy sets the conventions migrations have to follow, see LintPolicy.
+func WithLintPolicy(policy LintPolicy) Option {
+	return func(m *Migrate) {
+		m.lintPolicy = policy
+	}
+}
+
+// NoDown declares that a Go migration has no down migration on purpose, e.g. because the data it
+// changes cannot be restored, so Lint accepts it when the lint policy requires down migrations.
+//
+// Example:
+//
+//	func init() {
+//	    migris.AddMigrationContext(upNormalizeEmails, nil, migris.NoDown())
+//	}
+func NoDown() MigrationOption {
+	return func(m *Migration) {
+		m.noDown = true
+	}
+}
+
+// Lint checks the Go migrations and the SQL migration files against the lint policy, e.g. in CI,
+// and reports every violation with an error wrapping ErrLintViolation. The statements of Go
+// migrations are captured like in dry-run mode, so no database connection is needed.
+func (m *Migrate) Lint(ctx context.Context) error {
+	var errs []error
+	for _, migration := range registeredMigrations {
+		up, err := capturedStatements(ctx, migration.upFunc(m.dependencies))
+		if err != nil {
+			return fmt.Errorf("failed to lint migration %s: %w", migration.source, err)
+		}
+		down, err := capturedStatements(ctx, migration.downFunc(m.dependencies))
+		if err != nil {
+			return fmt.Errorf("failed to lint migration %s: %w", migration.source, err)
+		}
+		filename := pathutil.Base(migration.source)
+		errs = append(errs, m.lintPolicy.check(filename, up, down, migration.noDown)...)
+		errs = append(errs, m.lintPolicy.checkDialects(ctx, filename, migration.upFunc(m.dependencies),
+			migration.downFunc(m.dependencies))...)
+	}
+
+	fsys := m.migrationsFS()
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		if _, err = goose.NumericComponent(entry.Name()); err != nil {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return err
+		}
+		errs = append(errs, m.lintPolicy.check(entry.Name(), upStatements(content), downStatements(content),
+			noDownAnnotation.Match(content))...)
+	}
+	return errors.Join(errs...)
+}
+
+// check returns the violations of the migration with the given file name and statements.
+func (p LintPolicy) check(filename string, up, down []string, noDown bool) []error {
+	var errs []error
+	if name := migrationBaseName(filename); !p.matchesName(name) {
+		errs = append(errs, fmt.Errorf("%w: %s: name %s does not match %s",
+			ErrLintViolation, filename, name, p.NamePattern))
+	}
+	if p.RequireDown && len(down) == 0 && !noDown {
+		errs = append(errs, fmt.Errorf("%w: %s: the down migration is empty; declare it with NoDown "+
+			"or -- +migris NO DOWN if it has none on purpose", ErrLintViolation, filename))
+	}
+	if tables := statementTables(up); p.SingleTable && len(tables) > 1 {
+		errs = append(errs, fmt.Errorf("%w: %s: changes %d tables: %s",
+			ErrLintViolation, filename, len(tables), strings.Join(tables, ", ")))
+	}
+	return errs
+}
+
+// checkDialects returns the errors of the up and down migration of a Go migration compiled for the
+// dialects of the policy.
+func (p LintPolicy) checkDialects(ctx context.Context, filename string, up, down MigrationContext) []error {
+	if len(p.Dialects) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, fn := range []MigrationContext{up, down} {
+		if fn == nil {
+			continue
+		}
+		if _, err := schema.CompileDialects(ctx, fn, p.Dialects...); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s: %w", ErrLintViolation, filename, err))
+		}
+	}
+	return errs
+}
+
+// matchesName reports whether the snake case name of a migration matches the name pattern.
+func (p LintPolicy) matchesName(name string) bool {
+	return p.NamePattern == nil || p.NamePattern.MatchString(name)
+}
+
+// migrationBaseName returns the name of the migration file after the version, without the
+// extension.
+func migrationBaseName(filename string) string {
+	name := strings.TrimSuffix(filename, path.Ext(filename))
+	if _, after, ok := strings.Cut(name, "_"); ok {
+		return after
+	}
+	return name
+}
+
+// statementTables returns the tables the statements create, alter, drop or index, in order of
+// appearance.
+func statementTables(statements []string) []string {
+	var tables []string
+	for _, statement := range statements {
+		for _, pattern := range tableStatementPatterns {
+			match := pattern.FindStringSubmatch(strings.TrimSpace(statement))
+			if match == nil {
+				continue
+			}
+			if table := strings.Trim(match[1], "`\""); !slices.Contains(tables, table) {
+				tables = append(tables, table)
+			}
+			break
+		}
+	}
+	return tables
+}
+
+// capturedStatements returns the statements fn runs, captured like in dry-run mode.
+func capturedStatements(ctx context.Context, fn MigrationContext) ([]string, error) {
+	if fn == nil {
+		return nil, nil
+	}
+	dryRunCtx := schema.NewDryRunContext(ctx)
+	if err := fn(dryRunCtx); err != nil {
+		return nil, err
+	}
+	return dryRunCtx.GetCapturedSQL(), nil
+}
+
+// checkCreateName fails if the name of a migration created with Create does not match the name
+// pattern of the lint policy.
+func (m *Migrate) checkCreateName(name string) error {
+	if name = parser.SnakeCase(name); !m.lintPolicy.matchesName(name) {
+		return fmt.Errorf("%w: name %s does not match %s", ErrLintViolation, name, m.lintPolicy.NamePattern)
+	}
+	return nil
+}
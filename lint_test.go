@@ -0,0 +1,119 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementTables(t *testing.T) {
+	got := statementTables([]string{
+		"CREATE TABLE IF NOT EXISTS users (id BIGINT)",
+		"CREATE UNIQUE INDEX uq_users_email ON users (email)",
+		"ALTER TABLE ONLY \"orders\" ADD COLUMN note TEXT",
+		"DROP INDEX idx_tags_name ON `tags`",
+		"COMMENT ON TABLE audit.events IS 'Events'",
+		"INSERT INTO schema_renames (table_name) VALUES ('users')",
+	})
+	assert.Equal(t, []string{"users", "orders", "tags", "audit.events"}, got)
+}
+
+func TestMigrationBaseName(t *testing.T) {
+	assert.Equal(t, "add_email_to_users", migrationBaseName("20250101000000_add_email_to_users.go"))
+	assert.Equal(t, "create_tags", migrationBaseName("20250101000000_create_tags.sql"))
+	assert.Equal(t, "20250101000000", migrationBaseName("20250101000000.sql"))
+}
+
+func TestMigrate_Lint(t *testing.T) {
+	addEmail := func(c schema.Context) error {
+		return schema.Table(c, "users", func(table *schema.Blueprint) {
+			table.String("email")
+		})
+	}
+	dropEmail := func(c schema.Context) error {
+		return schema.Table(c, "users", func(table *schema.Blueprint) {
+			table.DropColumn("email")
+		})
+	}
+	linkOrders := func(c schema.Context) error {
+		if err := addEmail(c); err != nil {
+			return err
+		}
+		return schema.Table(c, "orders", func(table *schema.Blueprint) {
+			table.String("email")
+		})
+	}
+	reversible, err := newMigration("20250101000000_add_email_to_users.go", addEmail, dropEmail)
+	require.NoError(t, err)
+	withoutDown, err := newMigration("20250102000000_add_email_again.go", addEmail, nil)
+	require.NoError(t, err)
+	declared, err := newMigration("20250103000000_add_email_once_more.go", addEmail, nil, NoDown())
+	require.NoError(t, err)
+	twoTables, err := newMigration("20250104000000_link_orders.go", linkOrders, dropEmail)
+	require.NoError(t, err)
+	withRegisteredMigrations(t, reversible, withoutDown, declared, twoTables)
+
+	fsys := fstest.MapFS{
+		"20250105000000_create_tags.sql": {Data: []byte(
+			"-- +goose Up\nCREATE TABLE tags (id BIGINT);\n-- +goose Down\nDROP TABLE tags;\n")},
+		"20250106000000_seed_tags.sql": {Data: []byte(
+			"-- +goose Up\nINSERT INTO tags (id) VALUES (1);\n-- +goose Down\n")},
+		"20250107000000_backfill_tags.sql": {Data: []byte(
+			"-- +migris NO DOWN\n-- +goose Up\nUPDATE tags SET id = id;\n")},
+		"README.md": {Data: []byte("# Migrations\n")},
+	}
+
+	t.Run("without a policy", func(t *testing.T) {
+		m, err := New("pgx", WithFS(fsys))
+		require.NoError(t, err)
+		require.NoError(t, m.Lint(t.Context()))
+	})
+
+	t.Run("with a policy", func(t *testing.T) {
+		m, err := New("pgx", WithFS(fsys), WithLintPolicy(LintPolicy{
+			RequireDown: true,
+			NamePattern: regexp.MustCompile(`^(create|add|drop|alter|backfill)_[a-z0-9_]+$`),
+			SingleTable: true,
+		}))
+		require.NoError(t, err)
+
+		err = m.Lint(t.Context())
+		require.ErrorIs(t, err, ErrLintViolation)
+		assert.EqualError(t, err, "migration violates the lint policy: 20250102000000_add_email_again.go: "+
+			"the down migration is empty; declare it with NoDown or -- +migris NO DOWN if it has none on purpose\n"+
+			"migration violates the lint policy: 20250104000000_link_orders.go: "+
+			"name link_orders does not match ^(create|add|drop|alter|backfill)_[a-z0-9_]+$\n"+
+			"migration violates the lint policy: 20250104000000_link_orders.go: changes 2 tables: users, orders\n"+
+			"migration violates the lint policy: 20250106000000_seed_tags.sql: "+
+			"name seed_tags does not match ^(create|add|drop|alter|backfill)_[a-z0-9_]+$\n"+
+			"migration violates the lint policy: 20250106000000_seed_tags.sql: "+
+			"the down migration is empty; declare it with NoDown or -- +migris NO DOWN if it has none on purpose")
+	})
+}
+
+func TestMigrate_LintDialects(t *testing.T) {
+	truncate := func(c schema.Context) error {
+		return schema.Truncate(c, "users", schema.TruncateOptions{Cascade: true})
+	}
+	portable, err := newMigration("20250101000000_add_email_to_users.go", func(c schema.Context) error {
+		return schema.Table(c, "users", func(table *schema.Blueprint) {
+			table.String("email")
+		})
+	}, nil)
+	require.NoError(t, err)
+	postgresOnly, err := newMigration("20250102000000_truncate_users.go", truncate, nil)
+	require.NoError(t, err)
+	withRegisteredMigrations(t, portable, postgresOnly)
+
+	m, err := New("pgx", WithFS(fstest.MapFS{}), WithLintPolicy(LintPolicy{Dialects: []string{"postgres", "mysql"}}))
+	require.NoError(t, err)
+	err = m.Lint(t.Context())
+	require.ErrorIs(t, err, ErrLintViolation)
+	require.ErrorIs(t, err, schema.ErrUnsupportedFeature)
+	assert.EqualError(t, err, "migration violates the lint policy: 20250102000000_truncate_users.go: "+
+		"mysql: unsupported feature: mysql cannot truncate the tables referencing a table")
+}
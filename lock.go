@@ -0,0 +1,230 @@
+package migris
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// lockDiagnosticsInterval is how often the sessions holding a lock are logged while a statement
+// waits for it.
+var lockDiagnosticsInterval = 5 * time.Second
+
+// LockWait configures how the statements of Go migrations wait for locks, e.g. an ALTER TABLE
+// on MySQL blocked by the metadata lock of a long-running transaction, which in turn blocks all
+// queries on the table. Waiting briefly and retrying later keeps the table available.
+type LockWait struct {
+	// Timeout limits how long a statement waits for a lock before it fails, with
+	// lock_wait_timeout on MySQL and lock_timeout on PostgreSQL. MySQL rounds it up to whole
+	// seconds. A timeout of 0 keeps the default of the session.
+	Timeout time.Duration
+	// Retries is how often a statement that timed out waiting for a lock is retried. PostgreSQL
+	// aborts the transaction of a failed statement, so there only migrations added with
+	// NoTransaction are retried.
+	Retries int
+	// Backoff is the delay before the first retry. It is doubled for every further retry.
+	Backoff time.Duration
+}
+
+// WithLockWait sets how the statements of Go migrations wait for locks, see LockWait.
+//
+// Example:
+//
+//	migris.WithLockWait(migris.LockWait{Timeout: 5 * time.Second, Retries: 3, Backoff: 10 * time.Second})
+func WithLockWait(wait LockWait) Option {
+	return func(m *Migrate) {
+		m.lockWait = wait
+	}
+}
+
+// WithLockDiagnostics logs the sessions holding the lock a statement of a Go migration waits for,
+// every few seconds until the statement completes, instead of hanging silently. The holders are
+// read from sys.schema_table_lock_waits on MySQL, which requires the metadata lock instrument of
+// the performance schema, and from pg_stat_activity on PostgreSQL.
+func WithLockDiagnostics(enabled bool) Option {
+	return func(m *Migrate) {
+		m.lockDiagnostics = enabled
+	}
+}
+
+// setLockTimeout sets the lock timeout of the session of the migration. It returns the statement
+// that restores the session default, if the setting outlives the migration.
+func setLockTimeout(
+	ctx context.Context,
+	conn migrationConn,
+	d dialect.Dialect,
+	timeout time.Duration,
+	inTx bool,
+) (string, error) {
+	switch d {
+	case dialect.Postgres:
+		millis := timeout.Milliseconds()
+		if inTx {
+			_, err := conn.ExecContext(ctx, fmt.Sprintf("SET LOCAL lock_timeout = %d", millis))
+			return "", err
+		}
+		_, err := conn.ExecContext(ctx, fmt.Sprintf("SET lock_timeout = %d", millis))
+		return "RESET lock_timeout", err
+	case dialect.MySQL:
+		seconds := int64(math.Ceil(timeout.Seconds()))
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("SET SESSION lock_wait_timeout = %d", seconds)); err != nil {
+			return "", err
+		}
+		return "SET SESSION lock_wait_timeout = DEFAULT", nil
+	case dialect.Unknown:
+		return "", nil
+	default:
+		return "", nil
+	}
+}
+
+// lockWaiter runs the statements of a Go migration, retrying statements that timed out waiting
+// for a lock and logging the sessions holding the lock while a statement waits.
+type lockWaiter struct {
+	dialect dialect.Dialect
+	wait    LockWait
+	retry   bool    // whether a statement that timed out can be retried
+	db      *sql.DB // pool the lock holders are queried from, nil without diagnostics
+	session int64   // connection id of the migration, for diagnostics
+}
+
+// waitsForLocks reports whether the statements of the migrations need a lock waiter.
+func (r *migrationRun) waitsForLocks() bool {
+	return r.lockWait.Retries > 0 || r.diagnostics != nil
+}
+
+// newLockWaiter returns the lock waiter of a migration running on conn.
+func (r *migrationRun) newLockWaiter(ctx context.Context, conn migrationConn, inTx bool) (*lockWaiter, error) {
+	w := &lockWaiter{
+		dialect: r.dialect,
+		wait:    r.lockWait,
+		retry:   r.dialect == dialect.MySQL || !inTx,
+		db:      r.diagnostics,
+	}
+	if w.db != nil {
+		query := "SELECT CONNECTION_ID()"
+		if r.dialect == dialect.Postgres {
+			query = "SELECT pg_backend_pid()"
+		}
+		if err := conn.QueryRowContext(ctx, query).Scan(&w.session); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// exec runs the statement, retrying it with an exponential backoff while it times out waiting for
+// a lock.
+func (w *lockWaiter) exec(ctx context.Context, query string, exec func(ctx context.Context) error) error {
+	backoff := w.wait.Backoff
+	for attempt := 1; ; attempt++ {
+		err := w.watch(ctx, query, exec)
+		if err == nil || !w.retry || attempt > w.wait.Retries || !isLockTimeout(err) {
+			return err
+		}
+		logger.WarnMsg(logger.MessageLockRetry, backoff, attempt+1, w.wait.Retries+1, query)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// watch runs the statement and, with diagnostics, logs the sessions holding the lock it waits for
+// until it completes.
+func (w *lockWaiter) watch(ctx context.Context, query string, exec func(ctx context.Context) error) error {
+	if w.db == nil {
+		return exec(ctx)
+	}
+	// The holders are queried until the statement completes. Canceling the query also keeps it
+	// from waiting forever for a connection of a pool limited to the one of the migration.
+	watchCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		start := time.Now()
+		ticker := time.NewTicker(lockDiagnosticsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-watchCtx.Done():
+				return
+			case <-ticker.C:
+				holders, err := lockHolders(watchCtx, w.db, w.dialect, w.session)
+				if err != nil {
+					logger.Debug("querying lock holders failed", "error", err)
+					continue
+				}
+				if len(holders) > 0 {
+					logger.LockWaitBanner(query, time.Since(start).Round(time.Second), holders)
+				}
+			}
+		}
+	}()
+	err := exec(ctx)
+	cancel()
+	wg.Wait()
+	return err
+}
+
+// lockHolders returns the sessions holding the locks the session waits for.
+func lockHolders(ctx context.Context, db *sql.DB, d dialect.Dialect, session int64) ([]logger.LockHolder, error) {
+	var query string
+	switch d {
+	case dialect.Postgres:
+		query = `SELECT pid, COALESCE(usename, ''), COALESCE(state, ''),
+COALESCE(EXTRACT(EPOCH FROM now() - xact_start)::bigint, 0), COALESCE(query, '')
+FROM pg_stat_activity WHERE pid = ANY(pg_blocking_pids($1)) ORDER BY pid`
+	case dialect.MySQL:
+		query = `SELECT DISTINCT w.blocking_pid, w.blocking_account, COALESCE(p.COMMAND, ''),
+COALESCE(p.TIME, 0), COALESCE(p.INFO, '')
+FROM sys.schema_table_lock_waits w LEFT JOIN information_schema.PROCESSLIST p ON p.ID = w.blocking_pid
+WHERE w.waiting_pid = ? ORDER BY w.blocking_pid`
+	case dialect.Unknown:
+		return nil, nil
+	default:
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, query, session)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var holders []logger.LockHolder
+	for rows.Next() {
+		var h logger.LockHolder
+		var seconds int64
+		if err = rows.Scan(&h.ID, &h.User, &h.State, &seconds, &h.Query); err != nil {
+			return nil, err
+		}
+		h.Duration = time.Duration(seconds) * time.Second
+		holders = append(holders, h)
+	}
+	return holders, rows.Err()
+}
+
+// isLockTimeout reports whether the statement failed because it timed out waiting for a lock.
+func isLockTimeout(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1205 // ER_LOCK_WAIT_TIMEOUT
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "55P03" // lock_not_available
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLockTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "mysql lock wait timeout", err: &mysql.MySQLError{Number: 1205}, want: true},
+		{name: "wrapped", err: fmt.Errorf("alter users: %w", &mysql.MySQLError{Number: 1205}), want: true},
+		{name: "mysql deadlock", err: &mysql.MySQLError{Number: 1213}},
+		{name: "postgres lock not available", err: &pgconn.PgError{Code: "55P03"}, want: true},
+		{name: "postgres statement timeout", err: &pgconn.PgError{Code: "57014"}},
+		{name: "other error", err: errors.New("boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isLockTimeout(tt.err))
+		})
+	}
+}
+
+func TestLockWaiter_Exec(t *testing.T) {
+	lockTimeout := &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+	tests := []struct {
+		name      string
+		dialect   dialect.Dialect
+		inTx      bool
+		errs      []error // errors of the attempts, nil once they run out
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "succeeds after retries",
+			dialect:   dialect.MySQL,
+			inTx:      true,
+			errs:      []error{lockTimeout, lockTimeout},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after the retries",
+			dialect:   dialect.MySQL,
+			errs:      []error{lockTimeout, lockTimeout, lockTimeout, lockTimeout},
+			wantCalls: 3,
+			wantErr:   lockTimeout,
+		},
+		{
+			name:      "does not retry other errors",
+			dialect:   dialect.MySQL,
+			errs:      []error{errors.New("syntax error")},
+			wantCalls: 1,
+			wantErr:   errors.New("syntax error"),
+		},
+		{
+			name:      "does not retry in a postgres transaction",
+			dialect:   dialect.Postgres,
+			inTx:      true,
+			errs:      []error{&pgconn.PgError{Code: "55P03"}},
+			wantCalls: 1,
+			wantErr:   &pgconn.PgError{Code: "55P03"},
+		},
+		{
+			name:      "retries postgres without a transaction",
+			dialect:   dialect.Postgres,
+			errs:      []error{&pgconn.PgError{Code: "55P03"}},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := &migrationRun{dialect: tt.dialect, lockWait: LockWait{Retries: 2, Backoff: time.Millisecond}}
+			require.True(t, run.waitsForLocks())
+			waiter, err := run.newLockWaiter(context.Background(), nil, tt.inTx)
+			require.NoError(t, err)
+
+			calls := 0
+			err = waiter.exec(context.Background(), "ALTER TABLE users ADD COLUMN age INT", func(context.Context) error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestWithLockWait(t *testing.T) {
+	wait := LockWait{Timeout: 5 * time.Second, Retries: 3, Backoff: 10 * time.Second}
+	m, err := New("mysql", WithLockWait(wait), WithLockDiagnostics(true))
+	require.NoError(t, err)
+	assert.Equal(t, wait, m.lockWait)
+	assert.True(t, m.lockDiagnostics)
+
+	assert.False(t, (&migrationRun{}).waitsForLocks())
+}
@@ -0,0 +1,21 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := New("pgx", WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	require.NoError(t, err)
+	t.Cleanup(func() { logger.SetLogger(nil) })
+
+	logger.InfoMsg(logger.MessageNothingToMigrate)
+	assert.Contains(t, buf.String(), `"level":"INFO","msg":"Nothing to migrate."`)
+}
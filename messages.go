@@ -0,0 +1,61 @@
+package migris
+
+import "github.com/akfaiz/migris/internal/logger"
+
+// Logger receives the output of the migrator as structured log entries, e.g. for JSON logs in
+// production. The args are alternating keys and values. A *slog.Logger satisfies the interface
+// and can be passed to WithLogger directly; see the migriszap module for a zap adapter.
+type Logger = logger.Logger
+
+// Message identifies an operator-facing message printed by the migrator.
+// Use WithMessages to override (e.g. localize) the text of a message.
+type Message = logger.Message
+
+// Message keys that can be overridden with WithMessages.
+// Messages with arguments are fmt format strings, e.g. MessageCreatedFile receives the file path.
+const (
+	MessageInfoBadge           = logger.MessageInfoBadge
+	MessageWarnBadge           = logger.MessageWarnBadge
+	MessageIgnoredFeature      = logger.MessageIgnoredFeature
+	MessageNothingToMigrate    = logger.MessageNothingToMigrate
+	MessageNothingToRollback   = logger.MessageNothingToRollback
+	MessageRunningMigrations   = logger.MessageRunningMigrations
+	MessageRollingBack         = logger.MessageRollingBack
+	MessageDroppedAllTables    = logger.MessageDroppedAllTables
+	MessageLoadedSchema        = logger.MessageLoadedSchema
+	MessageCreatedFile         = logger.MessageCreatedFile
+	MessageSchemaUpToDate      = logger.MessageSchemaUpToDate
+	MessageStatusDone          = logger.MessageStatusDone
+	MessageStatusFail          = logger.MessageStatusFail
+	MessageStatusApplied       = logger.MessageStatusApplied
+	MessageStatusPending       = logger.MessageStatusPending
+	MessageDryRunBadge         = logger.MessageDryRunBadge
+	MessageDryRunUpStart       = logger.MessageDryRunUpStart
+	MessageDryRunDownStart     = logger.MessageDryRunDownStart
+	MessageDryRunResetStart    = logger.MessageDryRunResetStart
+	MessageDryRunMode          = logger.MessageDryRunMode
+	MessageDryRunProcessing    = logger.MessageDryRunProcessing
+	MessageDryRunVersionSuffix = logger.MessageDryRunVersionSuffix
+	MessageDryRunArguments     = logger.MessageDryRunArguments
+	MessageDryRunSummary       = logger.MessageDryRunSummary
+	MessageDryRunDownSummary   = logger.MessageDryRunDownSummary
+	MessageSummaryBadge        = logger.MessageSummaryBadge
+	MessageSummaryMigrations   = logger.MessageSummaryMigrations
+	MessageSummaryStatements   = logger.MessageSummaryStatements
+	MessageSummaryDuration     = logger.MessageSummaryDuration
+	MessageSummaryMode         = logger.MessageSummaryMode
+	MessageSummaryModeDryRun   = logger.MessageSummaryModeDryRun
+	MessageTargetBadge         = logger.MessageTargetBadge
+	MessageProductionBadge     = logger.MessageProductionBadge
+	MessageTargetWarning       = logger.MessageTargetWarning
+	MessageProductionWarning   = logger.MessageProductionWarning
+	MessageTargetEnvironment   = logger.MessageTargetEnvironment
+	MessageTargetHost          = logger.MessageTargetHost
+	MessageTargetDatabase      = logger.MessageTargetDatabase
+	MessageDataLossBadge       = logger.MessageDataLossBadge
+	MessageDataLossWarning     = logger.MessageDataLossWarning
+	MessageLockWaitBadge       = logger.MessageLockWaitBadge
+	MessageLockWaitWarning     = logger.MessageLockWaitWarning
+	MessageLockRetry           = logger.MessageLockRetry
+	MessageNoTransactionRouted = logger.MessageNoTransactionRouted
+)
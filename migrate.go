@@ -1,23 +1,78 @@
 package migris
 
 import (
+	"crypto/rand"
 	"database/sql"
 	"errors"
+	"io"
+	"io/fs"
 	"os"
+	"time"
 
 	"github.com/akfaiz/migris/internal/config"
 	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/akfaiz/migris/internal/sqlfile"
+	"github.com/akfaiz/migris/schema"
 	"github.com/pressly/goose/v3"
 	"github.com/pressly/goose/v3/database"
 )
 
 // Migrate handles database migrations.
 type Migrate struct {
-	dialect      dialect.Dialect
-	db           *sql.DB
-	migrationDir string
-	tableName    string
-	dryRun       bool
+	dialect       dialect.Dialect
+	db            *sql.DB
+	migrationDir  string
+	fsys          fs.FS
+	tableName     string
+	dryRun        bool
+	strict        bool
+	inlineIndexes bool
+	identityIDs   bool
+	naming        schema.NamingStrategy
+	renameLog     string
+	objectOwner   string
+	sqlArtifacts  string
+	encryptor     Encryptor
+	run           *migrationRun
+	dependencies  *Dependencies
+	clock         Clock
+	random        io.Reader
+	quiet         bool
+	noColor       bool
+	messages      map[Message]string
+	logger        Logger
+	hooks         Hooks
+	dsn           string
+	environment   string
+	target        target
+
+	statementTimeout   time.Duration
+	postMigrationHooks []PostMigrationHook
+	schemaPublisher    SchemaPublisher
+	productionHosts    []string
+
+	safeMode             bool
+	dataLossAcknowledger DataLossAcknowledger
+
+	lockWait        LockWait
+	lockDiagnostics bool
+
+	transactionPolicy TransactionPolicy
+	noTransaction     map[int64]bool // versions the transaction policy runs outside of a transaction
+
+	classPolicy ClassPolicy
+	lintPolicy  LintPolicy
+
+	sqlFormatter SQLFormatter
+
+	tableSnapshots string
+
+	preRunSQL  []string
+	postRunSQL []string
+
+	declaredSchema func(c schema.Context) error
 }
 
 // New creates a new Migrate instance.
@@ -32,10 +87,36 @@ func New(dialectValue string, opts ...Option) (*Migrate, error) {
 		dialect:      dialectVal,
 		migrationDir: "migrations",
 		tableName:    "schema_migrations",
+		dependencies: newDependencies(),
+		clock:        systemClock{},
+		random:       rand.Reader,
+
+		productionHosts: DefaultProductionHosts,
 	}
 	for _, opt := range opts {
 		opt(m)
 	}
+	m.dependencies.clock = m.clock
+	m.dependencies.random = m.random
+	target, err := parseTarget(dialectVal, m.dsn)
+	if err != nil {
+		return nil, err
+	}
+	m.target = target
+	config.SetStrict(m.strict)
+	config.SetInlineIndexes(m.inlineIndexes)
+	config.SetIdentityColumns(m.identityIDs)
+	config.SetRenameLogTable(m.renameLog)
+	config.SetObjectOwner(m.objectOwner)
+	schema.SetNamingStrategy(m.naming)
+	logger.SetQuiet(m.quiet)
+	logger.SetLogger(m.logger)
+	if m.noColor {
+		logger.SetNoColor(true)
+	}
+	if m.messages != nil {
+		logger.SetMessages(m.messages)
+	}
 	return m, nil
 }
 
@@ -47,18 +128,51 @@ func (m *Migrate) newProvider() (*goose.Provider, error) {
 	if m.db == nil {
 		return nil, errors.New("database connection is not set, please call WithDB option")
 	}
-	gooseDialect := val.GooseDialect()
-	store, err := database.NewStore(gooseDialect, m.tableName)
+	store, err := m.newStore(val)
 	if err != nil {
 		return nil, err
 	}
-	provider, err := goose.NewProvider(database.DialectCustom, m.db, os.DirFS(m.migrationDir),
+	run := &migrationRun{
+		dialect:   val,
+		timeout:   m.statementTimeout,
+		artifacts: m.newSQLArtifacts(),
+		snapshots: m.newTableSnapshots(),
+		lockWait:  m.lockWait,
+
+		noTransaction: m.noTransaction,
+	}
+	if m.lockDiagnostics {
+		run.diagnostics = m.db
+	}
+	fsys := m.migrationsFS()
+	provider, err := goose.NewProvider(database.DialectCustom, m.db, fsys,
 		goose.WithStore(store),
 		goose.WithDisableGlobalRegistry(true),
-		goose.WithGoMigrations(gooseMigrations()...),
+		goose.WithGoMigrations(gooseMigrations(m.dependencies, run)...),
+		goose.WithAllowOutofOrder(m.classPolicy != ClassPolicyInterleaved),
 	)
 	if err != nil {
 		return nil, err
 	}
+	store.checksums = migrationChecksums(provider.ListSources(), fsys)
+	m.run = run
 	return provider, nil
 }
+
+// newStore returns the store of the version table.
+func (m *Migrate) newStore(val dialect.Dialect) (*checksumStore, error) {
+	gooseStore, err := database.NewStore(val.GooseDialect(), m.tableName)
+	if err != nil {
+		return nil, err
+	}
+	return &checksumStore{Store: gooseStore, dialect: val}, nil
+}
+
+// migrationsFS returns the file system of the migrations, with up and down SQL files merged.
+func (m *Migrate) migrationsFS() fs.FS {
+	fsys := m.fsys
+	if fsys == nil {
+		fsys = os.DirFS(pathutil.Clean(m.migrationDir))
+	}
+	return sqlfile.New(fsys)
+}
@@ -0,0 +1,31 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"database/sql"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_WithFS(t *testing.T) {
+	// The provider does not connect to the database until migrations are run.
+	db, err := sql.Open("pgx", "postgres://localhost/migris")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := New("postgres", WithDB(db), WithMigrationDir(t.TempDir()), WithFS(fstest.MapFS{
+		"20250101000000_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id bigint);")},
+		"20250101000000_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	}))
+	require.NoError(t, err)
+
+	provider, err := m.newProvider()
+	require.NoError(t, err)
+	sources := provider.ListSources()
+	require.Len(t, sources, 1)
+	assert.Equal(t, int64(20250101000000), sources[0].Version)
+	assert.Equal(t, "20250101000000_create_users.sql", sources[0].Path)
+}
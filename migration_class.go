@@ -0,0 +1,149 @@
+package migris
+
+import (
+	"context"
+	"io/fs"
+	"regexp"
+
+	"github.com/pressly/goose/v3"
+)
+
+// MigrationClass tells schema migrations, which change the structure of the database and are
+// usually fast, from data migrations, which rewrite rows and can take long on large tables.
+type MigrationClass string
+
+const (
+	// ClassSchema is a migration that changes the schema. Migrations are schema migrations unless
+	// they are declared as data migrations.
+	ClassSchema MigrationClass = "schema"
+	// ClassData is a migration that changes data, e.g. a backfill. Go migrations are declared with
+	// DataMigration, SQL migrations with a -- +migris DATA annotation.
+	ClassData MigrationClass = "data"
+)
+
+// ClassPolicy decides which classes of pending migrations Up applies, and in which order. It lets
+// a deployment pipeline apply the schema migrations during the release and defer the data
+// migrations to a phase after it.
+type ClassPolicy string
+
+const (
+	// ClassPolicyInterleaved applies the schema and data migrations in version order.
+	ClassPolicyInterleaved ClassPolicy = ""
+	// ClassPolicySchemaFirst applies the schema migrations first, then the data migrations, each in
+	// version order.
+	ClassPolicySchemaFirst ClassPolicy = "schema-first"
+	// ClassPolicySchemaOnly applies only the schema migrations. The data migrations stay pending.
+	ClassPolicySchemaOnly ClassPolicy = "schema-only"
+	// ClassPolicyDataOnly applies only the data migrations, e.g. those deferred by a schema-only run.
+	ClassPolicyDataOnly ClassPolicy = "data-only"
+)
+
+// dataAnnotation declares a SQL migration as a data migration.
+var dataAnnotation = regexp.MustCompile(`(?mi)^--\s*\+migris\s+DATA\b`)
+
+// DataMigration declares a Go migration as a data migration, see ClassPolicy.
+//
+// Example:
+//
+//	func init() {
+//	    migris.AddMigrationContext(upBackfillNicknames, downBackfillNicknames, migris.DataMigration())
+//	}
+func DataMigration() MigrationOption {
+	return func(m *Migration) {
+		m.class = ClassData
+	}
+}
+
+// WithClassPolicy sets which classes of pending migrations Up applies, and in which order, see
+// ClassPolicy. Except for ClassPolicyInterleaved, migrations are applied out of version order, so
+// a data migration deferred by a schema-only run may be older than the applied migrations. Apply
+// the deferred data migrations before the next interleaved run, which rejects such gaps.
+func WithClassPolicy(policy ClassPolicy) Option {
+	return func(m *Migrate) {
+		m.classPolicy = policy
+	}
+}
+
+// pendingSources returns the pending migrations up to and including version, in the order the
+// class policy applies them.
+func (m *Migrate) pendingSources(
+	ctx context.Context,
+	provider *goose.Provider,
+	version int64,
+) ([]*goose.Source, error) {
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []*goose.Source
+	for _, status := range statuses {
+		if status.State == goose.StatePending && status.Source.Version <= version {
+			pending = append(pending, status.Source)
+		}
+	}
+	return m.orderByClass(pending)
+}
+
+// orderByClass filters and orders the pending migrations according to the class policy.
+func (m *Migrate) orderByClass(pending []*goose.Source) ([]*goose.Source, error) {
+	if m.classPolicy == ClassPolicyInterleaved {
+		return pending, nil
+	}
+	var schemaSources, dataSources []*goose.Source
+	for _, source := range pending {
+		class, err := m.migrationClass(source)
+		if err != nil {
+			return nil, err
+		}
+		if class == ClassData {
+			dataSources = append(dataSources, source)
+		} else {
+			schemaSources = append(schemaSources, source)
+		}
+	}
+
+	switch m.classPolicy {
+	case ClassPolicySchemaOnly:
+		return schemaSources, nil
+	case ClassPolicyDataOnly:
+		return dataSources, nil
+	default:
+		return append(schemaSources, dataSources...), nil
+	}
+}
+
+// migrationClass returns the class of the migration.
+func (m *Migrate) migrationClass(source *goose.Source) (MigrationClass, error) {
+	if source.Type == goose.TypeSQL {
+		content, err := fs.ReadFile(m.migrationsFS(), source.Path)
+		if err != nil {
+			return "", err
+		}
+		if dataAnnotation.Match(content) {
+			return ClassData, nil
+		}
+		return ClassSchema, nil
+	}
+	for _, migration := range registeredMigrations {
+		if migration.version == source.Version && migration.class == ClassData {
+			return ClassData, nil
+		}
+	}
+	return ClassSchema, nil
+}
+
+// registeredPending returns the registered Go migrations of the sources, in the order of the
+// sources.
+func registeredPending(sources []*goose.Source) []*Migration {
+	byVersion := make(map[int64]*Migration, len(registeredMigrations))
+	for _, migration := range registeredMigrations {
+		byVersion[migration.version] = migration
+	}
+	var migrations []*Migration
+	for _, source := range sources {
+		if migration, ok := byVersion[source.Version]; ok {
+			migrations = append(migrations, migration)
+		}
+	}
+	return migrations
+}
@@ -0,0 +1,86 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_OrderByClass(t *testing.T) {
+	createOrders, err := newMigration("20250101000000_create_orders.go", nil, nil)
+	require.NoError(t, err)
+	backfillTotals, err := newMigration("20250102000000_backfill_totals.go", nil, nil, DataMigration())
+	require.NoError(t, err)
+	withRegisteredMigrations(t, createOrders, backfillTotals)
+
+	fsys := fstest.MapFS{
+		"20250103000000_add_note.sql": {Data: []byte(
+			"-- +goose Up\nALTER TABLE orders ADD COLUMN note TEXT;\n")},
+		"20250104000000_backfill_notes.sql": {Data: []byte(
+			"-- +migris DATA\n-- +goose Up\nUPDATE orders SET note = '';\n")},
+	}
+	pending := []*goose.Source{
+		{Type: goose.TypeGo, Version: 20250101000000},
+		{Type: goose.TypeGo, Version: 20250102000000},
+		{Type: goose.TypeSQL, Path: "20250103000000_add_note.sql", Version: 20250103000000},
+		{Type: goose.TypeSQL, Path: "20250104000000_backfill_notes.sql", Version: 20250104000000},
+	}
+
+	tests := []struct {
+		name   string
+		policy ClassPolicy
+		want   []int64
+	}{
+		{
+			name:   "interleaved",
+			policy: ClassPolicyInterleaved,
+			want:   []int64{20250101000000, 20250102000000, 20250103000000, 20250104000000},
+		},
+		{
+			name:   "schema first",
+			policy: ClassPolicySchemaFirst,
+			want:   []int64{20250101000000, 20250103000000, 20250102000000, 20250104000000},
+		},
+		{
+			name:   "schema only",
+			policy: ClassPolicySchemaOnly,
+			want:   []int64{20250101000000, 20250103000000},
+		},
+		{
+			name:   "data only",
+			policy: ClassPolicyDataOnly,
+			want:   []int64{20250102000000, 20250104000000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New("pgx", WithFS(fsys), WithClassPolicy(tt.policy))
+			require.NoError(t, err)
+			got, err := m.orderByClass(pending)
+			require.NoError(t, err)
+			versions := make([]int64, 0, len(got))
+			for _, source := range got {
+				versions = append(versions, source.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+		})
+	}
+}
+
+func TestRegisteredPending(t *testing.T) {
+	createOrders, err := newMigration("20250101000000_create_orders.go", nil, nil)
+	require.NoError(t, err)
+	backfillTotals, err := newMigration("20250102000000_backfill_totals.go", nil, nil, DataMigration())
+	require.NoError(t, err)
+	withRegisteredMigrations(t, createOrders, backfillTotals)
+
+	got := registeredPending([]*goose.Source{
+		{Type: goose.TypeGo, Version: 20250102000000},
+		{Type: goose.TypeSQL, Path: "20250103000000_add_note.sql", Version: 20250103000000},
+		{Type: goose.TypeGo, Version: 20250101000000},
+	})
+	assert.Equal(t, []*Migration{backfillTotals, createOrders}, got)
+}
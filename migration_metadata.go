@@ -0,0 +1,90 @@
+package migris
+
+import (
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+// metadataAnnotation matches the -- author: and -- ticket: annotations of a SQL migration.
+var metadataAnnotation = regexp.MustCompile(`(?mi)^--\s*(author|ticket):[ \t]*(.*)$`)
+
+// migrationMetadata is who wrote a migration and the issue tracker entry it belongs to.
+type migrationMetadata struct {
+	author string
+	ticket string
+}
+
+// Author records who wrote a Go migration. It is reported by StatusInfo and passed to the lifecycle
+// hooks. SQL migrations are annotated with -- author: instead.
+//
+// Example:
+//
+//	func init() {
+//	    migris.AddMigrationContext(upCreateOrders, downCreateOrders,
+//	        migris.Author("jane"), migris.Ticket("SHOP-42"))
+//	}
+func Author(name string) MigrationOption {
+	return func(m *Migration) {
+		m.author = name
+	}
+}
+
+// Ticket records the issue tracker entry a Go migration belongs to, so every schema change can be
+// traced back to it. It is reported by StatusInfo and passed to the lifecycle hooks. SQL migrations
+// are annotated with -- ticket: instead.
+func Ticket(id string) MigrationOption {
+	return func(m *Migration) {
+		m.ticket = id
+	}
+}
+
+// sourceMetadata returns the author and the ticket of the migration. SQL migrations are read from
+// fsys; migrations whose file cannot be read have no metadata.
+func sourceMetadata(source *goose.Source, fsys fs.FS) migrationMetadata {
+	if source.Type == goose.TypeSQL {
+		content, err := fs.ReadFile(fsys, source.Path)
+		if err != nil {
+			return migrationMetadata{}
+		}
+		return parseMetadata(content)
+	}
+	for _, migration := range registeredMigrations {
+		if migration.version == source.Version {
+			return migrationMetadata{author: migration.author, ticket: migration.ticket}
+		}
+	}
+	return migrationMetadata{}
+}
+
+// migrationsMetadata returns the author and the ticket of each migration that declares them.
+func migrationsMetadata(sources []*goose.Source, fsys fs.FS) map[int64]migrationMetadata {
+	metadata := make(map[int64]migrationMetadata, len(sources))
+	for _, source := range sources {
+		if md := sourceMetadata(source, fsys); md != (migrationMetadata{}) {
+			metadata[source.Version] = md
+		}
+	}
+	return metadata
+}
+
+// parseMetadata returns the first -- author: and -- ticket: annotations of a SQL migration.
+func parseMetadata(content []byte) migrationMetadata {
+	var md migrationMetadata
+	for _, match := range metadataAnnotation.FindAllSubmatch(content, -1) {
+		value := strings.TrimSpace(string(match[2]))
+		switch strings.ToLower(string(match[1])) {
+		case "author":
+			if md.author == "" {
+				md.author = value
+			}
+		case "ticket":
+			if md.ticket == "" {
+				md.ticket = value
+			}
+		}
+	}
+	return md
+}
@@ -0,0 +1,66 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    migrationMetadata
+	}{
+		{
+			name:    "author and ticket",
+			content: "-- author: jane\n-- ticket: SHOP-42\n-- +goose Up\nCREATE TABLE orders (id INT);\n",
+			want:    migrationMetadata{author: "jane", ticket: "SHOP-42"},
+		},
+		{
+			name:    "case and spacing",
+			content: "--Ticket:   SHOP-42  \n-- +goose Up\nSELECT 1;\n",
+			want:    migrationMetadata{ticket: "SHOP-42"},
+		},
+		{
+			name:    "first annotation wins",
+			content: "-- ticket: SHOP-42\n-- +goose Up\n-- ticket: SHOP-43\nSELECT 1;\n",
+			want:    migrationMetadata{ticket: "SHOP-42"},
+		},
+		{
+			name:    "no annotations",
+			content: "-- +goose Up\nSELECT 1; -- author: jane\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseMetadata([]byte(tt.content)))
+		})
+	}
+}
+
+func TestMigrationsMetadata(t *testing.T) {
+	createOrders, err := newMigration("20250101000000_create_orders.go", nil, nil, Author("jane"), Ticket("SHOP-42"))
+	require.NoError(t, err)
+	addNote, err := newMigration("20250102000000_add_note.go", nil, nil)
+	require.NoError(t, err)
+	withRegisteredMigrations(t, createOrders, addNote)
+
+	fsys := fstest.MapFS{
+		"20250103000000_index_orders.sql": {Data: []byte(
+			"-- author: john\n-- ticket: SHOP-7\n-- +goose Up\nCREATE INDEX idx_orders_note ON orders (note);\n")},
+	}
+	got := migrationsMetadata([]*goose.Source{
+		{Type: goose.TypeGo, Version: 20250101000000},
+		{Type: goose.TypeGo, Version: 20250102000000},
+		{Type: goose.TypeSQL, Path: "20250103000000_index_orders.sql", Version: 20250103000000},
+		{Type: goose.TypeSQL, Path: "20250104000000_missing.sql", Version: 20250104000000},
+	}, fsys)
+	assert.Equal(t, map[int64]migrationMetadata{
+		20250101000000: {author: "jane", ticket: "SHOP-42"},
+		20250103000000: {author: "john", ticket: "SHOP-7"},
+	}, got)
+}
@@ -2,6 +2,10 @@ package migris
 
 import (
 	"database/sql"
+	"io"
+	"io/fs"
+
+	"github.com/akfaiz/migris/schema"
 )
 
 type Option func(*Migrate)
@@ -20,6 +24,25 @@ func WithMigrationDir(dir string) Option {
 	}
 }
 
+// WithFS sets the file system the SQL migration files are read from, instead of the migration
+// directory. It allows embedding the migrations in the binary with go:embed. The migrations must
+// be in the root of the file system; use fs.Sub for a subdirectory.
+//
+// Example:
+//
+//	//go:embed migrations/*.sql
+//	var migrationsFS embed.FS
+//
+//	sub, _ := fs.Sub(migrationsFS, "migrations")
+//	migrator, err := migris.New("pgx", migris.WithDB(db), migris.WithFS(sub))
+//
+// New migration files created with Create are still written to the migration directory.
+func WithFS(fsys fs.FS) Option {
+	return func(m *Migrate) {
+		m.fsys = fsys
+	}
+}
+
 // WithDB sets the database connection for the migration.
 func WithDB(db *sql.DB) Option {
 	return func(m *Migrate) {
@@ -33,3 +56,179 @@ func WithDryRun(enabled bool) Option {
 		m.dryRun = enabled
 	}
 }
+
+// WithStrict enables or disables strict mode. In strict mode, schema operations that use a
+// feature the dialect does not support (e.g. a table engine on PostgreSQL) fail with
+// schema.ErrUnsupportedFeature instead of the feature being silently ignored.
+func WithStrict(enabled bool) Option {
+	return func(m *Migrate) {
+		m.strict = enabled
+	}
+}
+
+// WithInlineIndexes enables or disables emitting the indexes of a new table inside its
+// CREATE TABLE statement (KEY, UNIQUE KEY and FULLTEXT KEY clauses) instead of as separate
+// statements. This reduces the statement count and metadata locking when creating many tables.
+// It is only supported by MySQL and is ignored for other dialects.
+func WithInlineIndexes(enabled bool) Option {
+	return func(m *Migrate) {
+		m.inlineIndexes = enabled
+	}
+}
+
+// WithIdentityColumns makes the ID columns of the schema builder identity columns, which
+// PostgreSQL recommends over serial columns: they compile to BIGINT GENERATED BY DEFAULT AS
+// IDENTITY instead of BIGSERIAL. MySQL keeps using AUTO_INCREMENT.
+func WithIdentityColumns(enabled bool) Option {
+	return func(m *Migrate) {
+		m.identityIDs = enabled
+	}
+}
+
+// WithRenameLog records every column renamed with RenameColumn in the given table, so downstream
+// consumers (e.g. ETL or reporting pipelines) can follow the renames. The table has the columns
+// table_name, old_name, new_name and renamed_at, and is created on the first rename. Renames are
+// recorded in the same transaction as the migration, including the ones of down migrations.
+func WithRenameLog(table string) Option {
+	return func(m *Migrate) {
+		m.renameLog = table
+	}
+}
+
+// WithSQLArtifacts records the SQL executed by every Go migration in the given table, so audits
+// can see exactly what ran in an environment, independent of later changes to the migration
+// files. Each run stores the version, the direction (up or down), the gzip-compressed statements
+// and the time it was applied. The table is created on the first run, in the same transaction as
+// the migration. SQL migrations are not recorded. Use AppliedSQL to read the statements back.
+func WithSQLArtifacts(table string) Option {
+	return func(m *Migrate) {
+		m.sqlArtifacts = table
+	}
+}
+
+// WithTableSnapshots captures the definition of every table a Go migration alters, renames or
+// drops through the schema builder, as returned by GetTableDDL, before the first change to it, and
+// stores it in the given table. It gives an authoritative "before" definition for a manual restore
+// when the down migration cannot rebuild the table. Each snapshot stores the version, the direction
+// (up or down), the table name, the definition and the time it was captured. The table is created
+// on the first snapshot, in the same transaction as the migration, so the snapshots of a failed
+// migration are rolled back with it. SQL migrations are not captured. Use TableDDLSnapshots to read
+// the definitions back.
+func WithTableSnapshots(table string) Option {
+	return func(m *Migrate) {
+		m.tableSnapshots = table
+	}
+}
+
+// WithArtifactEncryption encrypts the statements recorded with WithSQLArtifacts, and the table
+// definitions captured with WithTableSnapshots, before they are stored, for migrations whose DDL
+// contains sensitive identifiers. Use NewAESEncryptor for a local key, or implement Encryptor to
+// delegate to a key management service. AppliedSQL and TableDDLSnapshots decrypt them with the
+// same encryptor.
+func WithArtifactEncryption(encryptor Encryptor) Option {
+	return func(m *Migrate) {
+		m.encryptor = encryptor
+	}
+}
+
+// WithPostMigrationHook adds a hook that is called after every run that applied or rolled back
+// at least one migration, e.g. AnalyzeTables to refresh the statistics of the changed tables.
+// The hooks run in the order they were added; an error from a hook is returned by the run.
+func WithPostMigrationHook(hook PostMigrationHook) Option {
+	return func(m *Migrate) {
+		m.postMigrationHooks = append(m.postMigrationHooks, hook)
+	}
+}
+
+// WithHooks sets functions that are called around each migration and each run, e.g. to emit
+// metrics, send notifications or record audit logs.
+func WithHooks(hooks Hooks) Option {
+	return func(m *Migrate) {
+		m.hooks = hooks
+	}
+}
+
+// WithObjectOwner sets the role that owns every table created by the migrations, so objects
+// created by the migration role end up with the correct ownership. Use Blueprint.Owner to
+// override it for a single table. It is only supported by PostgreSQL and ignored on MySQL.
+func WithObjectOwner(role string) Option {
+	return func(m *Migrate) {
+		m.objectOwner = role
+	}
+}
+
+// WithLogger writes the output of the migrator to the given structured logger instead of the
+// colored console output. Migration results, statuses and warnings are logged as entries with
+// attributes; every executed schema statement is logged at debug level. Quiet mode and the
+// colors do not apply to a structured logger.
+//
+// Example:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+//	migrator, err := migris.New("pgx", migris.WithDB(db), migris.WithLogger(logger))
+func WithLogger(l Logger) Option {
+	return func(m *Migrate) {
+		m.logger = l
+	}
+}
+
+// WithNamingStrategy sets the strategy that names indexes and foreign keys that are not named
+// explicitly. The strategy is also used to resolve indexes dropped by their columns.
+func WithNamingStrategy(strategy schema.NamingStrategy) Option {
+	return func(m *Migrate) {
+		m.naming = strategy
+	}
+}
+
+// WithClock sets the time source used by the migrator. The clock is also available to migration
+// providers through Dependencies.Clock and Dependencies.Now.
+func WithClock(clock Clock) Option {
+	return func(m *Migrate) {
+		m.clock = clock
+	}
+}
+
+// WithRandom sets the source of random bytes used by the migrator. It defaults to crypto/rand.
+// The source is also available to migration providers through Dependencies.Random and
+// Dependencies.NewUUID, so tests can provide a fixed reader to make generated data reproducible.
+func WithRandom(r io.Reader) Option {
+	return func(m *Migrate) {
+		m.random = r
+	}
+}
+
+// WithDependency registers a dependency that is injected into migration providers.
+// The dependency can be retrieved in a provider with Dependencies.Get or Resolve.
+func WithDependency(name string, value any) Option {
+	return func(m *Migrate) {
+		m.dependencies.set(name, value)
+	}
+}
+
+// WithQuiet enables or disables quiet mode. In quiet mode informational messages, banners and
+// summaries are suppressed so the output can be consumed by automation.
+func WithQuiet(enabled bool) Option {
+	return func(m *Migrate) {
+		m.quiet = enabled
+	}
+}
+
+// WithNoColor disables colored output.
+func WithNoColor(disabled bool) Option {
+	return func(m *Migrate) {
+		m.noColor = disabled
+	}
+}
+
+// WithMessages overrides the text of operator-facing messages, e.g. to localize the output.
+//
+// Example:
+//
+//	migris.WithMessages(map[migris.Message]string{
+//	    migris.MessageNothingToMigrate: "Tidak ada migrasi.",
+//	})
+func WithMessages(messages map[Message]string) Option {
+	return func(m *Migrate) {
+		m.messages = messages
+	}
+}
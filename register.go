@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"path"
 	"runtime"
+	"slices"
+	"time"
 
+	"github.com/akfaiz/migris/internal/pathutil"
 	"github.com/akfaiz/migris/schema"
 	"github.com/pressly/goose/v3"
 )
@@ -20,83 +22,309 @@ type Migration struct {
 	version                    int64
 	source                     string
 	upFnContext, downFnContext MigrationContext
+	provider                   MigrationProvider
+	timeout                    *time.Duration // overrides the statement timeout of the migrator
+	noTransaction              bool
+	lossyDown                  *bool  // whether the down migration loses data, if declared
+	lossReason                 string // what the down migration loses, for LossyDown
+	class                      MigrationClass
+	noDown                     bool // the migration has no down migration on purpose, see NoDown
+	author, ticket             string
+}
+
+// MigrationOption configures a Go migration when it is added.
+type MigrationOption func(*Migration)
+
+// MigrationProvider is a Go migration written as a struct. Its Up and Down methods receive the
+// dependencies registered on the migrator with WithDependency, so data migrations can use
+// application services without resorting to global variables.
+type MigrationProvider interface {
+	Up(c schema.Context, deps *Dependencies) error
+	Down(c schema.Context, deps *Dependencies) error
 }
 
 // MigrationContext is a Go migration func that is run within a transaction and receives a
 // context.
 type MigrationContext func(ctx schema.Context) error
 
-func (m MigrationContext) runTxFunc(source string) func(ctx context.Context, tx *sql.Tx) error {
-	return func(ctx context.Context, tx *sql.Tx) error {
-		filename := path.Base(source)
+// goMigrationFunc runs one direction of a Go migration.
+type goMigrationFunc struct {
+	fn        MigrationContext
+	source    string
+	version   int64
+	direction string
+	timeout   time.Duration
+	run       *migrationRun
+}
+
+// migrationConn executes the statements of a Go migration besides the migration itself: a
+// transaction, or a single connection for migrations added with NoTransaction.
+type migrationConn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
 
-		// Check if we're in dry-run mode
-		isDryRun := getGlobalDryRunState()
+func (f *goMigrationFunc) runTx(ctx context.Context, tx *sql.Tx) error {
+	return f.execute(ctx, tx, true, func(opts ...schema.ContextOptions) schema.Context {
+		return schema.NewContext(ctx, tx, opts...)
+	})
+}
 
-		var c schema.Context
-		if isDryRun {
-			// Create dry-run context
-			c = schema.NewDryRunContext(ctx)
-		} else {
-			// Create regular context
-			c = schema.NewContext(ctx, tx, schema.WithFilename(filename))
+// runDB runs a migration added with NoTransaction on a single connection, so session settings
+// such as the statement timeout apply to all of its statements.
+func (f *goMigrationFunc) runDB(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return f.execute(ctx, conn, false, func(opts ...schema.ContextOptions) schema.Context {
+		return schema.NewConnContext(ctx, conn, opts...)
+	})
+}
+
+func (f *goMigrationFunc) execute(
+	ctx context.Context,
+	conn migrationConn,
+	inTx bool,
+	newContext func(opts ...schema.ContextOptions) schema.Context,
+) error {
+	// Check if we're in dry-run mode
+	isDryRun := getGlobalDryRunState()
+
+	if isDryRun {
+		// Create dry-run context
+		return f.fn(schema.NewDryRunContext(ctx))
+	}
+
+	// Create regular context
+	// tables are the tables changed by the migration and dropped the ones it dropped or renamed,
+	// each in the state of its last change.
+	var statements, tables, dropped []string
+	opts := []schema.ContextOptions{
+		schema.WithFilename(pathutil.Base(f.source)),
+		schema.WithTableHook(func(table string) {
+			dropped = slices.DeleteFunc(dropped, func(name string) bool { return name == table })
+			tables = append(tables, table)
+		}),
+		schema.WithDropTableHook(func(table string) {
+			tables = slices.DeleteFunc(tables, func(name string) bool { return name == table })
+			dropped = append(dropped, table)
+		}),
+	}
+	// The session settings would outlive the migration. A failed reset is ignored, as the
+	// migration has already run.
+	var resets []string
+	defer func() {
+		for _, reset := range resets {
+			_, _ = conn.ExecContext(ctx, reset)
+		}
+	}()
+	if f.timeout > 0 {
+		reset, err := setStatementTimeout(ctx, conn, f.run.dialect, f.timeout, inTx)
+		if err != nil {
+			return err
 		}
+		if reset != "" {
+			resets = append(resets, reset)
+		}
+		opts = append(opts, schema.WithStatementTimeout(f.timeout))
+	}
+	if f.run.lockWait.Timeout > 0 {
+		reset, err := setLockTimeout(ctx, conn, f.run.dialect, f.run.lockWait.Timeout, inTx)
+		if err != nil {
+			return err
+		}
+		if reset != "" {
+			resets = append(resets, reset)
+		}
+	}
+	if f.run.waitsForLocks() {
+		waiter, err := f.run.newLockWaiter(ctx, conn, inTx)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, schema.WithExecWrapper(waiter.exec))
+	}
+	if f.run.artifacts != nil {
+		opts = append(opts, schema.WithExecHook(func(query string, args []any) {
+			statements = append(statements, formatArtifactStatement(query, args))
+		}))
+	}
+	var c schema.Context
+	if f.run.snapshots != nil {
+		captured := make(map[string]bool)
+		opts = append(opts, schema.WithBeforeTableHook(func(table string) error {
+			if captured[table] {
+				return nil
+			}
+			captured[table] = true
+			return f.run.snapshots.capture(ctx, c, conn, f.version, f.direction, table)
+		}))
+	}
+	c = newContext(opts...)
+	if err := f.fn(c); err != nil {
+		return err
+	}
+	if f.run.artifacts != nil {
+		if err := f.run.artifacts.record(ctx, conn, f.version, f.direction, statements); err != nil {
+			return err
+		}
+	}
+	f.run.removeTables(dropped)
+	f.run.addTables(tables)
+	return nil
+}
 
-		return m(c)
+// NoTransaction runs the migration outside of a transaction, for statements that cannot run in
+// one, such as CREATE INDEX CONCURRENTLY on PostgreSQL. A failing migration is not rolled back,
+// so keep such migrations to a single statement or make them idempotent.
+//
+// Example:
+//
+//	func init() {
+//	    migris.AddMigrationContext(upIndexOrders, downIndexOrders, migris.NoTransaction())
+//	}
+//
+//	func upIndexOrders(c schema.Context) error {
+//	    return schema.Table(c, "orders", func(table *schema.Blueprint) {
+//	        table.Index("customer_id").Concurrently()
+//	    })
+//	}
+func NoTransaction() MigrationOption {
+	return func(m *Migration) {
+		m.noTransaction = true
 	}
 }
 
 // AddMigrationContext adds Go migrations.
-func AddMigrationContext(up, down MigrationContext) {
+func AddMigrationContext(up, down MigrationContext, opts ...MigrationOption) {
 	_, filename, _, _ := runtime.Caller(1)
-	AddNamedMigrationContext(filename, up, down)
+	AddNamedMigrationContext(filename, up, down, opts...)
 }
 
 // AddNamedMigrationContext adds named Go migrations.
-func AddNamedMigrationContext(source string, up, down MigrationContext) {
+func AddNamedMigrationContext(source string, up, down MigrationContext, opts ...MigrationOption) {
 	if err := register(
 		source,
 		up,
 		down,
+		opts...,
 	); err != nil {
 		panic(err)
 	}
 }
 
-func register(source string, up, down MigrationContext) error {
-	v, _ := goose.NumericComponent(source)
+// AddMigrationProvider adds a Go migration provider.
+//
+// Example:
+//
+//	type backfillPlans struct{}
+//
+//	func (backfillPlans) Up(c schema.Context, deps *migris.Dependencies) error {
+//	    cfg, err := migris.Resolve[*Config](deps, "config")
+//	    ...
+//	}
+//
+//	func init() {
+//	    migris.AddMigrationProvider(backfillPlans{})
+//	}
+func AddMigrationProvider(provider MigrationProvider, opts ...MigrationOption) {
+	_, filename, _, _ := runtime.Caller(1)
+	AddNamedMigrationProvider(filename, provider, opts...)
+}
+
+// AddNamedMigrationProvider adds a named Go migration provider.
+func AddNamedMigrationProvider(source string, provider MigrationProvider, opts ...MigrationOption) {
+	if provider == nil {
+		panic(fmt.Sprintf("failed to add migration %q: provider is nil", source))
+	}
+	m, err := newMigration(source, nil, nil, opts...)
+	if err != nil {
+		panic(err)
+	}
+	m.provider = provider
+	addMigration(m)
+}
+
+func register(source string, up, down MigrationContext, opts ...MigrationOption) error {
+	m, err := newMigration(source, up, down, opts...)
+	if err != nil {
+		return err
+	}
+	addMigration(m)
+	return nil
+}
+
+func newMigration(source string, up, down MigrationContext, opts ...MigrationOption) (*Migration, error) {
+	v, _ := goose.NumericComponent(pathutil.Base(source))
 	if existing, ok := registeredVersions[v]; ok {
-		return fmt.Errorf("failed to add migration %q: version %d conflicts with %q",
+		return nil, fmt.Errorf("failed to add migration %q: version %d conflicts with %q",
 			source,
 			v,
 			existing,
 		)
 	}
-	// Add to global as a registered migration.
 	m := &Migration{
 		version:       v,
 		source:        source,
 		upFnContext:   up,
 		downFnContext: down,
 	}
-	registeredVersions[v] = source
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// addMigration adds the migration to the global registry.
+func addMigration(m *Migration) {
+	registeredVersions[m.version] = m.source
 	registeredMigrations = append(registeredMigrations, m)
-	return nil
 }
 
-func gooseMigrations() []*goose.Migration {
-	migrations := make([]*goose.Migration, 0, len(registeredMigrations))
-	for _, m := range registeredMigrations {
-		upFunc := &goose.GoFunc{
-			RunTx: m.upFnContext.runTxFunc(m.source),
-			Mode:  goose.TransactionEnabled,
+// upFunc returns the up migration function, binding the dependencies for migration providers.
+func (m *Migration) upFunc(deps *Dependencies) MigrationContext {
+	if m.provider != nil {
+		return func(c schema.Context) error {
+			return m.provider.Up(c, deps)
 		}
-		downFunc := &goose.GoFunc{
-			RunTx: m.downFnContext.runTxFunc(m.source),
-			Mode:  goose.TransactionEnabled,
+	}
+	return m.upFnContext
+}
+
+// downFunc returns the down migration function, binding the dependencies for migration providers.
+func (m *Migration) downFunc(deps *Dependencies) MigrationContext {
+	if m.provider != nil {
+		return func(c schema.Context) error {
+			return m.provider.Down(c, deps)
 		}
-		gm := goose.NewGoMigration(m.version, upFunc, downFunc)
-		migrations = append(migrations, gm)
+	}
+	return m.downFnContext
+}
+
+func gooseMigrations(deps *Dependencies, run *migrationRun) []*goose.Migration {
+	migrations := make([]*goose.Migration, 0, len(registeredMigrations))
+	for _, m := range registeredMigrations {
+		up := m.goFunc(m.upFunc(deps), directionUp, run)
+		down := m.goFunc(m.downFunc(deps), directionDown, run)
+		migrations = append(migrations, goose.NewGoMigration(m.version, up, down))
 	}
 	return migrations
 }
+
+// goFunc returns the goose function of one direction of the migration.
+func (m *Migration) goFunc(fn MigrationContext, direction string, run *migrationRun) *goose.GoFunc {
+	f := &goMigrationFunc{
+		fn:        fn,
+		source:    m.source,
+		version:   m.version,
+		direction: direction,
+		timeout:   m.statementTimeout(run.timeout),
+		run:       run,
+	}
+	if m.noTransaction || run.noTransaction[m.version] {
+		return &goose.GoFunc{RunDB: f.runDB, Mode: goose.TransactionDisabled}
+	}
+	return &goose.GoFunc{RunTx: f.runTx, Mode: goose.TransactionEnabled}
+}
@@ -0,0 +1,33 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationGoFunc(t *testing.T) {
+	run := &migrationRun{}
+
+	t.Run("runs in a transaction by default", func(t *testing.T) {
+		m, err := newMigration("20250101000000_create_orders.go", nil, nil)
+		require.NoError(t, err)
+
+		fn := m.goFunc(nil, directionUp, run)
+		assert.Equal(t, goose.TransactionEnabled, fn.Mode)
+		assert.NotNil(t, fn.RunTx)
+		assert.Nil(t, fn.RunDB)
+	})
+
+	t.Run("runs on the database with NoTransaction", func(t *testing.T) {
+		m, err := newMigration("20250101000000_index_orders.go", nil, nil, NoTransaction())
+		require.NoError(t, err)
+
+		fn := m.goFunc(nil, directionDown, run)
+		assert.Equal(t, goose.TransactionDisabled, fn.Mode)
+		assert.NotNil(t, fn.RunDB)
+		assert.Nil(t, fn.RunTx)
+	})
+}
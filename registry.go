@@ -0,0 +1,240 @@
+package migris
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3/database"
+)
+
+// SchemaSnapshot is the structure of the database after a migration run, as published to a
+// schema registry with WithSchemaPublisher.
+type SchemaSnapshot struct {
+	Version   int64           `json:"version"` // latest applied migration version
+	Dialect   string          `json:"dialect"`
+	CreatedAt time.Time       `json:"created_at"`
+	Tables    []TableSnapshot `json:"tables"`
+}
+
+// TableSnapshot is a table of a SchemaSnapshot.
+type TableSnapshot struct {
+	Name    string           `json:"name"`
+	Columns []ColumnSnapshot `json:"columns"`
+	Indexes []IndexSnapshot  `json:"indexes"`
+}
+
+// ColumnSnapshot is a column of a TableSnapshot.
+type ColumnSnapshot struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"` // full type including modifiers, e.g. varchar(255)
+	Nullable bool    `json:"nullable"`
+	Default  *string `json:"default,omitempty"`
+	Comment  *string `json:"comment,omitempty"`
+}
+
+// IndexSnapshot is an index of a TableSnapshot.
+type IndexSnapshot struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+	Primary bool     `json:"primary"`
+}
+
+// SchemaPublisher publishes the schema snapshot taken after a migration run to an external
+// registry, so downstream services can validate their expectations against the live schema.
+type SchemaPublisher interface {
+	Publish(ctx context.Context, snapshot *SchemaSnapshot) error
+}
+
+// HTTPSchemaPublisher is a SchemaPublisher that posts the snapshot as JSON to an HTTP endpoint.
+// Any response status other than 2xx is reported as an error.
+type HTTPSchemaPublisher struct {
+	URL    string       // Endpoint of the registry
+	Client *http.Client // Client used for the request, defaults to http.DefaultClient
+	Header http.Header  // Additional request headers, e.g. for authorization
+}
+
+// Publish posts the snapshot to the endpoint.
+func (p *HTTPSchemaPublisher) Publish(ctx context.Context, snapshot *SchemaSnapshot) error {
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range p.Header {
+		req.Header[key] = slices.Clone(values)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("schema registry responded with %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// WithSchemaPublisher publishes a snapshot of the schema after every run that applied or rolled
+// back at least one migration. The version table and the tables maintained by migris, such as
+// the rename log, are not included.
+//
+// Example:
+//
+//	migrator, err := migris.New("pgx",
+//	    migris.WithDB(db),
+//	    migris.WithSchemaPublisher(&migris.HTTPSchemaPublisher{URL: "https://registry.internal/schemas/orders"}),
+//	)
+func WithSchemaPublisher(publisher SchemaPublisher) Option {
+	return func(m *Migrate) {
+		m.schemaPublisher = publisher
+	}
+}
+
+// publishSchema publishes the snapshot of the schema, if a publisher is set.
+func (m *Migrate) publishSchema(ctx context.Context) error {
+	if m.schemaPublisher == nil {
+		return nil
+	}
+	snapshot, err := m.schemaSnapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to take schema snapshot: %w", err)
+	}
+	if err = m.schemaPublisher.Publish(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to publish schema snapshot: %w", err)
+	}
+	return nil
+}
+
+// schemaSnapshot reads the structure of the database and the latest applied version.
+func (m *Migrate) schemaSnapshot(ctx context.Context) (*SchemaSnapshot, error) {
+	if m.db == nil {
+		return nil, errors.New("database connection is not set, please call WithDB option")
+	}
+	store, err := database.NewStore(m.dialect.GooseDialect(), m.tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	snapshot := &SchemaSnapshot{
+		Dialect:   m.dialect.String(),
+		CreatedAt: m.clock.Now().UTC(),
+		Tables:    []TableSnapshot{},
+	}
+	if snapshot.Version, err = store.GetLatestVersion(ctx, tx); err != nil &&
+		!errors.Is(err, database.ErrVersionNotFound) {
+		return nil, err
+	}
+
+	c := schema.NewContext(ctx, tx)
+	tables, err := schema.GetTables(c)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := schema.GetAllColumns(c)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := schema.GetAllIndexes(c)
+	if err != nil {
+		return nil, err
+	}
+	internal := []string{m.tableName, m.renameLog, m.sqlArtifacts, m.tableSnapshots}
+	for _, table := range tables {
+		if slices.Contains(internal, table.Name) {
+			continue
+		}
+		snapshot.Tables = append(snapshot.Tables, snapshotTable(table.Name, columns[table.Name], indexes[table.Name]))
+	}
+	slices.SortFunc(snapshot.Tables, func(a, b TableSnapshot) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return snapshot, nil
+}
+
+func snapshotTable(name string, columns []*schema.Column, indexes []*schema.Index) TableSnapshot {
+	return TableSnapshot{Name: name, Columns: toColumnSnapshots(columns), Indexes: toIndexSnapshots(indexes)}
+}
+
+// columnSnapshots returns the columns of the table in their ordinal order.
+func columnSnapshots(c schema.Context, table string) ([]ColumnSnapshot, error) {
+	columns, err := schema.GetColumns(c, table)
+	if err != nil {
+		return nil, err
+	}
+	return toColumnSnapshots(columns), nil
+}
+
+func toColumnSnapshots(columns []*schema.Column) []ColumnSnapshot {
+	snapshots := make([]ColumnSnapshot, 0, len(columns))
+	for _, col := range columns {
+		snapshots = append(snapshots, ColumnSnapshot{
+			Name:     col.Name,
+			Type:     col.TypeFull,
+			Nullable: col.Nullable,
+			Default:  nullStringPtr(col.DefaultVal),
+			Comment:  nullStringPtr(col.Comment),
+		})
+	}
+	return snapshots
+}
+
+// indexSnapshots returns the indexes of the table sorted by name.
+func indexSnapshots(c schema.Context, table string) ([]IndexSnapshot, error) {
+	indexes, err := schema.GetIndexes(c, table)
+	if err != nil {
+		return nil, err
+	}
+	return toIndexSnapshots(indexes), nil
+}
+
+func toIndexSnapshots(indexes []*schema.Index) []IndexSnapshot {
+	snapshots := make([]IndexSnapshot, 0, len(indexes))
+	for _, idx := range indexes {
+		snapshots = append(snapshots, IndexSnapshot{
+			Name:    idx.Name,
+			Columns: idx.Columns,
+			Unique:  idx.Unique,
+			Primary: idx.Primary,
+		})
+	}
+	slices.SortFunc(snapshots, func(a, b IndexSnapshot) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return snapshots
+}
+
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
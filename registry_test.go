@@ -0,0 +1,83 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotTable(t *testing.T) {
+	fake, err := schema.NewFake("pgx")
+	require.NoError(t, err)
+	require.NoError(t, schema.Create(fake, "users", func(table *schema.Blueprint) {
+		table.ID()
+		table.String("email").Unique().Comment("login")
+		table.String("name").Nullable()
+	}))
+
+	columns, err := schema.GetAllColumns(fake)
+	require.NoError(t, err)
+	indexes, err := schema.GetAllIndexes(fake)
+	require.NoError(t, err)
+
+	got := snapshotTable("users", columns["users"], indexes["users"])
+	comment := "login"
+	assert.Equal(t, TableSnapshot{
+		Name: "users",
+		Columns: []ColumnSnapshot{
+			{Name: "id", Type: "BIGSERIAL"},
+			{Name: "email", Type: "VARCHAR(255)", Comment: &comment},
+			{Name: "name", Type: "VARCHAR(255)", Nullable: true},
+		},
+		Indexes: []IndexSnapshot{
+			{Name: "pk_users", Columns: []string{"id"}, Unique: true, Primary: true},
+			{Name: "uk_users_email", Columns: []string{"email"}, Unique: true},
+		},
+	}, got)
+}
+
+func TestHTTPSchemaPublisher(t *testing.T) {
+	snapshot := &SchemaSnapshot{
+		Version:   20250101000000,
+		Dialect:   "postgres",
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Tables:    []TableSnapshot{{Name: "users", Columns: []ColumnSnapshot{}, Indexes: []IndexSnapshot{}}},
+	}
+
+	t.Run("posts the snapshot as JSON", func(t *testing.T) {
+		var received SchemaSnapshot
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		publisher := &HTTPSchemaPublisher{
+			URL:    server.URL,
+			Header: http.Header{"Authorization": []string{"Bearer token"}},
+		}
+		require.NoError(t, publisher.Publish(context.Background(), snapshot))
+		assert.Equal(t, *snapshot, received)
+	})
+
+	t.Run("reports unsuccessful responses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "schema rejected", http.StatusConflict)
+		}))
+		defer server.Close()
+
+		publisher := &HTTPSchemaPublisher{URL: server.URL}
+		err := publisher.Publish(context.Background(), snapshot)
+		require.EqualError(t, err, "schema registry responded with 409 Conflict: schema rejected")
+	})
+}
@@ -30,11 +30,12 @@ func (m *Migrate) ResetContext(ctx context.Context) error {
 		return err
 	}
 	if currentVersion == 0 {
-		logger.Info("Nothing to rollback.")
+		logger.InfoMsg(logger.MessageNothingToRollback)
 		return nil
 	}
-	logger.Info("Rolling back migrations.\n")
-	results, err := provider.DownTo(ctx, 0)
+	m.warnTarget()
+	logger.InfoMsg(logger.MessageRollingBack)
+	results, err := m.downTo(ctx, provider, 0)
 	if err != nil {
 		var partialErr *goose.PartialError
 		if errors.As(err, &partialErr) {
@@ -45,5 +46,5 @@ func (m *Migrate) ResetContext(ctx context.Context) error {
 		return err
 	}
 	logger.PrintResults(results)
-	return nil
+	return m.runPostMigrationHooks(ctx)
 }
@@ -0,0 +1,87 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// WithPreRunSQL sets statements executed once before the migrations of every Up, Down, DownTo
+// and Reset run, e.g. to pause event triggers with ALTER EVENT TRIGGER ... DISABLE or to change
+// replication filters. They run in order on a connection held for the whole run, on which the
+// statements set with WithPostRunSQL run afterwards. The migrations themselves run on other
+// connections of the pool, so the statements should change global state rather than settings of
+// the session, and the pool must allow more than one open connection. A failing statement stops
+// the run before any migration. The statements are not executed in dry-run mode.
+func WithPreRunSQL(statements ...string) Option {
+	return func(m *Migrate) {
+		m.preRunSQL = statements
+	}
+}
+
+// WithPostRunSQL sets statements executed once after the migrations of every Up, Down, DownTo
+// and Reset run, e.g. to re-enable the event triggers paused with WithPreRunSQL. They run on the
+// same connection as the pre-run statements, also when a migration failed or the run was
+// canceled. Every statement is executed even if a previous one failed; their errors are returned
+// with the error of the run.
+func WithPostRunSQL(statements ...string) Option {
+	return func(m *Migrate) {
+		m.postRunSQL = statements
+	}
+}
+
+// runSQLConn is the connection the pre-run and post-run statements are executed on.
+type runSQLConn interface {
+	migrationConn
+	Close() error
+}
+
+// runSQL holds the connection of a run between its pre-run and post-run statements.
+type runSQL struct {
+	conn runSQLConn
+	post []string
+}
+
+// startRunSQL executes the pre-run statements on a connection of the pool, which it keeps for the
+// post-run statements. It returns nil when neither are set.
+func (m *Migrate) startRunSQL(ctx context.Context) (*runSQL, error) {
+	if len(m.preRunSQL) == 0 && len(m.postRunSQL) == 0 {
+		return nil, nil
+	}
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a connection for the pre-run statements: %w", err)
+	}
+	return startRunSQL(ctx, conn, m.preRunSQL, m.postRunSQL)
+}
+
+func startRunSQL(ctx context.Context, conn runSQLConn, pre, post []string) (*runSQL, error) {
+	for _, statement := range pre {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("pre-run statement %q failed: %w", statement, err)
+		}
+	}
+	return &runSQL{conn: conn, post: post}, nil
+}
+
+// finish executes the post-run statements, even when ctx was canceled, and releases the
+// connection. Their errors are joined to the error of the run in err.
+func (r *runSQL) finish(ctx context.Context, err *error) {
+	if r == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for _, statement := range r.post {
+		if _, execErr := r.conn.ExecContext(ctx, statement); execErr != nil {
+			errs = append(errs, fmt.Errorf("post-run statement %q failed: %w", statement, execErr))
+		}
+	}
+	if closeErr := r.conn.Close(); closeErr != nil {
+		errs = append(errs, closeErr)
+	}
+	if len(errs) > 0 {
+		*err = errors.Join(append([]error{*err}, errs...)...)
+	}
+}
@@ -0,0 +1,98 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingConn records the statements executed on it and fails those listed in fail.
+type recordingConn struct {
+	statements []string
+	fail       map[string]bool
+	closed     bool
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, _ ...any) (sql.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.statements = append(c.statements, query)
+	if c.fail[query] {
+		return nil, errors.New("boom")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryRowContext(context.Context, string, ...any) *sql.Row {
+	return nil
+}
+
+func (c *recordingConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestRunSQL(t *testing.T) {
+	conn := &recordingConn{}
+	session, err := startRunSQL(t.Context(), conn,
+		[]string{"ALTER EVENT TRIGGER audit DISABLE"}, []string{"ALTER EVENT TRIGGER audit ENABLE"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALTER EVENT TRIGGER audit DISABLE"}, conn.statements)
+	assert.False(t, conn.closed)
+
+	var runErr error
+	session.finish(t.Context(), &runErr)
+	require.NoError(t, runErr)
+	assert.Equal(t, []string{"ALTER EVENT TRIGGER audit DISABLE", "ALTER EVENT TRIGGER audit ENABLE"},
+		conn.statements)
+	assert.True(t, conn.closed)
+}
+
+func TestRunSQL_PreRunFails(t *testing.T) {
+	conn := &recordingConn{fail: map[string]bool{"SELECT 1": true}}
+	session, err := startRunSQL(t.Context(), conn, []string{"SELECT 1", "SELECT 2"}, []string{"SELECT 3"})
+
+	require.EqualError(t, err, `pre-run statement "SELECT 1" failed: boom`)
+	assert.Nil(t, session)
+	assert.Equal(t, []string{"SELECT 1"}, conn.statements)
+	assert.True(t, conn.closed)
+}
+
+func TestRunSQL_PostRunAfterFailedRun(t *testing.T) {
+	conn := &recordingConn{fail: map[string]bool{"SELECT 2": true}}
+	ctx, cancel := context.WithCancel(t.Context())
+	session, err := startRunSQL(ctx, conn, nil, []string{"SELECT 2", "SELECT 3"})
+	require.NoError(t, err)
+	cancel()
+
+	failed := &goose.MigrationResult{Source: &goose.Source{Type: goose.TypeSQL, Version: 1}}
+	var runErr error = &goose.PartialError{Failed: failed, Err: errors.New("migration failed")}
+	session.finish(ctx, &runErr)
+
+	assert.Equal(t, []string{"SELECT 2", "SELECT 3"}, conn.statements, "runs every statement despite the cancel")
+	var partialErr *goose.PartialError
+	require.ErrorAs(t, runErr, &partialErr)
+	require.ErrorContains(t, runErr, `post-run statement "SELECT 2" failed: boom`)
+	assert.True(t, conn.closed)
+}
+
+func TestStartRunSQL_NoStatements(t *testing.T) {
+	m, err := New("pgx")
+	require.NoError(t, err)
+
+	session, err := m.startRunSQL(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	runErr := errors.New("migration failed")
+	err = runErr
+	session.finish(t.Context(), &err)
+	assert.Same(t, runErr, err)
+}
@@ -1,9 +1,11 @@
 package schema
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/logger"
 	"github.com/akfaiz/migris/internal/util"
 )
 
@@ -38,6 +40,10 @@ const (
 	columnTypeGeometry      string = "geometry"
 	columnTypePoint         string = "point"
 	columnTypeUUID          string = "uuid"
+	columnTypeInterval      string = "interval"
+	columnTypeInet          string = "inet"
+	columnTypeCidr          string = "cidr"
+	columnTypeMacAddr       string = "macAddr"
 	columnTypeEnum          string = "enum"
 )
 
@@ -46,9 +52,16 @@ const (
 	defaultTimePrecision int = 0
 )
 
+// ErrUnsupportedFeature is returned in strict mode when a blueprint uses a feature that
+// the target dialect does not support and would otherwise silently ignore.
+var ErrUnsupportedFeature = errors.New("unsupported feature")
+
+// ErrInvalidBlueprint is returned when a blueprint contradicts itself, e.g. when it defines a
+// column twice or both drops and changes a column.
+var ErrInvalidBlueprint = errors.New("invalid blueprint")
+
 // Blueprint represents a schema blueprint for creating or altering a database table.
 type Blueprint struct {
-	dialect   dialect.Dialect
 	columns   []*columnDefinition
 	commands  []*command
 	grammar   grammar
@@ -56,6 +69,26 @@ type Blueprint struct {
 	charset   string
 	collation string
 	engine    string
+	strict    bool
+	warnings  []string
+	implied   bool // whether the implied commands have been added
+	temporary bool
+	comment   *string
+
+	ifNotExists   bool // create the table only if it does not exist
+	inlineIndexes bool
+	identityIDs   bool // ID creates identity columns
+	naming        NamingStrategy
+	renameLog     string // table recording renamed columns; empty disables the log
+	owner         string // owner set with Owner
+	defaultOwner  string // owner of newly created tables set with WithObjectOwner
+
+	partitionMethod  PartitionMethod // set with PartitionBy
+	partitionColumns []string
+	partitions       []partitionDefinition
+
+	algorithm string // ALGORITHM of the MySQL statements altering the table, set with Algorithm
+	lock      string // LOCK of the MySQL statements altering the table, set with Lock
 }
 
 // Charset sets the character set for the table in the blueprint.
@@ -73,6 +106,46 @@ func (b *Blueprint) Engine(engine string) {
 	b.engine = engine
 }
 
+// Comment sets the comment of the table.
+func (b *Blueprint) Comment(comment string) {
+	b.comment = &comment
+}
+
+// Temporary creates the table as a temporary table, which is dropped at the end of the session,
+// e.g. to stage data in a data-fix migration.
+func (b *Blueprint) Temporary() {
+	b.temporary = true
+}
+
+// Owner sets the role that owns the table, overriding the owner configured for newly created
+// tables. It is only supported by PostgreSQL.
+func (b *Blueprint) Owner(role string) {
+	b.owner = role
+}
+
+// Algorithm sets the algorithm MySQL alters the table with: INSTANT, INPLACE, COPY or DEFAULT. It
+// is added as an ALGORITHM clause to every statement altering the table, so the statement fails
+// instead of falling back to a slower algorithm, e.g. to a copying ALTER of a large table. It is
+// only supported by MySQL and ignored when creating a table.
+//
+// Example:
+//
+//	schema.Table(c, "orders", func(table *schema.Blueprint) {
+//	    table.Algorithm("INSTANT")
+//	    table.String("note").Nullable()
+//	})
+func (b *Blueprint) Algorithm(algorithm string) {
+	b.algorithm = algorithm
+}
+
+// Lock sets the concurrent access MySQL allows while it alters the table: NONE, SHARED, EXCLUSIVE
+// or DEFAULT. It is added as a LOCK clause to every statement altering the table, so the statement
+// fails instead of taking a stronger lock. It is only supported by MySQL and ignored when creating
+// a table.
+func (b *Blueprint) Lock(lock string) {
+	b.lock = lock
+}
+
 // Column creates a new custom column definition in the blueprint with the specified name and type.
 func (b *Blueprint) Column(name string, columnType string) ColumnDefinition {
 	return b.addColumn(columnType, name)
@@ -162,8 +235,14 @@ func (b *Blueprint) Float(name string, precision ...int) ColumnDefinition {
 // ID creates a new big increments column definition in the blueprint with the name "id" or a custom name.
 //
 // If a name is provided, it will be used as the column name; otherwise, "id" will be used.
+// With identity columns enabled (see migris.WithIdentityColumns) the column is a GENERATED BY
+// DEFAULT AS IDENTITY column on PostgreSQL instead of a BIGSERIAL.
 func (b *Blueprint) ID(name ...string) ColumnDefinition {
-	return b.BigIncrements(util.Optional("id", name...)).Primary()
+	col := b.BigIncrements(util.Optional("id", name...)).Primary()
+	if b.identityIDs {
+		col.Identity()
+	}
+	return col
 }
 
 // Increments create a new increment column definition in the blueprint.
@@ -291,6 +370,28 @@ func (b *Blueprint) TimestampsTz(precision ...int) {
 	b.TimestampTz("updated_at", precision...).UseCurrent().UseCurrentOnUpdate()
 }
 
+// SoftDeletes adds a nullable deleted_at timestamp column to the blueprint, which marks rows as
+// deleted without removing them.
+//
+// Example:
+//
+//	table.SoftDeletes()
+//	table.SoftDeletes(6).Index() // deleted_at with microsecond precision and an index
+func (b *Blueprint) SoftDeletes(precision ...int) ColumnDefinition {
+	return b.Timestamp("deleted_at", precision...).Nullable()
+}
+
+// SoftDeletesTz adds a nullable deleted_at timestamp with time zone column to the blueprint.
+func (b *Blueprint) SoftDeletesTz(precision ...int) ColumnDefinition {
+	return b.TimestampTz("deleted_at", precision...).Nullable()
+}
+
+// RememberToken adds a nullable remember_token string column of 100 characters to the blueprint,
+// which stores the "remember me" token of a user.
+func (b *Blueprint) RememberToken() ColumnDefinition {
+	return b.String("remember_token", 100).Nullable()
+}
+
 // Year creates a new year column definition in the blueprint.
 func (b *Blueprint) Year(name string) ColumnDefinition {
 	return b.addColumn(columnTypeYear, name)
@@ -318,6 +419,71 @@ func (b *Blueprint) UUID(name string) ColumnDefinition {
 	return b.addColumn(columnTypeUUID, name)
 }
 
+// UUIDPrimary creates a UUID primary key column named "id" or a custom name, whose value is
+// generated by the database: with gen_random_uuid() on PostgreSQL, built in from PostgreSQL 13,
+// and with (UUID()) on MySQL, which requires MySQL 8.0.13 for expression defaults. A default set
+// with Default takes precedence.
+//
+// Example:
+//
+//	table.UUIDPrimary()
+func (b *Blueprint) UUIDPrimary(name ...string) ColumnDefinition {
+	return b.addColumn(columnTypeUUID, util.Optional("id", name...), &columnDefinition{
+		defaultUUID: true,
+	}).Primary()
+}
+
+// Interval creates a new interval column definition in the blueprint.
+// The precision parameter is optional and specifies the fractional seconds precision.
+// MySQL has no interval type and stores the value as VARCHAR(64).
+func (b *Blueprint) Interval(name string, precision ...int) ColumnDefinition {
+	return b.addColumn(columnTypeInterval, name, &columnDefinition{
+		precision: util.OptionalNil(precision...),
+	})
+}
+
+// Inet creates a new IPv4 or IPv6 host address column definition in the blueprint.
+// MySQL has no network address types and stores the address as VARCHAR(45).
+func (b *Blueprint) Inet(name string) ColumnDefinition {
+	return b.addColumn(columnTypeInet, name)
+}
+
+// Cidr creates a new IPv4 or IPv6 network address column definition in the blueprint.
+// MySQL has no network address types and stores the network as VARCHAR(49).
+func (b *Blueprint) Cidr(name string) ColumnDefinition {
+	return b.addColumn(columnTypeCidr, name)
+}
+
+// MacAddr creates a new MAC address column definition in the blueprint.
+// MySQL has no network address types and stores the address as VARCHAR(17).
+func (b *Blueprint) MacAddr(name string) ColumnDefinition {
+	return b.addColumn(columnTypeMacAddr, name)
+}
+
+// TextArray creates a new TEXT[] column definition in the blueprint.
+// This is only supported by PostgreSQL.
+func (b *Blueprint) TextArray(name string) ColumnDefinition {
+	return b.Text(name).Array()
+}
+
+// IntegerArray creates a new INTEGER[] column definition in the blueprint.
+// This is only supported by PostgreSQL.
+func (b *Blueprint) IntegerArray(name string) ColumnDefinition {
+	return b.Integer(name).Array()
+}
+
+// BigIntegerArray creates a new BIGINT[] column definition in the blueprint.
+// This is only supported by PostgreSQL.
+func (b *Blueprint) BigIntegerArray(name string) ColumnDefinition {
+	return b.BigInteger(name).Array()
+}
+
+// UUIDArray creates a new UUID[] column definition in the blueprint.
+// This is only supported by PostgreSQL.
+func (b *Blueprint) UUIDArray(name string) ColumnDefinition {
+	return b.UUID(name).Array()
+}
+
 // Geography creates a new geography column definition in the blueprint.
 // The subType parameter is optional and can be used to specify the type of geography (e.g., "Point", "LineString", "Polygon").
 // The srid parameter is optional and specifies the Spatial Reference Identifier (SRID) for the geography type.
@@ -358,6 +524,19 @@ func (b *Blueprint) Enum(name string, allowed []string) ColumnDefinition {
 	})
 }
 
+// EnumFrom creates a new enum column definition whose allowed values are resolved from the enum
+// registered under enumName with RegisterEnum when the migration runs.
+//
+// To add a value to an existing column, register the new value and change the column in a new
+// migration; the ALTER statement is generated from the registered values:
+//
+//	table.EnumFrom("status", "order_status").Change()
+func (b *Blueprint) EnumFrom(name string, enumName string) ColumnDefinition {
+	return b.addColumn(columnTypeEnum, name, &columnDefinition{
+		enumName: enumName,
+	})
+}
+
 // DropTimestamps removes the created_at and updated_at timestamp columns from the blueprint.
 func (b *Blueprint) DropTimestamps() {
 	b.DropColumn("created_at", "updated_at")
@@ -368,6 +547,16 @@ func (b *Blueprint) DropTimestampsTz() {
 	b.DropTimestamps()
 }
 
+// DropSoftDeletes removes the deleted_at timestamp column from the blueprint.
+func (b *Blueprint) DropSoftDeletes() {
+	b.DropColumn("deleted_at")
+}
+
+// DropSoftDeletesTz removes the deleted_at timestamp with time zone column from the blueprint.
+func (b *Blueprint) DropSoftDeletesTz() {
+	b.DropSoftDeletes()
+}
+
 // Index creates a new index definition in the blueprint.
 //
 // Example:
@@ -389,6 +578,29 @@ func (b *Blueprint) Unique(column string, otherColumns ...string) IndexDefinitio
 	return b.indexCommand(commandUnique, append([]string{column}, otherColumns...)...)
 }
 
+// IndexRaw creates a new index definition on SQL expressions instead of columns, e.g. a
+// functional index on lower(email). Each expression is compiled in its own parentheses; MySQL
+// supports functional indexes from version 8.0.13. The generated name is derived from the
+// identifiers of the expressions, e.g. idx_users_lower_email.
+//
+// Example:
+//
+//	table.IndexRaw("lower(email)")
+func (b *Blueprint) IndexRaw(expression string, otherExpressions ...string) IndexDefinition {
+	return b.expressionIndexCommand(commandIndex, append([]string{expression}, otherExpressions...))
+}
+
+// UniqueRaw creates a new unique index definition on SQL expressions instead of columns, e.g.
+// to enforce case-insensitive uniqueness. PostgreSQL builds a unique index instead of a unique
+// constraint, so drop it with DropIndex.
+//
+// Example:
+//
+//	table.UniqueRaw("lower(email)")
+func (b *Blueprint) UniqueRaw(expression string, otherExpressions ...string) IndexDefinition {
+	return b.expressionIndexCommand(commandUnique, append([]string{expression}, otherExpressions...))
+}
+
 // Primary creates a new primary key index definition in the blueprint.
 //
 // Example:
@@ -404,6 +616,83 @@ func (b *Blueprint) FullText(column string, otherColumns ...string) IndexDefinit
 	return b.indexCommand(commandFullText, append([]string{column}, otherColumns...)...)
 }
 
+// JSONIndex creates an index on the value at the given JSON path of a JSON column.
+// MySQL indexes a virtual generated column named <column>_<path> extracting the value; PostgreSQL
+// uses an expression index. Dropping the index on MySQL leaves the generated column in place.
+//
+// Example:
+//
+//	table.JSONIndex("data", "$.customer_id")
+func (b *Blueprint) JSONIndex(column string, path string) IndexDefinition {
+	command := b.addCommand(commandJSONIndex, &command{
+		columns: []string{column},
+		path:    path,
+	})
+	return &indexDefinition{command}
+}
+
+// ForeignID creates a new unsigned big integer column for a foreign key. Call Constrained to add
+// the foreign key itself.
+//
+// Example:
+//
+//	table.ForeignID("user_id").Constrained().CascadeOnDelete()
+func (b *Blueprint) ForeignID(name string) ColumnDefinition {
+	return b.addColumn(columnTypeBigInteger, name, &columnDefinition{
+		unsigned:         util.PtrOf(true),
+		implicitUnsigned: true,
+	})
+}
+
+// Morphs adds the {name}_type string and {name}_id unsigned big integer columns of a polymorphic
+// relation to the blueprint, along with a composite index on both. An optional index name
+// overrides the default one.
+//
+// Example:
+//
+//	table.Morphs("commentable") // commentable_type and commentable_id
+func (b *Blueprint) Morphs(name string, indexName ...string) {
+	b.morphs(name, false, false, indexName)
+}
+
+// NullableMorphs adds nullable {name}_type and {name}_id columns of a polymorphic relation to the
+// blueprint, along with a composite index on both.
+func (b *Blueprint) NullableMorphs(name string, indexName ...string) {
+	b.morphs(name, false, true, indexName)
+}
+
+// UUIDMorphs adds the {name}_type string and {name}_id UUID columns of a polymorphic relation to
+// the blueprint, along with a composite index on both.
+func (b *Blueprint) UUIDMorphs(name string, indexName ...string) {
+	b.morphs(name, true, false, indexName)
+}
+
+// NullableUUIDMorphs adds nullable {name}_type and {name}_id UUID columns of a polymorphic relation
+// to the blueprint, along with a composite index on both.
+func (b *Blueprint) NullableUUIDMorphs(name string, indexName ...string) {
+	b.morphs(name, true, true, indexName)
+}
+
+// morphs adds the columns and the index of a polymorphic relation.
+func (b *Blueprint) morphs(name string, uuid bool, nullable bool, indexName []string) {
+	typeColumn, idColumn := name+"_type", name+"_id"
+	columns := []ColumnDefinition{b.String(typeColumn)}
+	if uuid {
+		columns = append(columns, b.UUID(idColumn))
+	} else {
+		columns = append(columns, b.ForeignID(idColumn))
+	}
+	if nullable {
+		for _, col := range columns {
+			col.Nullable()
+		}
+	}
+	index := b.Index(typeColumn, idColumn)
+	if len(indexName) > 0 && indexName[0] != "" {
+		index.Name(indexName[0])
+	}
+}
+
 // Foreign creates a new foreign key definition in the blueprint.
 //
 // Example:
@@ -416,6 +705,19 @@ func (b *Blueprint) Foreign(column string) ForeignKeyDefinition {
 	return &foreignKeyDefinition{command: command}
 }
 
+// Check creates a new check constraint definition in the blueprint. MySQL enforces check
+// constraints from version 8.0.16.
+//
+// Example:
+//
+//	table.Check("price > 0").Name("chk_positive_price")
+func (b *Blueprint) Check(condition string) CheckDefinition {
+	command := b.addCommand(commandCheck, &command{
+		check: condition,
+	})
+	return &checkDefinition{command}
+}
+
 // DropColumn adds a column to be dropped from the table.
 //
 // Example:
@@ -460,6 +762,29 @@ func (b *Blueprint) DropUnique(index any) {
 	b.dropIndexCommand(commandDropUnique, commandUnique, index)
 }
 
+// DropUniqueByColumns adds the unique key on the given columns to be dropped from the table.
+// The name of the unique key is derived from the naming strategy and, when no unique key with
+// that name exists, resolved by looking up the unique key on the columns in the database.
+//
+// Example:
+//
+//	table.DropUniqueByColumns("email")
+//	table.DropUniqueByColumns("email", "tenant_id") // drops a composite unique key
+func (b *Blueprint) DropUniqueByColumns(column string, otherColumns ...string) {
+	b.DropUnique(append([]string{column}, otherColumns...))
+}
+
+// DropCheck adds a check constraint to be dropped from the table.
+//
+// Example:
+//
+//	table.DropCheck("chk_positive_price")
+func (b *Blueprint) DropCheck(name string) {
+	b.addCommand(commandDropCheck, &command{
+		index: name,
+	})
+}
+
 func (b *Blueprint) DropFulltext(index any) {
 	b.dropIndexCommand(commandDropFullText, commandFullText, index)
 }
@@ -475,6 +800,13 @@ func (b *Blueprint) RenameIndex(oldIndexName string, newIndexName string) {
 	})
 }
 
+func (b *Blueprint) namingStrategy() NamingStrategy {
+	if b.naming == nil {
+		return DefaultNamingStrategy{}
+	}
+	return b.naming
+}
+
 func (b *Blueprint) getAddedColumns() []*columnDefinition {
 	var addedColumns []*columnDefinition
 	for _, col := range b.columns {
@@ -499,6 +831,18 @@ func (b *Blueprint) create() {
 	b.addCommand(commandCreate)
 }
 
+// createKeyword returns the keyword of the CREATE TABLE statement of the blueprint.
+func (b *Blueprint) createKeyword() string {
+	keyword := "CREATE TABLE"
+	if b.temporary {
+		keyword = "CREATE TEMPORARY TABLE"
+	}
+	if b.ifNotExists {
+		keyword += " IF NOT EXISTS"
+	}
+	return keyword
+}
+
 func (b *Blueprint) creating() bool {
 	for _, command := range b.commands {
 		if command.name == commandCreate {
@@ -522,7 +866,14 @@ func (b *Blueprint) rename(to string) {
 	})
 }
 
+// addImpliedCommands adds the commands implied by the columns: the fluent indexes and foreign
+// keys after the declared commands, and the add and change commands before them. They are only
+// added once, so compiling the blueprint again yields the same statements.
 func (b *Blueprint) addImpliedCommands() {
+	if b.implied {
+		return
+	}
+	b.implied = true
 	b.addFluentIndexes()
 
 	if !b.creating() {
@@ -541,26 +892,24 @@ func (b *Blueprint) addImpliedCommands() {
 
 func (b *Blueprint) addFluentIndexes() {
 	for _, col := range b.columns {
-		skipped := b.addFluentIndexPrimary(col)
-		if skipped {
-			continue
-		}
+		b.addFluentIndexPrimary(col)
 		b.addFluentIndexIndex(col)
 		b.addFluentIndexUnique(col)
+		b.addFluentForeignKey(col)
 	}
 }
 
-func (b *Blueprint) addFluentIndexPrimary(col *columnDefinition) bool {
-	if col.primary != nil {
-		if b.dialect == dialect.MySQL {
-			return true
-		}
-		if !*col.primary && col.change {
-			b.DropPrimary([]string{col.name})
-			col.primary = nil
-		}
+func (b *Blueprint) addFluentForeignKey(col *columnDefinition) {
+	if col.foreignKey != nil {
+		b.commands = append(b.commands, col.foreignKey)
+		col.foreignKey = nil
+	}
+}
+
+func (b *Blueprint) addFluentIndexPrimary(col *columnDefinition) {
+	if col.primary != nil && !*col.primary && col.change {
+		b.DropPrimary([]string{col.name})
 	}
-	return false
 }
 
 func (b *Blueprint) addFluentIndexIndex(col *columnDefinition) {
@@ -604,7 +953,11 @@ func (b *Blueprint) build(ctx Context) error {
 	if err != nil {
 		return err
 	}
+	for _, warning := range b.warnings {
+		logger.WarnMsg(logger.MessageIgnoredFeature, warning, b.name)
+	}
 	for _, statement := range statements {
+		logger.Debug("executing statement", "table", b.name, "sql", statement)
 		if _, err = ctx.Exec(statement); err != nil {
 			return err
 		}
@@ -612,10 +965,39 @@ func (b *Blueprint) build(ctx Context) error {
 	return nil
 }
 
+// toSQL compiles the blueprint into its statements. The order only depends on the order in which
+// columns and commands were declared, never on map iteration, so the output is reproducible for
+// snapshot tests and checksums:
+//
+//  1. the enum types of the columns, in column order;
+//  2. the changed columns, in column order;
+//  3. CREATE TABLE, or the added columns in a single ALTER TABLE;
+//  4. the declared commands, in declaration order, followed by the fluent indexes and foreign keys
+//     of the columns, in column order and then primary, index, unique, foreign key;
+//  5. the partitions of the table, unless they are part of CREATE TABLE;
+//  6. the comment of the table, unless it is part of CREATE TABLE, then the fluent statements of
+//     the columns such as PostgreSQL comments, in column order;
+//  7. the rename log entries and the owner of the table.
+//
+// On MySQL the clauses set with Algorithm and Lock are added to the statements altering the table.
 func (b *Blueprint) toSQL() ([]string, error) {
 	b.addImpliedCommands()
 
-	var statements []string
+	if err := b.resolveEnums(); err != nil {
+		return nil, err
+	}
+	if err := b.collectWarnings(); err != nil {
+		return nil, err
+	}
+	if err := b.checkPartitions(); err != nil {
+		return nil, err
+	}
+	if err := b.checkConflicts(); err != nil {
+		return nil, err
+	}
+
+	// Enum types must exist before the columns that use them.
+	statements := b.grammar.CompileEnumTypes(b)
 
 	mainCommandMap := map[string]func(blueprint *Blueprint) (string, error){
 		commandCreate:       b.grammar.CompileCreate,
@@ -625,6 +1007,8 @@ func (b *Blueprint) toSQL() ([]string, error) {
 	}
 	secondaryCommandMap := map[string]func(blueprint *Blueprint, command *command) (string, error){
 		commandChange:       b.grammar.CompileChange,
+		commandCheck:        b.grammar.CompileCheck,
+		commandDropCheck:    b.grammar.CompileDropCheck,
 		commandDropColumn:   b.grammar.CompileDropColumn,
 		commandDropIndex:    b.grammar.CompileDropIndex,
 		commandDropForeign:  b.grammar.CompileDropForeign,
@@ -634,6 +1018,7 @@ func (b *Blueprint) toSQL() ([]string, error) {
 		commandForeign:      b.grammar.CompileForeign,
 		commandFullText:     b.grammar.CompileFullText,
 		commandIndex:        b.grammar.CompileIndex,
+		commandJSONIndex:    b.grammar.CompileJSONIndex,
 		commandPrimary:      b.grammar.CompilePrimary,
 		commandRename:       b.grammar.CompileRename,
 		commandRenameColumn: b.grammar.CompileRenameColumn,
@@ -641,6 +1026,9 @@ func (b *Blueprint) toSQL() ([]string, error) {
 		commandUnique:       b.grammar.CompileUnique,
 	}
 	for _, cmd := range b.commands {
+		if cmd.shouldBeSkipped {
+			continue
+		}
 		if compileFunc, exists := mainCommandMap[cmd.name]; exists {
 			sql, err := compileFunc(b)
 			if err != nil {
@@ -661,14 +1049,176 @@ func (b *Blueprint) toSQL() ([]string, error) {
 			}
 			continue
 		}
+		if cmd.isEnumValueCommand() {
+			sqls, err := b.grammar.CompileEnumValues(b, cmd)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, sqls...)
+			continue
+		}
 		return nil, fmt.Errorf("unknown command: %s", cmd.name)
 	}
 
+	partitions, err := b.grammar.CompilePartitions(b)
+	if err != nil {
+		return nil, err
+	}
+	statements = append(statements, partitions...)
+	if sql := b.grammar.CompileTableComment(b); sql != "" {
+		statements = append(statements, sql)
+	}
 	statements = append(statements, b.getFluentStatements()...)
+	if !b.creating() {
+		if statements, err = b.grammar.CompileAlterOptions(b, statements); err != nil {
+			return nil, err
+		}
+	}
+	statements = append(statements, b.getRenameLogStatements()...)
+	if owner := b.tableOwner(); owner != "" {
+		if sql := b.grammar.CompileOwner(b, owner); sql != "" {
+			statements = append(statements, sql)
+		}
+	}
 
 	return statements, nil
 }
 
+// tableOwner returns the role that should own the table: the one set with Owner, or else the
+// configured owner when a table other than a temporary one is created.
+func (b *Blueprint) tableOwner() string {
+	if b.owner != "" {
+		return b.owner
+	}
+	if b.creating() && !b.temporary {
+		return b.defaultOwner
+	}
+	return ""
+}
+
+// getRenameLogStatements returns the statements that record the renamed columns in the rename
+// log table, if one is configured.
+func (b *Blueprint) getRenameLogStatements() []string {
+	if b.renameLog == "" {
+		return nil
+	}
+	var statements []string
+	for _, cmd := range b.commands {
+		if cmd.name != commandRenameColumn || cmd.shouldBeSkipped {
+			continue
+		}
+		if len(statements) == 0 {
+			statements = append(statements, b.grammar.CompileCreateRenameLog(b.renameLog))
+		}
+		statements = append(statements, b.grammar.CompileRenameLogEntry(b.renameLog, b, cmd))
+	}
+	return statements
+}
+
+// checkConflicts validates the blueprint before it is compiled, so that columns or indexes defined
+// twice and a column both dropped and changed or renamed fail with every mistake at once instead of
+// with a server error halfway through the migration.
+func (b *Blueprint) checkConflicts() error {
+	var errs []error
+	conflict := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: table %s: %s", ErrInvalidBlueprint, b.name, fmt.Sprintf(format, args...)))
+	}
+
+	added := make(map[string]int)
+	changed := make(map[string]int)
+	for _, col := range b.columns {
+		if col.change {
+			changed[col.name]++
+		} else {
+			added[col.name]++
+		}
+	}
+	for _, col := range b.columns {
+		if col.change && changed[col.name] > 1 {
+			conflict("column %s is changed more than once", col.name)
+			changed[col.name] = 0
+		} else if !col.change && added[col.name] > 1 {
+			conflict("column %s is defined more than once", col.name)
+			added[col.name] = 0
+		}
+	}
+
+	dropped := make(map[string]bool)
+	indexes := make(map[string]int)
+	foreignKeys := make(map[string]int)
+	var indexNames, foreignKeyNames []string
+	for _, cmd := range b.commands {
+		switch cmd.name {
+		case commandDropColumn:
+			for _, column := range cmd.columns {
+				dropped[column] = true
+			}
+		case commandForeign:
+			name := b.commandIndexName(cmd)
+			if foreignKeys[name]++; foreignKeys[name] == 2 {
+				foreignKeyNames = append(foreignKeyNames, name)
+			}
+		case commandIndex, commandUnique, commandPrimary, commandFullText, commandJSONIndex:
+			name := b.commandIndexName(cmd)
+			if name == "" {
+				continue
+			}
+			if indexes[name]++; indexes[name] == 2 {
+				indexNames = append(indexNames, name)
+			}
+		}
+	}
+	for _, name := range indexNames {
+		conflict("index %s is defined more than once", name)
+	}
+	for _, name := range foreignKeyNames {
+		conflict("foreign key %s is defined more than once", name)
+	}
+	for _, col := range b.getChangedColumns() {
+		if dropped[col.name] {
+			conflict("column %s is both dropped and changed", col.name)
+		}
+	}
+	for _, cmd := range b.commands {
+		if cmd.name == commandRenameColumn && dropped[cmd.from] {
+			conflict("column %s is both dropped and renamed", cmd.from)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// commandIndexName returns the name of the index or foreign key the command creates, generated like
+// the grammar generates it when it is not set. It returns an empty string for unnamed indexes whose
+// generated name depends on the dialect, such as indexes of expressions and JSON paths.
+func (b *Blueprint) commandIndexName(cmd *command) string {
+	if cmd.index != "" {
+		return cmd.index
+	}
+	if cmd.expressions {
+		return ""
+	}
+	switch cmd.name {
+	case commandForeign:
+		return b.grammar.CreateForeignKeyName(b, cmd)
+	case commandIndex, commandUnique, commandPrimary:
+		return b.grammar.CreateIndexName(b, cmd.name, cmd.columns...)
+	case commandFullText:
+		return b.grammar.CreateIndexName(b, "fulltext", cmd.columns...)
+	}
+	return ""
+}
+
+// collectWarnings records the features and modifiers the grammar will not compile. In strict
+// mode unsupported features are returned as an error instead.
+func (b *Blueprint) collectWarnings() error {
+	features := b.grammar.GetUnsupportedFeatures(b)
+	if b.strict && len(features) > 0 {
+		return fmt.Errorf("%w in table %s: %s", ErrUnsupportedFeature, b.name, strings.Join(features, "; "))
+	}
+	b.warnings = append(features, b.grammar.GetIgnoredModifiers(b)...)
+	return nil
+}
+
 func (b *Blueprint) addColumn(colType string, name string, columnDefs ...*columnDefinition) *columnDefinition {
 	var col *columnDefinition
 	if len(columnDefs) > 0 {
@@ -694,6 +1244,14 @@ func (b *Blueprint) indexCommand(name string, columns ...string) IndexDefinition
 	return &indexDefinition{command}
 }
 
+func (b *Blueprint) expressionIndexCommand(name string, expressions []string) IndexDefinition {
+	command := b.addCommand(name, &command{
+		columns:     expressions,
+		expressions: true,
+	})
+	return &indexDefinition{command}
+}
+
 func (b *Blueprint) dropIndexCommand(name string, indexType string, index any) {
 	switch index := index.(type) {
 	case string:
@@ -703,7 +1261,8 @@ func (b *Blueprint) dropIndexCommand(name string, indexType string, index any) {
 	case []string:
 		indexName := b.grammar.CreateIndexName(b, indexType, index...)
 		b.addCommand(name, &command{
-			index: indexName,
+			index:       indexName,
+			dropColumns: index,
 		})
 	default:
 		panic(fmt.Sprintf("unsupported index type: %T", index))
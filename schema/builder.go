@@ -1,8 +1,12 @@
 package schema
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
 
+	"github.com/akfaiz/migris/internal/config"
 	"github.com/akfaiz/migris/internal/dialect"
 )
 
@@ -10,16 +14,34 @@ import (
 type Builder interface {
 	// Create creates a new table with the given name and applies the provided blueprint.
 	Create(c Context, name string, blueprint func(table *Blueprint)) error
+	// CreateTemporary creates a temporary table, which is dropped at the end of the session.
+	CreateTemporary(c Context, name string, blueprint func(table *Blueprint)) error
+	// CreateIfNotExists creates a new table like Create, unless a table with the given name exists.
+	CreateIfNotExists(c Context, name string, blueprint func(table *Blueprint)) error
 	// Drop removes the table with the given name.
 	Drop(c Context, name string) error
 	// DropIfExists removes the table with the given name if it exists.
 	DropIfExists(c Context, name string) error
+	// DropAllTables removes every table in the current schema.
+	DropAllTables(c Context) error
 	// GetColumns retrieves the columns of the specified table.
 	GetColumns(c Context, tableName string) ([]*Column, error)
 	// GetIndexes retrieves the indexes of the specified table.
 	GetIndexes(c Context, tableName string) ([]*Index, error)
+	// GetForeignKeys retrieves the foreign keys of the specified table.
+	GetForeignKeys(c Context, tableName string) ([]*ForeignKey, error)
 	// GetTables retrieves all tables in the database.
 	GetTables(c Context) ([]*TableInfo, error)
+	// GetViews retrieves all views in the database.
+	GetViews(c Context) ([]*ViewInfo, error)
+	// GetAllColumns retrieves the columns of every table in one query, keyed by table name.
+	GetAllColumns(c Context) (map[string][]*Column, error)
+	// GetAllIndexes retrieves the indexes of every table in one query, keyed by table name.
+	GetAllIndexes(c Context) (map[string][]*Index, error)
+	// GetTableDDL retrieves the CREATE TABLE statement of the specified table.
+	GetTableDDL(c Context, tableName string) (string, error)
+	// GetSchemaDDL retrieves the statements that recreate every table in the current schema.
+	GetSchemaDDL(c Context) ([]string, error)
 	// HasColumn checks if the specified table has the given column.
 	HasColumn(c Context, tableName string, columnName string) (bool, error)
 	// HasColumns checks if the specified table has all the given columns.
@@ -28,10 +50,80 @@ type Builder interface {
 	HasIndex(c Context, tableName string, indexes []string) (bool, error)
 	// HasTable checks if a table with the given name exists.
 	HasTable(c Context, name string) (bool, error)
+	// HasView checks if a view with the given name exists.
+	HasView(c Context, name string) (bool, error)
 	// Rename renames a table from oldName to newName.
 	Rename(c Context, oldName string, newName string) error
 	// Table applies the provided blueprint to the specified table.
 	Table(c Context, name string, blueprint func(table *Blueprint)) error
+	// WhenTableExists applies the provided blueprint to the specified table if the table exists.
+	WhenTableExists(c Context, name string, blueprint func(table *Blueprint)) error
+	// WhenColumnMissing applies the provided blueprint to the specified table if it lacks the given column.
+	WhenColumnMissing(c Context, tableName string, columnName string, blueprint func(table *Blueprint)) error
+	// WithoutForeignKeyConstraints runs fn with the foreign key constraints disabled.
+	WithoutForeignKeyConstraints(c Context, fn func() error) error
+	// DeferConstraints defers the checks of deferrable constraints until the end of the transaction.
+	DeferConstraints(c Context) error
+	// Analyze refreshes the planner statistics of the given tables.
+	Analyze(c Context, tables ...string) error
+	// Truncate removes every row of the given table.
+	Truncate(c Context, table string, options TruncateOptions) error
+	// AlterDefaultPrivileges sets the privileges granted on objects created in the future.
+	AlterDefaultPrivileges(c Context, privileges DefaultPrivileges) error
+	// CloneSchema creates the target schema with a copy of the tables of the source schema.
+	CloneSchema(c Context, source, target string, options CloneOptions) error
+	// SwapSchemas exchanges the names of two schemas.
+	SwapSchemas(c Context, schema, otherSchema string) error
+	// CreateSchema creates a schema with the given name.
+	CreateSchema(c Context, name string) error
+	// DropSchema removes the schema with the given name, and everything in it if cascade is set.
+	DropSchema(c Context, name string, cascade bool) error
+	// HasSchema checks if a schema with the given name exists.
+	HasSchema(c Context, name string) (bool, error)
+	// CreatePartition creates a partition of a table created with Blueprint.PartitionBy.
+	CreatePartition(c Context, parent, name string, bounds PartitionBounds) error
+	// CreateExtension enables the PostgreSQL extension with the given name.
+	CreateExtension(c Context, name string) error
+	// DropExtension removes the PostgreSQL extension with the given name.
+	DropExtension(c Context, name string) error
+	// HasExtension checks if the PostgreSQL extension with the given name is enabled.
+	HasExtension(c Context, name string) (bool, error)
+	// ReorderColumns moves the given columns of a table to the front, in the given order.
+	ReorderColumns(c Context, table string, columns []string, options ReorderOptions) error
+	// CreateEnumType creates a native enum type with the given values.
+	CreateEnumType(c Context, name string, values ...string) error
+	// DropEnumType drops a native enum type.
+	DropEnumType(c Context, name string) error
+	// CreateView creates a view with the given name and applies the provided blueprint.
+	CreateView(c Context, name string, blueprint func(view *ViewBlueprint)) error
+	// CreateOrReplaceView creates a view, or replaces the view with the given name if it exists.
+	CreateOrReplaceView(c Context, name string, blueprint func(view *ViewBlueprint)) error
+	// DropView removes the view with the given name.
+	DropView(c Context, name string) error
+	// CreateMaterializedView creates a materialized view with the given name and applies the provided blueprint.
+	CreateMaterializedView(c Context, name string, blueprint func(view *ViewBlueprint)) error
+	// RefreshMaterializedView replaces the rows of a materialized view with the current result of its query.
+	RefreshMaterializedView(c Context, name string, options RefreshOptions) error
+	// DropMaterializedView removes the materialized view with the given name.
+	DropMaterializedView(c Context, name string) error
+	// CreateFunction creates a function with the given name and applies the provided blueprint.
+	CreateFunction(c Context, name string, blueprint func(fn *FunctionBlueprint)) error
+	// DropFunction removes the function with the given name.
+	DropFunction(c Context, name string) error
+	// CreateTrigger creates a trigger on a table with the given name and applies the provided blueprint.
+	CreateTrigger(c Context, name, table string, blueprint func(trigger *TriggerBlueprint)) error
+	// DropTrigger removes the trigger with the given name from a table.
+	DropTrigger(c Context, name, table string) error
+	// CreateEventTrigger creates an event trigger with the given name and applies the provided blueprint.
+	CreateEventTrigger(c Context, name string, blueprint func(trigger *EventTriggerBlueprint)) error
+	// DropEventTrigger removes the event trigger with the given name.
+	DropEventTrigger(c Context, name string) error
+	// CreateSequence creates a sequence with the given name and applies the provided blueprint.
+	CreateSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error
+	// AlterSequence changes the options of the sequence with the given name set in the blueprint.
+	AlterSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error
+	// DropSequence removes the sequence with the given name.
+	DropSequence(c Context, name string) error
 }
 
 // NewBuilder creates a new Builder instance based on the specified dialect.
@@ -53,11 +145,46 @@ func NewBuilder(dialectValue string) (Builder, error) {
 }
 
 type baseBuilder struct {
-	grammar grammar
+	grammar        grammar
+	tableChecker   tableChecker
+	columnChecker  columnChecker
+	indexLister    indexLister
+	enumLister     enumColumnLister
+	versionChecker versionChecker
+}
+
+// tableChecker checks whether a table exists; it is implemented by the dialect builders.
+type tableChecker interface {
+	HasTable(c Context, name string) (bool, error)
+}
+
+// columnChecker checks whether a table has a column; it is implemented by the dialect builders.
+type columnChecker interface {
+	HasColumn(c Context, tableName string, columnName string) (bool, error)
+}
+
+// indexLister lists the indexes of a table; it is implemented by the dialect builders.
+type indexLister interface {
+	GetIndexes(c Context, tableName string) ([]*Index, error)
+}
+
+// versionChecker fails if the server is too old for a feature used by the blueprint; it is
+// implemented by the dialect builders whose features depend on the server version.
+type versionChecker interface {
+	checkServerVersion(c Context, bp *Blueprint) error
 }
 
 func (b *baseBuilder) newBlueprint(name string) *Blueprint {
-	return &Blueprint{name: name, grammar: b.grammar}
+	return &Blueprint{
+		name:          name,
+		grammar:       b.grammar,
+		strict:        config.IsStrict(),
+		inlineIndexes: config.IsInlineIndexes(),
+		identityIDs:   config.IsIdentityColumns(),
+		renameLog:     config.GetRenameLogTable(),
+		defaultOwner:  config.GetObjectOwner(),
+		naming:        getNamingStrategy(),
+	}
 }
 
 func (b *baseBuilder) Create(c Context, name string, blueprint func(table *Blueprint)) error {
@@ -69,13 +196,49 @@ func (b *baseBuilder) Create(c Context, name string, blueprint func(table *Bluep
 	bp.create()
 	blueprint(bp)
 
+	if err := b.checkServerVersion(c, bp); err != nil {
+		return err
+	}
 	if err := bp.build(c); err != nil {
 		return err
 	}
+	if !bp.temporary {
+		trackTable(c, name)
+	}
 
 	return nil
 }
 
+func (b *baseBuilder) CreateTemporary(c Context, name string, blueprint func(table *Blueprint)) error {
+	if blueprint == nil {
+		return errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+	return b.Create(c, name, func(table *Blueprint) {
+		table.Temporary()
+		blueprint(table)
+	})
+}
+
+func (b *baseBuilder) CreateIfNotExists(c Context, name string, blueprint func(table *Blueprint)) error {
+	if c == nil || name == "" || blueprint == nil {
+		return errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+
+	// The indexes, constraints and comments of the table are created by separate statements,
+	// which would fail for an existing table, so the table is looked up first. Dry runs cannot
+	// look it up and print the statements that create it.
+	if _, ok := c.(*DryRunContext); !ok && b.tableChecker != nil {
+		exists, err := b.tableChecker.HasTable(c, name)
+		if err != nil || exists {
+			return err
+		}
+	}
+	return b.Create(c, name, func(table *Blueprint) {
+		table.ifNotExists = true
+		blueprint(table)
+	})
+}
+
 func (b *baseBuilder) Drop(c Context, name string) error {
 	if c == nil || name == "" {
 		return errors.New("invalid arguments: context is nil or name is empty")
@@ -84,9 +247,13 @@ func (b *baseBuilder) Drop(c Context, name string) error {
 	bp := b.newBlueprint(name)
 	bp.drop()
 
+	if err := beforeTableChange(c, name); err != nil {
+		return err
+	}
 	if err := bp.build(c); err != nil {
 		return err
 	}
+	dropTable(c, name)
 
 	return nil
 }
@@ -99,9 +266,13 @@ func (b *baseBuilder) DropIfExists(c Context, name string) error {
 	bp := b.newBlueprint(name)
 	bp.dropIfExists()
 
+	if err := beforeTableChange(c, name); err != nil {
+		return err
+	}
 	if err := bp.build(c); err != nil {
 		return err
 	}
+	dropTable(c, name)
 
 	return nil
 }
@@ -114,9 +285,14 @@ func (b *baseBuilder) Rename(c Context, oldName string, newName string) error {
 	bp := b.newBlueprint(oldName)
 	bp.rename(newName)
 
+	if err := beforeTableChange(c, oldName); err != nil {
+		return err
+	}
 	if err := bp.build(c); err != nil {
 		return err
 	}
+	dropTable(c, oldName)
+	trackTable(c, newName)
 
 	return nil
 }
@@ -129,9 +305,615 @@ func (b *baseBuilder) Table(c Context, name string, blueprint func(table *Bluepr
 	bp := b.newBlueprint(name)
 	blueprint(bp)
 
+	if err := b.resolveDropIndexNames(c, bp); err != nil {
+		return err
+	}
+	if err := b.resolveEnumColumns(c, bp); err != nil {
+		return err
+	}
+	if err := b.checkServerVersion(c, bp); err != nil {
+		return err
+	}
+	if err := beforeTableChange(c, name); err != nil {
+		return err
+	}
 	if err := bp.build(c); err != nil {
 		return err
 	}
+	trackTable(c, name)
+
+	return nil
+}
+
+func (b *baseBuilder) WhenTableExists(c Context, name string, blueprint func(table *Blueprint)) error {
+	if c == nil || name == "" || blueprint == nil {
+		return errors.New("invalid arguments: context is nil or name/blueprint is empty")
+	}
+
+	// Dry runs cannot look the table up and print the statements as if it exists.
+	if _, ok := c.(*DryRunContext); !ok && b.tableChecker != nil {
+		exists, err := b.tableChecker.HasTable(c, name)
+		if err != nil || !exists {
+			return err
+		}
+	}
+	return b.Table(c, name, blueprint)
+}
+
+func (b *baseBuilder) WhenColumnMissing(
+	c Context,
+	tableName string,
+	columnName string,
+	blueprint func(table *Blueprint),
+) error {
+	if c == nil || tableName == "" || columnName == "" || blueprint == nil {
+		return errors.New("invalid arguments: context is nil or table name, column name or blueprint is empty")
+	}
+
+	// Dry runs cannot look the column up and print the statements as if it is missing.
+	if _, ok := c.(*DryRunContext); !ok && b.columnChecker != nil {
+		exists, err := b.columnChecker.HasColumn(c, tableName, columnName)
+		if err != nil || exists {
+			return err
+		}
+	}
+	return b.Table(c, tableName, blueprint)
+}
+
+func (b *baseBuilder) WithoutForeignKeyConstraints(c Context, fn func() error) error {
+	if c == nil || fn == nil {
+		return errors.New("invalid arguments: context or fn is nil")
+	}
+
+	if _, err := c.Exec(b.grammar.CompileDisableForeignKeyConstraints()); err != nil {
+		return err
+	}
+	err := fn()
+	// Re-enable the constraints even when fn fails, as the setting outlives the transaction.
+	if _, enableErr := c.Exec(b.grammar.CompileEnableForeignKeyConstraints()); err == nil {
+		err = enableErr
+	}
+	return err
+}
+
+func (b *baseBuilder) DeferConstraints(c Context) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDeferConstraints()
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) Analyze(c Context, tables ...string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileAnalyze(tables)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) Truncate(c Context, table string, options TruncateOptions) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileTruncate(table, options)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateEnumType(c Context, name string, values ...string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileCreateEnumType(name, values)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropEnumType(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropEnumType(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	view, err := newViewBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileCreateView(view, false)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateOrReplaceView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	view, err := newViewBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileCreateView(view, true)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropView(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropView(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateMaterializedView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	view, err := newViewBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileCreateMaterializedView(view)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) RefreshMaterializedView(c Context, name string, options RefreshOptions) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileRefreshMaterializedView(name, options)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropMaterializedView(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropMaterializedView(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateFunction(c Context, name string, blueprint func(fn *FunctionBlueprint)) error {
+	fn, err := newFunctionBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileCreateFunction(fn)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropFunction(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropFunction(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateTrigger(
+	c Context,
+	name, table string,
+	blueprint func(trigger *TriggerBlueprint),
+) error {
+	trigger, err := newTriggerBlueprint(c, name, table, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileCreateTrigger(trigger)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropTrigger(c Context, name, table string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropTrigger(name, table)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateEventTrigger(
+	c Context,
+	name string,
+	blueprint func(trigger *EventTriggerBlueprint),
+) error {
+	trigger, err := newEventTriggerBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileCreateEventTrigger(trigger)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropEventTrigger(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropEventTrigger(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error {
+	seq, err := newSequenceBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileCreateSequence(seq)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) AlterSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error {
+	seq, err := newSequenceBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := b.grammar.CompileAlterSequence(seq)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropSequence(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropSequence(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) AlterDefaultPrivileges(c Context, privileges DefaultPrivileges) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDefaultPrivileges(privileges)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CloneSchema(c Context, source, target string, options CloneOptions) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileCloneSchema(source, target, options)
+	if err != nil {
+		return err
+	}
+	rows, err := c.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var statement string
+		if err = rows.Scan(&statement); err != nil {
+			return err
+		}
+		statements = append(statements, statement)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	// The statements run after the rows are closed, as a transaction runs one query at a time.
+	rows.Close()
+	for _, statement := range statements {
+		if _, err = c.Exec(statement); err != nil {
+			return fmt.Errorf("cannot clone schema %s: %w", source, err)
+		}
+	}
+	return nil
+}
+
+func (b *baseBuilder) SwapSchemas(c Context, schema, otherSchema string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	queries, err := swapSchemaQueries(b.grammar, schema, otherSchema)
+	if err != nil {
+		return err
+	}
+	for _, query := range queries {
+		if _, err = c.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *baseBuilder) CreateSchema(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileCreateSchema(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropSchema(c Context, name string, cascade bool) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropSchema(name, cascade)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) HasSchema(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or schema name is empty")
+	}
 
+	query, err := b.grammar.CompileSchemaExists(name)
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	if err = c.QueryRow(query).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return exists, nil
+}
+
+func (b *baseBuilder) CreatePartition(c Context, parent, name string, bounds PartitionBounds) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileCreatePartition(parent, name, bounds)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) CreateExtension(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileCreateExtension(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) DropExtension(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileDropExtension(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(query)
+	return err
+}
+
+func (b *baseBuilder) HasExtension(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or extension name is empty")
+	}
+
+	query, err := b.grammar.CompileExtensionExists(name)
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	if err = c.QueryRow(query).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return exists, nil
+}
+
+// reorderColumns runs the statements that move the columns of the table defined by ddl.
+func (b *baseBuilder) reorderColumns(c Context, ddl string, columns []string, options ReorderOptions) error {
+	queries, err := b.grammar.CompileReorderColumns(ddl, columns, options)
+	if err != nil {
+		return err
+	}
+	for _, query := range queries {
+		if _, err = c.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swapSchemaQueries returns the statements that exchange the names of two schemas through a
+// temporary name.
+func swapSchemaQueries(g grammar, schema, otherSchema string) ([]string, error) {
+	if schema == otherSchema {
+		return nil, errors.New("schema names must differ")
+	}
+	temporary := schema + "_swap"
+	var queries []string
+	for _, rename := range [][2]string{{schema, temporary}, {otherSchema, schema}, {temporary, otherSchema}} {
+		query, err := g.CompileRenameSchema(rename[0], rename[1])
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+// resolveDropIndexNames looks up the actual name of each index dropped by its columns when the
+// name generated by the naming strategy does not exist, e.g. because the index was created by
+// another tool or with a different naming strategy. Dry runs keep the generated names.
+func (b *baseBuilder) resolveDropIndexNames(c Context, bp *Blueprint) error {
+	if b.indexLister == nil {
+		return nil
+	}
+	if _, ok := c.(*DryRunContext); ok {
+		return nil
+	}
+
+	var indexes []*Index
+	loaded := false
+	for _, cmd := range bp.commands {
+		if len(cmd.dropColumns) == 0 {
+			continue
+		}
+		if !loaded {
+			var err error
+			if indexes, err = b.indexLister.GetIndexes(c, bp.name); err != nil {
+				return fmt.Errorf("cannot resolve index names of table %s: %w", bp.name, err)
+			}
+			loaded = true
+		}
+		if name := findDroppedIndexName(indexes, cmd); name != "" {
+			cmd.index = name
+		}
+	}
 	return nil
 }
+
+// checkServerVersion fails before any statement of the blueprint runs if the server does not
+// support a feature the blueprint uses. Dry runs are not checked.
+func (b *baseBuilder) checkServerVersion(c Context, bp *Blueprint) error {
+	if b.versionChecker == nil {
+		return nil
+	}
+	if _, ok := c.(*DryRunContext); ok {
+		return nil
+	}
+	return b.versionChecker.checkServerVersion(c, bp)
+}
+
+// findDroppedIndexName returns the name of the existing index the drop command refers to, or an
+// empty string if there is none.
+func findDroppedIndexName(indexes []*Index, cmd *command) string {
+	for _, idx := range indexes {
+		if idx.Name == cmd.index {
+			return idx.Name
+		}
+	}
+	for _, idx := range indexes {
+		if !slices.Equal(idx.Columns, cmd.dropColumns) {
+			continue
+		}
+		switch cmd.name {
+		case commandDropPrimary:
+			if idx.Primary {
+				return idx.Name
+			}
+		case commandDropUnique:
+			if idx.Unique && !idx.Primary {
+				return idx.Name
+			}
+		case commandDropIndex, commandDropFullText:
+			if !idx.Unique && !idx.Primary {
+				return idx.Name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseBuilder_WithoutForeignKeyConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder Builder
+		want    []string
+	}{
+		{
+			name:    "mysql",
+			builder: newMysqlBuilder(),
+			want:    []string{"SET FOREIGN_KEY_CHECKS=0", "UPDATE users SET team_id = 2", "SET FOREIGN_KEY_CHECKS=1"},
+		},
+		{
+			name:    "postgres",
+			builder: newPostgresBuilder(),
+			want: []string{
+				"SET session_replication_role = replica",
+				"UPDATE users SET team_id = 2",
+				"SET session_replication_role = DEFAULT",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewDryRunContext(context.Background())
+			err := tt.builder.WithoutForeignKeyConstraints(c, func() error {
+				_, err := c.Exec("UPDATE users SET team_id = 2")
+				return err
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.GetCapturedSQL())
+		})
+	}
+
+	t.Run("constraints are re-enabled when fn fails", func(t *testing.T) {
+		c := NewDryRunContext(context.Background())
+		errBoom := errors.New("boom")
+		err := newMysqlBuilder().WithoutForeignKeyConstraints(c, func() error {
+			return errBoom
+		})
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, []string{"SET FOREIGN_KEY_CHECKS=0", "SET FOREIGN_KEY_CHECKS=1"}, c.GetCapturedSQL())
+	})
+
+	t.Run("fn is required", func(t *testing.T) {
+		err := newMysqlBuilder().WithoutForeignKeyConstraints(NewDryRunContext(context.Background()), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestBaseBuilder_DeferConstraints(t *testing.T) {
+	c := NewDryRunContext(context.Background())
+	require.NoError(t, newPostgresBuilder().DeferConstraints(c))
+	assert.Equal(t, []string{"SET CONSTRAINTS ALL DEFERRED"}, c.GetCapturedSQL())
+
+	c = NewDryRunContext(context.Background())
+	err := newMysqlBuilder().DeferConstraints(c)
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	assert.Empty(t, c.GetCapturedSQL())
+
+	require.Error(t, newPostgresBuilder().DeferConstraints(nil))
+}
+
+func TestBaseBuilder_SwapSchemas(t *testing.T) {
+	c := NewDryRunContext(context.Background())
+	require.NoError(t, newPostgresBuilder().SwapSchemas(c, "public", "green"))
+	assert.Equal(t, []string{
+		"ALTER SCHEMA public RENAME TO public_swap",
+		"ALTER SCHEMA green RENAME TO public",
+		"ALTER SCHEMA public_swap RENAME TO green",
+	}, c.GetCapturedSQL())
+
+	require.Error(t, newPostgresBuilder().SwapSchemas(c, "public", "public"))
+
+	c = NewDryRunContext(context.Background())
+	err := newMysqlBuilder().SwapSchemas(c, "app", "app_green")
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	assert.Empty(t, c.GetCapturedSQL())
+
+	err = newMysqlBuilder().CloneSchema(c, "app", "app_green", CloneOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+}
+
+func TestBaseBuilder_BeforeTableHook(t *testing.T) {
+	var tables []string
+	errBoom := errors.New("boom")
+	c := NewContext(context.Background(), nil, WithBeforeTableHook(func(table string) error {
+		tables = append(tables, table)
+		return errBoom
+	}))
+	builder := newPostgresBuilder()
+
+	require.ErrorIs(t, builder.Table(c, "users", func(table *Blueprint) {
+		table.String("nickname")
+	}), errBoom)
+	require.ErrorIs(t, builder.Rename(c, "posts", "articles"), errBoom)
+	require.ErrorIs(t, builder.Drop(c, "comments"), errBoom)
+	require.ErrorIs(t, builder.DropIfExists(c, "tags"), errBoom)
+	assert.Equal(t, []string{"users", "posts", "comments", "tags"}, tables)
+}
@@ -0,0 +1,18 @@
+package schema
+
+// CheckDefinition defines the interface for defining a check constraint in a database table.
+type CheckDefinition interface {
+	// Name sets the name of the check constraint.
+	// Without a name, one is generated from the table and the identifiers of the condition,
+	// e.g. chk_products_price for "price > 0".
+	Name(name string) CheckDefinition
+}
+
+type checkDefinition struct {
+	*command
+}
+
+func (cd *checkDefinition) Name(name string) CheckDefinition {
+	cd.index = name
+	return cd
+}
@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// goInitialisms are the name parts written in upper case in Go identifiers, e.g. user_id becomes
+// UserID.
+var goInitialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DB": true, "DNS": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "SKU": true, "SQL": true, "SSH": true,
+	"TLS": true, "TTL": true, "UI": true, "URI": true, "URL": true, "UTC": true, "UUID": true,
+	"XML": true,
+}
+
+// GenerateGo writes a Go source file to w that declares constants for the names of the tables
+// and columns in the current schema, so query code refers to them by identifier and a renamed
+// column breaks the build instead of the query. Tables in exclude, e.g. the version table, are
+// skipped.
+//
+// The schema is read through the introspection of the builder, so it can also be generated
+// without a database by running the migrations against a Fake first.
+//
+// Example:
+//
+//	err := schema.GenerateGo(c, f, "db")
+//
+// generates for a users table:
+//
+//	const (
+//	    TableUsers = "users"
+//	)
+//
+//	// Columns of the users table.
+//	const (
+//	    UsersID    = "id"
+//	    UsersEmail = "email"
+//	)
+func GenerateGo(c Context, w io.Writer, pkg string, exclude ...string) error {
+	if c == nil || w == nil || pkg == "" {
+		return errors.New("invalid arguments: context, writer or package is nil/empty")
+	}
+
+	tables, err := GetTables(c)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(tables))
+	for _, table := range tables {
+		if !slices.Contains(exclude, table.Name) {
+			names = append(names, table.Name)
+		}
+	}
+	slices.Sort(names)
+
+	g := &goGenerator{declared: make(map[string]string)}
+	g.printf("// Code generated by migris. DO NOT EDIT.\n\npackage %s\n", pkg)
+
+	if len(names) > 0 {
+		g.printf("\n// Tables.\nconst (\n")
+		for _, name := range names {
+			if err = g.constant("Table"+goIdentifier(name), name, "table "+name); err != nil {
+				return err
+			}
+		}
+		g.printf(")\n")
+	}
+
+	for _, name := range names {
+		if err = g.columns(c, name); err != nil {
+			return err
+		}
+	}
+
+	source, err := format.Source([]byte(g.buf.String()))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+type goGenerator struct {
+	buf      strings.Builder
+	declared map[string]string // declared identifiers and what they name
+}
+
+func (g *goGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// constant declares a string constant, failing when two names map to the same identifier.
+func (g *goGenerator) constant(identifier string, value string, description string) error {
+	if other, exists := g.declared[identifier]; exists {
+		return fmt.Errorf("identifier %s is generated for both %s and %s", identifier, other, description)
+	}
+	g.declared[identifier] = description
+	g.printf("%s = %q\n", identifier, value)
+	return nil
+}
+
+// columns declares the constants for the columns of a table.
+func (g *goGenerator) columns(c Context, table string) error {
+	columns, err := GetColumns(c, table)
+	if err != nil || len(columns) == 0 {
+		return err
+	}
+	g.printf("\n// Columns of the %s table.\nconst (\n", table)
+	prefix := goIdentifier(table)
+	for _, col := range columns {
+		if err = g.constant(prefix+goIdentifier(col.Name), col.Name, "column "+table+"."+col.Name); err != nil {
+			return err
+		}
+	}
+	g.printf(")\n")
+	return nil
+}
+
+// goIdentifier converts a database name, e.g. user_id, into an exported Go identifier, e.g.
+// UserID.
+func goIdentifier(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, part := range parts {
+		if upper := strings.ToUpper(part); goInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		b.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+	identifier := b.String()
+	if identifier == "" || !unicode.IsLetter([]rune(identifier)[0]) {
+		identifier = "X" + identifier
+	}
+	return identifier
+}
@@ -0,0 +1,86 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateGo(t *testing.T) {
+	t.Run("declares tables and columns", func(t *testing.T) {
+		fake, err := NewFake("pgx")
+		require.NoError(t, err)
+		require.NoError(t, Create(fake, "users", func(table *Blueprint) {
+			table.ID()
+			table.String("email")
+			table.String("avatar_url")
+		}))
+		require.NoError(t, Create(fake, "order_items", func(table *Blueprint) {
+			table.BigInteger("order_id")
+			table.Integer("quantity")
+		}))
+		require.NoError(t, Create(fake, "schema_migrations", func(table *Blueprint) {
+			table.ID()
+		}))
+
+		var buf bytes.Buffer
+		require.NoError(t, GenerateGo(fake, &buf, "db", "schema_migrations"))
+		assert.Equal(t, `// Code generated by migris. DO NOT EDIT.
+
+package db
+
+// Tables.
+const (
+	TableOrderItems = "order_items"
+	TableUsers      = "users"
+)
+
+// Columns of the order_items table.
+const (
+	OrderItemsOrderID  = "order_id"
+	OrderItemsQuantity = "quantity"
+)
+
+// Columns of the users table.
+const (
+	UsersID        = "id"
+	UsersEmail     = "email"
+	UsersAvatarURL = "avatar_url"
+)
+`, buf.String())
+	})
+
+	t.Run("fails on conflicting identifiers", func(t *testing.T) {
+		fake, err := NewFake("mysql")
+		require.NoError(t, err)
+		require.NoError(t, Create(fake, "users", func(table *Blueprint) {
+			table.String("user_name")
+			table.String("userName")
+		}))
+
+		err = GenerateGo(fake, &bytes.Buffer{}, "db")
+		require.EqualError(t, err,
+			"identifier UsersUserName is generated for both column users.user_name and column users.userName")
+	})
+}
+
+func TestGoIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "users", want: "Users"},
+		{name: "user_id", want: "UserID"},
+		{name: "api_keys", want: "APIKeys"},
+		{name: "created-at", want: "CreatedAt"},
+		{name: "2fa_codes", want: "X2faCodes"},
+		{name: "public.users", want: "PublicUsers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, goIdentifier(tt.name))
+		})
+	}
+}
@@ -8,27 +8,76 @@ import (
 
 // ColumnDefinition defines the interface for defining a column in a database table.
 type ColumnDefinition interface {
+	// After places the column after another column when it is added or changed.
+	// It is only supported by MySQL.
+	After(column string) ColumnDefinition
+	// Array makes the column an array of its type, e.g. TEXT[].
+	// This is only supported by PostgreSQL.
+	Array() ColumnDefinition
 	// AutoIncrement sets the column to auto-increment.
 	// This is typically used for primary key columns.
 	AutoIncrement() ColumnDefinition
 	// Change changes the column definition.
 	Change() ColumnDefinition
-	// Charset sets the character set for the column.
+	// Charset sets the character set of a string column, overriding the character set of the
+	// table. It is only supported by MySQL.
 	Charset(charset string) ColumnDefinition
-	// Collation sets the collation for the column.
+	// CheckJSONPathExists adds a check constraint requiring every given JSON path (e.g. "$.id") to
+	// exist in the column value. It applies to JSON columns being created or added.
+	CheckJSONPathExists(paths ...string) ColumnDefinition
+	// CheckJSONSchema adds a check constraint validating the column value against a JSON schema
+	// document. It applies to JSON columns being created or added and is only supported by MySQL.
+	CheckJSONSchema(schemaDoc string) ColumnDefinition
+	// Collation sets the collation of a string column, overriding the collation of the table.
+	// It is only supported by MySQL.
 	Collation(collation string) ColumnDefinition
 	// Comment adds a comment to the column definition.
 	Comment(comment string) ColumnDefinition
+	// Constrained adds a foreign key on the column. The optional parameters are the referenced
+	// table, by default the plural of the column name without the _id suffix (e.g. users for
+	// user_id), and the referenced column, by default id.
+	//
+	// Example:
+	//
+	//	table.ForeignID("user_id").Constrained().CascadeOnDelete()
+	Constrained(params ...string) ForeignKeyDefinition
 	// Default sets a default value for the column.
 	Default(value any) ColumnDefinition
+	// First places the column first in the table when it is added or changed.
+	// It is only supported by MySQL.
+	First() ColumnDefinition
+	// GeneratedAlwaysAsIdentity sets the column as an identity column whose value is always generated
+	// (GENERATED ALWAYS AS IDENTITY in PostgreSQL, AUTO_INCREMENT in MySQL).
+	// Combined with Change it adds the identity to an existing integer column.
+	GeneratedAlwaysAsIdentity() ColumnDefinition
+	// GeneratedAsIdentity sets the column as an identity column whose value is always generated
+	// if always is true, like GeneratedAlwaysAsIdentity, or else generated by default, like
+	// Identity.
+	GeneratedAsIdentity(always bool) ColumnDefinition
+	// Identity sets the column as an identity column whose value is generated by default
+	// (GENERATED BY DEFAULT AS IDENTITY in PostgreSQL, AUTO_INCREMENT in MySQL).
+	// Combined with Change it adds the identity to an existing integer column.
+	Identity() ColumnDefinition
 	// Index adds an index to the column.
 	Index(params ...any) ColumnDefinition
+	// Native makes an enum column use a native enum type on PostgreSQL instead of VARCHAR with a
+	// check constraint. The type is named after the registered enum of EnumFrom, or else
+	// <table>_<column>, unless a name is given, and is created with the column. A type named after
+	// a registered enum or given explicitly can be shared by several tables and is only created if
+	// it does not exist yet. An enum column without allowed values uses the existing type of the
+	// given name, e.g. one created with CreateEnumType. Combined with Change it adds the missing
+	// values to the type; values are never removed. MySQL enum columns are native already.
+	Native(typeName ...string) ColumnDefinition
 	// Nullable sets the column to be nullable or not.
 	Nullable(value ...bool) ColumnDefinition
 	// OnUpdate sets the value to be used when the column is updated.
 	OnUpdate(value any) ColumnDefinition
 	// Primary sets the column as a primary key.
 	Primary(value ...bool) ColumnDefinition
+	// StoredAs makes the column a generated column whose value is computed from the expression
+	// when a row is written and stored (GENERATED ALWAYS AS (expression) STORED).
+	// Combined with Change it replaces the expression, which requires PostgreSQL 17.
+	StoredAs(expression string) ColumnDefinition
 	// Unique sets the column to be unique.
 	Unique(params ...any) ColumnDefinition
 	// Unsigned sets the column to be unsigned (applicable for numeric types).
@@ -37,8 +86,21 @@ type ColumnDefinition interface {
 	UseCurrent() ColumnDefinition
 	// UseCurrentOnUpdate sets the column to use the current timestamp on update.
 	UseCurrentOnUpdate() ColumnDefinition
+	// VirtualAs makes the column a generated column whose value is computed from the expression
+	// when it is read (GENERATED ALWAYS AS (expression) VIRTUAL). PostgreSQL supports virtual
+	// generated columns from version 18.
+	// Combined with Change it replaces the expression, which requires PostgreSQL 17.
+	VirtualAs(expression string) ColumnDefinition
 }
 
+const (
+	identityAlways    = "ALWAYS"
+	identityByDefault = "BY DEFAULT"
+
+	generatedStored  = "STORED"
+	generatedVirtual = "VIRTUAL"
+)
+
 type columnDefinition struct {
 	commands           []string
 	name               string
@@ -47,23 +109,38 @@ type columnDefinition struct {
 	collation          *string
 	comment            *string
 	defaultValue       any
+	defaultUUID        bool // default to a generated UUID unless a default is set, see UUIDPrimary
 	onUpdateValue      any
 	useCurrent         bool
 	useCurrentOnUpdate bool
 	nullable           *bool
 	autoIncrement      *bool
 	unsigned           *bool
+	implicitUnsigned   bool // unsigned set by a helper such as ForeignID, not by the user
 	primary            *bool
 	index              *bool
 	indexName          string
 	unique             *bool
 	uniqueName         string
+	foreignKey         *command // foreign key added with Constrained
 	length             *int
 	precision          *int
 	total              *int
 	places             *int
 	change             bool
+	array              bool     // column is an array of its type
+	after              string   // column the column is placed after
+	first              bool     // column is placed first in the table
+	identity           string   // identity generation: "BY DEFAULT" or "ALWAYS"
+	generatedAs        string   // expression of a generated column
+	generatedStorage   string   // storage of a generated column: "STORED" or "VIRTUAL"
 	allowed            []string // for enum type columns
+	jsonPaths          []string // JSON paths that must exist in the column value
+	jsonSchema         *string  // JSON schema document the column value must match
+	enumName           string   // registered enum the allowed values are resolved from
+	nativeEnum         bool     // use a native enum type on PostgreSQL
+	enumType           string   // name of the native enum type
+	enumCheck          string   // name of the check constraint of an existing enum column on PostgreSQL
 	subtype            *string  // for geography and geometry types
 	srid               *int     // for geography and geometry types
 }
@@ -103,6 +180,16 @@ func (c *columnDefinition) SetSubtype(value *string) {
 	c.subtype = value
 }
 
+func (c *columnDefinition) After(column string) ColumnDefinition {
+	c.after = column
+	return c
+}
+
+func (c *columnDefinition) Array() ColumnDefinition {
+	c.array = true
+	return c
+}
+
 func (c *columnDefinition) AutoIncrement() ColumnDefinition {
 	c.autoIncrement = util.PtrOf(true)
 	return c
@@ -118,11 +205,41 @@ func (c *columnDefinition) Change() ColumnDefinition {
 	return c
 }
 
+func (c *columnDefinition) CheckJSONPathExists(paths ...string) ColumnDefinition {
+	c.jsonPaths = append(c.jsonPaths, paths...)
+	return c
+}
+
+func (c *columnDefinition) CheckJSONSchema(schemaDoc string) ColumnDefinition {
+	c.jsonSchema = &schemaDoc
+	return c
+}
+
+// hasJSONChecks reports whether JSON check constraints are declared on the column.
+func (c *columnDefinition) hasJSONChecks() bool {
+	return len(c.jsonPaths) > 0 || c.jsonSchema != nil
+}
+
 func (c *columnDefinition) Collation(collation string) ColumnDefinition {
 	c.collation = &collation
 	return c
 }
 
+func (c *columnDefinition) Constrained(params ...string) ForeignKeyDefinition {
+	table := util.Optional(foreignTableName(c.name), params...)
+	column := "id"
+	if len(params) > 1 {
+		column = params[1]
+	}
+	c.foreignKey = &command{
+		name:       commandForeign,
+		columns:    []string{c.name},
+		on:         table,
+		references: []string{column},
+	}
+	return &foreignKeyDefinition{command: c.foreignKey}
+}
+
 func (c *columnDefinition) Comment(comment string) ColumnDefinition {
 	c.addCommand("comment")
 	c.comment = &comment
@@ -136,6 +253,40 @@ func (c *columnDefinition) Default(value any) ColumnDefinition {
 	return c
 }
 
+func (c *columnDefinition) GeneratedAlwaysAsIdentity() ColumnDefinition {
+	c.identity = identityAlways
+	return c
+}
+
+func (c *columnDefinition) GeneratedAsIdentity(always bool) ColumnDefinition {
+	if always {
+		return c.GeneratedAlwaysAsIdentity()
+	}
+	return c.Identity()
+}
+
+func (c *columnDefinition) Identity() ColumnDefinition {
+	c.identity = identityByDefault
+	return c
+}
+
+func (c *columnDefinition) StoredAs(expression string) ColumnDefinition {
+	c.generatedAs = expression
+	c.generatedStorage = generatedStored
+	return c
+}
+
+func (c *columnDefinition) VirtualAs(expression string) ColumnDefinition {
+	c.generatedAs = expression
+	c.generatedStorage = generatedVirtual
+	return c
+}
+
+func (c *columnDefinition) First() ColumnDefinition {
+	c.first = true
+	return c
+}
+
 func (c *columnDefinition) Index(params ...any) ColumnDefinition {
 	index := true
 	for _, param := range params {
@@ -150,6 +301,12 @@ func (c *columnDefinition) Index(params ...any) ColumnDefinition {
 	return c
 }
 
+func (c *columnDefinition) Native(typeName ...string) ColumnDefinition {
+	c.nativeEnum = true
+	c.enumType = util.Optional("", typeName...)
+	return c
+}
+
 func (c *columnDefinition) Nullable(value ...bool) ColumnDefinition {
 	c.addCommand("nullable")
 	c.nullable = util.OptionalPtr(true, value...)
@@ -1,40 +1,55 @@
 package schema
 
 const (
-	commandAdd          string = "add"
-	commandChange       string = "change"
-	commandCreate       string = "create"
-	commandDrop         string = "drop"
-	commandDropIfExists string = "dropIfExists"
-	commandDropColumn   string = "dropColumn"
-	commandDropForeign  string = "dropForeign"
-	commandDropFullText string = "dropFullText"
-	commandDropIndex    string = "dropIndex"
-	commandDropPrimary  string = "dropPrimary"
-	commandDropUnique   string = "dropUnique"
-	commandForeign      string = "foreign"
-	commandFullText     string = "fullText"
-	commandIndex        string = "index"
-	commandPrimary      string = "primary"
-	commandRename       string = "rename"
-	commandRenameColumn string = "renameColumn"
-	commandRenameIndex  string = "renameIndex"
-	commandUnique       string = "unique"
+	commandAdd             string = "add"
+	commandAddEnumValue    string = "addEnumValue"
+	commandChange          string = "change"
+	commandCheck           string = "check"
+	commandCreate          string = "create"
+	commandDrop            string = "drop"
+	commandDropIfExists    string = "dropIfExists"
+	commandDropCheck       string = "dropCheck"
+	commandDropColumn      string = "dropColumn"
+	commandDropEnumValue   string = "dropEnumValue"
+	commandDropForeign     string = "dropForeign"
+	commandDropFullText    string = "dropFullText"
+	commandDropIndex       string = "dropIndex"
+	commandDropPrimary     string = "dropPrimary"
+	commandDropUnique      string = "dropUnique"
+	commandForeign         string = "foreign"
+	commandFullText        string = "fullText"
+	commandIndex           string = "index"
+	commandJSONIndex       string = "jsonIndex"
+	commandPrimary         string = "primary"
+	commandRename          string = "rename"
+	commandRenameColumn    string = "renameColumn"
+	commandRenameEnumValue string = "renameEnumValue"
+	commandRenameIndex     string = "renameIndex"
+	commandUnique          string = "unique"
 )
 
 type command struct {
 	column             *columnDefinition
 	deferrable         *bool
 	initiallyImmediate *bool
+	shouldBeSkipped    bool
+	concurrently       bool // build the index without blocking writes
+	expressions        bool // the columns of the index are SQL expressions
+	nullsNotDistinct   bool // unique index treats NULL values as equal
 	algorithm          string
 	from               string
 	index              string
 	language           string
 	name               string
 	on                 string
+	path               string // JSON path of a JSON index
+	where              string // condition of a partial index
+	check              string // condition of a check constraint
 	onDelete           string
 	onUpdate           string
 	to                 string
 	columns            []string
 	references         []string
+	dropColumns        []string // columns of an index dropped by its columns instead of its name
+	values             []string // enum values added or dropped
 }
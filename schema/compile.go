@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// portableDialects are the dialects CompileDialects compiles for by default.
+var portableDialects = []string{"postgres", "mysql"}
+
+// Statement is a SQL statement compiled for a dialect.
+type Statement struct {
+	Dialect string // Dialect is the dialect the statement was compiled for, e.g. "postgres".
+	SQL     string // SQL is the compiled statement.
+}
+
+// String returns the statement prefixed with its dialect, e.g. "mysql: DROP TABLE users".
+func (s Statement) String() string {
+	return s.Dialect + ": " + s.SQL
+}
+
+// CompileDialects runs fn once for each of the given dialects in dry-run mode and returns the
+// statements compiled for all of them, each annotated with its dialect. A migration meant to run
+// on both PostgreSQL and MySQL is thereby validated against both grammars in one pass, e.g. in a
+// unit test, without a database. The errors of every dialect are returned together, each
+// prefixed with its dialect. Without dialects it compiles for "postgres" and "mysql".
+//
+// Example:
+//
+//	statements, err := schema.CompileDialects(ctx, func(c schema.Context) error {
+//	    return schema.Table(c, "users", func(table *schema.Blueprint) {
+//	        table.String("phone").Nullable()
+//	    })
+//	})
+func CompileDialects(ctx context.Context, fn func(c Context) error, dialects ...string) ([]Statement, error) {
+	if ctx == nil || fn == nil {
+		return nil, errors.New("invalid arguments: context or fn is nil")
+	}
+	if len(dialects) == 0 {
+		dialects = portableDialects
+	}
+
+	var statements []Statement
+	var errs []error
+	for _, dialectValue := range dialects {
+		c, err := NewDialectDryRunContext(ctx, dialectValue)
+		if err != nil {
+			return nil, err
+		}
+		if err = fn(c); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", dialectValue, err))
+			continue
+		}
+		for _, sql := range c.GetCapturedSQL() {
+			statements = append(statements, Statement{Dialect: dialectValue, SQL: sql})
+		}
+	}
+	return statements, errors.Join(errs...)
+}
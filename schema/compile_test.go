@@ -0,0 +1,33 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileDialects(t *testing.T) {
+	statements, err := CompileDialects(t.Context(), func(c Context) error {
+		return Table(c, "users", func(table *Blueprint) {
+			table.Boolean("active").Default(true)
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Statement{
+		{Dialect: "postgres", SQL: "ALTER TABLE users ADD COLUMN active BOOLEAN DEFAULT '1' NOT NULL"},
+		{Dialect: "mysql", SQL: "ALTER TABLE users ADD COLUMN active TINYINT(1) DEFAULT '1' NOT NULL"},
+	}, statements)
+	assert.Equal(t, "mysql: ALTER TABLE users ADD COLUMN active TINYINT(1) DEFAULT '1' NOT NULL",
+		statements[1].String())
+
+	statements, err = CompileDialects(t.Context(), func(c Context) error {
+		return Truncate(c, "users", TruncateOptions{Cascade: true})
+	})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	require.ErrorContains(t, err, "mysql: unsupported feature")
+	assert.Equal(t, []Statement{{Dialect: "postgres", SQL: "TRUNCATE TABLE users CASCADE"}}, statements)
+
+	_, err = CompileDialects(t.Context(), func(Context) error { return nil }, "sqlite")
+	require.EqualError(t, err, "unsupported dialect: sqlite")
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 // Context interface defines the contract for database operations
@@ -15,9 +16,16 @@ type Context interface {
 
 // RegularContext implements Context for normal database operations.
 type RegularContext struct {
-	ctx      context.Context
-	tx       *sql.Tx
-	filename string
+	ctx       context.Context
+	conn      executor
+	filename  string
+	execHook  func(query string, args []any)
+	tableHook func(table string)
+	timeout   time.Duration
+	wrapper   ExecWrapper
+
+	beforeTableHook func(table string) error
+	dropTableHook   func(table string)
 }
 
 type ContextOptions func(*RegularContext)
@@ -28,10 +36,80 @@ func WithFilename(filename string) ContextOptions {
 	}
 }
 
+// WithExecHook sets a function that is called with every statement executed successfully
+// through Exec, e.g. to record the SQL applied by a migration.
+func WithExecHook(hook func(query string, args []any)) ContextOptions {
+	return func(c *RegularContext) {
+		c.execHook = hook
+	}
+}
+
+// WithTableHook sets a function that is called with the name of every table created or altered
+// successfully through the schema builder, e.g. to refresh its statistics after the migration.
+func WithTableHook(hook func(table string)) ContextOptions {
+	return func(c *RegularContext) {
+		c.tableHook = hook
+	}
+}
+
+// WithDropTableHook sets a function that is called with the name of every table dropped, or
+// renamed away from, successfully through the schema builder. The new name of a renamed table is
+// reported to the hook of WithTableHook.
+func WithDropTableHook(hook func(table string)) ContextOptions {
+	return func(c *RegularContext) {
+		c.dropTableHook = hook
+	}
+}
+
+// WithBeforeTableHook sets a function that is called with the name of every table the schema
+// builder is about to alter, rename or drop, e.g. to capture its definition first. An error from
+// the hook aborts the change.
+func WithBeforeTableHook(hook func(table string) error) ContextOptions {
+	return func(c *RegularContext) {
+		c.beforeTableHook = hook
+	}
+}
+
+// WithStatementTimeout cancels every statement executed through Exec that runs longer than
+// the given duration.
+func WithStatementTimeout(timeout time.Duration) ContextOptions {
+	return func(c *RegularContext) {
+		c.timeout = timeout
+	}
+}
+
+// ExecWrapper runs a statement executed through Exec by calling exec, e.g. to retry a statement
+// that timed out waiting for a lock. The statement timeout applies to every call of exec.
+type ExecWrapper func(ctx context.Context, query string, exec func(ctx context.Context) error) error
+
+// WithExecWrapper sets a function that runs every statement executed through Exec.
+func WithExecWrapper(wrapper ExecWrapper) ContextOptions {
+	return func(c *RegularContext) {
+		c.wrapper = wrapper
+	}
+}
+
+// executor runs the statements of a RegularContext: a transaction or a single connection.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 func NewContext(ctx context.Context, tx *sql.Tx, opts ...ContextOptions) Context {
+	return newRegularContext(ctx, tx, opts)
+}
+
+// NewConnContext creates a context that runs the statements directly on a connection, outside
+// of a transaction, e.g. for CREATE INDEX CONCURRENTLY, which PostgreSQL rejects in transactions.
+func NewConnContext(ctx context.Context, conn *sql.Conn, opts ...ContextOptions) Context {
+	return newRegularContext(ctx, conn, opts)
+}
+
+func newRegularContext(ctx context.Context, conn executor, opts []ContextOptions) *RegularContext {
 	c := &RegularContext{
-		ctx: ctx,
-		tx:  tx,
+		ctx:  ctx,
+		conn: conn,
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -40,13 +118,80 @@ func NewContext(ctx context.Context, tx *sql.Tx, opts ...ContextOptions) Context
 }
 
 func (c *RegularContext) Exec(query string, args ...any) (sql.Result, error) {
-	return c.tx.ExecContext(c.ctx, query, args...)
+	var result sql.Result
+	exec := func(ctx context.Context) error {
+		if c.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+		}
+		var err error
+		result, err = c.conn.ExecContext(ctx, query, args...)
+		return err
+	}
+	var err error
+	if c.wrapper != nil {
+		err = c.wrapper(c.ctx, query, exec)
+	} else {
+		err = exec(c.ctx)
+	}
+	if err == nil && c.execHook != nil {
+		c.execHook(query, args)
+	}
+	return result, err
 }
 
 func (c *RegularContext) Query(query string, args ...any) (*sql.Rows, error) {
-	return c.tx.QueryContext(c.ctx, query, args...)
+	return c.conn.QueryContext(c.ctx, query, args...)
 }
 
 func (c *RegularContext) QueryRow(query string, args ...any) *sql.Row {
-	return c.tx.QueryRowContext(c.ctx, query, args...)
+	return c.conn.QueryRowContext(c.ctx, query, args...)
+}
+
+func (c *RegularContext) trackTable(name string) {
+	if c.tableHook != nil {
+		c.tableHook(name)
+	}
+}
+
+func (c *RegularContext) dropTable(name string) {
+	if c.dropTableHook != nil {
+		c.dropTableHook(name)
+	}
+}
+
+func (c *RegularContext) beforeTableChange(name string) error {
+	if c.beforeTableHook != nil {
+		return c.beforeTableHook(name)
+	}
+	return nil
+}
+
+// tableTracker is implemented by contexts that report the tables changed through the builder.
+type tableTracker interface {
+	trackTable(name string)
+	dropTable(name string)
+	beforeTableChange(name string) error
+}
+
+func trackTable(c Context, name string) {
+	if t, ok := c.(tableTracker); ok {
+		t.trackTable(name)
+	}
+}
+
+// dropTable reports a table the builder has dropped or renamed.
+func dropTable(c Context, name string) {
+	if t, ok := c.(tableTracker); ok {
+		t.dropTable(name)
+	}
+}
+
+// beforeTableChange reports a table the builder is about to alter, rename or drop.
+func beforeTableChange(c Context, name string) error {
+	if t, ok := c.(tableTracker); ok {
+		return t.beforeTableChange(name)
+	}
+	return nil
 }
@@ -0,0 +1,416 @@
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/dialect"
+)
+
+const (
+	defaultCopyChunkSize = 1000
+	// maxCopyPlaceholders is the highest number of placeholders of a statement in PostgreSQL and
+	// MySQL, which limits the rows inserted per statement of a wide table.
+	maxCopyPlaceholders = 65535
+)
+
+// CopyTableConfig configures CopyTable.
+type CopyTableConfig struct {
+	Source        *sql.DB // Database to copy the table from
+	SourceDialect string  // Dialect of the source database, e.g. "pgx" or "mysql"
+	Target        *sql.DB // Database to copy the table to
+	TargetDialect string  // Dialect of the target database
+	Table         string  // Name of the table in the source database
+	TargetTable   string  // Name of the table in the target database, defaults to Table
+	CreateTable   bool    // Create the table in the target database from the source structure
+	ChunkSize     int     // Rows inserted per statement and transaction, defaults to 1000; capped at 65535 values
+}
+
+// CopyTable copies a table between two databases, which may use different engines, e.g. to move
+// a table from PostgreSQL to MySQL during a platform transition. It returns the number of copied
+// rows.
+//
+// With CreateTable the table is first created in the target database: the columns, primary key
+// and indexes of the source table are translated through the schema builder of the target
+// dialect. Column defaults, foreign keys and check constraints are not translated; add them with
+// a regular migration afterwards. Columns of types without a schema builder equivalent cause an
+// error.
+//
+// The rows are read from a consistent snapshot of the source table and inserted in chunks, each
+// in its own transaction, so a failed copy can leave a partially filled table behind.
+//
+// Example:
+//
+//	copied, err := schema.CopyTable(ctx, schema.CopyTableConfig{
+//	    Source:        pg,
+//	    SourceDialect: "pgx",
+//	    Target:        mysql,
+//	    TargetDialect: "mysql",
+//	    Table:         "orders",
+//	    CreateTable:   true,
+//	})
+func CopyTable(ctx context.Context, cfg CopyTableConfig) (int64, error) {
+	if cfg.Source == nil || cfg.Target == nil || cfg.Table == "" {
+		return 0, errors.New("invalid arguments: source, target or table is nil/empty")
+	}
+	source, err := NewBuilder(cfg.SourceDialect)
+	if err != nil {
+		return 0, err
+	}
+	target, err := NewBuilder(cfg.TargetDialect)
+	if err != nil {
+		return 0, err
+	}
+	sourceGrammar := newGrammar(dialect.FromString(cfg.SourceDialect))
+	targetGrammar := newGrammar(dialect.FromString(cfg.TargetDialect))
+	if cfg.TargetTable == "" {
+		cfg.TargetTable = cfg.Table
+	}
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = defaultCopyChunkSize
+	}
+
+	sourceTx, err := cfg.Source.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = sourceTx.Rollback()
+	}()
+	sourceCtx := NewContext(ctx, sourceTx)
+
+	columns, err := source.GetColumns(sourceCtx, cfg.Table)
+	if err != nil {
+		return 0, err
+	}
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("table %s does not exist", cfg.Table)
+	}
+	indexes, err := source.GetIndexes(sourceCtx, cfg.Table)
+	if err != nil {
+		return 0, err
+	}
+
+	if cfg.CreateTable {
+		if err = createCopiedTable(ctx, cfg, target, columns, indexes); err != nil {
+			return 0, err
+		}
+	}
+
+	copied, err := copyRows(ctx, cfg, sourceCtx, sourceGrammar, targetGrammar, columns, primaryKeyColumns(indexes))
+	if err != nil {
+		return copied, err
+	}
+
+	// Explicitly inserted values do not advance the sequence of an auto-incrementing column.
+	for _, col := range columns {
+		if !isAutoIncrement(col) {
+			continue
+		}
+		if query := targetGrammar.CompileResetSequence(cfg.TargetTable, col.Name); query != "" {
+			if _, err = cfg.Target.ExecContext(ctx, query); err != nil {
+				return copied, err
+			}
+		}
+	}
+	return copied, nil
+}
+
+// createCopiedTable creates the target table from the columns and indexes of the source table.
+func createCopiedTable(ctx context.Context, cfg CopyTableConfig, target Builder, columns []*Column, indexes []*Index) error {
+	primaryKey := primaryKeyColumns(indexes)
+	var autoIncrementKey string
+	for _, col := range columns {
+		if len(primaryKey) == 1 && col.Name == primaryKey[0] && isAutoIncrement(col) {
+			autoIncrementKey = col.Name
+		}
+	}
+
+	// The columns are translated before the table is created, so a column that cannot be
+	// translated leaves no partial table behind.
+	translated := &Blueprint{name: cfg.TargetTable}
+	for _, col := range columns {
+		def, err := translateColumn(translated, col)
+		if err != nil {
+			return err
+		}
+		def.Nullable(col.Nullable)
+		if col.Comment.Valid && col.Comment.String != "" {
+			def.Comment(col.Comment.String)
+		}
+		if col.Name == autoIncrementKey {
+			def.AutoIncrement().Primary()
+		}
+	}
+
+	tx, err := cfg.Target.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = target.Create(NewContext(ctx, tx), cfg.TargetTable, func(table *Blueprint) {
+		for _, col := range translated.columns {
+			table.addColumnDefinition(col)
+		}
+		if len(primaryKey) > 0 && autoIncrementKey == "" {
+			table.Primary(primaryKey[0], primaryKey[1:]...)
+		}
+		for _, idx := range indexes {
+			if idx.Primary || len(idx.Columns) == 0 || slices.Contains(idx.Columns, "") {
+				continue
+			}
+			if idx.Unique {
+				table.Unique(idx.Columns[0], idx.Columns[1:]...)
+			} else {
+				table.Index(idx.Columns[0], idx.Columns[1:]...)
+			}
+		}
+	})
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// copyRows streams the rows of the source table and inserts them into the target table in chunks.
+func copyRows(
+	ctx context.Context,
+	cfg CopyTableConfig,
+	sourceCtx Context,
+	sourceGrammar grammar,
+	targetGrammar grammar,
+	columns []*Column,
+	orderBy []string,
+) (int64, error) {
+	names := make([]string, len(columns))
+	binary := make([]bool, len(columns))
+	boolean := make([]bool, len(columns))
+	for i, col := range columns {
+		names[i] = targetGrammar.QuoteIdentifier(col.Name)
+		binary[i] = isBinaryType(col.TypeName)
+		boolean[i] = isTinyIntBoolean(col)
+	}
+	rows, err := sourceCtx.Query(copySelectQuery(sourceGrammar, cfg.Table, columns, orderBy))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	chunkSize := copyChunkSize(cfg.ChunkSize, len(columns))
+	targetTable := targetGrammar.QuoteIdentifier(cfg.TargetTable)
+
+	var copied int64
+	var args []any
+	flush := func() error {
+		if len(args) == 0 {
+			return nil
+		}
+		count := len(args) / len(columns)
+		insert := targetGrammar.CompileInsert(targetTable, names, count)
+		tx, err := cfg.Target.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, insert, args...); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to copy rows %d-%d of table %s: %w", copied+1, copied+int64(count), cfg.Table, err)
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		copied += int64(count)
+		args = args[:0]
+		return nil
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(pointers...); err != nil {
+			return copied, err
+		}
+		for i, value := range values {
+			args = append(args, copyValue(value, binary[i], boolean[i]))
+		}
+		if len(args) >= chunkSize*len(columns) {
+			if err = flush(); err != nil {
+				return copied, err
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return copied, err
+	}
+	return copied, flush()
+}
+
+// copySelectQuery returns the query reading the columns of the source table, ordered by the
+// primary key columns.
+func copySelectQuery(g grammar, table string, columns []*Column, orderBy []string) string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = g.QuoteIdentifier(col.Name)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(names, ", "), g.QuoteIdentifier(table))
+	if len(orderBy) > 0 {
+		keys := make([]string, len(orderBy))
+		for i, key := range orderBy {
+			keys[i] = g.QuoteIdentifier(key)
+		}
+		query += " ORDER BY " + strings.Join(keys, ", ")
+	}
+	return query
+}
+
+// copyChunkSize returns the number of rows inserted per statement, capped so that the statement
+// does not exceed the placeholder limit.
+func copyChunkSize(chunkSize int, columns int) int {
+	return max(1, min(chunkSize, maxCopyPlaceholders/columns))
+}
+
+// copyValue converts a scanned value so the target driver inserts it into the translated column.
+func copyValue(value any, binary bool, boolean bool) any {
+	// Drivers return text as bytes, which the target driver would send as binary data.
+	if b, ok := value.([]byte); ok && !binary {
+		value = string(b)
+	}
+	if !boolean {
+		return value
+	}
+	switch v := value.(type) {
+	case int64:
+		return v != 0
+	case string:
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
+var typeParamsPattern = regexp.MustCompile(`\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)`)
+
+// translateColumn adds the column of the schema builder that matches the introspected column.
+func translateColumn(table *Blueprint, col *Column) (ColumnDefinition, error) {
+	typeFull := strings.ToLower(col.TypeFull)
+	var params []int
+	if match := typeParamsPattern.FindStringSubmatch(typeFull); match != nil {
+		for _, param := range match[1:] {
+			if n, err := strconv.Atoi(param); err == nil {
+				params = append(params, n)
+			}
+		}
+	}
+	unsigned := strings.Contains(typeFull, "unsigned")
+
+	var def ColumnDefinition
+	switch strings.ToLower(col.TypeName) {
+	case "tinyint":
+		if isTinyIntBoolean(col) {
+			return table.Boolean(col.Name), nil
+		}
+		def = table.TinyInteger(col.Name)
+	case "smallint", "int2":
+		def = table.SmallInteger(col.Name)
+	case "mediumint":
+		def = table.MediumInteger(col.Name)
+	case "int", "integer", "int4":
+		def = table.Integer(col.Name)
+	case "bigint", "int8":
+		def = table.BigInteger(col.Name)
+	case "bool", "boolean":
+		return table.Boolean(col.Name), nil
+	case "decimal", "numeric":
+		def = table.Decimal(col.Name, params...)
+	case "float", "float4", "real":
+		return table.Float(col.Name), nil
+	case "double", "float8", "double precision":
+		return table.Double(col.Name), nil
+	case "char", "bpchar", "character":
+		return table.Char(col.Name, params...), nil
+	case "varchar", "character varying":
+		return table.String(col.Name, params...), nil
+	case "tinytext":
+		return table.TinyText(col.Name), nil
+	case "text":
+		return table.Text(col.Name), nil
+	case "mediumtext":
+		return table.MediumText(col.Name), nil
+	case "longtext":
+		return table.LongText(col.Name), nil
+	case "date":
+		return table.Date(col.Name), nil
+	case "datetime", "timestamp":
+		return table.DateTime(col.Name, params...), nil
+	case "timestamptz":
+		return table.DateTimeTz(col.Name, params...), nil
+	case "time":
+		return table.Time(col.Name, params...), nil
+	case "timetz":
+		return table.TimeTz(col.Name, params...), nil
+	case "year":
+		return table.Year(col.Name), nil
+	case "json":
+		return table.JSON(col.Name), nil
+	case "jsonb":
+		return table.JSONB(col.Name), nil
+	case "uuid":
+		return table.UUID(col.Name), nil
+	case "interval":
+		return table.Interval(col.Name), nil
+	case "inet":
+		return table.Inet(col.Name), nil
+	case "cidr":
+		return table.Cidr(col.Name), nil
+	case "macaddr":
+		return table.MacAddr(col.Name), nil
+	case "bytea", "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob":
+		return table.Binary(col.Name), nil
+	case "enum":
+		return table.Enum(col.Name, parseEnumValues(col.TypeFull)), nil
+	default:
+		return nil, fmt.Errorf("cannot translate column %s of type %s", col.Name, col.TypeFull)
+	}
+	if unsigned {
+		def.Unsigned()
+	}
+	return def, nil
+}
+
+// isTinyIntBoolean reports whether the column is a MySQL boolean, which is stored as TINYINT(1).
+func isTinyIntBoolean(col *Column) bool {
+	return strings.EqualFold(col.TypeFull, "tinyint(1)")
+}
+
+func isBinaryType(typeName string) bool {
+	switch strings.ToLower(typeName) {
+	case "bytea", "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob":
+		return true
+	}
+	return false
+}
+
+// isAutoIncrement reports whether the values of the column are generated by the database.
+func isAutoIncrement(col *Column) bool {
+	if col.Extra.Valid && strings.Contains(strings.ToLower(col.Extra.String), "auto_increment") {
+		return true
+	}
+	return col.DefaultVal.Valid && strings.HasPrefix(col.DefaultVal.String, "nextval(")
+}
+
+func primaryKeyColumns(indexes []*Index) []string {
+	for _, idx := range indexes {
+		if idx.Primary {
+			return idx.Columns
+		}
+	}
+	return nil
+}
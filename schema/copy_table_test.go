@@ -0,0 +1,151 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateColumn(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  *Column
+		grammar grammar
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "mysql unsigned integer to postgres",
+			column:  &Column{Name: "votes", TypeName: "int", TypeFull: "int unsigned"},
+			grammar: newPostgresGrammar(),
+			want:    "votes INTEGER NOT NULL",
+		},
+		{
+			name:    "mysql boolean to postgres",
+			column:  &Column{Name: "active", TypeName: "tinyint", TypeFull: "tinyint(1)"},
+			grammar: newPostgresGrammar(),
+			want:    "active BOOLEAN NOT NULL",
+		},
+		{
+			name:    "mysql enum to postgres",
+			column:  &Column{Name: "status", TypeName: "enum", TypeFull: "enum('draft','published')"},
+			grammar: newPostgresGrammar(),
+			want:    "status VARCHAR(255) CHECK (status IN ('draft', 'published')) NOT NULL",
+		},
+		{
+			name:    "postgres numeric to mysql",
+			column:  &Column{Name: "price", TypeName: "numeric", TypeFull: "numeric(10,3)"},
+			grammar: newMysqlGrammar(),
+			want:    "price DECIMAL(10, 3) NOT NULL",
+		},
+		{
+			name:    "postgres varchar to mysql",
+			column:  &Column{Name: "email", TypeName: "varchar", TypeFull: "character varying(120)"},
+			grammar: newMysqlGrammar(),
+			want:    "email VARCHAR(120) NOT NULL",
+		},
+		{
+			name:    "postgres timestamptz to mysql",
+			column:  &Column{Name: "created_at", TypeName: "timestamptz", TypeFull: "timestamp(3) with time zone"},
+			grammar: newMysqlGrammar(),
+			want:    "created_at DATETIME(3) NOT NULL",
+		},
+		{
+			name:    "postgres inet to mysql",
+			column:  &Column{Name: "ip_address", TypeName: "inet", TypeFull: "inet"},
+			grammar: newMysqlGrammar(),
+			want:    "ip_address VARCHAR(45) NOT NULL",
+		},
+		{
+			name:    "unknown type",
+			column:  &Column{Name: "tags", TypeName: "_text", TypeFull: "text[]"},
+			grammar: newMysqlGrammar(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "items", grammar: tt.grammar}
+			_, err := translateColumn(bp, tt.column)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var columns []string
+			switch g := tt.grammar.(type) {
+			case *mysqlGrammar:
+				columns, err = g.getColumns(bp)
+			case *postgresGrammar:
+				columns, err = g.getColumns(bp)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, columns)
+		})
+	}
+}
+
+func TestCopyValue(t *testing.T) {
+	assert.Equal(t, "hello", copyValue([]byte("hello"), false, false))
+	assert.Equal(t, []byte{0x01, 0x02}, copyValue([]byte{0x01, 0x02}, true, false))
+	assert.Equal(t, true, copyValue(int64(1), false, true))
+	assert.Equal(t, false, copyValue([]byte("0"), false, true))
+	assert.Nil(t, copyValue(nil, false, true))
+}
+
+func TestIsAutoIncrement(t *testing.T) {
+	assert.True(t, isAutoIncrement(&Column{Extra: sql.NullString{String: "auto_increment", Valid: true}}))
+	assert.True(t, isAutoIncrement(&Column{DefaultVal: sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true}}))
+	assert.False(t, isAutoIncrement(&Column{DefaultVal: sql.NullString{String: "0", Valid: true}}))
+}
+
+func TestCopyTable_InvalidArguments(t *testing.T) {
+	_, err := CopyTable(context.Background(), CopyTableConfig{Table: "users"})
+	require.Error(t, err)
+}
+
+// unreachableDB is a database/sql connector that fails to connect.
+type unreachableDB struct{}
+
+func (d unreachableDB) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("unexpected connection")
+}
+func (d unreachableDB) Driver() driver.Driver { return d }
+func (d unreachableDB) Open(string) (driver.Conn, error) {
+	return nil, errors.New("unexpected connection")
+}
+
+func TestCreateCopiedTable_UntranslatableColumn(t *testing.T) {
+	target := sql.OpenDB(unreachableDB{})
+	t.Cleanup(func() { target.Close() })
+	builder, err := NewBuilder("mysql")
+	require.NoError(t, err)
+
+	columns := []*Column{
+		{Name: "id", TypeName: "int8", TypeFull: "bigint"},
+		{Name: "tags", TypeName: "_text", TypeFull: "text[]"},
+	}
+	err = createCopiedTable(t.Context(), CopyTableConfig{Target: target, TargetTable: "items"}, builder, columns, nil)
+	require.EqualError(t, err, "cannot translate column tags of type text[]", "expected no table to be created")
+}
+
+func TestCopySelectQuery(t *testing.T) {
+	columns := []*Column{{Name: "id"}, {Name: "order"}, {Name: "group"}}
+	assert.Equal(t, `SELECT "id", "order", "group" FROM "shop"."items" ORDER BY "id", "order"`,
+		copySelectQuery(newPostgresGrammar(), "shop.items", columns, []string{"id", "order"}))
+	assert.Equal(t, "SELECT `id`, `order`, `group` FROM `items`",
+		copySelectQuery(newMysqlGrammar(), "items", columns, nil))
+}
+
+func TestCopyChunkSize(t *testing.T) {
+	assert.Equal(t, 1000, copyChunkSize(1000, 10))
+	assert.Equal(t, 65535/100, copyChunkSize(1000, 100), "a wide table stays below the placeholder limit")
+	assert.Equal(t, 1, copyChunkSize(1000, 70000))
+}
@@ -0,0 +1,479 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/akfaiz/migris/schema"
+)
+
+// ChangeKind is what a Change does with its table.
+type ChangeKind string
+
+const (
+	// CreateTable creates a table of the target snapshot missing in the source snapshot.
+	CreateTable ChangeKind = "create"
+	// AlterTable alters a table of both snapshots, e.g. to add a column or an index.
+	AlterTable ChangeKind = "alter"
+	// DropTable drops a table of the source snapshot missing in the target snapshot.
+	DropTable ChangeKind = "drop"
+)
+
+// Change converges a single table of the source snapshot to the target snapshot.
+type Change struct {
+	Kind  ChangeKind // Kind is what the change does with the table.
+	Table string     // Table is the name of the table.
+	// Operations describe the change for a review of the diff, e.g. "add column phone".
+	Operations []string
+	// Blueprint applies the change to the table; it is nil for DropTable and for an AlterTable
+	// change made of Statements only.
+	Blueprint func(table *schema.Blueprint)
+	// Statements are run after the blueprint for what the schema builder cannot declare, e.g.
+	// the composite foreign keys of the table.
+	Statements []string
+}
+
+// Apply runs the change with the schema builder of c.
+func (ch Change) Apply(c schema.Context) error {
+	var err error
+	switch ch.Kind {
+	case CreateTable:
+		err = schema.Create(c, ch.Table, ch.Blueprint)
+	case AlterTable:
+		if ch.Blueprint != nil {
+			err = schema.Table(c, ch.Table, ch.Blueprint)
+		}
+	case DropTable:
+		err = schema.Drop(c, ch.Table)
+	default:
+		err = fmt.Errorf("unknown change kind %s of table %s", ch.Kind, ch.Table)
+	}
+	if err != nil {
+		return err
+	}
+	for _, statement := range ch.Statements {
+		if _, err = c.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diff is the list of changes that converge the source snapshot of Compare to the target
+// snapshot, in the order they have to be applied.
+type Diff struct {
+	Changes []Change
+}
+
+// Empty reports whether the snapshots are equal, so there is nothing to change.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Apply runs the changes in order with the schema builder of c, e.g. in the up function of a
+// migration. It stops at the first failing change.
+func (d *Diff) Apply(c schema.Context) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	for _, change := range d.Changes {
+		if err := change.Apply(c); err != nil {
+			return fmt.Errorf("failed to %s table %s: %w", change.Kind, change.Table, err)
+		}
+	}
+	return nil
+}
+
+// SQL returns the statements of the changes compiled for the given dialect, e.g. to write them to
+// a SQL migration. No database is needed.
+func (d *Diff) SQL(ctx context.Context, dialect string) ([]string, error) {
+	c, err := schema.NewDialectDryRunContext(ctx, dialect)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.Apply(c); err != nil {
+		return nil, err
+	}
+	return c.GetCapturedSQL(), nil
+}
+
+// Compare computes the changes that converge the from snapshot to the to snapshot. Tables are
+// created first, then altered, then the foreign keys of the created tables are added, so they may
+// reference each other, and finally the tables missing in the to snapshot are dropped, after
+// their foreign keys.
+//
+// Tables, columns, indexes and foreign keys are matched by name; a renamed one is dropped and
+// added again. Columns are compared by type, nullability, default and comment, with the aliases
+// of the type names and the casts of the defaults of the databases removed, so a snapshot taken
+// from a database compares equal to the snapshot of the declared blueprints it was migrated with.
+// The implicit indexes MySQL creates for foreign keys are ignored. Composite foreign keys, which
+// the schema builder cannot declare, are added with ALTER TABLE statements.
+//
+// Example:
+//
+//	changes, err := diff.Compare(current, declared)
+//	if err != nil {
+//	    return err
+//	}
+//	statements, err := changes.SQL(ctx, "pgx")
+func Compare(from, to *Snapshot) (*Diff, error) {
+	if from == nil || to == nil {
+		return nil, errors.New("invalid arguments: snapshot is nil")
+	}
+
+	var creates, alters, foreignKeys, drops []Change
+	for _, target := range to.Tables {
+		current := from.Table(target.Name)
+		if current == nil {
+			creates = append(creates, createTable(target))
+			if change, ok := addForeignKeys(target); ok {
+				foreignKeys = append(foreignKeys, change)
+			}
+			continue
+		}
+		if change, ok := alterTable(current, target); ok {
+			alters = append(alters, change)
+		}
+	}
+	var dropForeignKeys []Change
+	for i := len(from.Tables) - 1; i >= 0; i-- {
+		current := from.Tables[i]
+		if to.Table(current.Name) != nil {
+			continue
+		}
+		if change, ok := dropTableForeignKeys(current); ok {
+			dropForeignKeys = append(dropForeignKeys, change)
+		}
+		drops = append(drops, Change{
+			Kind:       DropTable,
+			Table:      current.Name,
+			Operations: []string{"drop table " + current.Name},
+		})
+	}
+	return &Diff{Changes: slices.Concat(creates, alters, foreignKeys, dropForeignKeys, drops)}, nil
+}
+
+// createTable returns the change creating the table with its columns and indexes. Its foreign
+// keys are added by addForeignKeys once every table exists.
+func createTable(table *Table) Change {
+	change := Change{Kind: CreateTable, Table: table.Name, Operations: []string{"create table " + table.Name}}
+	ignored := foreignKeyNames(table)
+	var steps []func(bp *schema.Blueprint)
+	if table.Comment.Valid {
+		comment := table.Comment.String
+		steps = append(steps, func(bp *schema.Blueprint) { bp.Comment(comment) })
+	}
+	// MySQL requires an auto-increment column to be the key of the table it is created in, so
+	// such a primary key is declared on the column, with the name of the naming strategy.
+	primary := primaryIndex(table)
+	var primaryColumn string
+	if primary != nil && len(primary.Columns) == 1 {
+		if column := findColumn(table.Columns, primary.Columns[0]); column != nil && isAutoIncrement(column) {
+			primaryColumn = column.Name
+		}
+	}
+	for _, column := range table.Columns {
+		steps = append(steps, func(bp *schema.Blueprint) {
+			if definition := defineColumn(bp, column); column.Name == primaryColumn {
+				definition.Primary()
+			}
+		})
+	}
+	for _, index := range table.Indexes {
+		if !ignored[index.Name] && (index != primary || primaryColumn == "") {
+			steps = append(steps, func(bp *schema.Blueprint) { addIndex(bp, index) })
+		}
+	}
+	change.Blueprint = blueprint(steps)
+	return change
+}
+
+// addForeignKeys returns the change adding the foreign keys of a created table.
+func addForeignKeys(table *Table) (Change, bool) {
+	if len(table.ForeignKeys) == 0 {
+		return Change{}, false
+	}
+	change := Change{Kind: AlterTable, Table: table.Name}
+	var steps []func(bp *schema.Blueprint)
+	for _, foreignKey := range table.ForeignKeys {
+		change.Operations = append(change.Operations, "add foreign key "+foreignKey.Name)
+		if isComposite(foreignKey) {
+			change.Statements = append(change.Statements, addForeignKeyStatement(table.Name, foreignKey))
+			continue
+		}
+		steps = append(steps, func(bp *schema.Blueprint) { addForeignKey(bp, foreignKey) })
+	}
+	if len(steps) > 0 {
+		change.Blueprint = blueprint(steps)
+	}
+	return change, true
+}
+
+// dropTableForeignKeys returns the change dropping the foreign keys of a dropped table, so the
+// dropped tables it references can be dropped before it.
+func dropTableForeignKeys(table *Table) (Change, bool) {
+	if len(table.ForeignKeys) == 0 {
+		return Change{}, false
+	}
+	change := Change{Kind: AlterTable, Table: table.Name}
+	var steps []func(bp *schema.Blueprint)
+	for _, foreignKey := range table.ForeignKeys {
+		change.Operations = append(change.Operations, "drop foreign key "+foreignKey.Name)
+		steps = append(steps, func(bp *schema.Blueprint) { bp.DropForeign(foreignKey.Name) })
+	}
+	change.Blueprint = blueprint(steps)
+	return change, true
+}
+
+// alterTable returns the change converging the current table to the target table, and false if
+// they are equal.
+func alterTable(current, target *Table) (Change, bool) {
+	change := Change{Kind: AlterTable, Table: target.Name}
+	var steps []func(bp *schema.Blueprint)
+	step := func(operation string, fn func(bp *schema.Blueprint)) {
+		change.Operations = append(change.Operations, operation)
+		steps = append(steps, fn)
+	}
+
+	// Foreign keys and indexes are dropped before the columns they are on.
+	for _, foreignKey := range current.ForeignKeys {
+		other := findForeignKey(target.ForeignKeys, foreignKey.Name)
+		if other == nil || !foreignKeysEqual(foreignKey, other) {
+			step("drop foreign key "+foreignKey.Name, func(bp *schema.Blueprint) { bp.DropForeign(foreignKey.Name) })
+		}
+	}
+	ignored := foreignKeyNames(current, target)
+	currentPrimary, targetPrimary := primaryIndex(current), primaryIndex(target)
+	if currentPrimary != nil && (targetPrimary == nil || !indexesEqual(currentPrimary, targetPrimary)) {
+		step("drop primary key "+currentPrimary.Name, func(bp *schema.Blueprint) { dropIndex(bp, currentPrimary) })
+	}
+	for _, index := range current.Indexes {
+		if index.Primary || ignored[index.Name] {
+			continue
+		}
+		if other := findIndex(target.Indexes, index.Name); other == nil || !indexesEqual(index, other) {
+			step("drop index "+index.Name, func(bp *schema.Blueprint) { dropIndex(bp, index) })
+		}
+	}
+
+	for _, column := range current.Columns {
+		if findColumn(target.Columns, column.Name) == nil {
+			step("drop column "+column.Name, func(bp *schema.Blueprint) { bp.DropColumn(column.Name) })
+		}
+	}
+	for _, column := range target.Columns {
+		other := findColumn(current.Columns, column.Name)
+		switch {
+		case other == nil:
+			step("add column "+column.Name, func(bp *schema.Blueprint) { defineColumn(bp, column) })
+		case !columnsEqual(other, column):
+			step("change column "+column.Name, func(bp *schema.Blueprint) {
+				definition := defineColumn(bp, column)
+				if other.Comment.Valid && !column.Comment.Valid {
+					definition.Comment("")
+				}
+				definition.Change()
+			})
+		}
+	}
+	if current.Comment != target.Comment {
+		step("change comment", func(bp *schema.Blueprint) { bp.Comment(target.Comment.String) })
+	}
+
+	if targetPrimary != nil && (currentPrimary == nil || !indexesEqual(currentPrimary, targetPrimary)) {
+		step("add primary key "+targetPrimary.Name, func(bp *schema.Blueprint) { addIndex(bp, targetPrimary) })
+	}
+	for _, index := range target.Indexes {
+		if index.Primary || ignored[index.Name] {
+			continue
+		}
+		if other := findIndex(current.Indexes, index.Name); other == nil || !indexesEqual(index, other) {
+			step("add index "+index.Name, func(bp *schema.Blueprint) { addIndex(bp, index) })
+		}
+	}
+	for _, foreignKey := range target.ForeignKeys {
+		other := findForeignKey(current.ForeignKeys, foreignKey.Name)
+		switch {
+		case other != nil && foreignKeysEqual(foreignKey, other):
+		case isComposite(foreignKey):
+			change.Operations = append(change.Operations, "add foreign key "+foreignKey.Name)
+			change.Statements = append(change.Statements, addForeignKeyStatement(target.Name, foreignKey))
+		default:
+			step("add foreign key "+foreignKey.Name, func(bp *schema.Blueprint) { addForeignKey(bp, foreignKey) })
+		}
+	}
+
+	if len(change.Operations) == 0 {
+		return Change{}, false
+	}
+	if len(steps) > 0 {
+		change.Blueprint = blueprint(steps)
+	}
+	return change, true
+}
+
+// blueprint returns a blueprint function running the steps in order.
+func blueprint(steps []func(bp *schema.Blueprint)) func(table *schema.Blueprint) {
+	return func(table *schema.Blueprint) {
+		for _, step := range steps {
+			step(table)
+		}
+	}
+}
+
+// defineColumn adds the column to the blueprint. Auto-increment columns are added as increments
+// of their integer type, so they compile to the serial or auto-increment column of the dialect.
+func defineColumn(bp *schema.Blueprint, column *schema.Column) schema.ColumnDefinition {
+	var definition schema.ColumnDefinition
+	if isAutoIncrement(column) {
+		switch baseType(column) {
+		case "bigint":
+			definition = bp.BigIncrements(column.Name)
+		case "int":
+			definition = bp.Increments(column.Name)
+		case "mediumint":
+			definition = bp.MediumIncrements(column.Name)
+		case "smallint":
+			definition = bp.SmallIncrements(column.Name)
+		case "tinyint":
+			definition = bp.TinyIncrements(column.Name)
+		default:
+			definition = bp.Column(column.Name, column.TypeFull).AutoIncrement()
+		}
+	} else {
+		definition = bp.Column(column.Name, column.TypeFull)
+	}
+	definition.Nullable(column.Nullable)
+	if value := normalizeDefault(column); value != "" {
+		definition.Default(defaultValue(column.DefaultVal.String))
+	}
+	if column.Comment.Valid {
+		definition.Comment(column.Comment.String)
+	}
+	return definition
+}
+
+// addIndex adds the index to the blueprint under its name.
+func addIndex(bp *schema.Blueprint, index *schema.Index) {
+	column, others := index.Columns[0], index.Columns[1:]
+	switch {
+	case index.Primary:
+		bp.Primary(column, others...).Name(index.Name)
+	case isFullText(index):
+		bp.FullText(column, others...).Name(index.Name)
+	case index.Unique:
+		bp.Unique(column, others...).Name(index.Name)
+	default:
+		bp.Index(column, others...).Name(index.Name)
+	}
+}
+
+// dropIndex drops the index from the blueprint by its name.
+func dropIndex(bp *schema.Blueprint, index *schema.Index) {
+	switch {
+	case index.Primary:
+		bp.DropPrimary(index.Name)
+	case isFullText(index):
+		bp.DropFulltext(index.Name)
+	case index.Unique:
+		bp.DropUnique(index.Name)
+	default:
+		bp.DropIndex(index.Name)
+	}
+}
+
+// addForeignKey adds the single-column foreign key to the blueprint under its name.
+func addForeignKey(bp *schema.Blueprint, foreignKey *schema.ForeignKey) {
+	definition := bp.Foreign(foreignKey.Columns[0]).References(foreignKey.ForeignColumns[0]).
+		On(foreignTable(foreignKey)).Name(foreignKey.Name)
+	if action := normalizeAction(foreignKey.OnDelete); action != noAction {
+		definition.OnDelete(action)
+	}
+	if action := normalizeAction(foreignKey.OnUpdate); action != noAction {
+		definition.OnUpdate(action)
+	}
+}
+
+// foreignTable returns the name of the table the foreign key references, qualified with its schema
+// unless it is the default schema of PostgreSQL.
+func foreignTable(foreignKey *schema.ForeignKey) string {
+	if foreignKey.ForeignSchema != "" && foreignKey.ForeignSchema != defaultPostgresSchema {
+		return foreignKey.ForeignSchema + "." + foreignKey.ForeignTable
+	}
+	return foreignKey.ForeignTable
+}
+
+// isComposite reports whether the foreign key has several columns, which the schema builder
+// cannot declare.
+func isComposite(foreignKey *schema.ForeignKey) bool {
+	return len(foreignKey.Columns) != 1 || len(foreignKey.ForeignColumns) != 1
+}
+
+// addForeignKeyStatement returns the ALTER TABLE statement adding the composite foreign key to
+// the table under its name.
+func addForeignKeyStatement(table string, foreignKey *schema.ForeignKey) string {
+	statement := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		table, foreignKey.Name, strings.Join(foreignKey.Columns, ", "), foreignTable(foreignKey),
+		strings.Join(foreignKey.ForeignColumns, ", "))
+	if action := normalizeAction(foreignKey.OnDelete); action != noAction {
+		statement += " ON DELETE " + action
+	}
+	if action := normalizeAction(foreignKey.OnUpdate); action != noAction {
+		statement += " ON UPDATE " + action
+	}
+	return statement
+}
+
+// foreignKeyNames returns the names of the foreign keys of the tables, which are also the names
+// of the indexes MySQL creates for them.
+func foreignKeyNames(tables ...*Table) map[string]bool {
+	names := make(map[string]bool)
+	for _, table := range tables {
+		if table == nil {
+			continue
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			names[foreignKey.Name] = true
+		}
+	}
+	return names
+}
+
+func primaryIndex(table *Table) *schema.Index {
+	for _, index := range table.Indexes {
+		if index.Primary {
+			return index
+		}
+	}
+	return nil
+}
+
+func findColumn(columns []*schema.Column, name string) *schema.Column {
+	for _, column := range columns {
+		if column.Name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+func findIndex(indexes []*schema.Index, name string) *schema.Index {
+	for _, index := range indexes {
+		if index.Name == name {
+			return index
+		}
+	}
+	return nil
+}
+
+func findForeignKey(foreignKeys []*schema.ForeignKey, name string) *schema.ForeignKey {
+	for _, foreignKey := range foreignKeys {
+		if foreignKey.Name == name {
+			return foreignKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package diff //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func declareV1(c schema.Context) error {
+	if err := schema.Create(c, "users", func(table *schema.Blueprint) {
+		table.ID()
+		table.String("name")
+		table.String("email").Unique()
+	}); err != nil {
+		return err
+	}
+	return schema.Create(c, "legacy_sessions", func(table *schema.Blueprint) {
+		table.String("id").Primary()
+	})
+}
+
+func declareV2(c schema.Context) error {
+	if err := schema.Create(c, "users", func(table *schema.Blueprint) {
+		table.ID()
+		table.Text("name").Nullable()
+		table.String("email")
+		table.String("phone").Default("unknown").Comment("Mobile number")
+		table.Index("email")
+	}); err != nil {
+		return err
+	}
+	return schema.Create(c, "posts", func(table *schema.Blueprint) {
+		table.ID()
+		table.ForeignID("user_id").Constrained().CascadeOnDelete()
+		table.String("title")
+	})
+}
+
+func TestCompare(t *testing.T) {
+	from, err := Declare("pgx", declareV1)
+	require.NoError(t, err)
+	to, err := Declare("pgx", declareV2)
+	require.NoError(t, err)
+
+	changes, err := Compare(from, to)
+	require.NoError(t, err)
+	var operations [][]string
+	for _, change := range changes.Changes {
+		kind := string(change.Kind) + " " + change.Table
+		operations = append(operations, append([]string{kind}, change.Operations...))
+	}
+	assert.Equal(t, [][]string{
+		{"create posts", "create table posts"},
+		{
+			"alter users", "drop index uk_users_email", "change column name", "add column phone",
+			"add index idx_users_email",
+		},
+		{"alter posts", "add foreign key fk_posts_users"},
+		{"drop legacy_sessions", "drop table legacy_sessions"},
+	}, operations)
+
+	statements, err := changes.SQL(t.Context(), "pgx")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE posts (id BIGSERIAL NOT NULL, user_id BIGINT NOT NULL, title VARCHAR(255) NOT NULL, " +
+			"CONSTRAINT pk_posts PRIMARY KEY (id))",
+		"ALTER TABLE users ALTER COLUMN name TYPE TEXT, ALTER COLUMN name DROP NOT NULL",
+		"ALTER TABLE users ADD COLUMN phone VARCHAR(255) DEFAULT 'unknown' NOT NULL",
+		"ALTER TABLE users DROP CONSTRAINT uk_users_email",
+		"CREATE INDEX idx_users_email ON users (email)",
+		"COMMENT ON COLUMN users.phone IS 'Mobile number'",
+		"ALTER TABLE posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE",
+		"DROP TABLE legacy_sessions",
+	}, statements)
+}
+
+func TestCompare_Converges(t *testing.T) {
+	for _, dialect := range []string{"pgx", "mysql"} {
+		t.Run(dialect, func(t *testing.T) {
+			fake, err := schema.NewFake(dialect)
+			require.NoError(t, err)
+			require.NoError(t, declareV1(fake))
+			from, err := Take(fake)
+			require.NoError(t, err)
+			to, err := Declare(dialect, declareV2)
+			require.NoError(t, err)
+
+			changes, err := Compare(from, to)
+			require.NoError(t, err)
+			require.NoError(t, changes.Apply(fake))
+			converged, err := Take(fake)
+			require.NoError(t, err)
+			changes, err = Compare(converged, to)
+			require.NoError(t, err)
+			assert.True(t, changes.Empty(), "applying the diff converges the schema")
+
+			changes, err = Compare(to, to)
+			require.NoError(t, err)
+			assert.True(t, changes.Empty())
+		})
+	}
+}
+
+func TestCompare_CompositeForeignKey(t *testing.T) {
+	declare := func(c schema.Context) error {
+		if err := schema.Create(c, "orders", func(table *schema.Blueprint) {
+			table.BigInteger("id")
+			table.BigInteger("tenant_id")
+			table.Primary("id", "tenant_id")
+		}); err != nil {
+			return err
+		}
+		return schema.Create(c, "order_lines", func(table *schema.Blueprint) {
+			table.BigInteger("order_id")
+			table.BigInteger("tenant_id")
+		})
+	}
+	withForeignKey := func() *Snapshot {
+		snapshot, err := Declare("pgx", declare)
+		require.NoError(t, err)
+		snapshot.Table("order_lines").ForeignKeys = []*schema.ForeignKey{{
+			Name: "fk_order_lines_orders", Columns: []string{"order_id", "tenant_id"}, ForeignTable: "orders",
+			ForeignColumns: []string{"id", "tenant_id"}, OnDelete: "cascade",
+		}}
+		return snapshot
+	}
+	addForeignKey := "ALTER TABLE order_lines ADD CONSTRAINT fk_order_lines_orders " +
+		"FOREIGN KEY (order_id, tenant_id) REFERENCES orders (id, tenant_id) ON DELETE CASCADE"
+
+	t.Run("created table", func(t *testing.T) {
+		changes, err := Compare(&Snapshot{}, withForeignKey())
+		require.NoError(t, err)
+		statements, err := changes.SQL(t.Context(), "pgx")
+		require.NoError(t, err)
+		assert.Equal(t, addForeignKey, statements[len(statements)-1], "expected the foreign key after the tables")
+	})
+
+	t.Run("altered table", func(t *testing.T) {
+		without, err := Declare("pgx", declare)
+		require.NoError(t, err)
+
+		changes, err := Compare(without, withForeignKey())
+		require.NoError(t, err)
+		require.Len(t, changes.Changes, 1)
+		assert.Equal(t, []string{"add foreign key fk_order_lines_orders"}, changes.Changes[0].Operations)
+		statements, err := changes.SQL(t.Context(), "pgx")
+		require.NoError(t, err)
+		assert.Equal(t, []string{addForeignKey}, statements)
+
+		changes, err = Compare(withForeignKey(), without)
+		require.NoError(t, err)
+		statements, err = changes.SQL(t.Context(), "pgx")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ALTER TABLE order_lines DROP CONSTRAINT fk_order_lines_orders"}, statements)
+	})
+}
+
+func TestColumnsEqual(t *testing.T) {
+	column := func(typeFull, defaultValue, extra string) *schema.Column {
+		return &schema.Column{
+			TypeFull:   typeFull,
+			DefaultVal: sql.NullString{String: defaultValue, Valid: defaultValue != ""},
+			Extra:      sql.NullString{String: extra, Valid: extra != ""},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		database *schema.Column
+		declared *schema.Column
+		want     bool
+	}{
+		{
+			"varchar", column("character varying(255)", "'x'::character varying", ""),
+			column("VARCHAR(255)", "x", ""), true,
+		},
+		{"timestamp", column("timestamp(0) without time zone", "CURRENT_TIMESTAMP", ""),
+			column("TIMESTAMP(0)", "CURRENT_TIMESTAMP", ""), true},
+		{
+			"serial", column("bigint", "nextval('users_id_seq'::regclass)", ""),
+			column("BIGSERIAL", "", "auto_increment"), true,
+		},
+		{
+			"mysql auto-increment", column("bigint unsigned", "", "auto_increment"),
+			column("BIGINT", "", "auto_increment"), true,
+		},
+		{"mysql display width", column("int(11)", "0", ""), column("INT", "0", ""), true},
+		{"mysql boolean", column("tinyint(1)", "1", ""), column("TINYINT(1)", "true", ""), true},
+		{"length", column("varchar(100)", "", ""), column("VARCHAR(255)", "", ""), false},
+		{"default", column("integer", "1", ""), column("INTEGER", "2", ""), false},
+		{"sign", column("int unsigned", "", ""), column("INT", "", ""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, columnsEqual(tt.database, tt.declared))
+		})
+	}
+}
+
+func TestDefaultValue(t *testing.T) {
+	assert.Equal(t, schema.Expression("0"), defaultValue("0"))
+	assert.Equal(t, schema.Expression("CURRENT_TIMESTAMP"), defaultValue("CURRENT_TIMESTAMP"))
+	assert.Equal(t, schema.Expression("'x'::character varying"), defaultValue("'x'::character varying"))
+	assert.Equal(t, schema.Expression("gen_random_uuid()"), defaultValue("gen_random_uuid()"))
+	assert.Equal(t, "active", defaultValue("active"))
+}
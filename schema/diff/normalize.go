@@ -0,0 +1,195 @@
+package diff
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/akfaiz/migris/schema"
+)
+
+const (
+	// defaultPostgresSchema is the schema PostgreSQL reports for tables referenced without one.
+	defaultPostgresSchema = "public"
+	// noAction is the action of a foreign key without ON DELETE or ON UPDATE.
+	noAction = "NO ACTION"
+)
+
+// typeAliases map the type names of PostgreSQL, MySQL and the fake builder to one name, e.g. the
+// "character varying" of PostgreSQL and the "VARCHAR" of the grammar. Serial types are mapped to
+// their integer type, as auto-increment is compared separately.
+var typeAliases = map[string]string{
+	"bigserial":                   "bigint",
+	"bool":                        "boolean",
+	"character":                   "char",
+	"character varying":           "varchar",
+	"decimal":                     "numeric",
+	"double precision":            "double",
+	"float4":                      "real",
+	"float8":                      "double",
+	"int2":                        "smallint",
+	"int4":                        "int",
+	"int8":                        "bigint",
+	"integer":                     "int",
+	"serial":                      "int",
+	"serial2":                     "smallint",
+	"serial4":                     "int",
+	"serial8":                     "bigint",
+	"smallserial":                 "smallint",
+	"time with time zone":         "timetz",
+	"time without time zone":      "time",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+}
+
+// integerTypes are the types whose MySQL display width, e.g. the 11 of INT(11), is ignored.
+var integerTypes = []string{"bigint", "int", "mediumint", "smallint"}
+
+// typeArguments matches the length, precision or scale of a type, e.g. (255) or (8,2).
+var typeArguments = regexp.MustCompile(`\s*\([^)]*\)`)
+
+// trailingCast matches a PostgreSQL cast at the end of a default, e.g. ::character varying.
+var trailingCast = regexp.MustCompile(`::[a-z_ ]+(\([^)]*\))?(\[\])?$`)
+
+// normalizeType returns the type of the column in a form that compares equal across the
+// databases and the fake builder, e.g. varchar(255) for VARCHAR(255) and character varying(255).
+func normalizeType(column *schema.Column) string {
+	typeName := strings.Join(strings.Fields(strings.ToLower(column.TypeFull)), " ")
+	array := strings.HasSuffix(typeName, "[]")
+	typeName = strings.TrimSuffix(typeName, "[]")
+	unsigned := strings.HasSuffix(typeName, " unsigned")
+	typeName = strings.TrimSuffix(typeName, " unsigned")
+
+	arguments := strings.ReplaceAll(strings.Join(typeArguments.FindAllString(typeName, -1), ""), " ", "")
+	typeName = strings.TrimSpace(typeArguments.ReplaceAllString(typeName, ""))
+	if alias, ok := typeAliases[typeName]; ok {
+		typeName = alias
+	}
+	if slices.Contains(integerTypes, typeName) || typeName == "tinyint" && arguments != "(1)" {
+		arguments = ""
+	}
+
+	normalized := typeName + arguments
+	if unsigned {
+		normalized += " unsigned"
+	}
+	if array {
+		normalized += "[]"
+	}
+	return normalized
+}
+
+// baseType returns the normalized type of the column without its arguments and sign, e.g. int
+// for INT(10) UNSIGNED.
+func baseType(column *schema.Column) string {
+	typeName := strings.TrimSuffix(normalizeType(column), " unsigned")
+	typeName, _, _ = strings.Cut(typeName, "(")
+	return typeName
+}
+
+// isAutoIncrement reports whether the column is an auto-increment column of MySQL or the fake
+// builder, or a serial column of PostgreSQL.
+func isAutoIncrement(column *schema.Column) bool {
+	return strings.Contains(strings.ToLower(column.Extra.String), "auto_increment") ||
+		strings.HasPrefix(column.DefaultVal.String, "nextval(")
+}
+
+// normalizeDefault returns the default of the column in a form that compares equal across the
+// databases and the fake builder: without casts and quotes, and with booleans as 1 and 0. It
+// returns an empty string for columns without a default and for auto-increment columns.
+func normalizeDefault(column *schema.Column) string {
+	if !column.DefaultVal.Valid || isAutoIncrement(column) {
+		return ""
+	}
+	value := strings.TrimSpace(column.DefaultVal.String)
+	for {
+		trimmed := strings.TrimSpace(trailingCast.ReplaceAllString(value, ""))
+		if len(trimmed) >= 2 && trimmed[0] == '(' && trimmed[len(trimmed)-1] == ')' {
+			trimmed = trimmed[1 : len(trimmed)-1]
+		}
+		if trimmed == value {
+			break
+		}
+		value = trimmed
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+
+	switch strings.ToLower(value) {
+	case "null":
+		return ""
+	case "true":
+		return "1"
+	case "false":
+		return "0"
+	case "now()", "current_timestamp()", "current_timestamp(0)", "localtimestamp":
+		return "current_timestamp"
+	}
+	return strings.ToLower(value)
+}
+
+// defaultKeywords are the defaults that are SQL expressions rather than string values.
+var defaultKeywords = []string{
+	"CURRENT_DATE", "CURRENT_TIME", "CURRENT_TIMESTAMP", "FALSE", "LOCALTIME", "LOCALTIMESTAMP", "NULL", "TRUE",
+}
+
+// defaultValue returns the value the default of a column is set with: an expression for numbers,
+// keywords, function calls and quoted literals, which the databases report with their quotes,
+// and a string value otherwise, which MySQL and the fake builder report without quotes.
+func defaultValue(value string) any {
+	trimmed := strings.TrimSpace(value)
+	if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		return schema.Expression(trimmed)
+	}
+	if strings.HasPrefix(trimmed, "'") || strings.Contains(trimmed, "(") ||
+		slices.Contains(defaultKeywords, strings.ToUpper(trimmed)) {
+		return schema.Expression(trimmed)
+	}
+	return trimmed
+}
+
+// columnsEqual reports whether the columns have the same type, nullability, default and comment.
+func columnsEqual(a, b *schema.Column) bool {
+	if isAutoIncrement(a) != isAutoIncrement(b) {
+		return false
+	}
+	// The fake builder does not report the sign of auto-increment columns.
+	if isAutoIncrement(a) && baseType(a) != baseType(b) || !isAutoIncrement(a) && normalizeType(a) != normalizeType(b) {
+		return false
+	}
+	return a.Nullable == b.Nullable && normalizeDefault(a) == normalizeDefault(b) && a.Comment == b.Comment
+}
+
+// isFullText reports whether the index is a full-text index.
+func isFullText(index *schema.Index) bool {
+	return strings.EqualFold(index.Type, "fulltext")
+}
+
+// indexesEqual reports whether the indexes are of the same kind on the same columns.
+func indexesEqual(a, b *schema.Index) bool {
+	return slices.Equal(a.Columns, b.Columns) && a.Unique == b.Unique && a.Primary == b.Primary &&
+		isFullText(a) == isFullText(b)
+}
+
+// foreignKeysEqual reports whether the foreign keys reference the same columns with the same
+// actions. The schema of the referenced table is only compared if both report one, as MySQL
+// reports the database and the fake builder of MySQL none.
+func foreignKeysEqual(a, b *schema.ForeignKey) bool {
+	if a.ForeignSchema != "" && b.ForeignSchema != "" && a.ForeignSchema != b.ForeignSchema {
+		return false
+	}
+	return slices.Equal(a.Columns, b.Columns) && a.ForeignTable == b.ForeignTable &&
+		slices.Equal(a.ForeignColumns, b.ForeignColumns) &&
+		normalizeAction(a.OnDelete) == normalizeAction(b.OnDelete) &&
+		normalizeAction(a.OnUpdate) == normalizeAction(b.OnUpdate)
+}
+
+// normalizeAction returns the foreign key action in upper case, NO ACTION if it is not set.
+func normalizeAction(action string) string {
+	if action == "" {
+		return noAction
+	}
+	return strings.ToUpper(action)
+}
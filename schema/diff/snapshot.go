@@ -0,0 +1,103 @@
+// Package diff compares two snapshots of a schema and computes the schema builder changes that
+// converge the first into the second. A snapshot is read through the introspection of a builder,
+// either from a database or from a schema.Fake the declared blueprints were applied to, which
+// makes it the foundation for generating migrations from model definitions.
+package diff
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/akfaiz/migris/schema"
+)
+
+// Table is the structure of a table in a Snapshot.
+type Table struct {
+	Name        string               // Name is the name of the table.
+	Comment     sql.NullString       // Comment is the comment of the table, if any.
+	Columns     []*schema.Column     // Columns are the columns of the table, in their order in the table.
+	Indexes     []*schema.Index      // Indexes are the indexes of the table, including its primary key.
+	ForeignKeys []*schema.ForeignKey // ForeignKeys are the foreign keys of the table.
+}
+
+// Snapshot is the structure of the tables of a schema, sorted by name.
+type Snapshot struct {
+	Tables []*Table
+}
+
+// Table returns the table with the given name, or nil if the snapshot has no such table.
+func (s *Snapshot) Table(name string) *Table {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// Take reads the snapshot of the current schema through the introspection of the builder of c.
+// Tables in exclude, e.g. the version table, are skipped.
+//
+// Example:
+//
+//	current, err := diff.Take(schema.NewContext(ctx, db), "schema_migrations")
+func Take(c schema.Context, exclude ...string) (*Snapshot, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	tables, err := schema.GetTables(c)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	for _, info := range tables {
+		if slices.Contains(exclude, info.Name) {
+			continue
+		}
+		table := &Table{Name: info.Name, Comment: info.Comment}
+		if table.Columns, err = schema.GetColumns(c, info.Name); err != nil {
+			return nil, err
+		}
+		if table.Indexes, err = schema.GetIndexes(c, info.Name); err != nil {
+			return nil, err
+		}
+		if table.ForeignKeys, err = schema.GetForeignKeys(c, info.Name); err != nil {
+			return nil, err
+		}
+		snapshot.Tables = append(snapshot.Tables, table)
+	}
+	slices.SortFunc(snapshot.Tables, func(a, b *Table) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return snapshot, nil
+}
+
+// Declare returns the snapshot of the tables declared by fn, which creates them with the schema
+// builder, e.g. from model definitions. fn runs against a schema.Fake of the given dialect, so no
+// database is needed.
+//
+// Example:
+//
+//	declared, err := diff.Declare("pgx", func(c schema.Context) error {
+//	    return schema.Create(c, "users", func(table *schema.Blueprint) {
+//	        table.ID()
+//	        table.String("email").Unique()
+//	    })
+//	})
+func Declare(dialect string, fn func(c schema.Context) error) (*Snapshot, error) {
+	if fn == nil {
+		return nil, errors.New("invalid arguments: fn is nil")
+	}
+
+	fake, err := schema.NewFake(dialect)
+	if err != nil {
+		return nil, err
+	}
+	if err = fn(fake); err != nil {
+		return nil, err
+	}
+	return Take(fake)
+}
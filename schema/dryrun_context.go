@@ -3,7 +3,10 @@ package schema
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
+
+	"github.com/akfaiz/migris/internal/dialect"
 )
 
 // DryRunContext implements Context for dry-run mode (captures SQL without executing).
@@ -11,6 +14,7 @@ type DryRunContext struct {
 	ctx            context.Context
 	capturedSQL    []string
 	pendingQueries []QueryWithArgs
+	dialect        dialect.Dialect // overrides the dialect set with SetDialect, if known
 }
 
 // QueryWithArgs stores a query and its arguments.
@@ -74,6 +78,21 @@ func NewDryRunContext(ctx context.Context) *DryRunContext {
 	}
 }
 
+// NewDialectDryRunContext creates a DryRunContext that compiles the statements for the specified
+// dialect instead of the one set with SetDialect, e.g. to check that a migration compiles for
+// every database it is meant to run on.
+//
+// Supported dialects are "postgres", "pgx", "mysql", and "mariadb".
+func NewDialectDryRunContext(ctx context.Context, dialectValue string) (*DryRunContext, error) {
+	dialectVal := dialect.FromString(dialectValue)
+	if dialectVal == dialect.Unknown {
+		return nil, errors.New("unsupported dialect: " + dialectValue)
+	}
+	drc := NewDryRunContext(ctx)
+	drc.dialect = dialectVal
+	return drc, nil
+}
+
 func (drc *DryRunContext) Exec(query string, args ...any) (sql.Result, error) {
 	// Clean up the query for display
 	cleanQuery := strings.TrimSpace(query)
@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	foreignKeyLinePattern = regexp.MustCompile(`^\s*CONSTRAINT\s+\S+\s+FOREIGN KEY\s`)
+	autoIncrementPattern  = regexp.MustCompile(` AUTO_INCREMENT=\d+`)
+	sequencePattern       = regexp.MustCompile(`nextval\('([^']+)'::regclass\)`)
+)
+
+// schemaDDL returns the statements that recreate the given tables. The foreign keys are moved to
+// ALTER TABLE statements after all tables, so the tables can be created in any order, and the
+// partitions of a table are created after it.
+func schemaDDL(c Context, tables []string, getTableDDL func(Context, string) (string, error)) ([]string, error) {
+	var pending []tableDDL
+	var foreignKeys []string
+	dumped := make(map[string]bool)
+	for _, table := range tables {
+		ddl, err := getTableDDL(c, table)
+		if err != nil {
+			return nil, err
+		}
+		tableStatements, tableForeignKeys := splitTableDDL(ddl)
+		name, parent := tableDDLNames(tableStatements[0])
+		pending = append(pending, tableDDL{name: name, parent: parent, statements: tableStatements})
+		dumped[name] = true
+		foreignKeys = append(foreignKeys, tableForeignKeys...)
+	}
+
+	var statements []string
+	created := make(map[string]bool)
+	for len(pending) > 0 {
+		var waiting []tableDDL
+		for _, table := range pending {
+			if table.parent != "" && dumped[table.parent] && !created[table.parent] {
+				waiting = append(waiting, table)
+				continue
+			}
+			statements = append(statements, table.statements...)
+			created[table.name] = true
+		}
+		if len(waiting) == len(pending) {
+			return nil, fmt.Errorf("cannot order the partitions of %s", waiting[0].parent)
+		}
+		pending = waiting
+	}
+	return append(statements, foreignKeys...), nil
+}
+
+// tableDDL holds the statements that recreate a table, and the table it is a partition of.
+type tableDDL struct {
+	name       string
+	parent     string
+	statements []string
+}
+
+// tableDDLNames returns the name of the table created by a CREATE TABLE statement, and the name
+// of its parent table if the statement creates a partition.
+func tableDDLNames(statement string) (string, string) {
+	header, _, _ := strings.Cut(strings.TrimPrefix(statement, "CREATE TABLE "), "\n")
+	header = strings.TrimSuffix(header, " (")
+	name, parent, found := strings.Cut(header, " PARTITION OF ")
+	if !found {
+		return header, ""
+	}
+	parent, _, _ = strings.Cut(parent, " ")
+	return name, parent
+}
+
+// splitTableDDL splits the output of GetTableDDL into single statements without the trailing
+// semicolon, and returns the foreign key constraints of the table as separate statements.
+func splitTableDDL(ddl string) ([]string, []string) {
+	lines := strings.Split(strings.TrimSpace(ddl), "\n")
+	header := lines[0]
+	if !strings.HasSuffix(header, " (") {
+		// A table without a column list, e.g. a partition inheriting its columns.
+		return append([]string{strings.TrimSuffix(header, ";")}, trailingStatements(lines[1:])...), nil
+	}
+	table, _ := tableDDLNames(header)
+
+	end := len(lines)
+	for i := 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], ")") {
+			end = i
+			break
+		}
+	}
+	if end == len(lines) {
+		return []string{strings.TrimSuffix(strings.TrimSpace(ddl), ";")}, nil
+	}
+
+	var definitions, foreignKeys []string
+	for _, line := range lines[1:end] {
+		definition := strings.TrimSuffix(line, ",")
+		if foreignKeyLinePattern.MatchString(definition) {
+			foreignKeys = append(foreignKeys, fmt.Sprintf("ALTER TABLE %s ADD %s", table, strings.TrimSpace(definition)))
+			continue
+		}
+		definitions = append(definitions, definition)
+	}
+	closing := strings.TrimSuffix(autoIncrementPattern.ReplaceAllString(lines[end], ""), ";")
+	statements := []string{header + "\n" + strings.Join(definitions, ",\n") + "\n" + closing}
+	if len(definitions) == 0 {
+		// A partition whose only own constraints are foreign keys has no column list left.
+		statements[0] = strings.TrimSuffix(header, " (") + strings.TrimPrefix(closing, ")")
+	}
+
+	return append(statements, trailingStatements(lines[end+1:])...), foreignKeys
+}
+
+// trailingStatements splits the standalone indexes and comments following a table into single
+// statements, one per line unless a string literal spans multiple lines.
+func trailingStatements(lines []string) []string {
+	var statements, current []string
+	for _, line := range lines {
+		current = append(current, line)
+		statement := strings.Join(current, "\n")
+		if strings.HasSuffix(statement, ";") && strings.Count(statement, "'")%2 == 0 {
+			statements = append(statements, strings.TrimSuffix(statement, ";"))
+			current = nil
+		}
+	}
+	return statements
+}
+
+// sequenceStatements returns the statements that create the sequences used by the column
+// defaults of the given statements, e.g. those of serial columns.
+func sequenceStatements(statements []string) []string {
+	var sequences []string
+	seen := make(map[string]bool)
+	for _, statement := range statements {
+		for _, match := range sequencePattern.FindAllStringSubmatch(statement, -1) {
+			if seen[match[1]] {
+				continue
+			}
+			seen[match[1]] = true
+			sequences = append(sequences, "CREATE SEQUENCE IF NOT EXISTS "+match[1])
+		}
+	}
+	return sequences
+}
@@ -0,0 +1,130 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTableDDL(t *testing.T) {
+	tests := []struct {
+		name            string
+		ddl             string
+		wantStatements  []string
+		wantForeignKeys []string
+	}{
+		{
+			name: "mysql",
+			ddl: "CREATE TABLE `posts` (\n" +
+				"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `user_id` bigint unsigned NOT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `fk_posts_users` (`user_id`),\n" +
+				"  CONSTRAINT `fk_posts_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE\n" +
+				") ENGINE=InnoDB AUTO_INCREMENT=42 DEFAULT CHARSET=utf8mb4",
+			wantStatements: []string{
+				"CREATE TABLE `posts` (\n" +
+					"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+					"  `user_id` bigint unsigned NOT NULL,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `fk_posts_users` (`user_id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+			},
+			wantForeignKeys: []string{
+				"ALTER TABLE `posts` ADD CONSTRAINT `fk_posts_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE",
+			},
+		},
+		{
+			name: "postgres",
+			ddl: "CREATE TABLE public.posts (\n" +
+				"    id bigint NOT NULL DEFAULT nextval('posts_id_seq'::regclass),\n" +
+				"    user_id bigint NOT NULL,\n" +
+				"    CONSTRAINT pk_posts PRIMARY KEY (id),\n" +
+				"    CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)\n" +
+				");\n" +
+				"CREATE INDEX idx_posts_user_id ON public.posts USING btree (user_id);\n" +
+				"COMMENT ON COLUMN public.posts.user_id IS 'Author;\nof the post';",
+			wantStatements: []string{
+				"CREATE TABLE public.posts (\n" +
+					"    id bigint NOT NULL DEFAULT nextval('posts_id_seq'::regclass),\n" +
+					"    user_id bigint NOT NULL,\n" +
+					"    CONSTRAINT pk_posts PRIMARY KEY (id)\n" +
+					")",
+				"CREATE INDEX idx_posts_user_id ON public.posts USING btree (user_id)",
+				"COMMENT ON COLUMN public.posts.user_id IS 'Author;\nof the post'",
+			},
+			wantForeignKeys: []string{
+				"ALTER TABLE public.posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)",
+			},
+		},
+		{
+			name: "postgres partition",
+			ddl: "CREATE TABLE public.events_2025 PARTITION OF public.events FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');\n" +
+				"CREATE INDEX idx_events_2025_name ON public.events_2025 USING btree (name);",
+			wantStatements: []string{
+				"CREATE TABLE public.events_2025 PARTITION OF public.events FOR VALUES FROM ('2025-01-01') TO ('2026-01-01')",
+				"CREATE INDEX idx_events_2025_name ON public.events_2025 USING btree (name)",
+			},
+		},
+		{
+			name: "postgres partition with constraints",
+			ddl: "CREATE TABLE public.events_eu PARTITION OF public.events (\n" +
+				"    CONSTRAINT fk_events_eu_users FOREIGN KEY (user_id) REFERENCES users(id)\n" +
+				") FOR VALUES IN ('eu');",
+			wantStatements: []string{
+				"CREATE TABLE public.events_eu PARTITION OF public.events FOR VALUES IN ('eu')",
+			},
+			wantForeignKeys: []string{
+				"ALTER TABLE public.events_eu ADD CONSTRAINT fk_events_eu_users FOREIGN KEY (user_id) REFERENCES users(id)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, foreignKeys := splitTableDDL(tt.ddl)
+			assert.Equal(t, tt.wantStatements, statements)
+			assert.Equal(t, tt.wantForeignKeys, foreignKeys)
+		})
+	}
+}
+
+func TestSchemaDDL(t *testing.T) {
+	ddls := map[string]string{
+		"public.events_2025": "CREATE TABLE public.events_2025 PARTITION OF public.events " +
+			"FOR VALUES FROM ('2025-01-01') TO ('2026-01-01') PARTITION BY LIST (region);",
+		"public.events_2025_eu": "CREATE TABLE public.events_2025_eu PARTITION OF public.events_2025 FOR VALUES IN ('eu');",
+		"public.events": "CREATE TABLE public.events (\n" +
+			"    created_at date NOT NULL,\n" +
+			"    region text NOT NULL\n" +
+			") PARTITION BY RANGE (created_at);",
+		"public.archive": "CREATE TABLE public.archive PARTITION OF old.events DEFAULT;",
+	}
+	getTableDDL := func(_ Context, table string) (string, error) {
+		return ddls[table], nil
+	}
+
+	tables := []string{"public.archive", "public.events_2025_eu", "public.events_2025", "public.events"}
+	statements, err := schemaDDL(nil, tables, getTableDDL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE public.archive PARTITION OF old.events DEFAULT",
+		"CREATE TABLE public.events (\n    created_at date NOT NULL,\n    region text NOT NULL\n) PARTITION BY RANGE (created_at)",
+		"CREATE TABLE public.events_2025 PARTITION OF public.events " +
+			"FOR VALUES FROM ('2025-01-01') TO ('2026-01-01') PARTITION BY LIST (region)",
+		"CREATE TABLE public.events_2025_eu PARTITION OF public.events_2025 FOR VALUES IN ('eu')",
+	}, statements, "expected the partitions after their parent tables")
+}
+
+func TestSequenceStatements(t *testing.T) {
+	statements := []string{
+		"CREATE TABLE public.users (\n    id bigint NOT NULL DEFAULT nextval('users_id_seq'::regclass)\n)",
+		"CREATE TABLE public.teams (\n    id bigint NOT NULL DEFAULT nextval('teams_id_seq'::regclass),\n" +
+			"    number bigint NOT NULL DEFAULT nextval('users_id_seq'::regclass)\n)",
+	}
+	assert.Equal(t, []string{
+		"CREATE SEQUENCE IF NOT EXISTS users_id_seq",
+		"CREATE SEQUENCE IF NOT EXISTS teams_id_seq",
+	}, sequenceStatements(statements))
+}
@@ -0,0 +1,206 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	enumsMu sync.RWMutex
+	enums   = make(map[string][]string)
+)
+
+// RegisterEnum registers the allowed values of a named enum, typically sourced from constants or
+// configuration of the application. Columns created with Blueprint.EnumFrom resolve their allowed
+// values from the registry when the migration runs, so the values are not copied into every
+// migration. Registering a name again replaces its values. The registered values are not part of
+// the checksums of the migrations, so registering a new value does not change applied migrations.
+//
+// Example:
+//
+//	schema.RegisterEnum("order_status", orders.StatusPending, orders.StatusPaid, orders.StatusShipped)
+func RegisterEnum(name string, values ...string) {
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[name] = slices.Clone(values)
+}
+
+// EnumValues returns the allowed values of the enum registered under the given name.
+func EnumValues(name string) ([]string, bool) {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	values, ok := enums[name]
+	return slices.Clone(values), ok
+}
+
+// resolveEnums sets the allowed values of the enum columns that reference a registered enum and
+// names the types of native enum columns.
+func (b *Blueprint) resolveEnums() error {
+	for _, col := range b.columns {
+		if col.nativeEnum && col.enumType == "" {
+			col.enumType = b.enumTypeName(col)
+		}
+		if col.enumName == "" {
+			continue
+		}
+		values, ok := EnumValues(col.enumName)
+		if !ok {
+			return fmt.Errorf("enum %q of column %s is not registered", col.enumName, col.name)
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("enum %q of column %s has no values", col.enumName, col.name)
+		}
+		col.allowed = values
+	}
+	return nil
+}
+
+// enumTypeName returns the name of the native enum type of the column: the name of its registered
+// enum, or else <table>_<column>, in the schema of the table.
+func (b *Blueprint) enumTypeName(col *columnDefinition) string {
+	schema, table := splitQualifiedName(b.name)
+	name := col.enumName
+	if name == "" {
+		name = table + "_" + col.name
+	}
+	if schema != "" {
+		return schema + "." + name
+	}
+	return name
+}
+
+// sharesEnumType reports whether the native enum type of the column can be shared with other
+// columns, i.e. it is named after a registered enum or given explicitly instead of being named
+// after the column.
+func (b *Blueprint) sharesEnumType(col *columnDefinition) bool {
+	return col.enumType != b.enumTypeName(&columnDefinition{name: col.name})
+}
+
+// AddEnumValue adds values to the allowed values of an existing enum column. The current values
+// are read from the database when the migration runs, so they do not have to be repeated. On
+// PostgreSQL the values of a native enum column are added to its type.
+//
+// Example:
+//
+//	table.AddEnumValue("status", "archived")
+func (b *Blueprint) AddEnumValue(column string, value string, otherValues ...string) {
+	b.addCommand(commandAddEnumValue, &command{
+		column: &columnDefinition{name: column, columnType: columnTypeEnum},
+		values: append([]string{value}, otherValues...),
+	})
+}
+
+// DropEnumValue removes values from the allowed values of an existing enum column.
+// The rows that use a removed value must be updated first, otherwise the statement fails.
+// PostgreSQL cannot remove values from a native enum type, so dropping a value of a native enum
+// column fails.
+//
+// Example:
+//
+//	table.DropEnumValue("status", "archived")
+func (b *Blueprint) DropEnumValue(column string, value string, otherValues ...string) {
+	b.addCommand(commandDropEnumValue, &command{
+		column: &columnDefinition{name: column, columnType: columnTypeEnum},
+		values: append([]string{value}, otherValues...),
+	})
+}
+
+// RenameEnumValue renames an allowed value of an existing enum column and updates the rows that
+// use it. On PostgreSQL the value of a native enum column is renamed in its type.
+//
+// Example:
+//
+//	table.RenameEnumValue("status", "archived", "closed")
+func (b *Blueprint) RenameEnumValue(column string, from string, to string) {
+	b.addCommand(commandRenameEnumValue, &command{
+		column: &columnDefinition{name: column, columnType: columnTypeEnum},
+		from:   from,
+		to:     to,
+	})
+}
+
+// enumColumnLister reads the current definition of an enum column; it is implemented by the
+// dialect builders.
+type enumColumnLister interface {
+	getEnumColumn(c Context, tableName string, column string) (*columnDefinition, error)
+}
+
+// enumValuePattern matches the quoted values of an enum definition, e.g. enum('a','b') on MySQL
+// or CHECK ((status)::text = ANY (ARRAY['a'::character varying, ...])) on PostgreSQL.
+var enumValuePattern = regexp.MustCompile(`'((?:[^']|'')*)'`)
+
+// parseEnumValues returns the quoted values of an enum definition.
+func parseEnumValues(definition string) []string {
+	matches := enumValuePattern.FindAllStringSubmatch(definition, -1)
+	values := make([]string, 0, len(matches))
+	for _, match := range matches {
+		values = append(values, strings.ReplaceAll(match[1], "''", "'"))
+	}
+	return values
+}
+
+// resolveEnumColumns reads the current definition of the enum columns whose values are added,
+// dropped or renamed. It cannot be done in dry runs, as the current values are unknown.
+func (b *baseBuilder) resolveEnumColumns(c Context, bp *Blueprint) error {
+	for _, cmd := range bp.commands {
+		if !cmd.isEnumValueCommand() {
+			continue
+		}
+		if _, ok := c.(*DryRunContext); ok || b.enumLister == nil {
+			return fmt.Errorf("cannot resolve the values of enum column %s in dry-run mode", cmd.column.name)
+		}
+		column, err := b.enumLister.getEnumColumn(c, bp.name, cmd.column.name)
+		if err != nil {
+			return fmt.Errorf("cannot resolve the values of enum column %s: %w", cmd.column.name, err)
+		}
+		cmd.column = column
+	}
+	return nil
+}
+
+func (c *command) isEnumValueCommand() bool {
+	return c.name == commandAddEnumValue || c.name == commandDropEnumValue || c.name == commandRenameEnumValue
+}
+
+// enumValuesAfter returns the allowed values of the enum column once the command is applied.
+func enumValuesAfter(cmd *command) ([]string, error) {
+	current := cmd.column.allowed
+	switch cmd.name {
+	case commandAddEnumValue:
+		for _, value := range cmd.values {
+			if slices.Contains(current, value) {
+				return nil, fmt.Errorf("enum column %s already allows value %q", cmd.column.name, value)
+			}
+		}
+		return append(slices.Clone(current), cmd.values...), nil
+	case commandDropEnumValue:
+		for _, value := range cmd.values {
+			if !slices.Contains(current, value) {
+				return nil, fmt.Errorf("enum column %s does not allow value %q", cmd.column.name, value)
+			}
+		}
+		return slices.DeleteFunc(slices.Clone(current), func(value string) bool {
+			return slices.Contains(cmd.values, value)
+		}), nil
+	case commandRenameEnumValue:
+		if cmd.from == "" || cmd.to == "" {
+			return nil, errors.New("old and new enum values cannot be empty")
+		}
+		i := slices.Index(current, cmd.from)
+		if i < 0 {
+			return nil, fmt.Errorf("enum column %s does not allow value %q", cmd.column.name, cmd.from)
+		}
+		if slices.Contains(current, cmd.to) {
+			return nil, fmt.Errorf("enum column %s already allows value %q", cmd.column.name, cmd.to)
+		}
+		values := slices.Clone(current)
+		values[i] = cmd.to
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unknown enum command: %s", cmd.name)
+	}
+}
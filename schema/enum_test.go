@@ -0,0 +1,401 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumFrom(t *testing.T) {
+	RegisterEnum("order_status", "pending", "paid")
+	t.Cleanup(func() {
+		enumsMu.Lock()
+		delete(enums, "order_status")
+		enumsMu.Unlock()
+	})
+
+	tests := []struct {
+		name      string
+		grammar   grammar
+		blueprint func(table *Blueprint)
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:    "mysql add column",
+			grammar: newMysqlGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.EnumFrom("status", "order_status")
+			},
+			want: []string{"ALTER TABLE orders ADD COLUMN status ENUM('pending', 'paid') NOT NULL"},
+		},
+		{
+			name:    "mysql change column",
+			grammar: newMysqlGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.EnumFrom("status", "order_status").Change()
+			},
+			want: []string{"ALTER TABLE orders MODIFY COLUMN status ENUM('pending', 'paid') NOT NULL"},
+		},
+		{
+			name:    "postgres add column",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.EnumFrom("status", "order_status")
+			},
+			want: []string{"ALTER TABLE orders ADD COLUMN status VARCHAR(255) CHECK (status IN ('pending', 'paid')) NOT NULL"},
+		},
+		{
+			name:    "postgres change column replaces the check constraint",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.EnumFrom("status", "order_status").Change()
+			},
+			want: []string{"ALTER TABLE orders ALTER COLUMN status TYPE VARCHAR(255), " +
+				"DROP CONSTRAINT IF EXISTS orders_status_check, " +
+				"ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'paid'))"},
+		},
+		{
+			name:    "unregistered enum",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.EnumFrom("status", "payment_status")
+			},
+			wantErr: `enum "payment_status" of column status is not registered`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "orders", grammar: tt.grammar}
+			tt.blueprint(bp)
+
+			got, err := bp.toSQL()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNativeEnum(t *testing.T) {
+	RegisterEnum("order_status", "pending", "paid")
+	t.Cleanup(func() {
+		enumsMu.Lock()
+		delete(enums, "order_status")
+		enumsMu.Unlock()
+	})
+
+	tests := []struct {
+		name      string
+		table     string
+		grammar   grammar
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name:    "postgres creates the type before the column",
+			table:   "orders",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.Enum("status", []string{"pending", "paid"}).Native()
+			},
+			want: []string{
+				"CREATE TYPE orders_status AS ENUM ('pending', 'paid')",
+				"ALTER TABLE orders ADD COLUMN status orders_status NOT NULL",
+			},
+		},
+		{
+			name:    "postgres names the type after the registered enum in the schema of the table",
+			table:   "sales.orders",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.EnumFrom("status", "order_status").Native()
+			},
+			want: []string{
+				"DO $$ BEGIN IF to_regtype('sales.order_status') IS NULL THEN " +
+					"CREATE TYPE sales.order_status AS ENUM ('pending', 'paid'); END IF; END $$",
+				"ALTER TABLE sales.orders ADD COLUMN status sales.order_status NOT NULL",
+			},
+		},
+		{
+			name:    "postgres uses an existing type without values",
+			table:   "refunds",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.Enum("status", nil).Native("order_status").Nullable()
+			},
+			want: []string{"ALTER TABLE refunds ADD COLUMN status order_status NULL"},
+		},
+		{
+			name:    "postgres change adds the missing values",
+			table:   "orders",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.Enum("status", []string{"pending", "paid", "shipped"}).Native().Change()
+			},
+			want: []string{
+				"ALTER TYPE orders_status ADD VALUE IF NOT EXISTS 'pending'",
+				"ALTER TYPE orders_status ADD VALUE IF NOT EXISTS 'paid'",
+				"ALTER TYPE orders_status ADD VALUE IF NOT EXISTS 'shipped'",
+			},
+		},
+		{
+			name:    "mysql enum is native already",
+			table:   "orders",
+			grammar: newMysqlGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.Enum("status", []string{"pending", "paid"}).Native()
+			},
+			want: []string{"ALTER TABLE orders ADD COLUMN status ENUM('pending', 'paid') NOT NULL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: tt.table, grammar: tt.grammar}
+			tt.blueprint(bp)
+
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNativeEnum_SharedRegisteredEnum(t *testing.T) {
+	RegisterEnum("order_status", "pending", "paid")
+	t.Cleanup(func() {
+		enumsMu.Lock()
+		delete(enums, "order_status")
+		enumsMu.Unlock()
+	})
+
+	createType := "DO $$ BEGIN IF to_regtype('order_status') IS NULL THEN " +
+		"CREATE TYPE order_status AS ENUM ('pending', 'paid'); END IF; END $$"
+	for _, table := range []string{"orders", "refunds"} {
+		bp := &Blueprint{name: table, grammar: newPostgresGrammar()}
+		bp.create()
+		bp.ID()
+		bp.EnumFrom("status", "order_status").Native()
+
+		got, err := bp.toSQL()
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, createType, got[0], "the type is only created by the first table")
+		assert.Contains(t, got[1], "status order_status NOT NULL")
+	}
+}
+
+func TestEnumType(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	require.NoError(t, CreateEnumType(fake, "order_status", "pending", "paid"))
+	require.NoError(t, DropEnumType(fake, "order_status"))
+	require.EqualError(t, CreateEnumType(fake, "order_status"), "enum type order_status has no values")
+	assert.Equal(t, []FakeOperation{
+		{Name: "CreateEnumType", Statements: []string{"CREATE TYPE order_status AS ENUM ('pending', 'paid')"}},
+		{Name: "DropEnumType", Statements: []string{"DROP TYPE order_status"}},
+	}, fake.Operations())
+
+	fake = newTestFake(t, "mysql")
+	require.ErrorIs(t, CreateEnumType(fake, "order_status", "pending"), ErrUnsupportedFeature)
+}
+
+func TestRegisterEnum(t *testing.T) {
+	values := []string{"admin", "user"}
+	RegisterEnum("role", values...)
+	t.Cleanup(func() {
+		enumsMu.Lock()
+		delete(enums, "role")
+		enumsMu.Unlock()
+	})
+	values[0] = "root"
+
+	got, ok := EnumValues("role")
+	require.True(t, ok)
+	assert.Equal(t, []string{"admin", "user"}, got)
+
+	RegisterEnum("role", "admin", "user", "guest")
+	got, _ = EnumValues("role")
+	assert.Equal(t, []string{"admin", "user", "guest"}, got)
+
+	_, ok = EnumValues("unknown")
+	assert.False(t, ok)
+}
+
+type fakeEnumLister struct {
+	column *columnDefinition
+}
+
+func (f *fakeEnumLister) getEnumColumn(_ Context, _ string, column string) (*columnDefinition, error) {
+	def := *f.column
+	def.name = column
+	return &def, nil
+}
+
+func TestEnumValueCommands(t *testing.T) {
+	current := &columnDefinition{columnType: columnTypeEnum, allowed: []string{"pending", "paid"}}
+	current.Nullable(false)
+	current.Default("pending")
+	// A check constraint not named by the convention, e.g. truncated by PostgreSQL for a long name.
+	truncated := &columnDefinition{columnType: columnTypeEnum, allowed: []string{"pending", "paid"},
+		enumCheck: "orders_status_chec"}
+	native := &columnDefinition{columnType: columnTypeEnum, allowed: []string{"pending", "paid"},
+		nativeEnum: true, enumType: "order_status"}
+
+	tests := []struct {
+		name      string
+		grammar   grammar
+		current   *columnDefinition
+		blueprint func(table *Blueprint)
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:    "mysql add value",
+			grammar: newMysqlGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.AddEnumValue("status", "shipped")
+			},
+			want: []string{"ALTER TABLE orders MODIFY COLUMN status ENUM('pending', 'paid', 'shipped') NOT NULL DEFAULT 'pending'"},
+		},
+		{
+			name:    "mysql drop value",
+			grammar: newMysqlGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.DropEnumValue("status", "paid")
+			},
+			want: []string{"ALTER TABLE orders MODIFY COLUMN status ENUM('pending') NOT NULL DEFAULT 'pending'"},
+		},
+		{
+			name:    "mysql rename value",
+			grammar: newMysqlGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.RenameEnumValue("status", "paid", "settled")
+			},
+			want: []string{
+				"ALTER TABLE orders MODIFY COLUMN status ENUM('pending', 'paid', 'settled') NOT NULL DEFAULT 'pending'",
+				"UPDATE orders SET status = 'settled' WHERE status = 'paid'",
+				"ALTER TABLE orders MODIFY COLUMN status ENUM('pending', 'settled') NOT NULL DEFAULT 'pending'",
+			},
+		},
+		{
+			name:    "postgres add value",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.AddEnumValue("status", "shipped", "refunded")
+			},
+			want: []string{"ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check, " +
+				"ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'paid', 'shipped', 'refunded'))"},
+		},
+		{
+			name:    "postgres rename value",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.RenameEnumValue("status", "paid", "settled")
+			},
+			want: []string{
+				"ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check",
+				"UPDATE orders SET status = 'settled' WHERE status = 'paid'",
+				"ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'settled'))",
+			},
+		},
+		{
+			name:    "postgres truncated check constraint",
+			grammar: newPostgresGrammar(),
+			current: truncated,
+			blueprint: func(table *Blueprint) {
+				table.AddEnumValue("status", "shipped")
+			},
+			want: []string{"ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_chec, " +
+				"ADD CONSTRAINT orders_status_chec CHECK (status IN ('pending', 'paid', 'shipped'))"},
+		},
+		{
+			name:    "postgres native add value",
+			grammar: newPostgresGrammar(),
+			current: native,
+			blueprint: func(table *Blueprint) {
+				table.AddEnumValue("status", "shipped", "refunded")
+			},
+			want: []string{
+				"ALTER TYPE order_status ADD VALUE 'shipped'",
+				"ALTER TYPE order_status ADD VALUE 'refunded'",
+			},
+		},
+		{
+			name:    "postgres native rename value",
+			grammar: newPostgresGrammar(),
+			current: native,
+			blueprint: func(table *Blueprint) {
+				table.RenameEnumValue("status", "paid", "settled")
+			},
+			want: []string{"ALTER TYPE order_status RENAME VALUE 'paid' TO 'settled'"},
+		},
+		{
+			name:    "postgres native drop value",
+			grammar: newPostgresGrammar(),
+			current: native,
+			blueprint: func(table *Blueprint) {
+				table.DropEnumValue("status", "paid")
+			},
+			wantErr: "unsupported feature: values cannot be dropped from enum type order_status of column status",
+		},
+		{
+			name:    "adding an existing value",
+			grammar: newPostgresGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.AddEnumValue("status", "paid")
+			},
+			wantErr: `enum column status already allows value "paid"`,
+		},
+		{
+			name:    "dropping an unknown value",
+			grammar: newMysqlGrammar(),
+			blueprint: func(table *Blueprint) {
+				table.DropEnumValue("status", "shipped")
+			},
+			wantErr: `enum column status does not allow value "shipped"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := current
+			if tt.current != nil {
+				column = tt.current
+			}
+			b := &baseBuilder{grammar: tt.grammar, enumLister: &fakeEnumLister{column: column}}
+			bp := b.newBlueprint("orders")
+			tt.blueprint(bp)
+
+			require.NoError(t, b.resolveEnumColumns(NewContext(context.Background(), nil), bp))
+			got, err := bp.toSQL()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		b := &baseBuilder{grammar: newPostgresGrammar(), enumLister: &fakeEnumLister{column: current}}
+		bp := b.newBlueprint("orders")
+		bp.AddEnumValue("status", "shipped")
+
+		err := b.resolveEnumColumns(NewDryRunContext(context.Background()), bp)
+		require.ErrorContains(t, err, "dry-run")
+	})
+}
+
+func TestParseEnumValues(t *testing.T) {
+	assert.Equal(t, []string{"a", "b'c"}, parseEnumValues("enum('a','b''c')"))
+	assert.Equal(t, []string{"pending", "paid"}, parseEnumValues(
+		"CHECK (((status)::text = ANY ((ARRAY['pending'::character varying, 'paid'::character varying])::text[])))"))
+}
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EventTriggerBlueprint defines an event trigger created with CreateEventTrigger.
+type EventTriggerBlueprint struct {
+	name     string
+	event    string
+	tags     []string
+	function string
+}
+
+// eventTriggerEventNames are the events an event trigger can fire on.
+var eventTriggerEventNames = []string{"ddl_command_start", "ddl_command_end", "table_rewrite", "sql_drop", "login"}
+
+// On fires the event trigger on the given event: ddl_command_start, ddl_command_end,
+// table_rewrite, sql_drop or login.
+func (t *EventTriggerBlueprint) On(event string) {
+	t.event = event
+}
+
+// Tags fires the event trigger only for the given command tags, e.g. "CREATE TABLE" or
+// "DROP INDEX". Without tags it fires for every command.
+func (t *EventTriggerBlueprint) Tags(tags ...string) {
+	t.tags = tags
+}
+
+// Execute sets the function the event trigger executes, created with CreateFunction and returning
+// event_trigger.
+func (t *EventTriggerBlueprint) Execute(function string) {
+	t.function = function
+}
+
+// eventTriggerEvent returns the event the event trigger fires on, lower-cased.
+func (g *baseGrammar) eventTriggerEvent(trigger *EventTriggerBlueprint) (string, error) {
+	if trigger.event == "" {
+		return "", fmt.Errorf("event trigger %s has no event, call On", trigger.name)
+	}
+	event := strings.ToLower(strings.TrimSpace(trigger.event))
+	if !slices.Contains(eventTriggerEventNames, event) {
+		return "", fmt.Errorf("event trigger %s: unknown event %s, expected one of %s",
+			trigger.name, trigger.event, strings.Join(eventTriggerEventNames, ", "))
+	}
+	return event, nil
+}
+
+// newEventTriggerBlueprint returns the event trigger defined by blueprint.
+func newEventTriggerBlueprint(
+	c Context,
+	name string,
+	blueprint func(trigger *EventTriggerBlueprint),
+) (*EventTriggerBlueprint, error) {
+	if c == nil || name == "" || blueprint == nil {
+		return nil, errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+
+	trigger := &EventTriggerBlueprint{name: name}
+	blueprint(trigger)
+	return trigger, nil
+}
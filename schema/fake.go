@@ -0,0 +1,1331 @@
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/dialect"
+)
+
+// FakeOperation is a schema change recorded by the fake builder.
+type FakeOperation struct {
+	Name       string   // Name is the builder method, e.g. "Create", "Table" or "Exec" for raw statements.
+	Table      string   // Table is the affected table, empty for operations on the whole schema.
+	Statements []string // Statements are the SQL statements the operation compiles to.
+}
+
+// Fake is an in-memory Builder for unit tests of migrations that should run without a database.
+// It compiles every schema change with the grammar of its dialect, records it as an operation
+// and applies it to a simulated catalog, which answers the introspection methods such as
+// HasTable, HasColumn and GetIndexes.
+//
+// A Fake is also a Context: the package functions called with it, e.g. schema.Create, use the
+// fake instead of the builder of the configured dialect. Raw statements passed to Exec are
+// recorded but do not change the catalog, and Query and QueryRow always fail. A Fake is not
+// safe for concurrent use.
+//
+// Example:
+//
+//	fake, err := schema.NewFake("pgx")
+//	err = schema.Create(fake, "users", func(table *schema.Blueprint) {
+//	    table.ID()
+//	    table.String("email").Unique()
+//	})
+//	exists, err := schema.HasColumn(fake, "users", "email") // true
+type Fake struct {
+	baseBuilder
+	operations []FakeOperation
+	tables     map[string]*fakeTable
+	views      map[string]string // the query of each view
+
+	materializedViews map[string]bool
+	functions         map[string]bool
+	triggers          map[string]bool // keyed by table and trigger name
+	eventTriggers     map[string]bool
+	sequences         map[string]bool
+	schemas           map[string]bool
+	extensions        map[string]bool
+}
+
+var (
+	_ Builder = (*Fake)(nil)
+	_ Context = (*Fake)(nil)
+)
+
+// errFakeQuery is returned for raw queries, which the simulated catalog cannot answer.
+var errFakeQuery = errors.New("raw queries are not supported by the fake builder")
+
+// fakeTable is a table of the simulated catalog.
+type fakeTable struct {
+	columns     []*columnDefinition
+	indexes     []*Index
+	foreignKeys []*ForeignKey
+	comment     sql.NullString
+}
+
+// NewFake creates an empty fake builder that compiles statements for the specified dialect.
+//
+// Supported dialects are "postgres", "pgx", "mysql", and "mariadb".
+func NewFake(dialectValue string) (*Fake, error) {
+	dialectVal := dialect.FromString(dialectValue)
+	if dialectVal == dialect.Unknown {
+		return nil, errors.New("unsupported dialect: " + dialectValue)
+	}
+	f := &Fake{
+		baseBuilder: baseBuilder{grammar: newGrammar(dialectVal)},
+		tables:      make(map[string]*fakeTable),
+		views:       make(map[string]string),
+
+		materializedViews: make(map[string]bool),
+		functions:         make(map[string]bool),
+		triggers:          make(map[string]bool),
+		eventTriggers:     make(map[string]bool),
+		sequences:         make(map[string]bool),
+		schemas:           make(map[string]bool),
+		extensions:        make(map[string]bool),
+	}
+	f.indexLister = f
+	f.enumLister = f
+	return f, nil
+}
+
+// Operations returns the operations recorded so far, in order.
+func (f *Fake) Operations() []FakeOperation {
+	return slices.Clone(f.operations)
+}
+
+// Statements returns the SQL statements of the operations recorded so far, in order.
+func (f *Fake) Statements() []string {
+	var statements []string
+	for _, op := range f.operations {
+		statements = append(statements, op.Statements...)
+	}
+	return statements
+}
+
+// ResetOperations forgets the recorded operations but keeps the catalog, e.g. after the tables
+// a test starts from have been created.
+func (f *Fake) ResetOperations() {
+	f.operations = nil
+}
+
+func (f *Fake) record(name string, table string, statements ...string) {
+	f.operations = append(f.operations, FakeOperation{Name: name, Table: table, Statements: statements})
+}
+
+func (f *Fake) Exec(query string, _ ...any) (sql.Result, error) {
+	f.record("Exec", "", strings.TrimSpace(query))
+	return &MockResult{}, nil
+}
+
+func (f *Fake) Query(_ string, _ ...any) (*sql.Rows, error) {
+	return nil, errFakeQuery
+}
+
+func (f *Fake) QueryRow(query string, args ...any) *sql.Row {
+	// sql.Row cannot be constructed with an error, so it is obtained from a database whose
+	// connections always fail.
+	return failingDB.QueryRowContext(context.Background(), query, args...)
+}
+
+func (f *Fake) Create(c Context, name string, blueprint func(table *Blueprint)) error {
+	if c == nil || name == "" || blueprint == nil {
+		return errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+	if _, exists := f.tables[name]; exists {
+		return fmt.Errorf("table %s already exists", name)
+	}
+
+	bp := f.newBlueprint(name)
+	bp.create()
+	blueprint(bp)
+
+	return f.apply("Create", bp)
+}
+
+func (f *Fake) CreateTemporary(c Context, name string, blueprint func(table *Blueprint)) error {
+	if blueprint == nil {
+		return errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+	return f.Create(c, name, func(table *Blueprint) {
+		table.Temporary()
+		blueprint(table)
+	})
+}
+
+func (f *Fake) CreateIfNotExists(c Context, name string, blueprint func(table *Blueprint)) error {
+	if c == nil || name == "" || blueprint == nil {
+		return errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+	if _, exists := f.tables[name]; exists {
+		return nil
+	}
+	return f.Create(c, name, func(table *Blueprint) {
+		table.ifNotExists = true
+		blueprint(table)
+	})
+}
+
+func (f *Fake) Table(c Context, name string, blueprint func(table *Blueprint)) error {
+	if c == nil || name == "" || blueprint == nil {
+		return errors.New("invalid arguments: context is nil or name/blueprint is empty")
+	}
+	if _, exists := f.tables[name]; !exists {
+		return fmt.Errorf("table %s does not exist", name)
+	}
+
+	bp := f.newBlueprint(name)
+	blueprint(bp)
+
+	if err := f.resolveDropIndexNames(f, bp); err != nil {
+		return err
+	}
+	if err := f.resolveEnumColumns(f, bp); err != nil {
+		return err
+	}
+	return f.apply("Table", bp)
+}
+
+func (f *Fake) WhenTableExists(c Context, name string, blueprint func(table *Blueprint)) error {
+	if c == nil || name == "" || blueprint == nil {
+		return errors.New("invalid arguments: context is nil or name/blueprint is empty")
+	}
+	if _, exists := f.tables[name]; !exists {
+		return nil
+	}
+	return f.Table(c, name, blueprint)
+}
+
+func (f *Fake) WhenColumnMissing(
+	c Context,
+	tableName string,
+	columnName string,
+	blueprint func(table *Blueprint),
+) error {
+	if c == nil || tableName == "" || columnName == "" || blueprint == nil {
+		return errors.New("invalid arguments: context is nil or table name, column name or blueprint is empty")
+	}
+	if exists, _ := f.HasColumn(c, tableName, columnName); exists {
+		return nil
+	}
+	return f.Table(c, tableName, blueprint)
+}
+
+func (f *Fake) Drop(c Context, name string) error {
+	if c == nil || name == "" {
+		return errors.New("invalid arguments: context is nil or name is empty")
+	}
+	if _, exists := f.tables[name]; !exists {
+		return fmt.Errorf("table %s does not exist", name)
+	}
+
+	bp := f.newBlueprint(name)
+	bp.drop()
+	return f.apply("Drop", bp)
+}
+
+func (f *Fake) DropIfExists(c Context, name string) error {
+	if c == nil || name == "" {
+		return errors.New("invalid arguments: context is nil or name is empty")
+	}
+
+	bp := f.newBlueprint(name)
+	bp.dropIfExists()
+	return f.apply("DropIfExists", bp)
+}
+
+func (f *Fake) Rename(c Context, oldName string, newName string) error {
+	if c == nil || oldName == "" || newName == "" {
+		return errors.New("invalid arguments: context is nil or old/new table name is empty")
+	}
+	if _, exists := f.tables[oldName]; !exists {
+		return fmt.Errorf("table %s does not exist", oldName)
+	}
+	if _, exists := f.tables[newName]; exists {
+		return fmt.Errorf("table %s already exists", newName)
+	}
+
+	bp := f.newBlueprint(oldName)
+	bp.rename(newName)
+	return f.apply("Rename", bp)
+}
+
+func (f *Fake) DropAllTables(c Context) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if len(f.tables) == 0 {
+		return nil
+	}
+
+	query, err := f.grammar.CompileDropAllTables(f.tableNames())
+	if err != nil {
+		return err
+	}
+	f.record("DropAllTables", "", query)
+	clear(f.tables)
+	return nil
+}
+
+func (f *Fake) GetColumns(c Context, tableName string) ([]*Column, error) {
+	if c == nil || tableName == "" {
+		return nil, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	table, exists := f.tables[tableName]
+	if !exists {
+		return nil, nil
+	}
+	columns := make([]*Column, 0, len(table.columns))
+	for _, col := range table.columns {
+		columns = append(columns, f.column(col))
+	}
+	return columns, nil
+}
+
+func (f *Fake) GetIndexes(c Context, tableName string) ([]*Index, error) {
+	if c == nil || tableName == "" {
+		return nil, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	table, exists := f.tables[tableName]
+	if !exists {
+		return nil, nil
+	}
+	indexes := make([]*Index, 0, len(table.indexes))
+	for _, idx := range table.indexes {
+		index := *idx
+		index.Columns = slices.Clone(idx.Columns)
+		indexes = append(indexes, &index)
+	}
+	return indexes, nil
+}
+
+func (f *Fake) GetForeignKeys(c Context, tableName string) ([]*ForeignKey, error) {
+	if c == nil || tableName == "" {
+		return nil, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	table, exists := f.tables[tableName]
+	if !exists {
+		return nil, nil
+	}
+	foreignKeys := make([]*ForeignKey, 0, len(table.foreignKeys))
+	for _, fk := range table.foreignKeys {
+		foreignKey := *fk
+		foreignKey.Columns = slices.Clone(fk.Columns)
+		foreignKey.ForeignColumns = slices.Clone(fk.ForeignColumns)
+		foreignKeys = append(foreignKeys, &foreignKey)
+	}
+	// Ordered by name, as the dialect builders order them.
+	slices.SortFunc(foreignKeys, func(a, b *ForeignKey) int { return strings.Compare(a.Name, b.Name) })
+	return foreignKeys, nil
+}
+
+func (f *Fake) GetAllColumns(c Context) (map[string][]*Column, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	columns := make(map[string][]*Column, len(f.tables))
+	for name := range f.tables {
+		columns[name], _ = f.GetColumns(c, name)
+	}
+	return columns, nil
+}
+
+func (f *Fake) GetAllIndexes(c Context) (map[string][]*Index, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	indexes := make(map[string][]*Index, len(f.tables))
+	for name, table := range f.tables {
+		if len(table.indexes) > 0 {
+			indexes[name], _ = f.GetIndexes(c, name)
+		}
+	}
+	return indexes, nil
+}
+
+func (f *Fake) GetTables(c Context) ([]*TableInfo, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	var tables []*TableInfo
+	for _, name := range f.tableNames() {
+		tables = append(tables, &TableInfo{Name: name, Comment: f.tables[name].comment})
+	}
+	return tables, nil
+}
+
+func (f *Fake) GetViews(c Context) ([]*ViewInfo, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	var views []*ViewInfo
+	for _, name := range slices.Sorted(maps.Keys(f.views)) {
+		views = append(views, &ViewInfo{Name: name, Definition: f.views[name]})
+	}
+	return views, nil
+}
+
+func (f *Fake) GetTableDDL(_ Context, _ string) (string, error) {
+	return "", errors.New("GetTableDDL is not supported by the fake builder")
+}
+
+func (f *Fake) GetSchemaDDL(_ Context) ([]string, error) {
+	return nil, errors.New("GetSchemaDDL is not supported by the fake builder")
+}
+
+func (f *Fake) HasColumn(c Context, tableName string, columnName string) (bool, error) {
+	return f.HasColumns(c, tableName, []string{columnName})
+}
+
+func (f *Fake) HasColumns(c Context, tableName string, columnNames []string) (bool, error) {
+	if c == nil || tableName == "" {
+		return false, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+	if len(columnNames) == 0 {
+		return false, errors.New("no column names provided")
+	}
+
+	table, exists := f.tables[tableName]
+	if !exists {
+		return false, nil
+	}
+	for _, name := range columnNames {
+		if name == "" {
+			return false, errors.New("column name is empty")
+		}
+		if table.columnIndex(name) < 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (f *Fake) HasIndex(c Context, tableName string, indexes []string) (bool, error) {
+	if c == nil || tableName == "" {
+		return false, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	table, exists := f.tables[tableName]
+	if !exists || len(table.indexes) == 0 {
+		return false, nil
+	}
+	if len(indexes) == 0 {
+		return true, nil
+	}
+	// Like the dialect builders, a single value may be the name of the index; otherwise the
+	// values are the columns of an index.
+	if len(indexes) == 1 && table.indexPosition(indexes[0]) >= 0 {
+		return true, nil
+	}
+	for _, index := range table.indexes {
+		found := true
+		for _, col := range index.Columns {
+			if !slices.Contains(indexes, col) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *Fake) HasTable(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	_, exists := f.tables[name]
+	return exists, nil
+}
+
+func (f *Fake) HasView(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or view name is empty")
+	}
+
+	_, exists := f.views[name]
+	return exists, nil
+}
+
+func (f *Fake) WithoutForeignKeyConstraints(c Context, fn func() error) error {
+	if c == nil || fn == nil {
+		return errors.New("invalid arguments: context or fn is nil")
+	}
+
+	f.record("DisableForeignKeyConstraints", "", f.grammar.CompileDisableForeignKeyConstraints())
+	err := fn()
+	f.record("EnableForeignKeyConstraints", "", f.grammar.CompileEnableForeignKeyConstraints())
+	return err
+}
+
+func (f *Fake) DeferConstraints(c Context) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := f.grammar.CompileDeferConstraints()
+	if err != nil {
+		return err
+	}
+	f.record("DeferConstraints", "", query)
+	return nil
+}
+
+func (f *Fake) Analyze(c Context, tables ...string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := f.grammar.CompileAnalyze(tables)
+	if err != nil {
+		return err
+	}
+	f.record("Analyze", "", query)
+	return nil
+}
+
+func (f *Fake) Truncate(c Context, table string, options TruncateOptions) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if _, exists := f.tables[table]; !exists {
+		return fmt.Errorf("table %s does not exist", table)
+	}
+
+	query, err := f.grammar.CompileTruncate(table, options)
+	if err != nil {
+		return err
+	}
+	f.record("Truncate", table, query)
+	return nil
+}
+
+func (f *Fake) CreateEnumType(c Context, name string, values ...string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := f.grammar.CompileCreateEnumType(name, values)
+	if err != nil {
+		return err
+	}
+	f.record("CreateEnumType", "", query)
+	return nil
+}
+
+func (f *Fake) DropEnumType(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := f.grammar.CompileDropEnumType(name)
+	if err != nil {
+		return err
+	}
+	f.record("DropEnumType", "", query)
+	return nil
+}
+
+func (f *Fake) CreateView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	if _, exists := f.views[name]; exists {
+		return fmt.Errorf("view %s already exists", name)
+	}
+	return f.createView(c, "CreateView", name, blueprint, false)
+}
+
+func (f *Fake) CreateOrReplaceView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	return f.createView(c, "CreateOrReplaceView", name, blueprint, true)
+}
+
+func (f *Fake) createView(
+	c Context,
+	operation, name string,
+	blueprint func(view *ViewBlueprint),
+	orReplace bool,
+) error {
+	view, err := newViewBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := f.grammar.CompileCreateView(view, orReplace)
+	if err != nil {
+		return err
+	}
+	f.record(operation, name, query)
+	f.views[name] = view.query
+	return nil
+}
+
+func (f *Fake) DropView(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if _, exists := f.views[name]; !exists {
+		return fmt.Errorf("view %s does not exist", name)
+	}
+
+	query, err := f.grammar.CompileDropView(name)
+	if err != nil {
+		return err
+	}
+	f.record("DropView", name, query)
+	delete(f.views, name)
+	return nil
+}
+
+func (f *Fake) CreateMaterializedView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	if f.materializedViews[name] {
+		return fmt.Errorf("materialized view %s already exists", name)
+	}
+
+	view, err := newViewBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := f.grammar.CompileCreateMaterializedView(view)
+	if err != nil {
+		return err
+	}
+	f.record("CreateMaterializedView", name, query)
+	f.materializedViews[name] = true
+	return nil
+}
+
+func (f *Fake) RefreshMaterializedView(c Context, name string, options RefreshOptions) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.materializedViews[name] {
+		return fmt.Errorf("materialized view %s does not exist", name)
+	}
+
+	query, err := f.grammar.CompileRefreshMaterializedView(name, options)
+	if err != nil {
+		return err
+	}
+	f.record("RefreshMaterializedView", name, query)
+	return nil
+}
+
+func (f *Fake) DropMaterializedView(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.materializedViews[name] {
+		return fmt.Errorf("materialized view %s does not exist", name)
+	}
+
+	query, err := f.grammar.CompileDropMaterializedView(name)
+	if err != nil {
+		return err
+	}
+	f.record("DropMaterializedView", name, query)
+	delete(f.materializedViews, name)
+	return nil
+}
+
+func (f *Fake) CreateFunction(c Context, name string, blueprint func(fn *FunctionBlueprint)) error {
+	if f.functions[name] {
+		return fmt.Errorf("function %s already exists", name)
+	}
+
+	fn, err := newFunctionBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := f.grammar.CompileCreateFunction(fn)
+	if err != nil {
+		return err
+	}
+	f.record("CreateFunction", "", query)
+	f.functions[name] = true
+	return nil
+}
+
+func (f *Fake) DropFunction(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.functions[name] {
+		return fmt.Errorf("function %s does not exist", name)
+	}
+
+	query, err := f.grammar.CompileDropFunction(name)
+	if err != nil {
+		return err
+	}
+	f.record("DropFunction", "", query)
+	delete(f.functions, name)
+	return nil
+}
+
+func (f *Fake) CreateTrigger(c Context, name, table string, blueprint func(trigger *TriggerBlueprint)) error {
+	if _, exists := f.tables[table]; !exists {
+		return fmt.Errorf("table %s does not exist", table)
+	}
+	if f.triggers[table+"."+name] {
+		return fmt.Errorf("trigger %s on %s already exists", name, table)
+	}
+
+	trigger, err := newTriggerBlueprint(c, name, table, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := f.grammar.CompileCreateTrigger(trigger)
+	if err != nil {
+		return err
+	}
+	f.record("CreateTrigger", table, query)
+	f.triggers[table+"."+name] = true
+	return nil
+}
+
+func (f *Fake) DropTrigger(c Context, name, table string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.triggers[table+"."+name] {
+		return fmt.Errorf("trigger %s on %s does not exist", name, table)
+	}
+
+	query, err := f.grammar.CompileDropTrigger(name, table)
+	if err != nil {
+		return err
+	}
+	f.record("DropTrigger", table, query)
+	delete(f.triggers, table+"."+name)
+	return nil
+}
+
+func (f *Fake) CreateEventTrigger(c Context, name string, blueprint func(trigger *EventTriggerBlueprint)) error {
+	if f.eventTriggers[name] {
+		return fmt.Errorf("event trigger %s already exists", name)
+	}
+
+	trigger, err := newEventTriggerBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := f.grammar.CompileCreateEventTrigger(trigger)
+	if err != nil {
+		return err
+	}
+	f.record("CreateEventTrigger", "", query)
+	f.eventTriggers[name] = true
+	return nil
+}
+
+func (f *Fake) DropEventTrigger(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.eventTriggers[name] {
+		return fmt.Errorf("event trigger %s does not exist", name)
+	}
+
+	query, err := f.grammar.CompileDropEventTrigger(name)
+	if err != nil {
+		return err
+	}
+	f.record("DropEventTrigger", "", query)
+	delete(f.eventTriggers, name)
+	return nil
+}
+
+func (f *Fake) CreateSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error {
+	if f.sequences[name] {
+		return fmt.Errorf("sequence %s already exists", name)
+	}
+
+	seq, err := newSequenceBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := f.grammar.CompileCreateSequence(seq)
+	if err != nil {
+		return err
+	}
+	f.record("CreateSequence", "", query)
+	f.sequences[name] = true
+	return nil
+}
+
+func (f *Fake) AlterSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error {
+	if !f.sequences[name] {
+		return fmt.Errorf("sequence %s does not exist", name)
+	}
+
+	seq, err := newSequenceBlueprint(c, name, blueprint)
+	if err != nil {
+		return err
+	}
+	query, err := f.grammar.CompileAlterSequence(seq)
+	if err != nil {
+		return err
+	}
+	f.record("AlterSequence", "", query)
+	return nil
+}
+
+func (f *Fake) DropSequence(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.sequences[name] {
+		return fmt.Errorf("sequence %s does not exist", name)
+	}
+
+	query, err := f.grammar.CompileDropSequence(name)
+	if err != nil {
+		return err
+	}
+	f.record("DropSequence", "", query)
+	delete(f.sequences, name)
+	return nil
+}
+
+func (f *Fake) AlterDefaultPrivileges(c Context, privileges DefaultPrivileges) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := f.grammar.CompileDefaultPrivileges(privileges)
+	if err != nil {
+		return err
+	}
+	f.record("AlterDefaultPrivileges", "", query)
+	return nil
+}
+
+func (f *Fake) CloneSchema(_ Context, _, _ string, _ CloneOptions) error {
+	return errors.New("CloneSchema is not supported by the fake builder")
+}
+
+func (f *Fake) SwapSchemas(c Context, schema, otherSchema string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	queries, err := swapSchemaQueries(f.grammar, schema, otherSchema)
+	if err != nil {
+		return err
+	}
+	for _, query := range queries {
+		f.record("SwapSchemas", "", query)
+	}
+	return nil
+}
+
+func (f *Fake) CreateSchema(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if f.schemas[name] {
+		return fmt.Errorf("schema %s already exists", name)
+	}
+
+	query, err := f.grammar.CompileCreateSchema(name)
+	if err != nil {
+		return err
+	}
+	f.record("CreateSchema", "", query)
+	f.schemas[name] = true
+	return nil
+}
+
+// DropSchema removes a schema created with CreateSchema. With cascade, the tables qualified with
+// the schema are removed from the catalog as well; without it, they make the drop fail.
+func (f *Fake) DropSchema(c Context, name string, cascade bool) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.schemas[name] {
+		return fmt.Errorf("schema %s does not exist", name)
+	}
+	var tables []string
+	for table := range f.tables {
+		if strings.HasPrefix(table, name+".") {
+			tables = append(tables, table)
+		}
+	}
+	if len(tables) > 0 && !cascade {
+		return fmt.Errorf("schema %s is not empty", name)
+	}
+
+	query, err := f.grammar.CompileDropSchema(name, cascade)
+	if err != nil {
+		return err
+	}
+	f.record("DropSchema", "", query)
+	for _, table := range tables {
+		delete(f.tables, table)
+	}
+	delete(f.schemas, name)
+	return nil
+}
+
+func (f *Fake) HasSchema(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or schema name is empty")
+	}
+
+	return f.schemas[name], nil
+}
+
+func (f *Fake) CreatePartition(c Context, parent, name string, bounds PartitionBounds) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if _, exists := f.tables[parent]; !exists {
+		return fmt.Errorf("table %s does not exist", parent)
+	}
+
+	query, err := f.grammar.CompileCreatePartition(parent, name, bounds)
+	if err != nil {
+		return err
+	}
+	f.record("CreatePartition", parent, query)
+	return nil
+}
+
+func (f *Fake) CreateExtension(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if f.extensions[name] {
+		return fmt.Errorf("extension %s already exists", name)
+	}
+
+	query, err := f.grammar.CompileCreateExtension(name)
+	if err != nil {
+		return err
+	}
+	f.record("CreateExtension", "", query)
+	f.extensions[name] = true
+	return nil
+}
+
+func (f *Fake) DropExtension(c Context, name string) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+	if !f.extensions[name] {
+		return fmt.Errorf("extension %s does not exist", name)
+	}
+
+	query, err := f.grammar.CompileDropExtension(name)
+	if err != nil {
+		return err
+	}
+	f.record("DropExtension", "", query)
+	delete(f.extensions, name)
+	return nil
+}
+
+func (f *Fake) HasExtension(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or extension name is empty")
+	}
+
+	return f.extensions[name], nil
+}
+
+// ReorderColumns reorders the columns of the simulated table. The statements depend on the
+// definition of the table in the database, so the operation is recorded without any.
+func (f *Fake) ReorderColumns(c Context, table string, columns []string, _ ReorderOptions) error {
+	if c == nil || table == "" || len(columns) == 0 {
+		return errors.New("invalid arguments: context is nil, table name or columns are empty")
+	}
+	t, exists := f.tables[table]
+	if !exists {
+		return fmt.Errorf("table %s does not exist", table)
+	}
+
+	definitions := make([]ddlDefinition, len(t.columns))
+	for i, col := range t.columns {
+		definitions[i] = ddlDefinition{column: col.name}
+	}
+	reordered, err := reorderColumns(definitions, columns)
+	if err != nil {
+		return err
+	}
+	ordered := make([]*columnDefinition, len(reordered))
+	for i, def := range reordered {
+		ordered[i] = t.columns[t.columnIndex(def.column)]
+	}
+	t.columns = ordered
+	f.record("ReorderColumns", table)
+	return nil
+}
+
+func (f *Fake) getEnumColumn(_ Context, tableName string, column string) (*columnDefinition, error) {
+	table, exists := f.tables[tableName]
+	if !exists {
+		return nil, fmt.Errorf("table %s does not exist", tableName)
+	}
+	i := table.columnIndex(column)
+	if i < 0 {
+		return nil, fmt.Errorf("column %s of table %s does not exist", column, tableName)
+	}
+	col := *table.columns[i]
+	if col.columnType != columnTypeEnum {
+		return nil, fmt.Errorf("column %s of table %s is not an enum", column, tableName)
+	}
+	col.allowed = slices.Clone(col.allowed)
+	return &col, nil
+}
+
+// apply compiles the blueprint, applies its commands to a copy of the catalog and records the
+// operation once every command succeeded.
+func (f *Fake) apply(name string, bp *Blueprint) error {
+	statements, err := bp.toSQL()
+	if err != nil {
+		return err
+	}
+
+	var table *fakeTable
+	if existing, ok := f.tables[bp.name]; ok {
+		table = existing.clone()
+	}
+	renamed := ""
+	dropped := false
+	for _, cmd := range bp.commands {
+		switch cmd.name {
+		case commandCreate:
+			table = &fakeTable{}
+			err = table.addColumns(f, bp)
+		case commandAdd:
+			err = table.addColumns(f, bp)
+		case commandChange:
+			err = table.changeColumn(cmd.column)
+		case commandDropColumn:
+			err = table.dropColumns(cmd.columns)
+		case commandRenameColumn:
+			err = table.renameColumn(cmd.from, cmd.to)
+		case commandIndex, commandUnique, commandPrimary, commandFullText:
+			err = table.addIndex(f.index(bp, cmd))
+		case commandDropIndex, commandDropUnique, commandDropPrimary, commandDropFullText:
+			err = table.dropIndex(cmd.index)
+		case commandRenameIndex:
+			err = table.renameIndex(cmd.from, cmd.to)
+		case commandForeign:
+			err = table.addForeignKey(f.foreignKey(bp, cmd))
+		case commandDropForeign:
+			err = table.dropForeignKey(cmd.index)
+		case commandAddEnumValue, commandDropEnumValue, commandRenameEnumValue:
+			err = table.changeEnumValues(cmd)
+		case commandDrop, commandDropIfExists:
+			dropped = true
+		case commandRename:
+			renamed = cmd.to
+		}
+		if err != nil {
+			return fmt.Errorf("table %s: %w", bp.name, err)
+		}
+	}
+
+	// An empty comment is no comment, as on the databases.
+	if table != nil && bp.comment != nil {
+		table.comment = sql.NullString{String: *bp.comment, Valid: *bp.comment != ""}
+	}
+
+	switch {
+	case dropped:
+		delete(f.tables, bp.name)
+	case renamed != "":
+		delete(f.tables, bp.name)
+		f.tables[renamed] = table
+	case table != nil:
+		f.tables[bp.name] = table
+	}
+	f.record(name, bp.name, statements...)
+	return nil
+}
+
+// index returns the index created by the command, named like the grammar names it.
+func (f *Fake) index(bp *Blueprint, cmd *command) *Index {
+	index := &Index{
+		Name:    cmd.index,
+		Columns: slices.Clone(cmd.columns),
+		Unique:  cmd.name == commandUnique || cmd.name == commandPrimary,
+		Primary: cmd.name == commandPrimary,
+	}
+	if cmd.name == commandFullText {
+		index.Type = "fulltext"
+	}
+	if index.Name == "" {
+		index.Name = f.grammar.CreateIndexName(bp, strings.ToLower(cmd.name), cmd.columns...)
+	}
+	return index
+}
+
+// foreignKey returns the foreign key created by the command, named like the grammar names it and
+// with its actions as the introspection of the dialect builders reports them.
+func (f *Fake) foreignKey(bp *Blueprint, cmd *command) *ForeignKey {
+	foreignSchema, foreignTable := splitQualifiedName(cmd.on)
+	if _, ok := f.grammar.(*postgresGrammar); ok && foreignSchema == "" {
+		foreignSchema = defaultPostgresSchema
+	}
+	action := func(action string) string {
+		if action == "" {
+			return "NO ACTION"
+		}
+		return strings.ToUpper(action)
+	}
+	foreignKey := &ForeignKey{
+		Name:           cmd.index,
+		Columns:        slices.Clone(cmd.columns),
+		ForeignSchema:  foreignSchema,
+		ForeignTable:   foreignTable,
+		ForeignColumns: slices.Clone(cmd.references),
+		OnUpdate:       action(cmd.onUpdate),
+		OnDelete:       action(cmd.onDelete),
+	}
+	if foreignKey.Name == "" {
+		foreignKey.Name = f.grammar.CreateForeignKeyName(bp, cmd)
+	}
+	return foreignKey
+}
+
+// columnTyper compiles the SQL type of a column; it is implemented by the dialect grammars.
+type columnTyper interface {
+	getType(col *columnDefinition) string
+}
+
+// column returns the column as the introspection of the dialect builders reports it.
+func (f *Fake) column(col *columnDefinition) *Column {
+	typeFull := col.columnType
+	if g, ok := f.grammar.(columnTyper); ok {
+		typeFull = g.getType(col)
+	}
+	typeName, _, _ := strings.Cut(typeFull, "(")
+	column := &Column{
+		Name:     col.name,
+		TypeName: strings.ToLower(typeName),
+		TypeFull: typeFull,
+		Nullable: col.nullable != nil && *col.nullable,
+	}
+	if col.defaultValue != nil {
+		column.DefaultVal = sql.NullString{String: fmt.Sprint(col.defaultValue), Valid: true}
+	}
+	// An empty comment is no comment, as on the databases.
+	if col.comment != nil && *col.comment != "" {
+		column.Comment = sql.NullString{String: *col.comment, Valid: true}
+	}
+	if col.autoIncrement != nil && *col.autoIncrement {
+		column.Extra = sql.NullString{String: "auto_increment", Valid: true}
+	}
+	return column
+}
+
+func (f *Fake) tableNames() []string {
+	names := make([]string, 0, len(f.tables))
+	for name := range f.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (t *fakeTable) clone() *fakeTable {
+	return &fakeTable{
+		columns:     slices.Clone(t.columns),
+		indexes:     slices.Clone(t.indexes),
+		foreignKeys: slices.Clone(t.foreignKeys),
+		comment:     t.comment,
+	}
+}
+
+func (t *fakeTable) columnIndex(name string) int {
+	return slices.IndexFunc(t.columns, func(col *columnDefinition) bool {
+		return col.name == name
+	})
+}
+
+func (t *fakeTable) indexPosition(name string) int {
+	return slices.IndexFunc(t.indexes, func(idx *Index) bool {
+		return idx.Name == name
+	})
+}
+
+// addColumns adds the new columns of the blueprint, including the primary key defined on them.
+func (t *fakeTable) addColumns(f *Fake, bp *Blueprint) error {
+	var primary []string
+	for _, col := range bp.getAddedColumns() {
+		if t.columnIndex(col.name) >= 0 {
+			return fmt.Errorf("column %s already exists", col.name)
+		}
+		t.columns = append(t.columns, col)
+		if col.primary != nil && *col.primary {
+			primary = append(primary, col.name)
+		}
+	}
+	if len(primary) == 0 {
+		return nil
+	}
+	return t.addIndex(f.index(bp, &command{name: commandPrimary, columns: primary}))
+}
+
+func (t *fakeTable) changeColumn(col *columnDefinition) error {
+	i := t.columnIndex(col.name)
+	if i < 0 {
+		return fmt.Errorf("column %s does not exist", col.name)
+	}
+	t.columns[i] = col
+	return nil
+}
+
+// dropColumns removes the columns and the indexes that contain them.
+func (t *fakeTable) dropColumns(names []string) error {
+	for _, name := range names {
+		i := t.columnIndex(name)
+		if i < 0 {
+			return fmt.Errorf("column %s does not exist", name)
+		}
+		t.columns = slices.Delete(t.columns, i, i+1)
+		t.indexes = slices.DeleteFunc(t.indexes, func(idx *Index) bool {
+			return slices.Contains(idx.Columns, name)
+		})
+	}
+	return nil
+}
+
+func (t *fakeTable) renameColumn(from string, to string) error {
+	i := t.columnIndex(from)
+	if i < 0 {
+		return fmt.Errorf("column %s does not exist", from)
+	}
+	if t.columnIndex(to) >= 0 {
+		return fmt.Errorf("column %s already exists", to)
+	}
+	col := *t.columns[i]
+	col.name = to
+	t.columns[i] = &col
+	for j, idx := range t.indexes {
+		if !slices.Contains(idx.Columns, from) {
+			continue
+		}
+		index := *idx
+		index.Columns = slices.Clone(idx.Columns)
+		index.Columns[slices.Index(index.Columns, from)] = to
+		t.indexes[j] = &index
+	}
+	return nil
+}
+
+func (t *fakeTable) addIndex(index *Index) error {
+	if t.indexPosition(index.Name) >= 0 {
+		return fmt.Errorf("index %s already exists", index.Name)
+	}
+	for _, col := range index.Columns {
+		if t.columnIndex(col) < 0 {
+			return fmt.Errorf("column %s of index %s does not exist", col, index.Name)
+		}
+	}
+	t.indexes = append(t.indexes, index)
+	return nil
+}
+
+func (t *fakeTable) addForeignKey(foreignKey *ForeignKey) error {
+	if slices.ContainsFunc(t.foreignKeys, func(fk *ForeignKey) bool { return fk.Name == foreignKey.Name }) {
+		return fmt.Errorf("foreign key %s already exists", foreignKey.Name)
+	}
+	for _, col := range foreignKey.Columns {
+		if t.columnIndex(col) < 0 {
+			return fmt.Errorf("column %s of foreign key %s does not exist", col, foreignKey.Name)
+		}
+	}
+	t.foreignKeys = append(t.foreignKeys, foreignKey)
+	return nil
+}
+
+func (t *fakeTable) dropForeignKey(name string) error {
+	i := slices.IndexFunc(t.foreignKeys, func(fk *ForeignKey) bool { return fk.Name == name })
+	if i < 0 {
+		return fmt.Errorf("foreign key %s does not exist", name)
+	}
+	t.foreignKeys = slices.Delete(t.foreignKeys, i, i+1)
+	return nil
+}
+
+func (t *fakeTable) dropIndex(name string) error {
+	i := t.indexPosition(name)
+	if i < 0 {
+		return fmt.Errorf("index %s does not exist", name)
+	}
+	t.indexes = slices.Delete(t.indexes, i, i+1)
+	return nil
+}
+
+func (t *fakeTable) renameIndex(from string, to string) error {
+	i := t.indexPosition(from)
+	if i < 0 {
+		return fmt.Errorf("index %s does not exist", from)
+	}
+	if t.indexPosition(to) >= 0 {
+		return fmt.Errorf("index %s already exists", to)
+	}
+	index := *t.indexes[i]
+	index.Name = to
+	t.indexes[i] = &index
+	return nil
+}
+
+func (t *fakeTable) changeEnumValues(cmd *command) error {
+	i := t.columnIndex(cmd.column.name)
+	if i < 0 {
+		return fmt.Errorf("column %s does not exist", cmd.column.name)
+	}
+	col := *t.columns[i]
+	switch cmd.name {
+	case commandAddEnumValue:
+		col.allowed = append(slices.Clone(col.allowed), cmd.values...)
+	case commandDropEnumValue:
+		col.allowed = slices.DeleteFunc(slices.Clone(col.allowed), func(value string) bool {
+			return slices.Contains(cmd.values, value)
+		})
+	case commandRenameEnumValue:
+		col.allowed = slices.Clone(col.allowed)
+		if j := slices.Index(col.allowed, cmd.from); j >= 0 {
+			col.allowed[j] = cmd.to
+		}
+	}
+	t.columns[i] = &col
+	return nil
+}
+
+// failingDB is a database whose connections always fail with errFakeQuery.
+var failingDB = sql.OpenDB(failingConnector{})
+
+type failingConnector struct{}
+
+func (failingConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errFakeQuery
+}
+
+func (failingConnector) Driver() driver.Driver {
+	return failingDriver{}
+}
+
+type failingDriver struct{}
+
+func (failingDriver) Open(string) (driver.Conn, error) {
+	return nil, errFakeQuery
+}
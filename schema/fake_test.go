@@ -0,0 +1,554 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFake(t *testing.T, dialect string) *Fake {
+	t.Helper()
+	fake, err := NewFake(dialect)
+	require.NoError(t, err)
+	err = Create(fake, "users", func(table *Blueprint) {
+		table.ID()
+		table.String("name")
+		table.String("email").Unique()
+	})
+	require.NoError(t, err)
+	fake.ResetOperations()
+	return fake
+}
+
+func TestNewFake(t *testing.T) {
+	_, err := NewFake("sqlite")
+	require.Error(t, err)
+}
+
+func TestFake_Create(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	err := Create(fake, "posts", func(table *Blueprint) {
+		table.ID()
+		table.BigInteger("user_id").Comment("")
+		table.Foreign("user_id").References("id").On("users")
+		table.String("title").Comment("Post title").Index()
+	})
+	require.NoError(t, err)
+
+	ops := fake.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, "Create", ops[0].Name)
+	assert.Equal(t, "posts", ops[0].Table)
+	assert.NotEmpty(t, ops[0].Statements)
+
+	exists, err := HasTable(fake, "posts")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	columns, err := GetColumns(fake, "posts")
+	require.NoError(t, err)
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, col.Name)
+	}
+	assert.Equal(t, []string{"id", "user_id", "title"}, names)
+	assert.Equal(t, "varchar", columns[2].TypeName)
+	// An empty comment is no comment, as on the databases.
+	assert.False(t, columns[1].Comment.Valid)
+	assert.Equal(t, "Post title", columns[2].Comment.String)
+
+	indexes, err := GetIndexes(fake, "posts")
+	require.NoError(t, err)
+	require.Len(t, indexes, 2)
+	assert.Equal(t, &Index{Name: "pk_posts", Columns: []string{"id"}, Unique: true, Primary: true}, indexes[0])
+	assert.Equal(t, &Index{Name: "idx_posts_title", Columns: []string{"title"}}, indexes[1])
+
+	err = Create(fake, "posts", func(table *Blueprint) {
+		table.ID()
+	})
+	require.EqualError(t, err, "table posts already exists")
+}
+
+func TestFake_ConditionalTable(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	addPhone := func(table *Blueprint) {
+		table.String("phone").Nullable()
+	}
+
+	require.NoError(t, WhenTableExists(fake, "legacy_users", addPhone))
+	require.NoError(t, WhenColumnMissing(fake, "users", "email", addPhone))
+	assert.Empty(t, fake.Operations(), "the blueprints are skipped")
+
+	require.NoError(t, WhenColumnMissing(fake, "users", "phone", addPhone))
+	require.NoError(t, WhenColumnMissing(fake, "users", "phone", addPhone))
+	require.NoError(t, WhenTableExists(fake, "users", func(table *Blueprint) {
+		table.DropColumn("name")
+	}))
+	assert.Equal(t, []string{
+		"ALTER TABLE users ADD COLUMN phone VARCHAR(255) NULL",
+		"ALTER TABLE users DROP COLUMN name",
+	}, fake.Statements())
+}
+
+func TestFake_GetForeignKeys(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	require.NoError(t, Create(fake, "posts", func(table *Blueprint) {
+		table.ID()
+		table.ForeignID("user_id").Constrained().CascadeOnDelete()
+		table.BigInteger("editor_id")
+		table.Foreign("editor_id").References("id").On("billing.editors").Name("fk_posts_editor")
+	}))
+
+	foreignKeys, err := GetForeignKeys(fake, "posts")
+	require.NoError(t, err)
+	assert.Equal(t, []*ForeignKey{
+		{
+			Name: "fk_posts_editor", Columns: []string{"editor_id"}, ForeignSchema: "billing", ForeignTable: "editors",
+			ForeignColumns: []string{"id"}, OnUpdate: "NO ACTION", OnDelete: "NO ACTION",
+		},
+		{
+			Name: "fk_posts_users", Columns: []string{"user_id"}, ForeignSchema: "public", ForeignTable: "users",
+			ForeignColumns: []string{"id"}, OnUpdate: "NO ACTION", OnDelete: "CASCADE",
+		},
+	}, foreignKeys)
+
+	require.NoError(t, Table(fake, "posts", func(table *Blueprint) {
+		table.DropForeign("fk_posts_editor")
+	}))
+	foreignKeys, err = GetForeignKeys(fake, "posts")
+	require.NoError(t, err)
+	assert.Len(t, foreignKeys, 1)
+	require.EqualError(t, Table(fake, "posts", func(table *Blueprint) {
+		table.DropForeign("fk_posts_editor")
+	}), "table posts: foreign key fk_posts_editor does not exist")
+}
+
+func TestFake_GetViewsAndHasView(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	require.NoError(t, CreateView(fake, "user_emails", func(view *ViewBlueprint) {
+		view.As("SELECT email FROM users")
+	}))
+
+	exists, err := HasView(fake, "user_emails")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = HasView(fake, "users")
+	require.NoError(t, err)
+	assert.False(t, exists, "a table is not a view")
+
+	views, err := GetViews(fake)
+	require.NoError(t, err)
+	assert.Equal(t, []*ViewInfo{{Name: "user_emails", Definition: "SELECT email FROM users"}}, views)
+
+	require.NoError(t, DropView(fake, "user_emails"))
+	exists, err = HasView(fake, "user_emails")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestFake_GetAllColumnsAndIndexes(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	require.NoError(t, Create(fake, "tags", func(table *Blueprint) {
+		table.String("name")
+	}))
+
+	columns, err := GetAllColumns(fake)
+	require.NoError(t, err)
+	assert.Len(t, columns, 2)
+	usersColumns, err := GetColumns(fake, "users")
+	require.NoError(t, err)
+	assert.Equal(t, usersColumns, columns["users"])
+	assert.Len(t, columns["tags"], 1)
+
+	indexes, err := GetAllIndexes(fake)
+	require.NoError(t, err)
+	usersIndexes, err := GetIndexes(fake, "users")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]*Index{"users": usersIndexes}, indexes)
+}
+
+func TestFake_TableComment(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	err := Table(fake, "users", func(table *Blueprint) {
+		table.Comment("Registered users")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"COMMENT ON TABLE users IS 'Registered users'"}, fake.Operations()[0].Statements)
+
+	tables, err := GetTables(fake)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, sql.NullString{String: "Registered users", Valid: true}, tables[0].Comment)
+
+	err = Table(fake, "users", func(table *Blueprint) {
+		table.Comment("")
+	})
+	require.NoError(t, err)
+	tables, err = GetTables(fake)
+	require.NoError(t, err)
+	assert.False(t, tables[0].Comment.Valid)
+}
+
+func TestFake_CreateTemporary(t *testing.T) {
+	fake := newTestFake(t, "mysql")
+
+	err := CreateTemporary(fake, "order_fixes", func(table *Blueprint) {
+		table.BigInteger("order_id")
+	})
+	require.NoError(t, err)
+
+	ops := fake.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, []string{"CREATE TEMPORARY TABLE order_fixes (order_id BIGINT NOT NULL)"}, ops[0].Statements)
+
+	exists, err := HasTable(fake, "order_fixes")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestFake_CreateIfNotExists(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	err := CreateIfNotExists(fake, "users", func(table *Blueprint) {
+		table.ID()
+	})
+	require.NoError(t, err)
+	assert.Empty(t, fake.Operations(), "the existing table is kept")
+
+	err = CreateIfNotExists(fake, "sessions", func(table *Blueprint) {
+		table.String("id").Primary()
+	})
+	require.NoError(t, err)
+	ops := fake.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS sessions (id VARCHAR(255) NOT NULL, CONSTRAINT pk_sessions PRIMARY KEY (id))",
+	}, ops[0].Statements)
+
+	exists, err := HasTable(fake, "sessions")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestFake_Views(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	activeUsers := func(view *ViewBlueprint) {
+		view.As("SELECT id, email FROM users")
+	}
+
+	require.NoError(t, CreateView(fake, "active_users", activeUsers))
+	require.EqualError(t, CreateView(fake, "active_users", activeUsers), "view active_users already exists")
+	require.NoError(t, CreateOrReplaceView(fake, "active_users", activeUsers))
+	require.NoError(t, DropView(fake, "active_users"))
+	require.EqualError(t, DropView(fake, "active_users"), "view active_users does not exist")
+
+	assert.Equal(t, []FakeOperation{
+		{
+			Name:       "CreateView",
+			Table:      "active_users",
+			Statements: []string{"CREATE VIEW active_users AS SELECT id, email FROM users"},
+		},
+		{
+			Name:       "CreateOrReplaceView",
+			Table:      "active_users",
+			Statements: []string{"CREATE OR REPLACE VIEW active_users AS SELECT id, email FROM users"},
+		},
+		{Name: "DropView", Table: "active_users", Statements: []string{"DROP VIEW active_users"}},
+	}, fake.Operations())
+}
+
+func TestFake_MaterializedViews(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	dailySales := func(view *ViewBlueprint) {
+		view.As("SELECT created_at::date AS day, COUNT(*) AS orders FROM orders GROUP BY 1")
+	}
+
+	require.NoError(t, CreateMaterializedView(fake, "daily_sales", dailySales))
+	require.EqualError(t, CreateMaterializedView(fake, "daily_sales", dailySales),
+		"materialized view daily_sales already exists")
+	require.NoError(t, RefreshMaterializedView(fake, "daily_sales", RefreshOptions{Concurrently: true}))
+	require.NoError(t, DropMaterializedView(fake, "daily_sales"))
+	require.EqualError(t, RefreshMaterializedView(fake, "daily_sales", RefreshOptions{}),
+		"materialized view daily_sales does not exist")
+
+	assert.Equal(t, []string{
+		"CREATE MATERIALIZED VIEW daily_sales AS " +
+			"SELECT created_at::date AS day, COUNT(*) AS orders FROM orders GROUP BY 1",
+		"REFRESH MATERIALIZED VIEW CONCURRENTLY daily_sales",
+		"DROP MATERIALIZED VIEW daily_sales",
+	}, fake.Statements())
+}
+
+func TestFake_Sequences(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	require.NoError(t, CreateSequence(fake, "invoice_number_seq", func(seq *SequenceBlueprint) {
+		seq.Start(1000)
+	}))
+	require.EqualError(t, CreateSequence(fake, "invoice_number_seq", nil), "sequence invoice_number_seq already exists")
+	require.NoError(t, AlterSequence(fake, "invoice_number_seq", func(seq *SequenceBlueprint) {
+		seq.Start(2000)
+	}))
+	require.NoError(t, DropSequence(fake, "invoice_number_seq"))
+	require.EqualError(t, DropSequence(fake, "invoice_number_seq"), "sequence invoice_number_seq does not exist")
+	require.EqualError(t, AlterSequence(fake, "invoice_number_seq", nil), "sequence invoice_number_seq does not exist")
+
+	assert.Equal(t, []string{
+		"CREATE SEQUENCE invoice_number_seq START WITH 1000",
+		"ALTER SEQUENCE invoice_number_seq RESTART WITH 2000",
+		"DROP SEQUENCE invoice_number_seq",
+	}, fake.Statements())
+}
+
+func TestFake_Schemas(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	require.NoError(t, CreateSchema(fake, "billing"))
+	require.EqualError(t, CreateSchema(fake, "billing"), "schema billing already exists")
+	exists, err := HasSchema(fake, "billing")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, Create(fake, "billing.invoices", func(table *Blueprint) {
+		table.ID()
+	}))
+	require.EqualError(t, DropSchema(fake, "billing", false), "schema billing is not empty")
+	require.NoError(t, DropSchema(fake, "billing", true))
+	require.EqualError(t, DropSchema(fake, "billing", true), "schema billing does not exist")
+
+	exists, err = HasSchema(fake, "billing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = HasTable(fake, "billing.invoices")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestFake_Extensions(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	require.NoError(t, CreateExtension(fake, "pgcrypto"))
+	require.EqualError(t, CreateExtension(fake, "pgcrypto"), "extension pgcrypto already exists")
+	enabled, err := HasExtension(fake, "pgcrypto")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	require.NoError(t, DropExtension(fake, "pgcrypto"))
+	require.EqualError(t, DropExtension(fake, "pgcrypto"), "extension pgcrypto does not exist")
+
+	assert.Equal(t, []string{`CREATE EXTENSION "pgcrypto"`, `DROP EXTENSION "pgcrypto"`}, fake.Statements())
+
+	mysql := newTestFake(t, "mysql")
+	require.ErrorIs(t, CreateExtension(mysql, "pgcrypto"), ErrUnsupportedFeature)
+}
+
+func TestFake_Partitions(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	require.NoError(t, Create(fake, "events", func(table *Blueprint) {
+		table.BigInteger("id")
+		table.Timestamp("created_at")
+		table.PartitionBy(PartitionRange, "created_at")
+		table.Partition("events_2025_01", RangeBounds("'2025-01-01'", "'2025-02-01'"))
+	}))
+	require.NoError(t, CreatePartition(fake, "events", "events_2025_02", RangeBounds("'2025-02-01'", "'2025-03-01'")))
+	require.EqualError(t, CreatePartition(fake, "logs", "logs_2025_02", RangeBounds("'2025-02-01'", "'2025-03-01'")),
+		"table logs does not exist")
+
+	assert.Equal(t, []string{
+		"CREATE TABLE events (id BIGINT NOT NULL, created_at TIMESTAMP(0) NOT NULL) PARTITION BY RANGE (created_at)",
+		"CREATE TABLE events_2025_01 PARTITION OF events FOR VALUES FROM ('2025-01-01') TO ('2025-02-01')",
+		"CREATE TABLE events_2025_02 PARTITION OF events FOR VALUES FROM ('2025-02-01') TO ('2025-03-01')",
+	}, fake.Statements())
+}
+
+func TestFake_EventTriggers(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	forbidDropTable := func(trigger *EventTriggerBlueprint) {
+		trigger.On("ddl_command_start")
+		trigger.Tags("DROP TABLE")
+		trigger.Execute("abort_drop_table")
+	}
+
+	require.NoError(t, CreateEventTrigger(fake, "forbid_drop_table", forbidDropTable))
+	require.EqualError(t, CreateEventTrigger(fake, "forbid_drop_table", forbidDropTable),
+		"event trigger forbid_drop_table already exists")
+	require.NoError(t, DropEventTrigger(fake, "forbid_drop_table"))
+	require.EqualError(t, DropEventTrigger(fake, "forbid_drop_table"),
+		"event trigger forbid_drop_table does not exist")
+
+	assert.Equal(t, []string{
+		"CREATE EVENT TRIGGER forbid_drop_table ON ddl_command_start WHEN TAG IN ('DROP TABLE') " +
+			"EXECUTE FUNCTION abort_drop_table()",
+		"DROP EVENT TRIGGER forbid_drop_table",
+	}, fake.Statements())
+
+	mysql := newTestFake(t, "mysql")
+	require.ErrorIs(t, CreateEventTrigger(mysql, "forbid_drop_table", forbidDropTable), ErrUnsupportedFeature)
+}
+
+func TestFake_Truncate(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	require.NoError(t, Truncate(fake, "users", TruncateOptions{RestartIdentity: true, Cascade: true}))
+	require.EqualError(t, Truncate(fake, "posts", TruncateOptions{}), "table posts does not exist")
+
+	assert.Equal(t, []string{"TRUNCATE TABLE users RESTART IDENTITY CASCADE"}, fake.Statements())
+}
+
+func TestFake_Triggers(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	setUpdatedAt := func(fn *FunctionBlueprint) {
+		fn.Returns("trigger")
+		fn.Body("BEGIN NEW.updated_at = now(); RETURN NEW; END;")
+	}
+	usersUpdatedAt := func(trigger *TriggerBlueprint) {
+		trigger.Before("UPDATE")
+		trigger.Execute("set_updated_at")
+	}
+
+	require.NoError(t, CreateFunction(fake, "set_updated_at", setUpdatedAt))
+	require.EqualError(t, CreateFunction(fake, "set_updated_at", setUpdatedAt),
+		"function set_updated_at already exists")
+	require.NoError(t, CreateTrigger(fake, "users_updated_at", "users", usersUpdatedAt))
+	require.EqualError(t, CreateTrigger(fake, "users_updated_at", "users", usersUpdatedAt),
+		"trigger users_updated_at on users already exists")
+	require.EqualError(t, CreateTrigger(fake, "posts_updated_at", "posts", usersUpdatedAt),
+		"table posts does not exist")
+	require.NoError(t, DropTrigger(fake, "users_updated_at", "users"))
+	require.EqualError(t, DropTrigger(fake, "users_updated_at", "users"),
+		"trigger users_updated_at on users does not exist")
+	require.NoError(t, DropFunction(fake, "set_updated_at"))
+	require.EqualError(t, DropFunction(fake, "set_updated_at"), "function set_updated_at does not exist")
+
+	assert.Equal(t, []string{
+		"CREATE FUNCTION set_updated_at() RETURNS trigger LANGUAGE plpgsql AS " +
+			"$function$BEGIN NEW.updated_at = now(); RETURN NEW; END;$function$",
+		"CREATE TRIGGER users_updated_at BEFORE UPDATE ON users FOR EACH ROW EXECUTE FUNCTION set_updated_at()",
+		"DROP TRIGGER users_updated_at ON users",
+		"DROP FUNCTION set_updated_at",
+	}, fake.Statements())
+}
+
+func TestFake_Table(t *testing.T) {
+	t.Run("alters the catalog", func(t *testing.T) {
+		fake := newTestFake(t, "pgx")
+
+		err := Table(fake, "users", func(table *Blueprint) {
+			table.String("phone").Nullable()
+			table.RenameColumn("name", "full_name")
+			table.DropUnique([]string{"email"})
+		})
+		require.NoError(t, err)
+
+		hasColumns, err := HasColumns(fake, "users", []string{"id", "full_name", "email", "phone"})
+		require.NoError(t, err)
+		assert.True(t, hasColumns)
+		hasColumn, err := HasColumn(fake, "users", "name")
+		require.NoError(t, err)
+		assert.False(t, hasColumn)
+		hasIndex, err := HasIndex(fake, "users", []string{"uk_users_email"})
+		require.NoError(t, err)
+		assert.False(t, hasIndex)
+	})
+
+	t.Run("resolves dropped indexes by their columns", func(t *testing.T) {
+		fake := newTestFake(t, "pgx")
+		require.NoError(t, Table(fake, "users", func(table *Blueprint) {
+			table.RenameIndex("uk_users_email", "users_email_key")
+		}))
+
+		err := Table(fake, "users", func(table *Blueprint) {
+			table.DropUnique([]string{"email"})
+		})
+		require.NoError(t, err)
+		assert.Contains(t, fake.Statements()[len(fake.Statements())-1], "users_email_key")
+	})
+
+	t.Run("dropping a column drops its indexes", func(t *testing.T) {
+		fake := newTestFake(t, "mysql")
+
+		require.NoError(t, Table(fake, "users", func(table *Blueprint) {
+			table.DropColumn("email")
+		}))
+		indexes, err := GetIndexes(fake, "users")
+		require.NoError(t, err)
+		require.Len(t, indexes, 1)
+		assert.True(t, indexes[0].Primary)
+	})
+
+	t.Run("fails like the database and keeps the catalog", func(t *testing.T) {
+		fake := newTestFake(t, "pgx")
+
+		err := Table(fake, "users", func(table *Blueprint) {
+			table.String("phone")
+			table.DropColumn("missing")
+		})
+		require.EqualError(t, err, "table users: column missing does not exist")
+		hasColumn, err := HasColumn(fake, "users", "phone")
+		require.NoError(t, err)
+		assert.False(t, hasColumn)
+		assert.Empty(t, fake.Operations())
+
+		err = Table(fake, "orders", func(table *Blueprint) {
+			table.String("status")
+		})
+		require.EqualError(t, err, "table orders does not exist")
+	})
+
+	t.Run("changes enum values", func(t *testing.T) {
+		fake := newTestFake(t, "mysql")
+		require.NoError(t, Table(fake, "users", func(table *Blueprint) {
+			table.Enum("status", []string{"active", "banned"})
+		}))
+
+		err := Table(fake, "users", func(table *Blueprint) {
+			table.AddEnumValue("status", "archived")
+		})
+		require.NoError(t, err)
+		columns, err := GetColumns(fake, "users")
+		require.NoError(t, err)
+		assert.Equal(t, "ENUM('active', 'banned', 'archived')", columns[len(columns)-1].TypeFull)
+	})
+}
+
+func TestFake_DropAndRename(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	require.NoError(t, Rename(fake, "users", "accounts"))
+	tables, err := GetTables(fake)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "accounts", tables[0].Name)
+
+	require.EqualError(t, Drop(fake, "users"), "table users does not exist")
+	require.NoError(t, DropIfExists(fake, "users"))
+	require.NoError(t, Drop(fake, "accounts"))
+
+	exists, err := HasTable(fake, "accounts")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, []string{
+		"ALTER TABLE users RENAME TO accounts",
+		"DROP TABLE IF EXISTS users",
+		"DROP TABLE accounts",
+	}, fake.Statements())
+}
+
+func TestFake_Context(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	_, err := fake.Exec(" UPDATE users SET name = 'x' ")
+	require.NoError(t, err)
+	assert.Equal(t, []FakeOperation{
+		{Name: "Exec", Statements: []string{"UPDATE users SET name = 'x'"}},
+	}, fake.Operations())
+
+	_, err = fake.Query("SELECT 1")
+	require.ErrorIs(t, err, errFakeQuery)
+	var n int
+	require.ErrorIs(t, fake.QueryRow("SELECT 1").Scan(&n), errFakeQuery)
+}
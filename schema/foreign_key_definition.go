@@ -1,6 +1,10 @@
 package schema
 
-import "github.com/akfaiz/migris/internal/util"
+import (
+	"strings"
+
+	"github.com/akfaiz/migris/internal/util"
+)
 
 // ForeignKeyDefinition defines the interface for defining a foreign key constraint in a database table.
 type ForeignKeyDefinition interface {
@@ -41,6 +45,21 @@ type foreignKeyDefinition struct {
 	*command
 }
 
+// foreignTableName guesses the table a foreign key column references: the plural of the column
+// name without the _id suffix, e.g. users for user_id and categories for category_id.
+func foreignTableName(column string) string {
+	name := strings.TrimSuffix(column, "_id")
+	switch {
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "z"),
+		strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
 func (fd *foreignKeyDefinition) CascadeOnDelete() ForeignKeyDefinition {
 	return fd.OnDelete("CASCADE")
 }
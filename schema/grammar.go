@@ -3,17 +3,73 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/akfaiz/migris/internal/dialect"
 	"github.com/akfaiz/migris/internal/util"
 )
 
+var nonIdentifierPattern = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// newGrammar returns the grammar of the given dialect.
+func newGrammar(dialectVal dialect.Dialect) grammar {
+	if dialectVal == dialect.MySQL {
+		return newMysqlGrammar()
+	}
+	return newPostgresGrammar()
+}
+
 type grammar interface {
 	CompileTableExists(schema string, table string) (string, error)
 	CompileTables(schema string) (string, error)
+	CompileViews(schema string) (string, error)
+	CompileViewExists(schema string, view string) (string, error)
+	CompileCurrentSchema() string
+	CompileDropAllTables(tables []string) (string, error)
+	CompileDisableForeignKeyConstraints() string
+	CompileEnableForeignKeyConstraints() string
+	CompileDeferConstraints() (string, error)
+	CompileEnumCheck(schema, table, column string) (string, error)
+	CompileEnumValues(blueprint *Blueprint, command *command) ([]string, error)
 	CompileColumns(schema, table string) (string, error)
 	CompileIndexes(schema, table string) (string, error)
+	CompileForeignKeys(schema, table string) (string, error)
+	CompileAllColumns() (string, error)
+	CompileAllIndexes() (string, error)
+	CompileTableDDL(schema, table string) (string, error)
+	CompileReorderColumns(ddl string, columns []string, options ReorderOptions) ([]string, error)
+	CompileCloneSchema(source, target string, options CloneOptions) (string, error)
+	CompileRenameSchema(from, to string) (string, error)
+	CompileCreateSchema(name string) (string, error)
+	CompileDropSchema(name string, cascade bool) (string, error)
+	CompileSchemaExists(name string) (string, error)
+	CompileCreateExtension(name string) (string, error)
+	CompileDropExtension(name string) (string, error)
+	CompileExtensionExists(name string) (string, error)
+	CompilePartitions(blueprint *Blueprint) ([]string, error)
+	CompileAlterOptions(blueprint *Blueprint, statements []string) ([]string, error)
+	CompileCreatePartition(parent, name string, bounds PartitionBounds) (string, error)
+	CompileCreateEnumType(name string, values []string) (string, error)
+	CompileDropEnumType(name string) (string, error)
+	CompileEnumTypeDDL(schema string) (string, error)
+	CompileCreateView(view *ViewBlueprint, orReplace bool) (string, error)
+	CompileDropView(name string) (string, error)
+	CompileCreateMaterializedView(view *ViewBlueprint) (string, error)
+	CompileRefreshMaterializedView(name string, options RefreshOptions) (string, error)
+	CompileDropMaterializedView(name string) (string, error)
+	CompileCreateFunction(fn *FunctionBlueprint) (string, error)
+	CompileDropFunction(name string) (string, error)
+	CompileCreateTrigger(trigger *TriggerBlueprint) (string, error)
+	CompileDropTrigger(name, table string) (string, error)
+	CompileCreateEventTrigger(trigger *EventTriggerBlueprint) (string, error)
+	CompileDropEventTrigger(name string) (string, error)
+	CompileCreateSequence(seq *SequenceBlueprint) (string, error)
+	CompileAlterSequence(seq *SequenceBlueprint) (string, error)
+	CompileDropSequence(name string) (string, error)
+	CompileEnumTypes(blueprint *Blueprint) []string
 	CompileCreate(bp *Blueprint) (string, error)
 	CompileAdd(bp *Blueprint) (string, error)
 	CompileChange(bp *Blueprint, command *command) (string, error)
@@ -26,15 +82,35 @@ type grammar interface {
 	CompileUnique(blueprint *Blueprint, command *command) (string, error)
 	CompilePrimary(blueprint *Blueprint, command *command) (string, error)
 	CompileFullText(blueprint *Blueprint, command *command) (string, error)
+	CompileJSONIndex(blueprint *Blueprint, command *command) (string, error)
 	CompileDropIndex(blueprint *Blueprint, command *command) (string, error)
 	CompileDropUnique(blueprint *Blueprint, command *command) (string, error)
 	CompileDropFulltext(blueprint *Blueprint, command *command) (string, error)
 	CompileDropPrimary(blueprint *Blueprint, command *command) (string, error)
 	CompileRenameIndex(blueprint *Blueprint, command *command) (string, error)
 	CompileForeign(blueprint *Blueprint, command *command) (string, error)
+	CompileCheck(blueprint *Blueprint, command *command) (string, error)
+	CompileDropCheck(blueprint *Blueprint, command *command) (string, error)
+	CompileInsert(table string, columns []string, rows int) string
+	CompileResetSequence(table, column string) string
+	CompileAnalyze(tables []string) (string, error)
+	CompileTruncate(table string, options TruncateOptions) (string, error)
+	CompileOwner(blueprint *Blueprint, owner string) string
+	// CompileTableComment compiles the statement setting the comment of the table, if the
+	// blueprint sets one that is not part of the CREATE TABLE statement.
+	CompileTableComment(blueprint *Blueprint) string
+	CompileDefaultPrivileges(privileges DefaultPrivileges) (string, error)
+	CompileCreateRenameLog(table string) string
+	CompileRenameLogEntry(table string, blueprint *Blueprint, command *command) string
 	CompileDropForeign(blueprint *Blueprint, command *command) (string, error)
 	GetFluentCommands() []func(blueprint *Blueprint, command *command) string
+	GetUnsupportedFeatures(blueprint *Blueprint) []string
+	GetIgnoredModifiers(blueprint *Blueprint) []string
 	CreateIndexName(blueprint *Blueprint, idxType string, columns ...string) string
+	CreateForeignKeyName(blueprint *Blueprint, command *command) string
+	// QuoteIdentifier quotes a column or table name, which may be qualified by a schema, so that
+	// reserved words such as order can be used as names.
+	QuoteIdentifier(name string) string
 }
 
 type baseGrammar struct{}
@@ -68,39 +144,215 @@ func (g *baseGrammar) CompileForeign(blueprint *Blueprint, command *command) (st
 	), nil
 }
 
+func (g *baseGrammar) CompileCheck(blueprint *Blueprint, command *command) (string, error) {
+	if command.check == "" {
+		return "", errors.New("check constraint condition cannot be empty")
+	}
+	index := command.index
+	if index == "" {
+		index = g.CreateIndexName(blueprint, "check", g.checkNameParts(command.check)...)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s)", blueprint.name, index, command.check), nil
+}
+
+func (g *baseGrammar) CompileRenameLogEntry(table string, blueprint *Blueprint, command *command) string {
+	return fmt.Sprintf("INSERT INTO %s (table_name, old_name, new_name) VALUES (%s, %s, %s)",
+		table,
+		g.QuoteString(blueprint.name),
+		g.QuoteString(command.from),
+		g.QuoteString(command.to),
+	)
+}
+
+func (g *baseGrammar) compileUpdateEnumValue(blueprint *Blueprint, command *command) string {
+	return fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s",
+		blueprint.name,
+		command.column.name,
+		g.QuoteString(command.to),
+		command.column.name,
+		g.QuoteString(command.from),
+	)
+}
+
 func (g *baseGrammar) CreateIndexName(blueprint *Blueprint, idxType string, columns ...string) string {
-	tableName := blueprint.name
-	if strings.Contains(tableName, ".") {
-		parts := strings.Split(tableName, ".")
-		tableName = parts[len(parts)-1] // Use the last part as the table name
-	}
-
-	switch idxType {
-	case "primary":
-		return fmt.Sprintf("pk_%s", tableName)
-	case "unique":
-		return fmt.Sprintf("uk_%s_%s", tableName, strings.Join(columns, "_"))
-	case "index":
-		return fmt.Sprintf("idx_%s_%s", tableName, strings.Join(columns, "_"))
-	case "fulltext":
-		return fmt.Sprintf("ft_%s_%s", tableName, strings.Join(columns, "_"))
-	default:
-		return ""
+	return blueprint.namingStrategy().IndexName(unqualifiedName(blueprint.name), idxType, columns)
+}
+
+// indexNameParts returns the names the generated name of an index is derived from: its columns,
+// or the identifiers of its expressions, e.g. lower_email for lower(email).
+func (g *baseGrammar) indexNameParts(command *command) []string {
+	if !command.expressions {
+		return command.columns
+	}
+	parts := make([]string, 0, len(command.columns))
+	for _, expression := range command.columns {
+		parts = append(parts, strings.Trim(nonIdentifierPattern.ReplaceAllString(strings.ToLower(expression), "_"), "_"))
+	}
+	return parts
+}
+
+// checkNameParts returns the identifiers of the condition of a check constraint that its generated
+// name is derived from, e.g. price for "price > 0".
+func (g *baseGrammar) checkNameParts(condition string) []string {
+	var parts []string
+	for _, part := range nonIdentifierPattern.Split(strings.ToLower(condition), -1) {
+		if strings.TrimLeft(part, "0123456789") != "" {
+			parts = append(parts, part)
+		}
 	}
+	return parts
+}
+
+// indexedColumns returns the column list of an index, with each expression of an expression
+// index in its own parentheses.
+func (g *baseGrammar) indexedColumns(command *command) string {
+	if !command.expressions {
+		return g.Columnize(command.columns)
+	}
+	return "(" + strings.Join(command.columns, "), (") + ")"
 }
 
 func (g *baseGrammar) CreateForeignKeyName(blueprint *Blueprint, command *command) string {
-	tableName := blueprint.name
-	if strings.Contains(tableName, ".") {
-		parts := strings.Split(tableName, ".")
-		tableName = parts[len(parts)-1] // Use the last part as the table name
+	return blueprint.namingStrategy().ForeignKeyName(
+		unqualifiedName(blueprint.name),
+		unqualifiedName(command.on),
+		command.columns,
+	)
+}
+
+// ignoredAlgorithms reports the index commands with the given names that set an algorithm
+// the grammar does not compile.
+func (g *baseGrammar) ignoredAlgorithms(blueprint *Blueprint, names ...string) []string {
+	var ignored []string
+	for _, cmd := range blueprint.commands {
+		if cmd.algorithm != "" && slices.Contains(names, cmd.name) {
+			ignored = append(ignored, fmt.Sprintf("algorithm %q on %s index", cmd.algorithm, cmd.name))
+		}
 	}
-	on := command.on
-	if strings.Contains(on, ".") {
-		parts := strings.Split(on, ".")
-		on = parts[len(parts)-1] // Use the last part as the column name
+	return ignored
+}
+
+// buildsConcurrently reports whether the index of the command is built without blocking writes.
+// A new table has no writes to wait for, so its indexes are never built concurrently.
+func (g *baseGrammar) buildsConcurrently(blueprint *Blueprint, command *command) bool {
+	return command.concurrently && !blueprint.creating()
+}
+
+// unsupportedConcurrently reports the indexes that should be built concurrently but whose
+// command is not one of the given names.
+func (g *baseGrammar) unsupportedConcurrently(blueprint *Blueprint, names ...string) []string {
+	var features []string
+	for _, cmd := range blueprint.commands {
+		if g.buildsConcurrently(blueprint, cmd) && !slices.Contains(names, cmd.name) {
+			features = append(features, fmt.Sprintf("concurrently on %s index", cmd.name))
+		}
+	}
+	return features
+}
+
+// unsupportedPartial reports the partial indexes whose command is not one of the given names.
+func (g *baseGrammar) unsupportedPartial(blueprint *Blueprint, names ...string) []string {
+	var features []string
+	for _, cmd := range blueprint.commands {
+		if cmd.where != "" && !slices.Contains(names, cmd.name) {
+			features = append(features, fmt.Sprintf("condition %q on %s index", cmd.where, cmd.name))
+		}
 	}
-	return fmt.Sprintf("fk_%s_%s", tableName, on)
+	return features
+}
+
+// jsonIndexTarget validates the column and JSON path of a JSON index command and returns the
+// keys of the path along with the name of the indexed value, e.g. data_customer_id for
+// the path $.customer_id of the column data.
+func (g *baseGrammar) jsonIndexTarget(command *command) ([]string, string, error) {
+	if len(command.columns) != 1 || command.columns[0] == "" {
+		return nil, "", errors.New("JSON index column cannot be empty")
+	}
+	keys, err := parseJSONPath(command.path)
+	if err != nil {
+		return nil, "", err
+	}
+	name := command.columns[0]
+	for _, key := range keys {
+		name += "_" + strings.ToLower(nonIdentifierPattern.ReplaceAllString(key, "_"))
+	}
+	return keys, name, nil
+}
+
+// parseJSONPath returns the keys of a simple JSON path such as $.customer.id or $.items[0].id.
+// Wildcards and filters are not supported.
+func parseJSONPath(path string) ([]string, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok || rest == "" {
+		return nil, fmt.Errorf("invalid JSON path %q: must start with $ and select a value", path)
+	}
+	var keys []string
+	for rest != "" {
+		var key string
+		switch {
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key, rest = rest[1:end+1], rest[end+1:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: unterminated array index", path)
+			}
+			key, rest = rest[1:end], rest[end+1:]
+			if _, err := strconv.Atoi(key); err != nil {
+				return nil, fmt.Errorf("invalid JSON path %q: array index must be a number", path)
+			}
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q", path)
+		}
+		if key == "" || strings.ContainsAny(key, "*?'\",{}") {
+			return nil, fmt.Errorf("invalid JSON path %q: only plain keys and array indexes are supported", path)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// compileInsert compiles a multi-row INSERT statement; placeholder returns the placeholder of
+// the n-th argument.
+func (g *baseGrammar) compileInsert(table string, columns []string, rows int, placeholder func(n int) string) string {
+	values := make([]string, rows)
+	for row := range rows {
+		placeholders := make([]string, len(columns))
+		for i := range columns {
+			placeholders[i] = placeholder(row*len(columns) + i + 1)
+		}
+		values[row] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, g.Columnize(columns), strings.Join(values, ", "))
+}
+
+// ignoredJSONChecks reports the JSON check constraints declared on changed columns, which are
+// only compiled for columns being created or added.
+func (g *baseGrammar) ignoredJSONChecks(blueprint *Blueprint) []string {
+	var ignored []string
+	for _, col := range blueprint.getChangedColumns() {
+		if col.hasJSONChecks() {
+			ignored = append(ignored, fmt.Sprintf("JSON check on changed column %s", col.name))
+		}
+	}
+	return ignored
+}
+
+// jsonCheck joins the given conditions into a check constraint.
+func (g *baseGrammar) jsonCheck(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " CHECK (" + strings.Join(conditions, " AND ") + ")"
+}
+
+// quoteLiteral quotes s as a SQL string literal, escaping embedded quotes.
+func (g *baseGrammar) quoteLiteral(s string) string {
+	return g.QuoteString(strings.ReplaceAll(s, "'", "''"))
 }
 
 func (g *baseGrammar) QuoteString(s string) string {
@@ -115,6 +367,16 @@ func (g *baseGrammar) PrefixArray(prefix string, items []string) []string {
 	return prefixed
 }
 
+// quoteIdentifier quotes each part of a qualified name with the quote character, doubling the
+// quote characters within it.
+func (g *baseGrammar) quoteIdentifier(name string, quote string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+	return strings.Join(parts, ".")
+}
+
 func (g *baseGrammar) Columnize(columns []string) string {
 	if len(columns) == 0 {
 		return ""
@@ -6,6 +6,11 @@ import "github.com/akfaiz/migris/internal/util"
 type IndexDefinition interface {
 	// Algorithm sets the algorithm for the index.
 	Algorithm(algorithm string) IndexDefinition
+	// Concurrently builds the index of an existing table without blocking writes to the table.
+	// PostgreSQL uses CREATE INDEX CONCURRENTLY, which cannot run in a transaction, and builds a
+	// unique index instead of a unique constraint. MySQL uses LOCK=NONE, which is not available
+	// for full-text and JSON indexes.
+	Concurrently() IndexDefinition
 	// Deferrable sets the index as deferrable.
 	Deferrable(value ...bool) IndexDefinition
 	// InitiallyImmediate sets the index to be initially immediate.
@@ -15,6 +20,15 @@ type IndexDefinition interface {
 	Language(language string) IndexDefinition
 	// Name sets the name of the index.
 	Name(name string) IndexDefinition
+	// NullsNotDistinct makes a unique index treat NULL values as equal, so at most one row can
+	// have NULL in the columns. It requires PostgreSQL 15 or later. MySQL always allows duplicate
+	// NULL values; index a generated column instead, e.g. StoredAs("COALESCE(email, '')").
+	NullsNotDistinct() IndexDefinition
+	// Where limits the index to the rows matching the condition, e.g. "deleted_at IS NULL".
+	// Used for partial indexes in PostgreSQL, where a unique index is built instead of a unique
+	// constraint, so drop a partial unique index with DropIndex. MySQL does not support partial
+	// indexes.
+	Where(condition string) IndexDefinition
 }
 
 type indexDefinition struct {
@@ -26,6 +40,11 @@ func (id *indexDefinition) Algorithm(algorithm string) IndexDefinition {
 	return id
 }
 
+func (id *indexDefinition) Concurrently() IndexDefinition {
+	id.concurrently = true
+	return id
+}
+
 func (id *indexDefinition) Deferrable(value ...bool) IndexDefinition {
 	val := util.Optional(true, value...)
 	id.deferrable = &val
@@ -47,3 +66,13 @@ func (id *indexDefinition) Name(name string) IndexDefinition {
 	id.index = name
 	return id
 }
+
+func (id *indexDefinition) NullsNotDistinct() IndexDefinition {
+	id.nullsNotDistinct = true
+	return id
+}
+
+func (id *indexDefinition) Where(condition string) IndexDefinition {
+	id.where = condition
+	return id
+}
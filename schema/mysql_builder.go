@@ -3,6 +3,7 @@ package schema
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -15,9 +16,43 @@ var _ Builder = (*mysqlBuilder)(nil)
 func newMysqlBuilder() Builder {
 	grammar := newMysqlGrammar()
 
-	return &mysqlBuilder{
+	builder := &mysqlBuilder{
 		baseBuilder: baseBuilder{grammar: grammar},
 	}
+	builder.tableChecker = builder
+	builder.columnChecker = builder
+	builder.indexLister = builder
+	builder.enumLister = builder
+
+	return builder
+}
+
+func (b *mysqlBuilder) DropAllTables(c Context) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	tables, err := b.GetTables(c)
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(tables))
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	query, err := b.grammar.CompileDropAllTables(names)
+	if err != nil {
+		return err
+	}
+
+	// Tables referenced by foreign keys can only be dropped with the foreign key checks disabled.
+	return b.WithoutForeignKeyConstraints(c, func() error {
+		_, err := c.Exec(query)
+		return err
+	})
 }
 
 func (b *mysqlBuilder) GetColumns(c Context, tableName string) ([]*Column, error) {
@@ -25,7 +60,8 @@ func (b *mysqlBuilder) GetColumns(c Context, tableName string) ([]*Column, error
 		return nil, errors.New("invalid arguments: context is nil or table name is empty")
 	}
 
-	query, err := b.grammar.CompileColumns("", tableName)
+	schema, name := splitQualifiedName(tableName)
+	query, err := b.grammar.CompileColumns(schema, name)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +101,8 @@ func (b *mysqlBuilder) GetIndexes(c Context, tableName string) ([]*Index, error)
 		return nil, errors.New("invalid arguments: context is nil or table name is empty")
 	}
 
-	query, err := b.grammar.CompileIndexes("", tableName)
+	schema, name := splitQualifiedName(tableName)
+	query, err := b.grammar.CompileIndexes(schema, name)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +121,7 @@ func (b *mysqlBuilder) GetIndexes(c Context, tableName string) ([]*Index, error)
 			return nil, err
 		}
 		idx.Columns = strings.Split(columnsStr, ",")
+		idx.Primary = idx.Name == "PRIMARY"
 		indexes = append(indexes, &idx)
 	}
 	if err = rows.Err(); err != nil {
@@ -93,6 +131,120 @@ func (b *mysqlBuilder) GetIndexes(c Context, tableName string) ([]*Index, error)
 	return indexes, nil
 }
 
+func (b *mysqlBuilder) GetForeignKeys(c Context, tableName string) ([]*ForeignKey, error) {
+	if c == nil || tableName == "" {
+		return nil, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	schema, name := splitQualifiedName(tableName)
+	query, err := b.grammar.CompileForeignKeys(schema, name)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []*ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		var columnsStr, foreignColumnsStr string
+		if err = rows.Scan(
+			&fk.Name, &columnsStr, &fk.ForeignSchema, &fk.ForeignTable, &foreignColumnsStr,
+			&fk.OnUpdate, &fk.OnDelete,
+		); err != nil {
+			return nil, err
+		}
+		fk.Columns = strings.Split(columnsStr, ",")
+		fk.ForeignColumns = strings.Split(foreignColumnsStr, ",")
+		foreignKeys = append(foreignKeys, &fk)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return foreignKeys, nil
+}
+
+func (b *mysqlBuilder) GetAllColumns(c Context) (map[string][]*Column, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileAllColumns()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string][]*Column)
+	for rows.Next() {
+		var table string
+		var col Column
+		var nullableStr string
+		if err = rows.Scan(
+			&table,
+			&col.Name, &col.TypeName, &col.TypeFull,
+			&col.Collation, &nullableStr,
+			&col.DefaultVal, &col.Comment,
+			&col.Extra,
+		); err != nil {
+			return nil, err
+		}
+		if nullableStr == "YES" {
+			col.Nullable = true
+		}
+		columns[table] = append(columns[table], &col)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+func (b *mysqlBuilder) GetAllIndexes(c Context) (map[string][]*Index, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileAllIndexes()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := make(map[string][]*Index)
+	for rows.Next() {
+		var table, columnsStr string
+		var idx Index
+		if err = rows.Scan(&table, &idx.Name, &columnsStr, &idx.Type, &idx.Unique); err != nil {
+			return nil, err
+		}
+		idx.Columns = strings.Split(columnsStr, ",")
+		idx.Primary = idx.Name == "PRIMARY"
+		indexes[table] = append(indexes[table], &idx)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
 func (b *mysqlBuilder) GetTables(c Context) ([]*TableInfo, error) {
 	if c == nil {
 		return nil, errors.New("invalid arguments: context is nil")
@@ -123,6 +275,82 @@ func (b *mysqlBuilder) GetTables(c Context) ([]*TableInfo, error) {
 	return tables, nil
 }
 
+func (b *mysqlBuilder) GetViews(c Context) ([]*ViewInfo, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileViews("")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []*ViewInfo
+	for rows.Next() {
+		var view ViewInfo
+		if err = rows.Scan(&view.Name, &view.Schema, &view.Definition); err != nil {
+			return nil, err
+		}
+		views = append(views, &view)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (b *mysqlBuilder) GetTableDDL(c Context, tableName string) (string, error) {
+	if c == nil || tableName == "" {
+		return "", errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	schema, name := splitQualifiedName(tableName)
+	query, err := b.grammar.CompileTableDDL(schema, name)
+	if err != nil {
+		return "", err
+	}
+
+	var table, ddl string
+	if err = c.QueryRow(query).Scan(&table, &ddl); err != nil {
+		return "", err
+	}
+	return ddl, nil
+}
+
+func (b *mysqlBuilder) ReorderColumns(c Context, table string, columns []string, options ReorderOptions) error {
+	if c == nil || table == "" || len(columns) == 0 {
+		return errors.New("invalid arguments: context is nil, table name or columns are empty")
+	}
+
+	ddl, err := b.GetTableDDL(c, table)
+	if err != nil {
+		return err
+	}
+	return b.reorderColumns(c, ddl, columns, options)
+}
+
+func (b *mysqlBuilder) GetSchemaDDL(c Context) ([]string, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	tables, err := b.GetTables(c)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(tables))
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	return schemaDDL(c, names, b.GetTableDDL)
+}
+
 func (b *mysqlBuilder) HasColumn(c Context, tableName string, columnName string) (bool, error) {
 	if c == nil || columnName == "" {
 		return false, errors.New("invalid arguments: context is nil or column name is empty")
@@ -204,7 +432,8 @@ func (b *mysqlBuilder) HasTable(c Context, name string) (bool, error) {
 		return false, errors.New("invalid arguments: context is nil or table name is empty")
 	}
 
-	query, err := b.grammar.CompileTableExists("", name)
+	schema, table := splitQualifiedName(name)
+	query, err := b.grammar.CompileTableExists(schema, table)
 	if err != nil {
 		return false, err
 	}
@@ -219,3 +448,54 @@ func (b *mysqlBuilder) HasTable(c Context, name string) (bool, error) {
 	}
 	return exists, nil // Return true if the table exists
 }
+
+func (b *mysqlBuilder) HasView(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or view name is empty")
+	}
+
+	schema, view := splitQualifiedName(name)
+	query, err := b.grammar.CompileViewExists(schema, view)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err = c.QueryRow(query).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return exists, nil
+}
+
+func (b *mysqlBuilder) getEnumColumn(c Context, tableName string, column string) (*columnDefinition, error) {
+	columns, err := b.GetColumns(c, tableName)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range columns {
+		if col.Name != column {
+			continue
+		}
+		if !strings.EqualFold(col.TypeName, "enum") {
+			return nil, fmt.Errorf("column %s of table %s is not an enum", column, tableName)
+		}
+		def := &columnDefinition{name: column, columnType: columnTypeEnum, allowed: parseEnumValues(col.TypeFull)}
+		def.Nullable(col.Nullable)
+		// MariaDB reports string defaults quoted and a missing default as NULL.
+		if col.DefaultVal.Valid && col.DefaultVal.String != "NULL" {
+			if strings.HasPrefix(col.DefaultVal.String, "'") {
+				def.Default(Expression(col.DefaultVal.String))
+			} else {
+				def.Default(col.DefaultVal.String)
+			}
+		}
+		if col.Comment.Valid && col.Comment.String != "" {
+			def.Comment(col.Comment.String)
+		}
+		return def, nil
+	}
+	return nil, fmt.Errorf("column %s does not exist in table %s", column, tableName)
+}
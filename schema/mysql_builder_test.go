@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/akfaiz/migris/schema"
@@ -213,6 +214,39 @@ func (s *mysqlBuilderSuite) TestDropIfExists() {
 	})
 }
 
+func (s *mysqlBuilderSuite) TestDropAllTables() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		err := builder.DropAllTables(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when tables reference each other, should drop all of them", func() {
+		err = builder.Create(c, "teams", func(table *schema.Blueprint) {
+			table.ID()
+		})
+		s.Require().NoError(err)
+		err = builder.Create(c, "members", func(table *schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("team_id")
+			table.Foreign("team_id").References("id").On("teams")
+		})
+		s.Require().NoError(err)
+
+		err = builder.DropAllTables(c)
+		s.Require().NoError(err, "expected no error when dropping all tables")
+
+		tables, err := builder.GetTables(c)
+		s.Require().NoError(err)
+		s.Empty(tables, "expected no tables after dropping all tables")
+	})
+}
+
 func (s *mysqlBuilderSuite) TestRename() {
 	builder := s.builder
 	tx, err := s.db.BeginTx(s.ctx, nil)
@@ -438,6 +472,91 @@ func (s *mysqlBuilderSuite) TestGetIndexes() {
 	})
 }
 
+func (s *mysqlBuilderSuite) TestGetForeignKeys() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetForeignKeys(nil, "posts")
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when table name is empty, should return error", func() {
+		_, err := builder.GetForeignKeys(c, "")
+		s.Require().Error(err, "expected error when table name is empty")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+		})
+		s.Require().NoError(err, "expected no error when creating users table")
+		err = builder.Create(c, "posts", func(table *schema.Blueprint) {
+			table.ID()
+			table.ForeignID("user_id").Constrained().CascadeOnDelete()
+		})
+		s.Require().NoError(err, "expected no error when creating posts table")
+
+		foreignKeys, err := builder.GetForeignKeys(c, "posts")
+		s.Require().NoError(err, "expected no error when getting foreign keys with valid parameters")
+		s.Require().Len(foreignKeys, 1, "expected 1 foreign key to be returned")
+		s.Equal("fk_posts_users", foreignKeys[0].Name)
+		s.Equal([]string{"user_id"}, foreignKeys[0].Columns)
+		s.Equal("users", foreignKeys[0].ForeignTable)
+		s.Equal([]string{"id"}, foreignKeys[0].ForeignColumns)
+		s.Equal("CASCADE", foreignKeys[0].OnDelete)
+		s.Equal("NO ACTION", foreignKeys[0].OnUpdate)
+	})
+	s.Run("when table does not exist, should return empty foreign keys", func() {
+		foreignKeys, err := builder.GetForeignKeys(c, "non_existent_table")
+		s.Require().NoError(err, "expected no error when getting foreign keys of non-existent table")
+		s.Empty(foreignKeys, "expected empty foreign keys for non-existent table")
+	})
+}
+
+func (s *mysqlBuilderSuite) TestGetAllColumnsAndIndexes() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetAllColumns(nil)
+		s.Require().Error(err, "expected error when context is nil")
+		_, err = builder.GetAllIndexes(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("should match the columns and indexes of each table", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("email", 255).Unique()
+		})
+		s.Require().NoError(err)
+		err = builder.Create(c, "posts", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("title", 255)
+		})
+		s.Require().NoError(err)
+
+		columns, err := builder.GetAllColumns(c)
+		s.Require().NoError(err)
+		indexes, err := builder.GetAllIndexes(c)
+		s.Require().NoError(err)
+		for _, table := range []string{"users", "posts"} {
+			tableColumns, err := builder.GetColumns(c, table)
+			s.Require().NoError(err)
+			s.Equal(tableColumns, columns[table])
+			tableIndexes, err := builder.GetIndexes(c, table)
+			s.Require().NoError(err)
+			s.ElementsMatch(tableIndexes, indexes[table])
+		}
+	})
+}
+
 func (s *mysqlBuilderSuite) TestGetTables() {
 	builder := s.builder
 	tx, err := s.db.BeginTx(s.ctx, nil)
@@ -475,6 +594,102 @@ func (s *mysqlBuilderSuite) TestGetTables() {
 	})
 }
 
+func (s *mysqlBuilderSuite) TestGetViews() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetViews(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name", 255)
+			table.Boolean("active")
+		})
+		s.Require().NoError(err, "expected no error when creating table before creating view")
+		err = builder.CreateView(c, "active_users", func(view *schema.ViewBlueprint) {
+			view.As("SELECT id, name FROM users WHERE active")
+		})
+		s.Require().NoError(err, "expected no error when creating view")
+
+		views, err := builder.GetViews(c)
+		s.Require().NoError(err, "expected no error when getting views with valid parameters")
+		s.Require().Len(views, 1, "expected 1 view to be returned")
+		s.Equal("active_users", views[0].Name, "expected view name to be 'active_users'")
+		s.Contains(views[0].Definition, "users", "expected view definition to select from users")
+	})
+}
+
+func (s *mysqlBuilderSuite) TestGetTableDDL() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetTableDDL(nil, "users")
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when table name is empty, should return error", func() {
+		_, err := builder.GetTableDDL(c, "")
+		s.Require().Error(err, "expected error when table name is empty")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name", 255)
+			table.String("email", 255).Unique()
+		})
+		s.Require().NoError(err, "expected no error when creating table before getting its DDL")
+
+		ddl, err := builder.GetTableDDL(c, "users")
+		s.Require().NoError(err, "expected no error when getting DDL of an existing table")
+		s.Contains(ddl, "CREATE TABLE `users`", "expected DDL to create the users table")
+		s.Contains(ddl, "UNIQUE KEY `uk_users_email` (`email`)", "expected DDL to contain the unique index")
+	})
+}
+
+func (s *mysqlBuilderSuite) TestGetSchemaDDL() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetSchemaDDL(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+		})
+		s.Require().NoError(err, "expected no error when creating users table")
+		err = builder.Create(c, "posts", func(table *schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("user_id")
+			table.Foreign("user_id").References("id").On("users")
+		})
+		s.Require().NoError(err, "expected no error when creating posts table")
+
+		statements, err := builder.GetSchemaDDL(c)
+		s.Require().NoError(err, "expected no error when getting the schema DDL")
+		s.Require().NotEmpty(statements, "expected statements for the existing tables")
+		s.Contains(statements, "ALTER TABLE `posts` ADD CONSTRAINT `fk_posts_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)", "expected the foreign key as a separate statement")
+		s.Equal("ALTER TABLE `posts` ADD CONSTRAINT `fk_posts_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)", statements[len(statements)-1], "expected foreign keys after all tables")
+		s.Contains(strings.Join(statements, "\n"), "CREATE TABLE `posts` (", "expected the posts table to be created")
+	})
+}
+
 func (s *mysqlBuilderSuite) TestHasColumn() {
 	builder := s.builder
 	tx, err := s.db.BeginTx(s.ctx, nil)
@@ -645,3 +860,37 @@ func (s *mysqlBuilderSuite) TestHasTable() {
 		s.False(exists, "expected exists to be false for non-existent table")
 	})
 }
+
+func (s *mysqlBuilderSuite) TestHasView() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when view name is empty, should return error", func() {
+		exists, err := builder.HasView(c, "")
+		s.Require().Error(err, "expected error when view name is empty")
+		s.False(exists, "expected exists to be false when view name is empty")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name", 255)
+		})
+		s.Require().NoError(err, "expected no error when creating table before creating view")
+		err = builder.CreateView(c, "user_names", func(view *schema.ViewBlueprint) {
+			view.As("SELECT name FROM users")
+		})
+		s.Require().NoError(err, "expected no error when creating view")
+
+		exists, err := builder.HasView(c, "user_names")
+		s.Require().NoError(err, "expected no error when checking if view exists")
+		s.True(exists, "expected exists to be true for existing view")
+
+		exists, err = builder.HasView(c, "users")
+		s.Require().NoError(err, "expected no error when checking a table")
+		s.False(exists, "expected exists to be false for a table")
+	})
+}
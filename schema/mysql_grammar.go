@@ -3,12 +3,24 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"math"
 	"slices"
 	"strings"
 
 	"github.com/akfaiz/migris/internal/util"
 )
 
+var mysqlIndexAlgorithms = []string{"BTREE", "HASH"}
+
+var (
+	mysqlAlterAlgorithms = []string{"DEFAULT", "INSTANT", "INPLACE", "COPY"}
+	mysqlAlterLocks      = []string{"DEFAULT", "NONE", "SHARED", "EXCLUSIVE"}
+)
+
+var mysqlGeometrySubtypes = []string{
+	"POINT", "LINESTRING", "POLYGON", "GEOMETRYCOLLECTION", "MULTIPOINT", "MULTILINESTRING",
+}
+
 type mysqlGrammar struct {
 	baseGrammar
 
@@ -35,18 +47,238 @@ func (g *mysqlGrammar) CompileTableExists(schema string, table string) (string,
 func (g *mysqlGrammar) CompileTables(schema string) (string, error) {
 	return fmt.Sprintf(
 		"select table_name as `name`, (data_length + index_length) as `size`, "+
-			"table_comment as `comment`, engine as `engine`, table_collation as `collation` "+
+			"nullif(table_comment, '') as `comment`, engine as `engine`, table_collation as `collation` "+
 			"from information_schema.tables where table_schema = %s and table_type in ('BASE TABLE', 'SYSTEM VERSIONED') "+
 			"order by table_name",
 		util.Ternary(schema != "", g.QuoteString(schema), "schema()"),
 	), nil
 }
 
+func (g *mysqlGrammar) CompileViews(schema string) (string, error) {
+	return fmt.Sprintf(
+		"select table_name as `name`, table_schema as `schema`, view_definition as `definition` "+
+			"from information_schema.views where table_schema = %s order by table_name",
+		util.Ternary(schema != "", g.QuoteString(schema), "schema()"),
+	), nil
+}
+
+func (g *mysqlGrammar) CompileViewExists(schema string, view string) (string, error) {
+	return fmt.Sprintf(
+		"SELECT 1 FROM information_schema.views WHERE table_schema = %s AND table_name = %s",
+		util.Ternary(schema != "", g.QuoteString(schema), "schema()"),
+		g.QuoteString(view),
+	), nil
+}
+
+func (g *mysqlGrammar) CompileCurrentSchema() string {
+	return "select schema()"
+}
+
+func (g *mysqlGrammar) CompileDropAllTables(tables []string) (string, error) {
+	if len(tables) == 0 || slices.Contains(tables, "") {
+		return "", errors.New("table names cannot be empty")
+	}
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s", strings.Join(tables, ", ")), nil
+}
+
+func (g *mysqlGrammar) CompileDisableForeignKeyConstraints() string {
+	return "SET FOREIGN_KEY_CHECKS=0"
+}
+
+func (g *mysqlGrammar) CompileEnableForeignKeyConstraints() string {
+	return "SET FOREIGN_KEY_CHECKS=1"
+}
+
+func (g *mysqlGrammar) CompileDeferConstraints() (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support deferred constraints", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileCloneSchema(_, _ string, _ CloneOptions) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support cloning schemas", ErrUnsupportedFeature)
+}
+
+// CompileReorderColumns returns the statement that moves the columns to their new order with
+// MODIFY COLUMN ... FIRST and AFTER, keeping the definitions returned by GetTableDDL. The columns
+// that are not listed keep their order after the listed ones.
+func (g *mysqlGrammar) CompileReorderColumns(ddl string, columns []string, options ReorderOptions) ([]string, error) {
+	table, definitions, err := parseCreateTable(ddl, '`')
+	if err != nil {
+		return nil, err
+	}
+	reordered, err := reorderColumns(definitions, columns)
+	if err != nil || !reordersColumns(definitions, reordered) {
+		return nil, err
+	}
+
+	modifications := make([]string, len(columns))
+	for i, def := range reordered[:len(columns)] {
+		position := " FIRST"
+		if i > 0 {
+			position = " AFTER " + reordered[i-1].name
+		}
+		modifications[i] = "MODIFY COLUMN " + def.definition + position
+	}
+	statement := fmt.Sprintf("ALTER TABLE %s %s", table, strings.Join(modifications, ", "))
+	if options.LockTimeout <= 0 {
+		return []string{statement}, nil
+	}
+	return []string{
+		fmt.Sprintf("SET SESSION lock_wait_timeout = %d", int64(math.Ceil(options.LockTimeout.Seconds()))),
+		statement,
+		"SET SESSION lock_wait_timeout = DEFAULT",
+	}, nil
+}
+
+func (g *mysqlGrammar) CompileRenameSchema(_, _ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support renaming schemas", ErrUnsupportedFeature)
+}
+
+// CompileCreateSchema creates a database, which MySQL calls a schema as well.
+func (g *mysqlGrammar) CompileCreateSchema(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("schema name cannot be empty")
+	}
+	return fmt.Sprintf("CREATE SCHEMA %s", name), nil
+}
+
+// CompileDropSchema drops a database. MySQL always drops the tables of the database with it, so
+// dropping without cascade is rejected instead of silently dropping them.
+func (g *mysqlGrammar) CompileDropSchema(name string, cascade bool) (string, error) {
+	if name == "" {
+		return "", errors.New("schema name cannot be empty")
+	}
+	if !cascade {
+		return "", fmt.Errorf("%w: mysql always drops the tables of a schema with it, drop it with cascade",
+			ErrUnsupportedFeature)
+	}
+	return fmt.Sprintf("DROP SCHEMA %s", name), nil
+}
+
+func (g *mysqlGrammar) CompileSchemaExists(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("schema name cannot be empty")
+	}
+	return fmt.Sprintf("SELECT 1 FROM information_schema.schemata WHERE schema_name = %s", g.QuoteString(name)), nil
+}
+
+func (g *mysqlGrammar) CompileCreateExtension(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support extensions", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileDropExtension(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support extensions", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileExtensionExists(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support extensions", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileCreateEnumType(_ string, _ []string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support enum types", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileDropEnumType(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support enum types", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileEnumTypeDDL(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support enum types", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileCreateMaterializedView(_ *ViewBlueprint) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support materialized views", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileRefreshMaterializedView(_ string, _ RefreshOptions) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support materialized views", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileDropMaterializedView(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support materialized views", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileCreateSequence(_ *SequenceBlueprint) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support sequences", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileAlterSequence(_ *SequenceBlueprint) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support sequences", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileDropSequence(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support sequences", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileCreateFunction(fn *FunctionBlueprint) (string, error) {
+	signature, err := g.functionSignature(fn)
+	if err != nil {
+		return "", err
+	}
+	if fn.language != "" && !strings.EqualFold(fn.language, "sql") {
+		return "", fmt.Errorf("%w: mysql does not support functions in %s", ErrUnsupportedFeature, fn.language)
+	}
+	deterministic := ""
+	if fn.deterministic {
+		deterministic = " DETERMINISTIC"
+	}
+	return fmt.Sprintf("CREATE FUNCTION %s%s %s", signature, deterministic, fn.body), nil
+}
+
+func (g *mysqlGrammar) CompileCreateTrigger(trigger *TriggerBlueprint) (string, error) {
+	events, err := g.triggerEvents(trigger)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case trigger.timing == "INSTEAD OF":
+		return "", fmt.Errorf("%w: mysql does not support INSTEAD OF triggers", ErrUnsupportedFeature)
+	case len(events) > 1:
+		return "", fmt.Errorf("%w: mysql does not support triggers on more than one event", ErrUnsupportedFeature)
+	case events[0] == "TRUNCATE":
+		return "", fmt.Errorf("%w: mysql does not support TRUNCATE triggers", ErrUnsupportedFeature)
+	case trigger.forEachStatement:
+		return "", fmt.Errorf("%w: mysql does not support statement-level triggers", ErrUnsupportedFeature)
+	case trigger.when != "":
+		return "", fmt.Errorf("%w: mysql does not support trigger conditions, use IF in the body",
+			ErrUnsupportedFeature)
+	case trigger.function != "":
+		return "", fmt.Errorf("trigger %s: mysql triggers run a body, call Body instead of Execute", trigger.name)
+	case strings.TrimSpace(trigger.body) == "":
+		return "", fmt.Errorf("trigger %s has no body, call Body", trigger.name)
+	}
+	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW %s",
+		trigger.name, trigger.timing, events[0], trigger.table, trigger.body), nil
+}
+
+func (g *mysqlGrammar) CompileDropTrigger(name, _ string) (string, error) {
+	if name == "" {
+		return "", errors.New("trigger name cannot be empty")
+	}
+	return fmt.Sprintf("DROP TRIGGER %s", name), nil
+}
+
+func (g *mysqlGrammar) CompileCreateEventTrigger(_ *EventTriggerBlueprint) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support event triggers", ErrUnsupportedFeature)
+}
+
+func (g *mysqlGrammar) CompileDropEventTrigger(_ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support event triggers", ErrUnsupportedFeature)
+}
+
+// CompileEnumTypes returns no statements, as MySQL enum columns are native already.
+func (g *mysqlGrammar) CompileEnumTypes(_ *Blueprint) []string {
+	return nil
+}
+
+func (g *mysqlGrammar) CompileEnumCheck(_, _, _ string) (string, error) {
+	return "", fmt.Errorf("%w: mysql enum columns have no check constraint", ErrUnsupportedFeature)
+}
+
 func (g *mysqlGrammar) CompileColumns(schema, table string) (string, error) {
 	return fmt.Sprintf(
 		"select column_name as `name`, data_type as `type_name`, column_type as `type`, "+
 			"collation_name as `collation`, is_nullable as `nullable`, "+
-			"column_default as `default`, column_comment as `comment`, extra as `extra` "+
+			"column_default as `default`, nullif(column_comment, '') as `comment`, extra as `extra` "+
 			"from information_schema.columns where table_schema = %s and table_name = %s "+
 			"order by ordinal_position asc",
 		util.Ternary(schema != "", g.QuoteString(schema), "schema()"),
@@ -65,14 +297,206 @@ func (g *mysqlGrammar) CompileIndexes(schema, table string) (string, error) {
 	), nil
 }
 
+func (g *mysqlGrammar) CompileForeignKeys(schema, table string) (string, error) {
+	return fmt.Sprintf(
+		"select kc.constraint_name as `name`, "+
+			"group_concat(kc.column_name order by kc.ordinal_position) as `columns`, "+
+			"kc.referenced_table_schema as `foreign_schema`, kc.referenced_table_name as `foreign_table`, "+
+			"group_concat(kc.referenced_column_name order by kc.ordinal_position) as `foreign_columns`, "+
+			"rc.update_rule as `on_update`, rc.delete_rule as `on_delete` "+
+			"from information_schema.key_column_usage kc join information_schema.referential_constraints rc "+
+			"on kc.constraint_schema = rc.constraint_schema and kc.constraint_name = rc.constraint_name "+
+			"where kc.table_schema = %s and kc.table_name = %s and kc.referenced_table_name is not null "+
+			"group by kc.constraint_name, kc.referenced_table_schema, kc.referenced_table_name, "+
+			"rc.update_rule, rc.delete_rule "+
+			"order by kc.constraint_name",
+		util.Ternary(schema != "", g.QuoteString(schema), "schema()"),
+		g.QuoteString(table),
+	), nil
+}
+
+// CompileAllColumns compiles the query for the columns of every table of the current database, like
+// CompileColumns, led by the name of the table.
+func (g *mysqlGrammar) CompileAllColumns() (string, error) {
+	return "select c.table_name as `table_name`, " +
+		"c.column_name as `name`, c.data_type as `type_name`, c.column_type as `type`, " +
+		"c.collation_name as `collation`, c.is_nullable as `nullable`, " +
+		"c.column_default as `default`, nullif(c.column_comment, '') as `comment`, c.extra as `extra` " +
+		"from information_schema.columns c join information_schema.tables t " +
+		"on t.table_schema = c.table_schema and t.table_name = c.table_name " +
+		"where c.table_schema = schema() and t.table_type in ('BASE TABLE', 'SYSTEM VERSIONED') " +
+		"order by c.table_name, c.ordinal_position", nil
+}
+
+// CompileAllIndexes compiles the query for the indexes of every table of the current database, like
+// CompileIndexes, led by the name of the table.
+func (g *mysqlGrammar) CompileAllIndexes() (string, error) {
+	return "select table_name as `table_name`, " +
+		"index_name as `name`, group_concat(column_name order by seq_in_index) as `columns`, " +
+		"index_type as `type`, not non_unique as `unique` " +
+		"from information_schema.statistics where table_schema = schema() " +
+		"group by table_name, index_name, index_type, non_unique " +
+		"order by table_name, index_name", nil
+}
+
+func (g *mysqlGrammar) CompileTableDDL(schema, table string) (string, error) {
+	quote := func(s string) string {
+		return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+	}
+	if schema != "" {
+		return fmt.Sprintf("SHOW CREATE TABLE %s.%s", quote(schema), quote(table)), nil
+	}
+	return fmt.Sprintf("SHOW CREATE TABLE %s", quote(table)), nil
+}
+
 func (g *mysqlGrammar) CompileCreate(blueprint *Blueprint) (string, error) {
 	sql, err := g.compileCreateTable(blueprint)
 	if err != nil {
 		return "", err
 	}
 	sql = g.compileCreateEncoding(sql, blueprint)
+	sql = g.compileCreateEngine(sql, blueprint)
+
+	return g.compileCreatePartitions(sql, blueprint)
+}
+
+// mysqlPartitionMethods are the partitioning types used for the partition methods. The COLUMNS
+// variants of RANGE and LIST, and KEY instead of HASH, accept columns of any type instead of an
+// integer expression.
+var mysqlPartitionMethods = map[PartitionMethod]string{
+	PartitionRange: "RANGE COLUMNS",
+	PartitionList:  "LIST COLUMNS",
+	PartitionHash:  "KEY",
+}
+
+// compileCreatePartitions appends the PARTITION BY clause, with the partitions declared with
+// Partition, to the CREATE TABLE statement.
+func (g *mysqlGrammar) compileCreatePartitions(sql string, blueprint *Blueprint) (string, error) {
+	if blueprint.partitionMethod == "" {
+		return sql, nil
+	}
+	sql += fmt.Sprintf(" PARTITION BY %s (%s)", mysqlPartitionMethods[blueprint.partitionMethod],
+		g.Columnize(blueprint.partitionColumns))
+	if len(blueprint.partitions) == 0 {
+		if blueprint.partitionMethod != PartitionHash {
+			return "", fmt.Errorf("table %s: mysql requires the partitions of a %s partitioned table, call Partition",
+				blueprint.name, blueprint.partitionMethod)
+		}
+		return sql, nil
+	}
+	partitions := make([]string, 0, len(blueprint.partitions))
+	for _, partition := range blueprint.partitions {
+		definition, err := g.partitionDefinition(partition.name, partition.bounds)
+		if err != nil {
+			return "", err
+		}
+		partitions = append(partitions, definition)
+	}
+	return fmt.Sprintf("%s (%s)", sql, strings.Join(partitions, ", ")), nil
+}
+
+// CompileAlterOptions adds the ALGORITHM and LOCK clauses set with Algorithm and Lock to the
+// statements altering the table and creating its indexes. They replace the LOCK=NONE of indexes
+// built concurrently.
+func (g *mysqlGrammar) CompileAlterOptions(blueprint *Blueprint, statements []string) ([]string, error) {
+	if blueprint.algorithm == "" && blueprint.lock == "" {
+		return statements, nil
+	}
+	algorithm, err := g.normalizeAlterOption("algorithm", blueprint.algorithm, mysqlAlterAlgorithms)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := g.normalizeAlterOption("lock", blueprint.lock, mysqlAlterLocks)
+	if err != nil {
+		return nil, err
+	}
+	var clauses []string
+	if algorithm != "" {
+		clauses = append(clauses, "ALGORITHM="+algorithm)
+	}
+	if lock != "" {
+		clauses = append(clauses, "LOCK="+lock)
+	}
+
+	altered := make([]string, len(statements))
+	for i, sql := range statements {
+		switch {
+		case strings.HasPrefix(sql, "ALTER TABLE "+blueprint.name+" "):
+			sql += ", " + strings.Join(clauses, ", ")
+		case isMysqlCreateIndex(sql):
+			if lock != "" {
+				sql = strings.TrimSuffix(sql, " LOCK=NONE")
+			}
+			sql += " " + strings.Join(clauses, " ")
+		}
+		altered[i] = sql
+	}
+	return altered, nil
+}
+
+// normalizeAlterOption validates the ALGORITHM or LOCK of an ALTER TABLE and returns it in upper
+// case.
+func (g *mysqlGrammar) normalizeAlterOption(option, value string, allowed []string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(value)
+	if !slices.Contains(allowed, normalized) {
+		return "", fmt.Errorf("invalid %s %q for mysql: must be one of %s", option, value, strings.Join(allowed, ", "))
+	}
+	return normalized, nil
+}
+
+// isMysqlCreateIndex reports whether the statement creates an index.
+func isMysqlCreateIndex(sql string) bool {
+	for _, prefix := range []string{"CREATE INDEX ", "CREATE UNIQUE INDEX ", "CREATE FULLTEXT INDEX "} {
+		if strings.HasPrefix(sql, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompilePartitions returns no statements, as the partitions are part of CREATE TABLE.
+func (g *mysqlGrammar) CompilePartitions(_ *Blueprint) ([]string, error) {
+	return nil, nil
+}
+
+// CompileCreatePartition adds a RANGE or LIST partition to a partitioned table. Hash partitions
+// cannot be added by name.
+func (g *mysqlGrammar) CompileCreatePartition(parent, name string, bounds PartitionBounds) (string, error) {
+	if err := checkPartitionNames(parent, name); err != nil {
+		return "", err
+	}
+	if bounds.method == PartitionHash {
+		return "", fmt.Errorf("%w: mysql cannot add a hash partition by name, use ALTER TABLE %s ADD PARTITION "+
+			"PARTITIONS n", ErrUnsupportedFeature, parent)
+	}
+	definition, err := g.partitionDefinition(name, bounds)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD PARTITION (%s)", parent, definition), nil
+}
 
-	return g.compileCreateEngine(sql, blueprint), nil
+// partitionDefinition returns the definition of a partition in a PARTITION BY clause.
+func (g *mysqlGrammar) partitionDefinition(name string, bounds PartitionBounds) (string, error) {
+	switch bounds.method {
+	case PartitionRange:
+		if bounds.to == "" {
+			return "", fmt.Errorf("range partition %s has no upper bound, use MAXVALUE for an unbounded one", name)
+		}
+		return fmt.Sprintf("PARTITION %s VALUES LESS THAN (%s)", name, bounds.to), nil
+	case PartitionList:
+		if len(bounds.values) == 0 {
+			return "", fmt.Errorf("list partition %s has no values", name)
+		}
+		return fmt.Sprintf("PARTITION %s VALUES IN (%s)", name, strings.Join(bounds.values, ", ")), nil
+	case PartitionHash:
+		return fmt.Sprintf("PARTITION %s", name), nil
+	default:
+		return "", fmt.Errorf("partition %s has no bounds, use RangeBounds, ListBounds or HashBounds", name)
+	}
 }
 
 func (g *mysqlGrammar) compileCreateTable(blueprint *Blueprint) (string, error) {
@@ -83,8 +507,53 @@ func (g *mysqlGrammar) compileCreateTable(blueprint *Blueprint) (string, error)
 
 	constraints := g.getConstraints(blueprint)
 	columns = append(columns, constraints...)
+	if blueprint.inlineIndexes {
+		indexes, err := g.getInlineIndexes(blueprint)
+		if err != nil {
+			return "", err
+		}
+		columns = append(columns, indexes...)
+	}
 
-	return fmt.Sprintf("CREATE TABLE %s (%s)", blueprint.name, strings.Join(columns, ", ")), nil
+	return fmt.Sprintf("%s %s (%s)", blueprint.createKeyword(), blueprint.name, strings.Join(columns, ", ")), nil
+}
+
+// getInlineIndexes compiles the index, unique and fulltext commands of the blueprint as
+// KEY clauses of the CREATE TABLE statement and marks them to be skipped afterwards.
+func (g *mysqlGrammar) getInlineIndexes(blueprint *Blueprint) ([]string, error) {
+	keywords := map[string]string{
+		commandIndex:    "KEY",
+		commandUnique:   "UNIQUE KEY",
+		commandFullText: "FULLTEXT KEY",
+	}
+	// Commands skipped by an earlier compilation of the blueprint are inlined again.
+	var indexes []string
+	for _, cmd := range blueprint.commands {
+		keyword, ok := keywords[cmd.name]
+		if !ok {
+			continue
+		}
+		if slices.Contains(cmd.columns, "") {
+			return nil, fmt.Errorf("%s column cannot be empty", cmd.name)
+		}
+		indexName := cmd.index
+		if indexName == "" {
+			indexName = g.CreateIndexName(blueprint, strings.ToLower(cmd.name), g.indexNameParts(cmd)...)
+		}
+		sql := fmt.Sprintf("%s %s (%s)", keyword, indexName, g.indexedColumns(cmd))
+		if cmd.name != commandFullText {
+			algorithm, err := g.normalizeAlgorithm(cmd.algorithm)
+			if err != nil {
+				return nil, err
+			}
+			if algorithm != "" {
+				sql += fmt.Sprintf(" USING %s", algorithm)
+			}
+		}
+		indexes = append(indexes, sql)
+		cmd.shouldBeSkipped = true
+	}
+	return indexes, nil
 }
 
 func (g *mysqlGrammar) compileCreateEncoding(sql string, blueprint *Blueprint) string {
@@ -102,9 +571,25 @@ func (g *mysqlGrammar) compileCreateEngine(sql string, blueprint *Blueprint) str
 	if blueprint.engine != "" {
 		sql += fmt.Sprintf(" ENGINE = %s", blueprint.engine)
 	}
+	if blueprint.comment != nil {
+		sql += " COMMENT = " + g.quoteTableComment(blueprint)
+	}
 	return sql
 }
 
+// CompileTableComment compiles the ALTER TABLE statement setting the comment of an existing
+// table. New tables get the comment in their CREATE TABLE statement.
+func (g *mysqlGrammar) CompileTableComment(blueprint *Blueprint) string {
+	if blueprint.comment == nil || blueprint.creating() {
+		return ""
+	}
+	return fmt.Sprintf("ALTER TABLE %s COMMENT = %s", blueprint.name, g.quoteTableComment(blueprint))
+}
+
+func (g *mysqlGrammar) quoteTableComment(blueprint *Blueprint) string {
+	return g.QuoteString(strings.ReplaceAll(*blueprint.comment, "'", "''"))
+}
+
 func (g *mysqlGrammar) CompileAdd(blueprint *Blueprint) (string, error) {
 	if len(blueprint.getAddedColumns()) == 0 {
 		return "", nil
@@ -137,9 +622,48 @@ func (g *mysqlGrammar) CompileChange(bp *Blueprint, command *command) (string, e
 		sqlBuilder.WriteString(modifier(column))
 	}
 	sql += sqlBuilder.String()
+	// An auto-increment column must be a key, so the primary key is added in the same statement.
+	if column.primary != nil && *column.primary {
+		sql += fmt.Sprintf(", ADD CONSTRAINT %s PRIMARY KEY (%s)", g.CreateIndexName(bp, "primary"), column.name)
+	}
 	return sql, nil
 }
 
+// CompileEnumValues regenerates the ENUM type of the column with the new allowed values.
+func (g *mysqlGrammar) CompileEnumValues(blueprint *Blueprint, command *command) ([]string, error) {
+	values, err := enumValuesAfter(command)
+	if err != nil {
+		return nil, err
+	}
+	modify := func(allowed []string) (string, error) {
+		column := *command.column
+		column.allowed = allowed
+		column.change = true
+		changed := *command
+		changed.column = &column
+		return g.CompileChange(blueprint, &changed)
+	}
+
+	if command.name != commandRenameEnumValue {
+		sql, err := modify(values)
+		if err != nil {
+			return nil, err
+		}
+		return []string{sql}, nil
+	}
+
+	// Allow both values while the rows are updated, then remove the old value.
+	widen, err := modify(append(slices.Clone(command.column.allowed), command.to))
+	if err != nil {
+		return nil, err
+	}
+	narrow, err := modify(values)
+	if err != nil {
+		return nil, err
+	}
+	return []string{widen, g.compileUpdateEnumValue(blueprint, command), narrow}, nil
+}
+
 func (g *mysqlGrammar) CompileRename(blueprint *Blueprint, command *command) (string, error) {
 	return fmt.Sprintf("ALTER TABLE %s RENAME TO %s", blueprint.name, command.to), nil
 }
@@ -180,6 +704,12 @@ func (g *mysqlGrammar) CompileRenameColumn(blueprint *Blueprint, command *comman
 	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", blueprint.name, command.from, command.to), nil
 }
 
+func (g *mysqlGrammar) CompileCreateRenameLog(table string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY, "+
+		"table_name VARCHAR(255) NOT NULL, old_name VARCHAR(255) NOT NULL, new_name VARCHAR(255) NOT NULL, "+
+		"renamed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)", table)
+}
+
 func (g *mysqlGrammar) CompileIndex(blueprint *Blueprint, command *command) (string, error) {
 	if slices.Contains(command.columns, "") {
 		return "", errors.New("index column cannot be empty")
@@ -187,14 +717,86 @@ func (g *mysqlGrammar) CompileIndex(blueprint *Blueprint, command *command) (str
 
 	indexName := command.index
 	if indexName == "" {
-		indexName = g.CreateIndexName(blueprint, "index", command.columns...)
+		indexName = g.CreateIndexName(blueprint, "index", g.indexNameParts(command)...)
+	}
+
+	algorithm, err := g.normalizeAlgorithm(command.algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	// MySQL accepts the index type after the column list.
+	sql := fmt.Sprintf("CREATE INDEX %s ON %s (%s)", indexName, blueprint.name, g.indexedColumns(command))
+	if algorithm != "" {
+		sql += fmt.Sprintf(" USING %s", algorithm)
+	}
+	sql += g.lockNone(blueprint, command)
+
+	return sql, nil
+}
+
+func (g *mysqlGrammar) CompileInsert(table string, columns []string, rows int) string {
+	return g.compileInsert(table, columns, rows, func(int) string { return "?" })
+}
+
+func (g *mysqlGrammar) QuoteIdentifier(name string) string {
+	return g.quoteIdentifier(name, "`")
+}
+
+// CompileResetSequence returns no statement: MySQL moves the AUTO_INCREMENT counter past
+// explicitly inserted values.
+func (g *mysqlGrammar) CompileResetSequence(_, _ string) string {
+	return ""
+}
+
+func (g *mysqlGrammar) CompileAnalyze(tables []string) (string, error) {
+	if len(tables) == 0 || slices.Contains(tables, "") {
+		return "", errors.New("tables must not be empty")
 	}
+	return "ANALYZE TABLE " + strings.Join(tables, ", "), nil
+}
+
+// CompileTruncate ignores RestartIdentity: MySQL always resets the AUTO_INCREMENT counter of a
+// truncated table.
+func (g *mysqlGrammar) CompileTruncate(table string, options TruncateOptions) (string, error) {
+	if table == "" {
+		return "", errors.New("table name cannot be empty")
+	}
+	if options.Cascade {
+		return "", fmt.Errorf("%w: mysql cannot truncate the tables referencing a table", ErrUnsupportedFeature)
+	}
+	return "TRUNCATE TABLE " + table, nil
+}
+
+// CompileOwner returns no statement: MySQL tables have no owner.
+func (g *mysqlGrammar) CompileOwner(_ *Blueprint, _ string) string {
+	return ""
+}
 
-	sql := fmt.Sprintf("CREATE INDEX %s ON %s (%s)", indexName, blueprint.name, g.Columnize(command.columns))
-	if command.algorithm != "" {
-		sql += fmt.Sprintf(" USING %s", command.algorithm)
+func (g *mysqlGrammar) CompileDefaultPrivileges(_ DefaultPrivileges) (string, error) {
+	return "", fmt.Errorf("%w: mysql does not support default privileges", ErrUnsupportedFeature)
+}
+
+// CompileJSONIndex adds a virtual generated column extracting the JSON value and indexes it.
+func (g *mysqlGrammar) CompileJSONIndex(blueprint *Blueprint, command *command) (string, error) {
+	_, column, err := g.jsonIndexTarget(command)
+	if err != nil {
+		return "", err
+	}
+	indexName := command.index
+	if indexName == "" {
+		indexName = g.CreateIndexName(blueprint, "index", column)
+	}
+	algorithm, err := g.normalizeAlgorithm(command.algorithm)
+	if err != nil {
+		return "", err
 	}
 
+	sql := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s VARCHAR(255) AS (JSON_UNQUOTE(JSON_EXTRACT(%s, %s))) VIRTUAL, "+
+		"ADD INDEX %s (%s)", blueprint.name, column, command.columns[0], g.quoteLiteral(command.path), indexName, column)
+	if algorithm != "" {
+		sql += fmt.Sprintf(" USING %s", algorithm)
+	}
 	return sql, nil
 }
 
@@ -205,16 +807,34 @@ func (g *mysqlGrammar) CompileUnique(blueprint *Blueprint, command *command) (st
 
 	indexName := command.index
 	if indexName == "" {
-		indexName = g.CreateIndexName(blueprint, "unique", command.columns...)
+		indexName = g.CreateIndexName(blueprint, "unique", g.indexNameParts(command)...)
+	}
+	algorithm, err := g.normalizeAlgorithm(command.algorithm)
+	if err != nil {
+		return "", err
 	}
-	sql := fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", indexName, blueprint.name, g.Columnize(command.columns))
-	if command.algorithm != "" {
-		sql += fmt.Sprintf(" USING %s", command.algorithm)
+	sql := fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", indexName, blueprint.name, g.indexedColumns(command))
+	if algorithm != "" {
+		sql += fmt.Sprintf(" USING %s", algorithm)
 	}
+	sql += g.lockNone(blueprint, command)
 
 	return sql, nil
 }
 
+// normalizeAlgorithm validates the index algorithm and returns it in upper case.
+func (g *mysqlGrammar) normalizeAlgorithm(algorithm string) (string, error) {
+	if algorithm == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(algorithm)
+	if !slices.Contains(mysqlIndexAlgorithms, normalized) {
+		return "", fmt.Errorf("invalid index algorithm %q for mysql: must be one of %s",
+			algorithm, strings.Join(mysqlIndexAlgorithms, ", "))
+	}
+	return normalized, nil
+}
+
 func (g *mysqlGrammar) CompileFullText(blueprint *Blueprint, command *command) (string, error) {
 	if slices.Contains(command.columns, "") {
 		return "", errors.New("fulltext index column cannot be empty")
@@ -287,10 +907,99 @@ func (g *mysqlGrammar) CompileDropForeign(blueprint *Blueprint, command *command
 	return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s", blueprint.name, command.index), nil
 }
 
+func (g *mysqlGrammar) CompileDropCheck(blueprint *Blueprint, command *command) (string, error) {
+	if command.index == "" {
+		return "", errors.New("check constraint name cannot be empty")
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CHECK %s", blueprint.name, command.index), nil
+}
+
 func (g *mysqlGrammar) GetFluentCommands() []func(*Blueprint, *command) string {
 	return []func(*Blueprint, *command) string{}
 }
 
+func (g *mysqlGrammar) GetUnsupportedFeatures(blueprint *Blueprint) []string {
+	var features []string
+	if blueprint.owner != "" {
+		features = append(features, fmt.Sprintf("table owner %q", blueprint.owner))
+	}
+	for _, col := range blueprint.columns {
+		if col.array {
+			features = append(features, fmt.Sprintf("array on column %s", col.name))
+		}
+		if col.columnType != columnTypeGeography && col.columnType != columnTypeGeometry {
+			continue
+		}
+		if col.subtype != nil && *col.subtype != "" &&
+			!slices.Contains(mysqlGeometrySubtypes, strings.ToUpper(*col.subtype)) {
+			features = append(features, fmt.Sprintf("%s subtype %q on column %s", col.columnType, *col.subtype, col.name))
+		}
+	}
+	for _, cmd := range blueprint.commands {
+		if cmd.name == commandFullText && cmd.language != "" {
+			features = append(features, fmt.Sprintf("fulltext language %q", cmd.language))
+		}
+		if cmd.deferrable != nil || cmd.initiallyImmediate != nil {
+			features = append(features, fmt.Sprintf("deferrable %s constraint", cmd.name))
+		}
+		if cmd.nullsNotDistinct {
+			features = append(features, fmt.Sprintf("NULLS NOT DISTINCT on %s index", cmd.name))
+		}
+	}
+	// MySQL cannot build full-text indexes or the generated columns of JSON indexes without
+	// blocking writes.
+	features = append(features, g.unsupportedConcurrently(blueprint, commandIndex, commandUnique)...)
+	return append(features, g.unsupportedPartial(blueprint)...)
+}
+
+// lockNone returns the LOCK=NONE clause of CREATE INDEX if the index is built without blocking
+// writes.
+func (g *mysqlGrammar) lockNone(blueprint *Blueprint, command *command) string {
+	if g.buildsConcurrently(blueprint, command) {
+		return " LOCK=NONE"
+	}
+	return ""
+}
+
+func (g *mysqlGrammar) GetIgnoredModifiers(blueprint *Blueprint) []string {
+	var ignored []string
+	for _, col := range blueprint.columns {
+		// A new table lists its columns in order already.
+		if blueprint.creating() && (col.first || col.after != "") {
+			ignored = append(ignored, fmt.Sprintf("position on column %s", col.name))
+		}
+		if g.isTextual(col) {
+			continue
+		}
+		if col.charset != nil && *col.charset != "" {
+			ignored = append(ignored, fmt.Sprintf("charset %q on column %s", *col.charset, col.name))
+		}
+		if col.collation != nil && *col.collation != "" {
+			ignored = append(ignored, fmt.Sprintf("collation %q on column %s", *col.collation, col.name))
+		}
+	}
+	// CREATE TABLE takes no ALGORITHM or LOCK.
+	if blueprint.creating() && blueprint.algorithm != "" {
+		ignored = append(ignored, fmt.Sprintf("algorithm %q on table %s", blueprint.algorithm, blueprint.name))
+	}
+	if blueprint.creating() && blueprint.lock != "" {
+		ignored = append(ignored, fmt.Sprintf("lock %q on table %s", blueprint.lock, blueprint.name))
+	}
+	ignored = append(ignored, g.ignoredAlgorithms(blueprint, commandPrimary, commandFullText)...)
+	return append(ignored, g.ignoredJSONChecks(blueprint)...)
+}
+
+// isTextual reports whether the column stores text, so it can have its own character set and
+// collation.
+func (g *mysqlGrammar) isTextual(col *columnDefinition) bool {
+	switch col.columnType {
+	case columnTypeChar, columnTypeString, columnTypeTinyText, columnTypeText, columnTypeMediumText,
+		columnTypeLongText, columnTypeEnum:
+		return true
+	}
+	return false
+}
+
 func (g *mysqlGrammar) getColumns(blueprint *Blueprint) ([]string, error) {
 	var columns []string
 	for _, col := range blueprint.getAddedColumns() {
@@ -299,13 +1008,18 @@ func (g *mysqlGrammar) getColumns(blueprint *Blueprint) ([]string, error) {
 		}
 		sql := col.name + " " + g.getType(col)
 		sql += g.modifyUnsigned(col)
+		sql += g.modifyCharset(col)
+		sql += g.modifyCollate(col)
 		sql += g.modifyIncrement(col)
 		sql += g.modifyDefault(col)
 		sql += g.modifyOnUpdate(col)
-		sql += g.modifyCharset(col)
-		sql += g.modifyCollate(col)
+		sql += g.modifyGenerated(col)
 		sql += g.modifyNullable(col)
 		sql += g.modifyComment(col)
+		sql += g.modifyJSONCheck(col)
+		if !blueprint.creating() {
+			sql += g.modifyPosition(col)
+		}
 
 		columns = append(columns, sql)
 	}
@@ -358,6 +1072,10 @@ func (g *mysqlGrammar) getType(col *columnDefinition) string {
 		columnTypeYear:          g.typeYear,
 		columnTypeBinary:        g.typeBinary,
 		columnTypeUUID:          g.typeUUID,
+		columnTypeInterval:      g.typeInterval,
+		columnTypeInet:          g.typeInet,
+		columnTypeCidr:          g.typeCidr,
+		columnTypeMacAddr:       g.typeMacAddr,
 		columnTypeGeography:     g.typeGeography,
 		columnTypeGeometry:      g.typeGeometry,
 		columnTypePoint:         g.typePoint,
@@ -519,13 +1237,26 @@ func (g *mysqlGrammar) typeUUID(_ *columnDefinition) string {
 	return "CHAR(36)" // Default UUID length
 }
 
+func (g *mysqlGrammar) typeInterval(_ *columnDefinition) string {
+	return "VARCHAR(64)" // e.g. "1 day 02:03:04"
+}
+
+func (g *mysqlGrammar) typeInet(_ *columnDefinition) string {
+	return "VARCHAR(45)" // Longest IPv6 address
+}
+
+func (g *mysqlGrammar) typeCidr(_ *columnDefinition) string {
+	return "VARCHAR(49)" // Longest IPv6 address with prefix length
+}
+
+func (g *mysqlGrammar) typeMacAddr(_ *columnDefinition) string {
+	return "VARCHAR(17)"
+}
+
 func (g *mysqlGrammar) typeGeometry(col *columnDefinition) string {
 	subtype := util.Ternary(col.subtype != nil, util.PtrOf(strings.ToUpper(*col.subtype)), nil)
 	if subtype != nil {
-		if !slices.Contains(
-			[]string{"POINT", "LINESTRING", "POLYGON", "GEOMETRYCOLLECTION", "MULTIPOINT", "MULTILINESTRING"},
-			*subtype,
-		) {
+		if !slices.Contains(mysqlGeometrySubtypes, *subtype) {
 			subtype = nil
 		}
 	}
@@ -553,28 +1284,55 @@ func (g *mysqlGrammar) modifiers() []func(*columnDefinition) string {
 		g.modifyUnsigned,
 		g.modifyCharset,
 		g.modifyCollate,
+		g.modifyGenerated,
 		g.modifyNullable,
 		g.modifyDefault,
 		g.modifyOnUpdate,
 		g.modifyIncrement,
 		g.modifyComment,
+		g.modifyPosition,
 	}
 }
 
+func (g *mysqlGrammar) modifyJSONCheck(col *columnDefinition) string {
+	var conditions []string
+	if len(col.jsonPaths) > 0 {
+		paths := make([]string, len(col.jsonPaths))
+		for i, path := range col.jsonPaths {
+			paths[i] = g.quoteLiteral(path)
+		}
+		conditions = append(conditions, fmt.Sprintf("JSON_CONTAINS_PATH(%s, 'all', %s)", col.name, strings.Join(paths, ", ")))
+	}
+	if col.jsonSchema != nil {
+		conditions = append(conditions, fmt.Sprintf("JSON_SCHEMA_VALID(%s, %s)", g.quoteLiteral(*col.jsonSchema), col.name))
+	}
+	return g.jsonCheck(conditions)
+}
+
 func (g *mysqlGrammar) modifyCharset(col *columnDefinition) string {
-	if col.charset != nil && *col.charset != "" {
+	if col.charset != nil && *col.charset != "" && g.isTextual(col) {
 		return fmt.Sprintf(" CHARACTER SET %s", *col.charset)
 	}
 	return ""
 }
 
 func (g *mysqlGrammar) modifyCollate(col *columnDefinition) string {
-	if col.collation != nil && *col.collation != "" {
+	if col.collation != nil && *col.collation != "" && g.isTextual(col) {
 		return fmt.Sprintf(" COLLATE %s", *col.collation)
 	}
 	return ""
 }
 
+func (g *mysqlGrammar) modifyPosition(col *columnDefinition) string {
+	if col.first {
+		return " FIRST"
+	}
+	if col.after != "" {
+		return " AFTER " + col.after
+	}
+	return ""
+}
+
 func (g *mysqlGrammar) modifyComment(col *columnDefinition) string {
 	if col.comment != nil {
 		return fmt.Sprintf(" COMMENT '%s'", *col.comment)
@@ -586,13 +1344,26 @@ func (g *mysqlGrammar) modifyDefault(col *columnDefinition) string {
 	if col.hasCommand("default") {
 		return fmt.Sprintf(" DEFAULT %s", g.GetDefaultValue(col.defaultValue))
 	}
+	if col.defaultUUID {
+		return " DEFAULT (UUID())"
+	}
 	return ""
 }
 
+func (g *mysqlGrammar) modifyGenerated(col *columnDefinition) string {
+	if col.generatedAs == "" {
+		return ""
+	}
+	return fmt.Sprintf(" GENERATED ALWAYS AS (%s) %s", col.generatedAs, col.generatedStorage)
+}
+
 func (g *mysqlGrammar) modifyIncrement(col *columnDefinition) string {
-	if slices.Contains(g.serials, col.columnType) &&
-		col.autoIncrement != nil && *col.autoIncrement &&
-		col.primary != nil && *col.primary {
+	// A column that is no longer the primary key cannot remain auto-incrementing.
+	if !slices.Contains(g.serials, col.columnType) || col.primary != nil && !*col.primary {
+		return ""
+	}
+	if col.identity != "" ||
+		col.autoIncrement != nil && *col.autoIncrement && col.primary != nil && *col.primary {
 		return " AUTO_INCREMENT"
 	}
 	return ""
@@ -3,7 +3,6 @@ package schema //nolint:testpackage // Need to access unexported members for tes
 import (
 	"testing"
 
-	"github.com/akfaiz/migris/internal/dialect"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -70,6 +69,28 @@ func TestMysqlGrammar_CompileCreate(t *testing.T) {
 			want:    "CREATE TABLE users (id BIGINT UNSIGNED AUTO_INCREMENT NOT NULL, CONSTRAINT pk_users PRIMARY KEY (id)) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci ENGINE = InnoDB",
 			wantErr: false,
 		},
+		{
+			name:  "columns with charset and collation",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Collation("utf8mb4_unicode_ci")
+				table.String("username", 50).Charset("ascii").Collation("ascii_bin").Default("guest")
+				table.Text("bio").Collation("utf8mb4_bin")
+			},
+			want: "CREATE TABLE users (username VARCHAR(50) CHARACTER SET ascii COLLATE ascii_bin " +
+				"DEFAULT 'guest' NOT NULL, bio TEXT COLLATE utf8mb4_bin NOT NULL) COLLATE utf8mb4_unicode_ci",
+			wantErr: false,
+		},
+		{
+			name:  "table with JSON checks",
+			table: "events",
+			blueprint: func(table *Blueprint) {
+				table.JSON("payload").CheckJSONPathExists("$.id", "$.type").CheckJSONSchema(`{"title": "it's an event"}`)
+			},
+			want: "CREATE TABLE events (payload JSON NOT NULL CHECK (JSON_CONTAINS_PATH(payload, 'all', '$.id', '$.type') " +
+				"AND JSON_SCHEMA_VALID('{\"title\": \"it''s an event\"}', payload)))",
+			wantErr: false,
+		},
 		{
 			name:  "table with empty column name should return error",
 			table: "users",
@@ -114,6 +135,38 @@ func TestMysqlGrammar_CompileAdd(t *testing.T) {
 			want:    "ALTER TABLE users ADD COLUMN email VARCHAR(255) NOT NULL",
 			wantErr: false,
 		},
+		{
+			name:  "add positioned columns",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.String("middle_name", 100).Nullable().After("first_name")
+				table.UUID("uuid").First()
+			},
+			want: "ALTER TABLE users ADD COLUMN middle_name VARCHAR(100) NULL AFTER first_name, " +
+				"ADD COLUMN uuid CHAR(36) NOT NULL FIRST",
+		},
+		{
+			name:  "add interval and network address columns",
+			table: "hosts",
+			blueprint: func(table *Blueprint) {
+				table.Interval("uptime", 3)
+				table.Inet("ip_address")
+				table.Cidr("network")
+				table.MacAddr("mac_address").Nullable()
+			},
+			want: "ALTER TABLE hosts ADD COLUMN uptime VARCHAR(64) NOT NULL, " +
+				"ADD COLUMN ip_address VARCHAR(45) NOT NULL, ADD COLUMN network VARCHAR(49) NOT NULL, ADD COLUMN mac_address VARCHAR(17) NULL",
+		},
+		{
+			name:  "add stored generated column",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Decimal("total", 10, 2).StoredAs("price * quantity")
+			},
+			want: "ALTER TABLE orders ADD COLUMN " +
+				"total DECIMAL(10, 2) GENERATED ALWAYS AS (price * quantity) STORED NOT NULL",
+			wantErr: false,
+		},
 		{
 			name:  "add multiple columns",
 			table: "users",
@@ -247,6 +300,27 @@ func TestMysqlGrammar_CompileChange(t *testing.T) {
 			want:    []string{"ALTER TABLE users MODIFY COLUMN description TEXT NULL DEFAULT NULL"},
 			wantErr: false,
 		},
+		{
+			name:  "change column position",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.String("email", 255).Comment("Login").After("name").Change()
+			},
+			want:    []string{"ALTER TABLE users MODIFY COLUMN email VARCHAR(255) NOT NULL COMMENT 'Login' AFTER name"},
+			wantErr: false,
+		},
+		{
+			name:  "change column collation",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.String("username", 50).Charset("utf8mb4").Collation("utf8mb4_bin").Change()
+			},
+			want: []string{
+				"ALTER TABLE users MODIFY COLUMN username VARCHAR(50) " +
+					"CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL",
+			},
+			wantErr: false,
+		},
 		{
 			name:  "change column with comment",
 			table: "users",
@@ -293,6 +367,62 @@ func TestMysqlGrammar_CompileChange(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "change column to auto increment",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.UnsignedBigInteger("id").Identity().Change()
+			},
+			want:    []string{"ALTER TABLE users MODIFY COLUMN id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT"},
+			wantErr: false,
+		},
+		{
+			name:  "change expression of generated column",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Decimal("total", 10, 2).VirtualAs("price * quantity - discount").Change()
+			},
+			want: []string{
+				"ALTER TABLE orders MODIFY COLUMN total DECIMAL(10, 2) " +
+					"GENERATED ALWAYS AS (price * quantity - discount) VIRTUAL NOT NULL",
+			},
+			wantErr: false,
+		},
+		{
+			name:  "change column to auto increment primary key",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Integer("id").GeneratedAlwaysAsIdentity().Primary().Change()
+			},
+			want: []string{
+				"ALTER TABLE users MODIFY COLUMN id INT NOT NULL AUTO_INCREMENT, ADD CONSTRAINT pk_users PRIMARY KEY (id)",
+			},
+			wantErr: false,
+		},
+		{
+			name:  "change column to no longer be the primary key",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.UnsignedBigInteger("id").Primary(false).Change()
+			},
+			want: []string{
+				"ALTER TABLE users MODIFY COLUMN id BIGINT UNSIGNED NOT NULL",
+				"ALTER TABLE users DROP PRIMARY KEY",
+			},
+			wantErr: false,
+		},
+		{
+			name:  "change auto increment column to no longer be the primary key",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.BigIncrements("id").Primary(false).Change()
+			},
+			want: []string{
+				"ALTER TABLE users MODIFY COLUMN id BIGINT UNSIGNED NOT NULL",
+				"ALTER TABLE users DROP PRIMARY KEY",
+			},
+			wantErr: false,
+		},
 		{
 			name:  "change column with empty name should return error",
 			table: "users",
@@ -305,7 +435,7 @@ func TestMysqlGrammar_CompileChange(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			bp := &Blueprint{name: tt.table, grammar: g, dialect: dialect.MySQL}
+			bp := &Blueprint{name: tt.table, grammar: g}
 			tt.blueprint(bp)
 			statements, err := bp.toSQL()
 			if tt.wantErr {
@@ -786,6 +916,15 @@ func TestMysqlGrammar_CompileIndex(t *testing.T) {
 			want:    "CREATE INDEX idx_users_first_name_last_name ON users (first_name, last_name)",
 			wantErr: false,
 		},
+		{
+			name:  "functional index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.IndexRaw("lower(email)")
+			},
+			want:    "CREATE INDEX idx_users_lower_email ON users ((lower(email)))",
+			wantErr: false,
+		},
 		{
 			name:  "index with custom name",
 			table: "products",
@@ -804,6 +943,15 @@ func TestMysqlGrammar_CompileIndex(t *testing.T) {
 			want:    "CREATE INDEX idx_logs_created_at ON logs (created_at) USING BTREE",
 			wantErr: false,
 		},
+		{
+			name:  "concurrent index",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Index("customer_id").Concurrently()
+			},
+			want:    "CREATE INDEX idx_orders_customer_id ON orders (customer_id) LOCK=NONE",
+			wantErr: false,
+		},
 		{
 			name:  "index with custom name and algorithm",
 			table: "orders",
@@ -813,6 +961,23 @@ func TestMysqlGrammar_CompileIndex(t *testing.T) {
 			want:    "CREATE INDEX idx_order_status_date ON orders (status, created_at) USING HASH",
 			wantErr: false,
 		},
+		{
+			name:  "index algorithm is normalized to upper case",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Index("status").Algorithm("hash")
+			},
+			want:    "CREATE INDEX idx_orders_status ON orders (status) USING HASH",
+			wantErr: false,
+		},
+		{
+			name:  "index with invalid algorithm",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Index("status").Algorithm("gin")
+			},
+			wantErr: true,
+		},
 		{
 			name:  "empty columns should return error",
 			table: "users",
@@ -838,6 +1003,62 @@ func TestMysqlGrammar_CompileIndex(t *testing.T) {
 	}
 }
 
+func TestMysqlGrammar_CompileJSONIndex(t *testing.T) {
+	g := newMysqlGrammar()
+
+	tests := []struct {
+		name      string
+		table     string
+		blueprint func(table *Blueprint)
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "top-level key",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.customer_id")
+			},
+			want: "ALTER TABLE orders ADD COLUMN data_customer_id VARCHAR(255) " +
+				"AS (JSON_UNQUOTE(JSON_EXTRACT(data, '$.customer_id'))) VIRTUAL, " +
+				"ADD INDEX idx_orders_data_customer_id (data_customer_id)",
+		},
+		{
+			name:  "nested key with array index and algorithm",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.items[0].SKU").Algorithm("btree")
+			},
+			want: "ALTER TABLE orders ADD COLUMN data_items_0_sku VARCHAR(255) " +
+				"AS (JSON_UNQUOTE(JSON_EXTRACT(data, '$.items[0].SKU'))) VIRTUAL, " +
+				"ADD INDEX idx_orders_data_items_0_sku (data_items_0_sku) USING BTREE",
+		},
+		{
+			name:  "invalid path",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.items[first]")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: tt.table}
+			tt.blueprint(bp)
+			got, err := g.CompileJSONIndex(bp, bp.commands[0])
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestMysqlGrammar_CompileUnique(t *testing.T) {
 	g := newMysqlGrammar()
 
@@ -857,6 +1078,24 @@ func TestMysqlGrammar_CompileUnique(t *testing.T) {
 			want:    "CREATE UNIQUE INDEX uk_users_email ON users (email)",
 			wantErr: false,
 		},
+		{
+			name:  "functional unique index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.UniqueRaw("lower(email)").Name("uk_users_email_ci")
+			},
+			want:    "CREATE UNIQUE INDEX uk_users_email_ci ON users ((lower(email)))",
+			wantErr: false,
+		},
+		{
+			name:  "concurrent unique index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Concurrently()
+			},
+			want:    "CREATE UNIQUE INDEX uk_users_email ON users (email) LOCK=NONE",
+			wantErr: false,
+		},
 		{
 			name:  "unique index on multiple columns",
 			table: "users",
@@ -1806,3 +2045,748 @@ func TestMysqlGrammar_GetColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestMysqlGrammar_GetUnsupportedFeatures(t *testing.T) {
+	g := newMysqlGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name: "supported features only",
+			blueprint: func(table *Blueprint) {
+				table.Engine("InnoDB")
+				table.Charset("utf8mb4")
+				table.String("name").Charset("utf8mb4")
+				table.Geometry("area", "Polygon")
+				table.FullText("name")
+			},
+		},
+		{
+			name: "fulltext language",
+			blueprint: func(table *Blueprint) {
+				table.FullText("content").Language("english")
+			},
+			want: []string{`fulltext language "english"`},
+		},
+		{
+			name: "deferrable unique constraint",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Deferrable()
+			},
+			want: []string{"deferrable unique constraint"},
+		},
+		{
+			name: "concurrently on fulltext and JSON index",
+			blueprint: func(table *Blueprint) {
+				table.Index("name").Concurrently()
+				table.FullText("content").Concurrently()
+				table.JSONIndex("data", "$.id").Concurrently()
+			},
+			want: []string{"concurrently on fullText index", "concurrently on jsonIndex index"},
+		},
+		{
+			name: "nulls not distinct",
+			blueprint: func(table *Blueprint) {
+				table.Unique("tenant_id", "email").NullsNotDistinct()
+			},
+			want: []string{"NULLS NOT DISTINCT on unique index"},
+		},
+		{
+			name: "partial index",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Where("deleted_at IS NULL")
+			},
+			want: []string{`condition "deleted_at IS NULL" on unique index`},
+		},
+		{
+			name: "unknown geography subtype",
+			blueprint: func(table *Blueprint) {
+				table.Geography("area", "MultiPolygonZ")
+			},
+			want: []string{`geography subtype "MultiPolygonZ" on column area`},
+		},
+		{
+			name: "array column",
+			blueprint: func(table *Blueprint) {
+				table.TextArray("tags")
+			},
+			want: []string{"array on column tags"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "test_table", grammar: g}
+			tt.blueprint(bp)
+			assert.Equal(t, tt.want, g.GetUnsupportedFeatures(bp))
+		})
+	}
+}
+
+func TestMysqlGrammar_Partitions(t *testing.T) {
+	grammar := newMysqlGrammar()
+	create := "CREATE TABLE events (id BIGINT NOT NULL, region VARCHAR(2) NOT NULL, created_at TIMESTAMP NOT NULL) "
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      string
+		wantErr   string
+	}{
+		{
+			name: "range",
+			blueprint: func(table *Blueprint) {
+				table.PartitionBy(PartitionRange, "created_at")
+				table.Partition("p2025_01", RangeBounds("'2025-01-01'", "'2025-02-01'"))
+				table.Partition("p_future", RangeBounds("'2025-02-01'", "MAXVALUE"))
+			},
+			want: create + "PARTITION BY RANGE COLUMNS (created_at) (" +
+				"PARTITION p2025_01 VALUES LESS THAN ('2025-02-01'), PARTITION p_future VALUES LESS THAN (MAXVALUE))",
+		},
+		{
+			name: "list",
+			blueprint: func(table *Blueprint) {
+				table.PartitionBy(PartitionList, "region")
+				table.Partition("p_europe", ListBounds("'eu'", "'uk'"))
+			},
+			want: create + "PARTITION BY LIST COLUMNS (region) (PARTITION p_europe VALUES IN ('eu', 'uk'))",
+		},
+		{
+			name: "hash",
+			blueprint: func(table *Blueprint) {
+				table.PartitionBy(PartitionHash, "id")
+			},
+			want: create + "PARTITION BY KEY (id)",
+		},
+		{
+			name: "range without partitions",
+			blueprint: func(table *Blueprint) {
+				table.PartitionBy(PartitionRange, "created_at")
+			},
+			wantErr: "table events: mysql requires the partitions of a RANGE partitioned table, call Partition",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "events", grammar: grammar}
+			bp.create()
+			bp.BigInteger("id")
+			bp.String("region", 2)
+			bp.Timestamp("created_at")
+			tt.blueprint(bp)
+
+			got, err := bp.toSQL()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, got)
+		})
+	}
+
+	got, err := grammar.CompileCreatePartition("events", "p2025_02", RangeBounds("'2025-02-01'", "'2025-03-01'"))
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE events ADD PARTITION (PARTITION p2025_02 VALUES LESS THAN ('2025-03-01'))", got)
+
+	_, err = grammar.CompileCreatePartition("events", "p1", HashBounds(2, 1))
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+}
+
+func TestMysqlGrammar_StrictMode(t *testing.T) {
+	g := newMysqlGrammar()
+
+	bp := &Blueprint{name: "articles", grammar: g, strict: true}
+	bp.create()
+	bp.Text("content")
+	bp.FullText("content").Language("english")
+	_, err := bp.toSQL()
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	assert.Contains(t, err.Error(), "articles")
+
+	bp = &Blueprint{name: "articles", grammar: g}
+	bp.create()
+	bp.Text("content")
+	bp.FullText("content").Language("english")
+	_, err = bp.toSQL()
+	require.NoError(t, err)
+}
+
+func TestMysqlGrammar_GetIgnoredModifiers(t *testing.T) {
+	g := newMysqlGrammar()
+
+	bp := &Blueprint{name: "articles", grammar: g}
+	bp.Index("title").Algorithm("btree")
+	bp.FullText("content").Algorithm("btree")
+	bp.Primary("id").Algorithm("hash")
+	bp.JSON("payload").CheckJSONPathExists("$.id").Change()
+	bp.Integer("views").Charset("utf8mb4").Collation("utf8mb4_bin")
+	assert.Equal(t, []string{
+		`charset "utf8mb4" on column views`,
+		`collation "utf8mb4_bin" on column views`,
+		`algorithm "btree" on fullText index`,
+		`algorithm "hash" on primary index`,
+		"JSON check on changed column payload",
+	}, g.GetIgnoredModifiers(bp))
+
+	created := &Blueprint{name: "articles", grammar: g}
+	created.create()
+	created.String("title").After("id")
+	assert.Equal(t, []string{"position on column title"}, g.GetIgnoredModifiers(created))
+
+	created.Algorithm("INSTANT")
+	assert.Contains(t, g.GetIgnoredModifiers(created), `algorithm "INSTANT" on table articles`)
+}
+
+func TestMysqlGrammar_CompileAlterOptions(t *testing.T) {
+	g := newMysqlGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+		wantErr   string
+	}{
+		{
+			name: "instant add column",
+			blueprint: func(table *Blueprint) {
+				table.Algorithm("instant")
+				table.String("note").Nullable()
+			},
+			want: []string{"ALTER TABLE orders ADD COLUMN note VARCHAR(255) NULL, ALGORITHM=INSTANT"},
+		},
+		{
+			name: "inplace index without locking",
+			blueprint: func(table *Blueprint) {
+				table.Algorithm("INPLACE")
+				table.Lock("NONE")
+				table.Index("customer_id").Concurrently()
+			},
+			want: []string{
+				"CREATE INDEX idx_orders_customer_id ON orders (customer_id) ALGORITHM=INPLACE LOCK=NONE",
+			},
+		},
+		{
+			name: "lock only",
+			blueprint: func(table *Blueprint) {
+				table.Lock("SHARED")
+				table.DropColumn("note")
+			},
+			want: []string{"ALTER TABLE orders DROP COLUMN note, LOCK=SHARED"},
+		},
+		{
+			name: "invalid algorithm",
+			blueprint: func(table *Blueprint) {
+				table.Algorithm("fast")
+				table.String("note")
+			},
+			wantErr: `invalid algorithm "fast" for mysql`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "orders", grammar: g}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMysqlGrammar_InlineIndexes(t *testing.T) {
+	g := newMysqlGrammar()
+
+	tests := []struct {
+		name          string
+		inlineIndexes bool
+		blueprint     func(table *Blueprint)
+		want          []string
+	}{
+		{
+			name:          "indexes inline in create table",
+			inlineIndexes: true,
+			blueprint: func(table *Blueprint) {
+				table.ID()
+				table.String("email").Unique()
+				table.String("name").Index()
+				table.Text("bio")
+				table.FullText("bio")
+				table.Index("name", "email").Name("idx_name_email").Algorithm("BTREE")
+			},
+			want: []string{
+				"CREATE TABLE users (id BIGINT UNSIGNED AUTO_INCREMENT NOT NULL, email VARCHAR(255) NOT NULL, " +
+					"name VARCHAR(255) NOT NULL, bio TEXT NOT NULL, CONSTRAINT pk_users PRIMARY KEY (id), " +
+					"FULLTEXT KEY ft_users_bio (bio), KEY idx_name_email (name, email) USING BTREE, " +
+					"UNIQUE KEY uk_users_email (email), KEY idx_users_name (name))",
+			},
+		},
+		{
+			name: "indexes as separate statements by default",
+			blueprint: func(table *Blueprint) {
+				table.ID()
+				table.String("email").Unique()
+			},
+			want: []string{
+				"CREATE TABLE users (id BIGINT UNSIGNED AUTO_INCREMENT NOT NULL, email VARCHAR(255) NOT NULL, " +
+					"CONSTRAINT pk_users PRIMARY KEY (id))",
+				"CREATE UNIQUE INDEX uk_users_email ON users (email)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "users", grammar: g, inlineIndexes: tt.inlineIndexes}
+			bp.create()
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMysqlGrammar_InlineIndexesOnExistingTable(t *testing.T) {
+	g := newMysqlGrammar()
+
+	bp := &Blueprint{name: "users", grammar: g, inlineIndexes: true}
+	bp.String("email").Unique()
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE users ADD COLUMN email VARCHAR(255) NOT NULL",
+		"CREATE UNIQUE INDEX uk_users_email ON users (email)",
+	}, got)
+}
+
+func TestMysqlGrammar_InlineIndexesCompiledTwice(t *testing.T) {
+	bp := &Blueprint{name: "users", grammar: newMysqlGrammar(), inlineIndexes: true}
+	bp.create()
+	bp.ID()
+	bp.String("email").Unique()
+
+	first, err := bp.toSQL()
+	require.NoError(t, err)
+	second, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, []string{
+		"CREATE TABLE users (id BIGINT UNSIGNED AUTO_INCREMENT NOT NULL, email VARCHAR(255) NOT NULL, " +
+			"CONSTRAINT pk_users PRIMARY KEY (id), UNIQUE KEY uk_users_email (email))",
+	}, second)
+}
+
+func TestMysqlGrammar_CompileTableDDL(t *testing.T) {
+	g := newMysqlGrammar()
+
+	tests := []struct {
+		name   string
+		schema string
+		table  string
+		want   string
+	}{
+		{
+			name:  "table in the current schema",
+			table: "users",
+			want:  "SHOW CREATE TABLE `users`",
+		},
+		{
+			name:   "schema-qualified table",
+			schema: "app",
+			table:  "users",
+			want:   "SHOW CREATE TABLE `app`.`users`",
+		},
+		{
+			name:  "backticks are escaped",
+			table: "odd`name",
+			want:  "SHOW CREATE TABLE `odd``name`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.CompileTableDDL(tt.schema, tt.table)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMysqlGrammar_CompileDropAllTables(t *testing.T) {
+	g := newMysqlGrammar()
+
+	got, err := g.CompileDropAllTables([]string{"users", "posts"})
+	require.NoError(t, err)
+	assert.Equal(t, "DROP TABLE IF EXISTS users, posts", got)
+
+	_, err = g.CompileDropAllTables(nil)
+	require.Error(t, err)
+}
+
+func TestMysqlGrammar_CompileInsert(t *testing.T) {
+	g := newMysqlGrammar()
+
+	assert.Equal(t, "INSERT INTO users (id, name) VALUES (?, ?), (?, ?)", g.CompileInsert("users", []string{"id", "name"}, 2))
+	assert.Empty(t, g.CompileResetSequence("users", "id"))
+}
+
+func TestMysqlGrammar_QuoteIdentifier(t *testing.T) {
+	g := newMysqlGrammar()
+
+	assert.Equal(t, "`order`", g.QuoteIdentifier("order"))
+	assert.Equal(t, "`shop`.`order`", g.QuoteIdentifier("shop.order"))
+	assert.Equal(t, "`odd``name`", g.QuoteIdentifier("odd`name"))
+}
+
+func TestMysqlGrammar_CompileAnalyze(t *testing.T) {
+	g := newMysqlGrammar()
+
+	got, err := g.CompileAnalyze([]string{"users", "posts"})
+	require.NoError(t, err)
+	assert.Equal(t, "ANALYZE TABLE users, posts", got)
+
+	_, err = g.CompileAnalyze([]string{""})
+	require.Error(t, err)
+}
+
+func TestMysqlGrammar_CompileTruncate(t *testing.T) {
+	g := newMysqlGrammar()
+
+	got, err := g.CompileTruncate("users", TruncateOptions{RestartIdentity: true})
+	require.NoError(t, err)
+	assert.Equal(t, "TRUNCATE TABLE users", got)
+
+	_, err = g.CompileTruncate("users", TruncateOptions{Cascade: true})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	_, err = g.CompileTruncate("", TruncateOptions{})
+	require.Error(t, err)
+}
+
+func TestMysqlGrammar_CompileCheck(t *testing.T) {
+	g := newMysqlGrammar()
+
+	tests := []struct {
+		name      string
+		table     string
+		blueprint func(table *Blueprint)
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "check with name",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.Check("price > 0").Name("chk_positive_price")
+			},
+			want: "ALTER TABLE products ADD CONSTRAINT chk_positive_price CHECK (price > 0)",
+		},
+		{
+			name:  "check without name should use generated name",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.Check("price > 0")
+			},
+			want: "ALTER TABLE products ADD CONSTRAINT chk_products_price CHECK (price > 0)",
+		},
+		{
+			name:  "drop check",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.DropCheck("chk_positive_price")
+			},
+			want: "ALTER TABLE products DROP CHECK chk_positive_price",
+		},
+		{
+			name:  "drop check without name should return error",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.DropCheck("")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: tt.table, grammar: g}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, got)
+		})
+	}
+}
+
+func TestMysqlGrammar_ForeignID(t *testing.T) {
+	bp := &Blueprint{name: "posts", grammar: newMysqlGrammar()}
+	bp.ForeignID("user_id").Constrained().CascadeOnDelete()
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE posts ADD COLUMN user_id BIGINT UNSIGNED NOT NULL",
+		"ALTER TABLE posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE",
+	}, got)
+}
+
+func TestMysqlGrammar_Morphs(t *testing.T) {
+	bp := &Blueprint{name: "comments", grammar: newMysqlGrammar()}
+	bp.NullableMorphs("commentable")
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE comments ADD COLUMN commentable_type VARCHAR(255) NULL, " +
+			"ADD COLUMN commentable_id BIGINT UNSIGNED NULL",
+		"CREATE INDEX idx_comments_commentable_type_commentable_id ON comments (commentable_type, commentable_id)",
+	}, got)
+}
+
+func TestMysqlGrammar_SoftDeletes(t *testing.T) {
+	bp := &Blueprint{name: "posts", grammar: newMysqlGrammar()}
+	bp.SoftDeletes()
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMP NULL"}, got)
+}
+
+func TestMysqlGrammar_UUIDPrimary(t *testing.T) {
+	bp := &Blueprint{name: "users", grammar: newMysqlGrammar()}
+	bp.create()
+	bp.UUIDPrimary()
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE users (id CHAR(36) DEFAULT (UUID()) NOT NULL, CONSTRAINT pk_users PRIMARY KEY (id))",
+	}, got)
+}
+
+func TestMysqlGrammar_Temporary(t *testing.T) {
+	bp := &Blueprint{name: "order_fixes", grammar: newMysqlGrammar(), engine: "InnoDB"}
+	bp.create()
+	bp.Temporary()
+	bp.BigInteger("order_id")
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CREATE TEMPORARY TABLE order_fixes (order_id BIGINT NOT NULL) ENGINE = InnoDB"}, got)
+}
+
+func TestMysqlGrammar_CreateIfNotExists(t *testing.T) {
+	bp := &Blueprint{name: "sessions", grammar: newMysqlGrammar(), ifNotExists: true}
+	bp.create()
+	bp.String("id").Primary()
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS sessions (id VARCHAR(255) NOT NULL, CONSTRAINT pk_sessions PRIMARY KEY (id))",
+	}, got)
+}
+
+func TestMysqlGrammar_View(t *testing.T) {
+	grammar := newMysqlGrammar()
+	view := &ViewBlueprint{
+		name:    "active_users",
+		columns: []string{"user_id"},
+		query:   "SELECT id FROM users WHERE active = 1",
+	}
+
+	got, err := grammar.CompileCreateView(view, true)
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE OR REPLACE VIEW active_users (user_id) AS SELECT id FROM users WHERE active = 1", got)
+
+	got, err = grammar.CompileDropView("active_users")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP VIEW active_users", got)
+}
+
+func TestMysqlGrammar_MaterializedView(t *testing.T) {
+	grammar := newMysqlGrammar()
+
+	_, err := grammar.CompileCreateMaterializedView(&ViewBlueprint{name: "daily_sales", query: "SELECT 1"})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	_, err = grammar.CompileRefreshMaterializedView("daily_sales", RefreshOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	_, err = grammar.CompileDropMaterializedView("daily_sales")
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+}
+
+func TestMysqlGrammar_Sequence(t *testing.T) {
+	grammar := newMysqlGrammar()
+
+	_, err := grammar.CompileCreateSequence(&SequenceBlueprint{name: "invoice_number_seq"})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	_, err = grammar.CompileAlterSequence(&SequenceBlueprint{name: "invoice_number_seq"})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	_, err = grammar.CompileDropSequence("invoice_number_seq")
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+}
+
+func TestMysqlGrammar_Schema(t *testing.T) {
+	grammar := newMysqlGrammar()
+
+	got, err := grammar.CompileCreateSchema("billing")
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE SCHEMA billing", got)
+
+	got, err = grammar.CompileDropSchema("billing", true)
+	require.NoError(t, err)
+	assert.Equal(t, "DROP SCHEMA billing", got)
+
+	_, err = grammar.CompileDropSchema("billing", false)
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+
+	got, err = grammar.CompileSchemaExists("billing")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 1 FROM information_schema.schemata WHERE schema_name = 'billing'", got)
+}
+
+func TestMysqlGrammar_Extension(t *testing.T) {
+	grammar := newMysqlGrammar()
+
+	_, err := grammar.CompileCreateExtension("pgcrypto")
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	_, err = grammar.CompileDropExtension("pgcrypto")
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+	_, err = grammar.CompileExtensionExists("pgcrypto")
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+}
+
+func TestMysqlGrammar_Function(t *testing.T) {
+	grammar := newMysqlGrammar()
+
+	got, err := grammar.CompileCreateFunction(&FunctionBlueprint{
+		name:          "add_tax",
+		parameters:    []string{"amount DECIMAL(10,2)"},
+		returns:       "DECIMAL(10,2)",
+		body:          "RETURN amount * 1.2",
+		deterministic: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE FUNCTION add_tax(amount DECIMAL(10,2)) RETURNS DECIMAL(10,2) DETERMINISTIC "+
+		"RETURN amount * 1.2", got)
+
+	_, err = grammar.CompileCreateFunction(&FunctionBlueprint{
+		name: "set_updated_at", returns: "trigger", language: "plpgsql", body: "BEGIN RETURN NEW; END;",
+	})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+
+	got, err = grammar.CompileDropFunction("add_tax")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP FUNCTION add_tax", got)
+}
+
+func TestMysqlGrammar_Trigger(t *testing.T) {
+	grammar := newMysqlGrammar()
+
+	got, err := grammar.CompileCreateTrigger(&TriggerBlueprint{
+		name:   "users_updated_at",
+		table:  "users",
+		timing: "BEFORE",
+		events: []string{"update"},
+		body:   "SET NEW.updated_at = NOW()",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TRIGGER users_updated_at BEFORE UPDATE ON users FOR EACH ROW "+
+		"SET NEW.updated_at = NOW()", got)
+
+	tests := []struct {
+		name      string
+		blueprint func(trigger *TriggerBlueprint)
+		wantErr   string
+	}{
+		{
+			name:      "several events",
+			blueprint: func(trigger *TriggerBlueprint) { trigger.Before("INSERT", "UPDATE") },
+			wantErr:   "unsupported feature: mysql does not support triggers on more than one event",
+		},
+		{
+			name:      "instead of",
+			blueprint: func(trigger *TriggerBlueprint) { trigger.InsteadOf("UPDATE") },
+			wantErr:   "unsupported feature: mysql does not support INSTEAD OF triggers",
+		},
+		{
+			name: "for each statement",
+			blueprint: func(trigger *TriggerBlueprint) {
+				trigger.After("UPDATE")
+				trigger.ForEachStatement()
+			},
+			wantErr: "unsupported feature: mysql does not support statement-level triggers",
+		},
+		{
+			name: "with a function",
+			blueprint: func(trigger *TriggerBlueprint) {
+				trigger.Before("UPDATE")
+				trigger.Execute("set_updated_at")
+			},
+			wantErr: "trigger users_updated_at: mysql triggers run a body, call Body instead of Execute",
+		},
+		{
+			name:      "without a body",
+			blueprint: func(trigger *TriggerBlueprint) { trigger.Before("UPDATE") },
+			wantErr:   "trigger users_updated_at has no body, call Body",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := &TriggerBlueprint{name: "users_updated_at", table: "users"}
+			tt.blueprint(trigger)
+			_, err := grammar.CompileCreateTrigger(trigger)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+
+	got, err = grammar.CompileDropTrigger("users_updated_at", "users")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP TRIGGER users_updated_at", got)
+}
+
+func TestMysqlGrammar_TableComment(t *testing.T) {
+	grammar := newMysqlGrammar()
+
+	bp := &Blueprint{name: "orders", grammar: grammar, engine: "InnoDB"}
+	bp.create()
+	bp.Comment("Customer's orders")
+	bp.BigInteger("total")
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE orders (total BIGINT NOT NULL) ENGINE = InnoDB COMMENT = 'Customer''s orders'",
+	}, got)
+
+	bp = &Blueprint{name: "orders", grammar: grammar}
+	bp.Comment("Orders")
+	got, err = bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALTER TABLE orders COMMENT = 'Orders'"}, got)
+}
+
+func TestMysqlGrammar_IdentityID(t *testing.T) {
+	bp := &Blueprint{name: "users", grammar: newMysqlGrammar(), identityIDs: true}
+	bp.create()
+	bp.ID()
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	// MySQL keeps AUTO_INCREMENT.
+	assert.Equal(t, []string{
+		"CREATE TABLE users (id BIGINT UNSIGNED AUTO_INCREMENT NOT NULL, CONSTRAINT pk_users PRIMARY KEY (id))",
+	}, got)
+}
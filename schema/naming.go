@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// NamingStrategy generates the names of indexes and foreign keys that are not named explicitly.
+//
+// The same strategy is used when an index is created and when it is dropped by its columns,
+// e.g. table.DropIndex([]string{"email"}).
+type NamingStrategy interface {
+	// IndexName returns the name of an index of the given type ("primary", "unique", "index",
+	// "fulltext" or "check") on the columns of the table. The table name does not include the
+	// schema. For a check constraint the columns are the identifiers of its condition.
+	IndexName(table string, indexType string, columns []string) string
+	// ForeignKeyName returns the name of a foreign key of the table on the columns that
+	// references the table on. The table names do not include the schema.
+	ForeignKeyName(table string, on string, columns []string) string
+}
+
+// DefaultNamingStrategy is the naming strategy used when none is set.
+// It generates names such as pk_users, uk_users_email, idx_users_name, ft_posts_body,
+// chk_products_price and fk_posts_users.
+type DefaultNamingStrategy struct{}
+
+var _ NamingStrategy = DefaultNamingStrategy{}
+
+// IndexName implements NamingStrategy.
+func (DefaultNamingStrategy) IndexName(table string, indexType string, columns []string) string {
+	switch indexType {
+	case "primary":
+		return fmt.Sprintf("pk_%s", table)
+	case "unique":
+		return fmt.Sprintf("uk_%s_%s", table, strings.Join(columns, "_"))
+	case "index":
+		return fmt.Sprintf("idx_%s_%s", table, strings.Join(columns, "_"))
+	case "fulltext":
+		return fmt.Sprintf("ft_%s_%s", table, strings.Join(columns, "_"))
+	case "check":
+		return fmt.Sprintf("chk_%s_%s", table, strings.Join(columns, "_"))
+	default:
+		return ""
+	}
+}
+
+// ForeignKeyName implements NamingStrategy.
+func (DefaultNamingStrategy) ForeignKeyName(table string, on string, _ []string) string {
+	return fmt.Sprintf("fk_%s_%s", table, on)
+}
+
+type namingStrategyHolder struct {
+	strategy NamingStrategy
+}
+
+var namingStrategy atomic.Pointer[namingStrategyHolder]
+
+// SetNamingStrategy sets the naming strategy used by blueprints created afterwards.
+// Passing nil restores DefaultNamingStrategy.
+func SetNamingStrategy(strategy NamingStrategy) {
+	if strategy == nil {
+		namingStrategy.Store(nil)
+		return
+	}
+	namingStrategy.Store(&namingStrategyHolder{strategy: strategy})
+}
+
+func getNamingStrategy() NamingStrategy {
+	if holder := namingStrategy.Load(); holder != nil {
+		return holder.strategy
+	}
+	return DefaultNamingStrategy{}
+}
+
+// splitQualifiedName splits a schema-qualified name such as "custom_public.users" into its
+// schema and name. The schema is empty for unqualified names.
+func splitQualifiedName(name string) (string, string) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// unqualifiedName strips the schema from a schema-qualified name.
+func unqualifiedName(name string) string {
+	_, name = splitQualifiedName(name)
+	return name
+}
+
+// qualifyName prefixes name with the schema of the schema-qualified table, so that objects such
+// as indexes are looked up in the schema of their table. Already qualified names are kept.
+func qualifyName(table string, name string) string {
+	schema, _ := splitQualifiedName(table)
+	if schema == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return schema + "." + name
+}
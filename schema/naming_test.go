@@ -0,0 +1,200 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type laravelNamingStrategy struct{}
+
+func (laravelNamingStrategy) IndexName(table string, indexType string, columns []string) string {
+	if indexType == "primary" {
+		indexType = "pkey"
+	}
+	return fmt.Sprintf("%s_%s_%s", table, strings.Join(columns, "_"), indexType)
+}
+
+func (laravelNamingStrategy) ForeignKeyName(table string, _ string, columns []string) string {
+	return fmt.Sprintf("%s_%s_foreign", table, strings.Join(columns, "_"))
+}
+
+func TestNamingStrategy(t *testing.T) {
+	bp := &Blueprint{name: "public.users", grammar: newPostgresGrammar(), naming: laravelNamingStrategy{}}
+	bp.String("email").Unique()
+	bp.Index("first_name", "last_name")
+	bp.Foreign("team_id").References("id").On("public.teams")
+	bp.DropIndex([]string{"nickname"})
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE public.users ADD COLUMN email VARCHAR(255) NOT NULL",
+		"CREATE INDEX users_first_name_last_name_index ON public.users (first_name, last_name)",
+		"ALTER TABLE public.users ADD CONSTRAINT users_team_id_foreign FOREIGN KEY (team_id) REFERENCES public.teams(id)",
+		"DROP INDEX public.users_nickname_index",
+		"ALTER TABLE public.users ADD CONSTRAINT users_email_unique UNIQUE (email)",
+	}, got)
+}
+
+func TestSetNamingStrategy(t *testing.T) {
+	t.Cleanup(func() { SetNamingStrategy(nil) })
+
+	assert.Equal(t, DefaultNamingStrategy{}, getNamingStrategy())
+
+	SetNamingStrategy(laravelNamingStrategy{})
+	assert.Equal(t, laravelNamingStrategy{}, getNamingStrategy())
+	assert.Equal(t, laravelNamingStrategy{}, newPostgresBuilder().(*postgresBuilder).newBlueprint("users").naming)
+
+	SetNamingStrategy(nil)
+	assert.Equal(t, DefaultNamingStrategy{}, getNamingStrategy())
+}
+
+type fakeIndexLister struct {
+	indexes []*Index
+	err     error
+	calls   int
+}
+
+func (f *fakeIndexLister) GetIndexes(_ Context, _ string) ([]*Index, error) {
+	f.calls++
+	return f.indexes, f.err
+}
+
+func TestBaseBuilder_ResolveDropIndexNames(t *testing.T) {
+	lister := &fakeIndexLister{indexes: []*Index{
+		{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+		{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+		{Name: "users_name_idx", Columns: []string{"first_name", "last_name"}},
+		{Name: "idx_users_nickname", Columns: []string{"nickname"}},
+	}}
+	b := &baseBuilder{grammar: newPostgresGrammar(), indexLister: lister}
+	bp := b.newBlueprint("users")
+	bp.DropPrimary([]string{"id"})
+	bp.DropUnique([]string{"email"})
+	bp.DropIndex([]string{"first_name", "last_name"})
+	bp.DropIndex([]string{"nickname"})
+	bp.DropIndex([]string{"missing"})
+	bp.DropIndex("named_index")
+
+	require.NoError(t, b.resolveDropIndexNames(NewContext(context.Background(), nil), bp))
+	assert.Equal(t, 1, lister.calls)
+
+	names := make([]string, len(bp.commands))
+	for i, cmd := range bp.commands {
+		names[i] = cmd.index
+	}
+	assert.Equal(t, []string{
+		"users_pkey",
+		"users_email_key",
+		"users_name_idx",
+		"idx_users_nickname",
+		"idx_users_missing",
+		"named_index",
+	}, names)
+}
+
+func TestBaseBuilder_ResolveDropIndexNamesSkipped(t *testing.T) {
+	lister := &fakeIndexLister{err: errors.New("connection refused")}
+	b := &baseBuilder{grammar: newPostgresGrammar(), indexLister: lister}
+
+	bp := b.newBlueprint("users")
+	bp.DropIndex("named_index")
+	require.NoError(t, b.resolveDropIndexNames(NewContext(context.Background(), nil), bp))
+
+	bp = b.newBlueprint("users")
+	bp.DropIndex([]string{"email"})
+	require.NoError(t, b.resolveDropIndexNames(NewDryRunContext(context.Background()), bp))
+	assert.Equal(t, 0, lister.calls)
+
+	err := b.resolveDropIndexNames(NewContext(context.Background(), nil), bp)
+	require.Error(t, err)
+	assert.Equal(t, 1, lister.calls)
+}
+
+func TestBlueprint_DropUniqueByColumns(t *testing.T) {
+	lister := &fakeIndexLister{indexes: []*Index{
+		{Name: "users_email_tenant_id_key", Columns: []string{"email", "tenant_id"}, Unique: true},
+	}}
+	b := &baseBuilder{grammar: newPostgresGrammar(), indexLister: lister}
+	bp := b.newBlueprint("users")
+	bp.DropUniqueByColumns("email", "tenant_id")
+
+	require.NoError(t, b.resolveDropIndexNames(NewContext(context.Background(), nil), bp))
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALTER TABLE users DROP CONSTRAINT users_email_tenant_id_key"}, got)
+}
+
+func TestSplitQualifiedName(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantSchema string
+		wantName   string
+	}{
+		{name: "unqualified", input: "users", wantName: "users"},
+		{name: "schema qualified", input: "custom_public.users", wantSchema: "custom_public", wantName: "users"},
+		{name: "database and schema qualified", input: "app.custom_public.users", wantSchema: "app.custom_public", wantName: "users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, name := splitQualifiedName(tt.input)
+			assert.Equal(t, tt.wantSchema, schema)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestSchemaQualifiedNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		grammar grammar
+		want    []string
+	}{
+		{
+			name:    "postgres",
+			grammar: newPostgresGrammar(),
+			want: []string{
+				"ALTER TABLE custom_public.posts ADD COLUMN user_id BIGINT NOT NULL",
+				"ALTER TABLE custom_public.posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) " +
+					"REFERENCES custom_public.users(id)",
+				"DROP INDEX custom_public.idx_posts_title",
+				"ALTER INDEX custom_public.idx_old RENAME TO idx_new",
+				"CREATE INDEX idx_posts_user_id ON custom_public.posts (user_id)",
+			},
+		},
+		{
+			name:    "mysql",
+			grammar: newMysqlGrammar(),
+			want: []string{
+				"ALTER TABLE custom_public.posts ADD COLUMN user_id BIGINT NOT NULL",
+				"ALTER TABLE custom_public.posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) " +
+					"REFERENCES custom_public.users(id)",
+				"ALTER TABLE custom_public.posts DROP INDEX idx_posts_title",
+				"ALTER TABLE custom_public.posts RENAME INDEX idx_old TO idx_new",
+				"CREATE INDEX idx_posts_user_id ON custom_public.posts (user_id)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "custom_public.posts", grammar: tt.grammar}
+			bp.BigInteger("user_id").Index()
+			bp.Foreign("user_id").References("id").On("custom_public.users")
+			bp.DropIndex([]string{"title"})
+			bp.RenameIndex("idx_old", "idx_new")
+
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/akfaiz/migris/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableOwner(t *testing.T) {
+	config.SetObjectOwner("app_owner")
+	t.Cleanup(func() { config.SetObjectOwner("") })
+
+	t.Run("created tables get the configured owner", func(t *testing.T) {
+		bp := newPostgresBuilder().(*postgresBuilder).newBlueprint("users")
+		bp.create()
+		bp.ID()
+
+		got, err := bp.toSQL()
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"CREATE TABLE users (id BIGSERIAL NOT NULL, CONSTRAINT pk_users PRIMARY KEY (id))",
+			"ALTER TABLE users OWNER TO app_owner",
+		}, got)
+	})
+
+	t.Run("altered tables keep their owner", func(t *testing.T) {
+		bp := newPostgresBuilder().(*postgresBuilder).newBlueprint("users")
+		bp.String("name")
+
+		got, err := bp.toSQL()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ALTER TABLE users ADD COLUMN name VARCHAR(255) NOT NULL"}, got)
+	})
+
+	t.Run("explicit owner", func(t *testing.T) {
+		bp := newPostgresBuilder().(*postgresBuilder).newBlueprint("users")
+		bp.Owner("reporting")
+
+		got, err := bp.toSQL()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ALTER TABLE users OWNER TO reporting"}, got)
+	})
+
+	t.Run("ignored on mysql", func(t *testing.T) {
+		bp := newMysqlBuilder().(*mysqlBuilder).newBlueprint("users")
+		bp.create()
+		bp.ID()
+
+		got, err := bp.toSQL()
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Empty(t, bp.warnings)
+	})
+
+	t.Run("explicit owner is unsupported on mysql", func(t *testing.T) {
+		bp := newMysqlBuilder().(*mysqlBuilder).newBlueprint("users")
+		bp.strict = true
+		bp.Owner("reporting")
+
+		_, err := bp.toSQL()
+		require.ErrorIs(t, err, ErrUnsupportedFeature)
+	})
+}
+
+func TestCompileDefaultPrivileges(t *testing.T) {
+	got, err := newPostgresGrammar().CompileDefaultPrivileges(DefaultPrivileges{
+		ForRole:    "app_owner",
+		Schema:     "public",
+		Privileges: []string{"SELECT", "INSERT"},
+		Grantee:    "app_user",
+	})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"ALTER DEFAULT PRIVILEGES FOR ROLE app_owner IN SCHEMA public GRANT SELECT, INSERT ON TABLES TO app_user", got)
+
+	got, err = newPostgresGrammar().CompileDefaultPrivileges(DefaultPrivileges{
+		Privileges: []string{"USAGE"},
+		ObjectType: "sequences",
+		Grantee:    "app_user",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER DEFAULT PRIVILEGES GRANT USAGE ON SEQUENCES TO app_user", got)
+
+	_, err = newPostgresGrammar().CompileDefaultPrivileges(DefaultPrivileges{Grantee: "app_user"})
+	require.Error(t, err)
+
+	_, err = newMysqlGrammar().CompileDefaultPrivileges(DefaultPrivileges{
+		Privileges: []string{"SELECT"},
+		Grantee:    "app_user",
+	})
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+}
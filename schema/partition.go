@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PartitionMethod is how the rows of a partitioned table are distributed over its partitions.
+type PartitionMethod string
+
+const (
+	// PartitionRange distributes the rows by ranges of the partition key, e.g. one partition per
+	// month of an event table.
+	PartitionRange PartitionMethod = "RANGE"
+	// PartitionList distributes the rows by lists of values of the partition key.
+	PartitionList PartitionMethod = "LIST"
+	// PartitionHash distributes the rows evenly by a hash of the partition key.
+	PartitionHash PartitionMethod = "HASH"
+)
+
+// PartitionBounds are the rows a partition holds, created with RangeBounds, ListBounds or
+// HashBounds.
+type PartitionBounds struct {
+	method    PartitionMethod
+	from      string
+	to        string
+	values    []string
+	modulus   int
+	remainder int
+}
+
+// RangeBounds holds the rows whose partition key is from (inclusive) up to to (exclusive). The
+// bounds are SQL expressions, e.g. "'2025-01-01'", MINVALUE or MAXVALUE. MySQL only uses the upper
+// bound, as its ranges start where the previous partition ends.
+func RangeBounds(from, to string) PartitionBounds {
+	return PartitionBounds{method: PartitionRange, from: from, to: to}
+}
+
+// ListBounds holds the rows whose partition key is one of the values, given as SQL expressions,
+// e.g. "'eu'".
+func ListBounds(values ...string) PartitionBounds {
+	return PartitionBounds{method: PartitionList, values: values}
+}
+
+// HashBounds holds the rows whose hashed partition key leaves the remainder when divided by the
+// modulus. MySQL distributes the rows over the partitions itself and ignores both.
+func HashBounds(modulus, remainder int) PartitionBounds {
+	return PartitionBounds{method: PartitionHash, modulus: modulus, remainder: remainder}
+}
+
+// partitionDefinition is a partition declared with Blueprint.Partition.
+type partitionDefinition struct {
+	name   string
+	bounds PartitionBounds
+}
+
+// PartitionBy creates the table as a partitioned table, partitioned by the given columns. On
+// PostgreSQL the partitions are separate tables created with Partition or CreatePartition; MySQL
+// requires the partitions of RANGE and LIST tables to be declared with Partition.
+//
+// Every primary key and unique index of a partitioned table must include the partition columns.
+//
+// Example:
+//
+//	table.PartitionBy(schema.PartitionRange, "created_at")
+//	table.Partition("events_2025_01", schema.RangeBounds("'2025-01-01'", "'2025-02-01'"))
+func (b *Blueprint) PartitionBy(method PartitionMethod, columns ...string) {
+	b.partitionMethod = method
+	b.partitionColumns = columns
+}
+
+// Partition declares a partition of the table created with PartitionBy.
+func (b *Blueprint) Partition(name string, bounds PartitionBounds) {
+	b.partitions = append(b.partitions, partitionDefinition{name: name, bounds: bounds})
+}
+
+// checkPartitions validates the partitioning declared with PartitionBy and Partition.
+func (b *Blueprint) checkPartitions() error {
+	if b.partitionMethod == "" {
+		if len(b.partitions) > 0 {
+			return fmt.Errorf("table %s has partitions but no partition key, call PartitionBy", b.name)
+		}
+		return nil
+	}
+	if !b.creating() {
+		return fmt.Errorf("table %s: PartitionBy is only supported when creating a table", b.name)
+	}
+	switch b.partitionMethod {
+	case PartitionRange, PartitionList, PartitionHash:
+	default:
+		return fmt.Errorf("table %s: unknown partition method %s", b.name, b.partitionMethod)
+	}
+	if len(b.partitionColumns) == 0 {
+		return fmt.Errorf("table %s: PartitionBy requires at least one column", b.name)
+	}
+	for _, partition := range b.partitions {
+		if partition.bounds.method != b.partitionMethod {
+			return fmt.Errorf("partition %s of table %s: %s bounds on a table partitioned by %s",
+				partition.name, b.name, partition.bounds.method, b.partitionMethod)
+		}
+	}
+	return nil
+}
+
+// checkPartitionNames validates the names of the parent table and of the partition.
+func checkPartitionNames(parent, name string) error {
+	if parent == "" || name == "" {
+		return errors.New("partition and parent table names cannot be empty")
+	}
+	return nil
+}
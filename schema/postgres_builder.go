@@ -3,6 +3,8 @@ package schema
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -13,27 +15,63 @@ type postgresBuilder struct {
 func newPostgresBuilder() Builder {
 	grammar := newPostgresGrammar()
 
-	return &postgresBuilder{
+	builder := &postgresBuilder{
 		baseBuilder: baseBuilder{grammar: grammar},
 	}
+	builder.tableChecker = builder
+	builder.columnChecker = builder
+	builder.indexLister = builder
+	builder.enumLister = builder
+	builder.versionChecker = builder
+
+	return builder
 }
 
-func (b *postgresBuilder) parseSchemaAndTable(name string) (string, string) {
-	names := strings.Split(name, ".")
-	if len(names) == 2 {
-		return names[0], names[1]
+const defaultPostgresSchema = "public"
+
+// checkServerVersion fails if the blueprint builds a unique index with NULLS NOT DISTINCT on a
+// server older than PostgreSQL 15.
+func (b *postgresBuilder) checkServerVersion(c Context, bp *Blueprint) error {
+	if !slices.ContainsFunc(bp.commands, func(cmd *command) bool { return cmd.nullsNotDistinct }) {
+		return nil
+	}
+	var version int
+	if err := c.QueryRow("SHOW server_version_num").Scan(&version); err != nil {
+		return err
 	}
-	return "", names[0]
+	if version < 150000 {
+		return fmt.Errorf("%w: NULLS NOT DISTINCT requires PostgreSQL 15 or later", ErrUnsupportedFeature)
+	}
+	return nil
 }
 
-const defaultPostgresSchema = "public"
+func (b *postgresBuilder) DropAllTables(c Context) error {
+	if c == nil {
+		return errors.New("invalid arguments: context is nil")
+	}
+
+	names, err := b.getCurrentSchemaTables(c)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	query, err := b.grammar.CompileDropAllTables(names)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Exec(query)
+	return err
+}
 
 func (b *postgresBuilder) GetColumns(c Context, tableName string) ([]*Column, error) {
 	if c == nil || tableName == "" {
 		return nil, errors.New("invalid arguments: context is nil or table name is empty")
 	}
 
-	schema, name := b.parseSchemaAndTable(tableName)
+	schema, name := splitQualifiedName(tableName)
 	if schema == "" {
 		schema = defaultPostgresSchema
 	}
@@ -70,7 +108,7 @@ func (b *postgresBuilder) GetIndexes(c Context, tableName string) ([]*Index, err
 	if c == nil || tableName == "" {
 		return nil, errors.New("invalid arguments: context is nil or table name is empty")
 	}
-	schema, name := b.parseSchemaAndTable(tableName)
+	schema, name := splitQualifiedName(tableName)
 	if schema == "" {
 		schema = "public" // Default schema for PostgreSQL
 	}
@@ -101,6 +139,146 @@ func (b *postgresBuilder) GetIndexes(c Context, tableName string) ([]*Index, err
 	return indexes, nil
 }
 
+func (b *postgresBuilder) GetForeignKeys(c Context, tableName string) ([]*ForeignKey, error) {
+	if c == nil || tableName == "" {
+		return nil, errors.New("invalid arguments: context is nil or table name is empty")
+	}
+	schema, name := splitQualifiedName(tableName)
+	if schema == "" {
+		schema = defaultPostgresSchema
+	}
+	query, err := b.grammar.CompileForeignKeys(schema, name)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []*ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		var columnsStr, foreignColumnsStr string
+		if err = rows.Scan(
+			&fk.Name, &columnsStr, &fk.ForeignSchema, &fk.ForeignTable, &foreignColumnsStr,
+			&fk.OnUpdate, &fk.OnDelete,
+		); err != nil {
+			return nil, err
+		}
+		fk.Columns = strings.Split(columnsStr, ",")
+		fk.ForeignColumns = strings.Split(foreignColumnsStr, ",")
+		fk.OnUpdate = postgresForeignKeyAction(fk.OnUpdate)
+		fk.OnDelete = postgresForeignKeyAction(fk.OnDelete)
+		foreignKeys = append(foreignKeys, &fk)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return foreignKeys, nil
+}
+
+// postgresForeignKeyAction returns the action of a foreign key for its pg_constraint code.
+func postgresForeignKeyAction(code string) string {
+	switch code {
+	case "c":
+		return "CASCADE"
+	case "n":
+		return "SET NULL"
+	case "d":
+		return "SET DEFAULT"
+	case "r":
+		return "RESTRICT"
+	default:
+		return "NO ACTION"
+	}
+}
+
+func (b *postgresBuilder) GetAllColumns(c Context) (map[string][]*Column, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileAllColumns()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string][]*Column)
+	for rows.Next() {
+		var schema, table string
+		var col Column
+		if err = rows.Scan(
+			&schema, &table,
+			&col.Name, &col.TypeName, &col.TypeFull, &col.Collation,
+			&col.Nullable, &col.DefaultVal, &col.Comment,
+		); err != nil {
+			return nil, err
+		}
+		name := postgresTableKey(schema, table)
+		columns[name] = append(columns[name], &col)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+func (b *postgresBuilder) GetAllIndexes(c Context) (map[string][]*Index, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileAllIndexes()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := make(map[string][]*Index)
+	for rows.Next() {
+		var schema, table, columnsStr string
+		var index Index
+		if err = rows.Scan(
+			&schema, &table,
+			&index.Name, &columnsStr, &index.Type, &index.Unique, &index.Primary,
+		); err != nil {
+			return nil, err
+		}
+		index.Columns = strings.Split(columnsStr, ",")
+		name := postgresTableKey(schema, table)
+		indexes[name] = append(indexes[name], &index)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// postgresTableKey returns the name GetColumns and GetIndexes take for a table: qualified with its
+// schema unless it is in the default schema.
+func postgresTableKey(schema, table string) string {
+	if schema == defaultPostgresSchema {
+		return table
+	}
+	return schema + "." + table
+}
+
 func (b *postgresBuilder) GetTables(c Context) ([]*TableInfo, error) {
 	if c == nil {
 		return nil, errors.New("invalid arguments: context is nil")
@@ -132,6 +310,139 @@ func (b *postgresBuilder) GetTables(c Context) ([]*TableInfo, error) {
 	return tables, nil
 }
 
+func (b *postgresBuilder) GetViews(c Context) ([]*ViewInfo, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	query, err := b.grammar.CompileViews("")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []*ViewInfo
+	for rows.Next() {
+		var view ViewInfo
+		if err = rows.Scan(&view.Name, &view.Schema, &view.Definition); err != nil {
+			return nil, err
+		}
+		views = append(views, &view)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (b *postgresBuilder) GetTableDDL(c Context, tableName string) (string, error) {
+	if c == nil || tableName == "" {
+		return "", errors.New("invalid arguments: context is nil or table name is empty")
+	}
+
+	schema, name := splitQualifiedName(tableName)
+	if schema == "" {
+		schema = defaultPostgresSchema
+	}
+	query, err := b.grammar.CompileTableDDL(schema, name)
+	if err != nil {
+		return "", err
+	}
+
+	var ddl string
+	if err = c.QueryRow(query).Scan(&ddl); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("table %s does not exist", tableName)
+		}
+		return "", err
+	}
+	return ddl, nil
+}
+
+func (b *postgresBuilder) ReorderColumns(c Context, table string, columns []string, options ReorderOptions) error {
+	if c == nil || table == "" || len(columns) == 0 {
+		return errors.New("invalid arguments: context is nil, table name or columns are empty")
+	}
+
+	ddl, err := b.GetTableDDL(c, table)
+	if err != nil {
+		return err
+	}
+	return b.reorderColumns(c, ddl, columns, options)
+}
+
+func (b *postgresBuilder) GetSchemaDDL(c Context) ([]string, error) {
+	if c == nil {
+		return nil, errors.New("invalid arguments: context is nil")
+	}
+
+	names, err := b.getCurrentSchemaTables(c)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := schemaDDL(c, names, b.GetTableDDL)
+	if err != nil {
+		return nil, err
+	}
+	types, err := b.getEnumTypeDDL(c)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(types, sequenceStatements(statements)...), statements...), nil
+}
+
+// getEnumTypeDDL returns the statements that create the enum types of the current schema, which
+// the columns of native enums use.
+func (b *postgresBuilder) getEnumTypeDDL(c Context) ([]string, error) {
+	var currentSchema string
+	if err := c.QueryRow(b.grammar.CompileCurrentSchema()).Scan(&currentSchema); err != nil {
+		return nil, err
+	}
+	query, err := b.grammar.CompileEnumTypeDDL(currentSchema)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var statement string
+		if err = rows.Scan(&statement); err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return statements, rows.Err()
+}
+
+// getCurrentSchemaTables returns the qualified names of the tables in the current schema.
+func (b *postgresBuilder) getCurrentSchemaTables(c Context) ([]string, error) {
+	var currentSchema string
+	if err := c.QueryRow(b.grammar.CompileCurrentSchema()).Scan(&currentSchema); err != nil {
+		return nil, err
+	}
+	tables, err := b.GetTables(c)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, table := range tables {
+		if table.Schema == currentSchema {
+			names = append(names, table.Schema+"."+table.Name)
+		}
+	}
+	return names, nil
+}
+
 func (b *postgresBuilder) HasColumn(c Context, tableName string, columnName string) (bool, error) {
 	return b.HasColumns(c, tableName, []string{columnName})
 }
@@ -218,7 +529,7 @@ func (b *postgresBuilder) HasTable(c Context, name string) (bool, error) {
 		return false, errors.New("invalid arguments: context is nil or table name is empty")
 	}
 
-	schema, name := b.parseSchemaAndTable(name)
+	schema, name := splitQualifiedName(name)
 	if schema == "" {
 		schema = "public" // Default schema for PostgreSQL
 	}
@@ -236,3 +547,57 @@ func (b *postgresBuilder) HasTable(c Context, name string) (bool, error) {
 	}
 	return exists, nil
 }
+
+func (b *postgresBuilder) HasView(c Context, name string) (bool, error) {
+	if c == nil || name == "" {
+		return false, errors.New("invalid arguments: context is nil or view name is empty")
+	}
+
+	schema, name := splitQualifiedName(name)
+	if schema == "" {
+		schema = defaultPostgresSchema
+	}
+	query, err := b.grammar.CompileViewExists(schema, name)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err = c.QueryRow(query).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return exists, nil
+}
+
+func (b *postgresBuilder) getEnumColumn(c Context, tableName string, column string) (*columnDefinition, error) {
+	schema, name := splitQualifiedName(tableName)
+	if schema == "" {
+		schema = defaultPostgresSchema
+	}
+	query, err := b.grammar.CompileEnumCheck(schema, name, column)
+	if err != nil {
+		return nil, err
+	}
+
+	var enumType, checkName, definition string
+	if err = c.QueryRow(query).Scan(&enumType, &checkName, &definition); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("column %s of table %s does not exist", column, tableName)
+		}
+		return nil, err
+	}
+	if enumType == "" && checkName == "" {
+		return nil, fmt.Errorf("column %s of table %s has no enum check constraint", column, tableName)
+	}
+	return &columnDefinition{
+		name:       column,
+		columnType: columnTypeEnum,
+		allowed:    parseEnumValues(definition),
+		nativeEnum: enumType != "",
+		enumType:   enumType,
+		enumCheck:  checkName,
+	}, nil
+}
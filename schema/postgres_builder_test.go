@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/akfaiz/migris/schema"
@@ -228,6 +229,39 @@ func (s *postgresBuilderSuite) TestDropIfExists() {
 	})
 }
 
+func (s *postgresBuilderSuite) TestDropAllTables() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		err := builder.DropAllTables(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when tables reference each other, should drop all of them", func() {
+		err = builder.Create(c, "teams", func(table *schema.Blueprint) {
+			table.ID()
+		})
+		s.Require().NoError(err)
+		err = builder.Create(c, "members", func(table *schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("team_id")
+			table.Foreign("team_id").References("id").On("teams")
+		})
+		s.Require().NoError(err)
+
+		err = builder.DropAllTables(c)
+		s.Require().NoError(err, "expected no error when dropping all tables")
+
+		tables, err := builder.GetTables(c)
+		s.Require().NoError(err)
+		s.Empty(tables, "expected no tables after dropping all tables")
+	})
+}
+
 func (s *postgresBuilderSuite) TestRename() {
 	builder := s.builder
 	tx, err := s.db.BeginTx(s.ctx, nil)
@@ -450,6 +484,92 @@ func (s *postgresBuilderSuite) TestGetIndexes() {
 	})
 }
 
+func (s *postgresBuilderSuite) TestGetForeignKeys() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetForeignKeys(nil, "posts")
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when table name is empty, should return error", func() {
+		_, err := builder.GetForeignKeys(c, "")
+		s.Require().Error(err, "expected error when table name is empty")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+		})
+		s.Require().NoError(err, "expected no error when creating users table")
+		err = builder.Create(c, "posts", func(table *schema.Blueprint) {
+			table.ID()
+			table.ForeignID("user_id").Constrained().CascadeOnDelete()
+		})
+		s.Require().NoError(err, "expected no error when creating posts table")
+
+		foreignKeys, err := builder.GetForeignKeys(c, "posts")
+		s.Require().NoError(err, "expected no error when getting foreign keys with valid parameters")
+		s.Require().Len(foreignKeys, 1, "expected 1 foreign key to be returned")
+		s.Equal("fk_posts_users", foreignKeys[0].Name)
+		s.Equal([]string{"user_id"}, foreignKeys[0].Columns)
+		s.Equal("public", foreignKeys[0].ForeignSchema)
+		s.Equal("users", foreignKeys[0].ForeignTable)
+		s.Equal([]string{"id"}, foreignKeys[0].ForeignColumns)
+		s.Equal("CASCADE", foreignKeys[0].OnDelete)
+		s.Equal("NO ACTION", foreignKeys[0].OnUpdate)
+	})
+	s.Run("when table does not exist, should return empty foreign keys", func() {
+		foreignKeys, err := builder.GetForeignKeys(c, "non_existent_table")
+		s.Require().NoError(err, "expected no error when getting foreign keys of non-existent table")
+		s.Empty(foreignKeys, "expected empty foreign keys for non-existent table")
+	})
+}
+
+func (s *postgresBuilderSuite) TestGetAllColumnsAndIndexes() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetAllColumns(nil)
+		s.Require().Error(err, "expected error when context is nil")
+		_, err = builder.GetAllIndexes(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("should match the columns and indexes of each table", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("email", 255).Unique()
+		})
+		s.Require().NoError(err)
+		err = builder.Create(c, "posts", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("title", 255)
+		})
+		s.Require().NoError(err)
+
+		columns, err := builder.GetAllColumns(c)
+		s.Require().NoError(err)
+		indexes, err := builder.GetAllIndexes(c)
+		s.Require().NoError(err)
+		for _, table := range []string{"users", "posts"} {
+			tableColumns, err := builder.GetColumns(c, table)
+			s.Require().NoError(err)
+			s.Equal(tableColumns, columns[table])
+			tableIndexes, err := builder.GetIndexes(c, table)
+			s.Require().NoError(err)
+			s.ElementsMatch(tableIndexes, indexes[table])
+		}
+	})
+}
+
 func (s *postgresBuilderSuite) TestGetTables() {
 	builder := s.builder
 	tx, err := s.db.BeginTx(s.ctx, nil)
@@ -482,6 +602,200 @@ func (s *postgresBuilderSuite) TestGetTables() {
 	})
 }
 
+func (s *postgresBuilderSuite) TestGetViews() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetViews(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name", 255)
+			table.Boolean("active")
+		})
+		s.Require().NoError(err, "expected no error when creating table before creating view")
+		err = builder.CreateView(c, "active_users", func(view *schema.ViewBlueprint) {
+			view.As("SELECT id, name FROM users WHERE active")
+		})
+		s.Require().NoError(err, "expected no error when creating view")
+
+		views, err := builder.GetViews(c)
+		s.Require().NoError(err, "expected no error when getting views with valid parameters")
+		s.Require().Len(views, 1, "expected 1 view to be returned")
+		s.Equal("active_users", views[0].Name, "expected view name to be 'active_users'")
+		s.Equal("public", views[0].Schema, "expected view schema to be 'public'")
+		s.Contains(views[0].Definition, "users", "expected view definition to select from users")
+	})
+}
+
+func (s *postgresBuilderSuite) TestGetTableDDL() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetTableDDL(nil, "users")
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when table name is empty, should return error", func() {
+		_, err := builder.GetTableDDL(c, "")
+		s.Require().Error(err, "expected error when table name is empty")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name", 255).Comment("Full name")
+			table.String("email", 255).Unique()
+		})
+		s.Require().NoError(err, "expected no error when creating table before getting its DDL")
+
+		ddl, err := builder.GetTableDDL(c, "users")
+		s.Require().NoError(err, "expected no error when getting DDL of an existing table")
+		s.Contains(ddl, "CREATE TABLE public.users (", "expected DDL to create the users table")
+		s.Contains(ddl, "CONSTRAINT uk_users_email UNIQUE (email)", "expected DDL to contain the unique constraint")
+		s.Contains(ddl, "COMMENT ON COLUMN public.users.name IS 'Full name';", "expected DDL to contain the column comment")
+	})
+}
+
+func (s *postgresBuilderSuite) TestGetSchemaDDL() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when context is nil, should return error", func() {
+		_, err := builder.GetSchemaDDL(nil)
+		s.Require().Error(err, "expected error when context is nil")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+		})
+		s.Require().NoError(err, "expected no error when creating users table")
+		err = builder.Create(c, "posts", func(table *schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("user_id")
+			table.Foreign("user_id").References("id").On("users")
+		})
+		s.Require().NoError(err, "expected no error when creating posts table")
+
+		statements, err := builder.GetSchemaDDL(c)
+		s.Require().NoError(err, "expected no error when getting the schema DDL")
+		s.Require().NotEmpty(statements, "expected statements for the existing tables")
+		s.Equal("CREATE SEQUENCE IF NOT EXISTS posts_id_seq", statements[0], "expected the sequence of the serial column first")
+		s.Contains(statements, "ALTER TABLE public.posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)", "expected the foreign key as a separate statement")
+		s.Equal("ALTER TABLE public.posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)", statements[len(statements)-1], "expected foreign keys after all tables")
+		s.Contains(strings.Join(statements, "\n"), "CREATE TABLE public.posts (", "expected the posts table to be created")
+	})
+	s.Run("when a table has a native enum column, should create its type first", func() {
+		err = builder.Create(c, "orders", func(table *schema.Blueprint) {
+			table.ID()
+			table.Enum("status", []string{"pending", "paid"}).Native()
+		})
+		s.Require().NoError(err, "expected no error when creating the orders table")
+
+		statements, err := builder.GetSchemaDDL(c)
+		s.Require().NoError(err, "expected no error when getting the schema DDL")
+		s.Equal("CREATE TYPE public.orders_status AS ENUM ('pending', 'paid')", statements[0],
+			"expected the enum type before the tables")
+	})
+	s.Run("when the schema has partitioned tables, should load back", func() {
+		err = builder.Create(c, "events", func(table *schema.Blueprint) {
+			table.Date("created_at")
+			table.String("name").Index()
+			table.PartitionBy(schema.PartitionRange, "created_at")
+			table.Partition("events_2025", schema.RangeBounds("'2025-01-01'", "'2026-01-01'"))
+			table.Partition("events_2026", schema.RangeBounds("'2026-01-01'", "'2027-01-01'"))
+		})
+		s.Require().NoError(err, "expected no error when creating the partitioned table")
+
+		dump := func() []string {
+			statements, err := builder.GetSchemaDDL(c)
+			s.Require().NoError(err, "expected no error when getting the schema DDL")
+			var events []string
+			for _, statement := range statements {
+				if strings.Contains(statement, "events") {
+					events = append(events, statement)
+				}
+			}
+			return events
+		}
+		statements := dump()
+		s.Contains(statements, "CREATE TABLE public.events_2025 PARTITION OF public.events "+
+			"FOR VALUES FROM ('2025-01-01') TO ('2026-01-01')", "expected the partition to be created from its parent")
+		s.Contains(strings.Join(statements, "\n"), ") PARTITION BY RANGE (created_at)",
+			"expected the partition key of the parent table")
+
+		s.Require().NoError(builder.Drop(c, "events"), "expected no error when dropping the partitioned table")
+		for _, statement := range statements {
+			_, err = c.Exec(statement)
+			s.Require().NoError(err, "expected no error when loading %q", statement)
+		}
+		s.Equal(statements, dump(), "expected the loaded tables to give the same dump")
+	})
+}
+
+func (s *postgresBuilderSuite) TestEnumValues() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when the column uses a native enum type", func() {
+		err = builder.Create(c, "orders", func(table *schema.Blueprint) {
+			table.ID()
+			table.Enum("status", []string{"pending", "paid"}).Native()
+		})
+		s.Require().NoError(err, "expected no error when creating the orders table")
+
+		err = builder.Table(c, "orders", func(table *schema.Blueprint) {
+			table.AddEnumValue("status", "shipped")
+			table.RenameEnumValue("status", "paid", "settled")
+		})
+		s.Require().NoError(err, "expected no error when changing the values of the native enum")
+
+		var values string
+		err = c.QueryRow("select array_to_string(enum_range(null::orders_status), ',')").Scan(&values)
+		s.Require().NoError(err)
+		s.Equal("pending,settled,shipped", values, "expected the values to be changed in the enum type")
+
+		err = builder.Table(c, "orders", func(table *schema.Blueprint) {
+			table.DropEnumValue("status", "shipped")
+		})
+		s.Require().ErrorIs(err, schema.ErrUnsupportedFeature, "expected values of a native enum not to be dropped")
+	})
+	s.Run("when the check constraint name is truncated", func() {
+		table := "customer_subscription_renewal_notifications_archive"
+		err = builder.Create(c, table, func(table *schema.Blueprint) {
+			table.ID()
+			table.Enum("delivery_status", []string{"queued", "sent"})
+		})
+		s.Require().NoError(err, "expected no error when creating the table")
+
+		err = builder.Table(c, table, func(table *schema.Blueprint) {
+			table.AddEnumValue("delivery_status", "failed")
+		})
+		s.Require().NoError(err, "expected no error when adding a value to the enum column")
+
+		_, err = c.Exec("insert into " + table + " (delivery_status) values ('failed')")
+		s.Require().NoError(err, "expected the added value to be allowed")
+	})
+}
+
 func (s *postgresBuilderSuite) TestHasColumn() {
 	builder := s.builder
 	tx, err := s.db.BeginTx(s.ctx, nil)
@@ -661,3 +975,37 @@ func (s *postgresBuilderSuite) TestHasTable() {
 		s.False(exists, "expected exists to be false for non-existent table with custom schema")
 	})
 }
+
+func (s *postgresBuilderSuite) TestHasView() {
+	builder := s.builder
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Run("when view name is empty, should return error", func() {
+		exists, err := builder.HasView(c, "")
+		s.Require().Error(err, "expected error when view name is empty")
+		s.False(exists, "expected exists to be false when view name is empty")
+	})
+	s.Run("when all parameters are valid", func() {
+		err = builder.Create(c, "users", func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name", 255)
+		})
+		s.Require().NoError(err, "expected no error when creating table before creating view")
+		err = builder.CreateView(c, "user_names", func(view *schema.ViewBlueprint) {
+			view.As("SELECT name FROM users")
+		})
+		s.Require().NoError(err, "expected no error when creating view")
+
+		exists, err := builder.HasView(c, "user_names")
+		s.Require().NoError(err, "expected no error when checking if view exists")
+		s.True(exists, "expected exists to be true for existing view")
+
+		exists, err = builder.HasView(c, "users")
+		s.Require().NoError(err, "expected no error when checking a table")
+		s.False(exists, "expected exists to be false for a table")
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 )
 
+var postgresIndexAlgorithms = []string{"btree", "hash", "gist", "spgist", "gin", "brin"}
+
 type postgresGrammar struct {
 	baseGrammar
 }
@@ -30,6 +33,296 @@ func (g *postgresGrammar) CompileTables(_ string) (string, error) {
 		"order by c.relname", nil
 }
 
+func (g *postgresGrammar) CompileViews(_ string) (string, error) {
+	return "select c.relname as name, n.nspname as schema, pg_get_viewdef(c.oid) as definition " +
+		"from pg_class c, pg_namespace n " +
+		"where c.relkind = 'v' and n.oid = c.relnamespace and n.nspname not in ('pg_catalog', 'information_schema') " +
+		"order by c.relname", nil
+}
+
+func (g *postgresGrammar) CompileViewExists(schema string, view string) (string, error) {
+	return fmt.Sprintf(
+		"SELECT 1 FROM information_schema.views WHERE table_schema = %s AND table_name = %s",
+		g.QuoteString(schema),
+		g.QuoteString(view),
+	), nil
+}
+
+func (g *postgresGrammar) CompileCurrentSchema() string {
+	return "select current_schema()"
+}
+
+func (g *postgresGrammar) CompileDropAllTables(tables []string) (string, error) {
+	if len(tables) == 0 || slices.Contains(tables, "") {
+		return "", errors.New("table names cannot be empty")
+	}
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", strings.Join(tables, ", ")), nil
+}
+
+func (g *postgresGrammar) CompileDisableForeignKeyConstraints() string {
+	return "SET session_replication_role = replica"
+}
+
+func (g *postgresGrammar) CompileEnableForeignKeyConstraints() string {
+	return "SET session_replication_role = DEFAULT"
+}
+
+func (g *postgresGrammar) CompileDeferConstraints() (string, error) {
+	return "SET CONSTRAINTS ALL DEFERRED", nil
+}
+
+func (g *postgresGrammar) CompileCreateEnumType(name string, values []string) (string, error) {
+	if name == "" {
+		return "", errors.New("enum type name cannot be empty")
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("enum type %s has no values", name)
+	}
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = g.QuoteString(value)
+	}
+	return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", name, strings.Join(quoted, ", ")), nil
+}
+
+func (g *postgresGrammar) CompileDropEnumType(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("enum type name cannot be empty")
+	}
+	return fmt.Sprintf("DROP TYPE %s", name), nil
+}
+
+// CompileEnumTypeDDL returns a query that lists the CREATE TYPE statements of the enum types in
+// the schema, with their values in their sort order.
+func (g *postgresGrammar) CompileEnumTypeDDL(schema string) (string, error) {
+	return fmt.Sprintf(
+		"select format('CREATE TYPE %%I.%%I AS ENUM (%%s)', n.nspname, t.typname, "+
+			"string_agg(quote_literal(e.enumlabel), ', ' order by e.enumsortorder)) "+
+			"from pg_type t join pg_namespace n on n.oid = t.typnamespace "+
+			"join pg_enum e on e.enumtypid = t.oid "+
+			"where n.nspname = %s group by n.nspname, t.typname order by t.typname",
+		g.QuoteString(schema),
+	), nil
+}
+
+func (g *postgresGrammar) CompileCreateMaterializedView(view *ViewBlueprint) (string, error) {
+	definition, err := g.viewDefinition(view)
+	if err != nil {
+		return "", err
+	}
+	withNoData := ""
+	if view.withNoData {
+		withNoData = " WITH NO DATA"
+	}
+	return fmt.Sprintf("CREATE MATERIALIZED VIEW %s%s", definition, withNoData), nil
+}
+
+func (g *postgresGrammar) CompileRefreshMaterializedView(name string, options RefreshOptions) (string, error) {
+	if name == "" {
+		return "", errors.New("materialized view name cannot be empty")
+	}
+	if options.Concurrently {
+		return fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", name), nil
+	}
+	return fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", name), nil
+}
+
+func (g *postgresGrammar) CompileDropMaterializedView(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("materialized view name cannot be empty")
+	}
+	return fmt.Sprintf("DROP MATERIALIZED VIEW %s", name), nil
+}
+
+func (g *postgresGrammar) CompileCreateSequence(seq *SequenceBlueprint) (string, error) {
+	clauses := g.sequenceClauses(seq, "START WITH")
+	if seq.dataType != "" {
+		clauses = append([]string{"AS " + seq.dataType}, clauses...)
+	}
+	if len(clauses) == 0 {
+		return fmt.Sprintf("CREATE SEQUENCE %s", seq.name), nil
+	}
+	return fmt.Sprintf("CREATE SEQUENCE %s %s", seq.name, strings.Join(clauses, " ")), nil
+}
+
+func (g *postgresGrammar) CompileAlterSequence(seq *SequenceBlueprint) (string, error) {
+	clauses := g.sequenceClauses(seq, "RESTART WITH")
+	if seq.dataType != "" {
+		clauses = append([]string{"AS " + seq.dataType}, clauses...)
+	}
+	if len(clauses) == 0 {
+		return "", fmt.Errorf("sequence %s has no changes", seq.name)
+	}
+	return fmt.Sprintf("ALTER SEQUENCE %s %s", seq.name, strings.Join(clauses, " ")), nil
+}
+
+func (g *postgresGrammar) CompileDropSequence(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("sequence name cannot be empty")
+	}
+	return fmt.Sprintf("DROP SEQUENCE %s", name), nil
+}
+
+// sequenceClauses returns the options set on the sequence, with the start value introduced by
+// startKeyword.
+func (g *postgresGrammar) sequenceClauses(seq *SequenceBlueprint, startKeyword string) []string {
+	var clauses []string
+	if seq.increment != nil {
+		clauses = append(clauses, fmt.Sprintf("INCREMENT BY %d", *seq.increment))
+	}
+	if seq.minValue != nil {
+		clauses = append(clauses, fmt.Sprintf("MINVALUE %d", *seq.minValue))
+	}
+	if seq.maxValue != nil {
+		clauses = append(clauses, fmt.Sprintf("MAXVALUE %d", *seq.maxValue))
+	}
+	if seq.start != nil {
+		clauses = append(clauses, fmt.Sprintf("%s %d", startKeyword, *seq.start))
+	}
+	if seq.cache != nil {
+		clauses = append(clauses, fmt.Sprintf("CACHE %d", *seq.cache))
+	}
+	if seq.cycle != nil && *seq.cycle {
+		clauses = append(clauses, "CYCLE")
+	} else if seq.cycle != nil {
+		clauses = append(clauses, "NO CYCLE")
+	}
+	if seq.ownedBy != "" {
+		clauses = append(clauses, "OWNED BY "+seq.ownedBy)
+	}
+	return clauses
+}
+
+func (g *postgresGrammar) CompileCreateFunction(fn *FunctionBlueprint) (string, error) {
+	signature, err := g.functionSignature(fn)
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(fn.body, "$function$") {
+		return "", fmt.Errorf("function %s: the body cannot contain $function$", fn.name)
+	}
+	language := fn.language
+	if language == "" {
+		language = "plpgsql"
+	}
+	volatility := ""
+	if fn.deterministic {
+		volatility = " IMMUTABLE"
+	}
+	return fmt.Sprintf("CREATE FUNCTION %s LANGUAGE %s%s AS $function$%s$function$",
+		signature, language, volatility, fn.body), nil
+}
+
+func (g *postgresGrammar) CompileCreateTrigger(trigger *TriggerBlueprint) (string, error) {
+	events, err := g.triggerEvents(trigger)
+	if err != nil {
+		return "", err
+	}
+	if trigger.body != "" {
+		return "", fmt.Errorf("trigger %s: postgres triggers execute a function, call Execute instead of Body",
+			trigger.name)
+	}
+	if trigger.function == "" {
+		return "", fmt.Errorf("trigger %s has no function, call Execute", trigger.name)
+	}
+	forEach := "ROW"
+	if trigger.forEachStatement {
+		forEach = "STATEMENT"
+	}
+	when := ""
+	if trigger.when != "" {
+		when = fmt.Sprintf(" WHEN (%s)", trigger.when)
+	}
+	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH %s%s EXECUTE FUNCTION %s()",
+		trigger.name, trigger.timing, strings.Join(events, " OR "), trigger.table, forEach, when,
+		trigger.function), nil
+}
+
+func (g *postgresGrammar) CompileDropTrigger(name, table string) (string, error) {
+	if name == "" || table == "" {
+		return "", errors.New("trigger name and table cannot be empty")
+	}
+	return fmt.Sprintf("DROP TRIGGER %s ON %s", name, table), nil
+}
+
+func (g *postgresGrammar) CompileCreateEventTrigger(trigger *EventTriggerBlueprint) (string, error) {
+	event, err := g.eventTriggerEvent(trigger)
+	if err != nil {
+		return "", err
+	}
+	if trigger.function == "" {
+		return "", fmt.Errorf("event trigger %s has no function, call Execute", trigger.name)
+	}
+	when := ""
+	if len(trigger.tags) > 0 {
+		tags := make([]string, len(trigger.tags))
+		for i, tag := range trigger.tags {
+			tags[i] = g.quoteLiteral(strings.ToUpper(strings.TrimSpace(tag)))
+		}
+		when = fmt.Sprintf(" WHEN TAG IN (%s)", strings.Join(tags, ", "))
+	}
+	return fmt.Sprintf("CREATE EVENT TRIGGER %s ON %s%s EXECUTE FUNCTION %s()",
+		trigger.name, event, when, trigger.function), nil
+}
+
+func (g *postgresGrammar) CompileDropEventTrigger(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("event trigger name cannot be empty")
+	}
+	return fmt.Sprintf("DROP EVENT TRIGGER %s", name), nil
+}
+
+// CompileEnumTypes creates the native enum types of the added enum columns and adds the missing
+// values to the types of the changed ones. A shared type, e.g. one named after a registered enum,
+// is only created if it does not exist yet, as another table may have created it already.
+// ALTER TYPE ... ADD VALUE can run in a transaction from PostgreSQL 12, but the new values cannot
+// be used before the transaction is committed.
+func (g *postgresGrammar) CompileEnumTypes(blueprint *Blueprint) []string {
+	var statements []string
+	for _, col := range blueprint.columns {
+		if col.columnType != columnTypeEnum || !col.nativeEnum || len(col.allowed) == 0 {
+			continue
+		}
+		if !col.change {
+			sql, _ := g.CompileCreateEnumType(col.enumType, col.allowed)
+			if blueprint.sharesEnumType(col) {
+				sql = fmt.Sprintf("DO $$ BEGIN IF to_regtype(%s) IS NULL THEN %s; END IF; END $$",
+					g.QuoteString(col.enumType), sql)
+			}
+			statements = append(statements, sql)
+			continue
+		}
+		for _, value := range col.allowed {
+			statements = append(statements,
+				fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s", col.enumType, g.QuoteString(value)))
+		}
+	}
+	return statements
+}
+
+// CompileEnumCheck returns a query for the definition of an enum column: the name of its native
+// enum type with the quoted values of the type, or else the name and definition of the check
+// constraint on the column alone, preferring the one named by the enum convention.
+func (g *postgresGrammar) CompileEnumCheck(schema, table, column string) (string, error) {
+	return fmt.Sprintf(
+		"select case when t.typtype = 'e' then format_type(t.oid, null) else '' end as enum_type, "+
+			"case when t.typtype = 'e' then '' else coalesce(ck.conname, '') end as check_name, "+
+			"case when t.typtype = 'e' then (select string_agg(quote_literal(e.enumlabel), ', ' "+
+			"order by e.enumsortorder) from pg_enum e where e.enumtypid = t.oid) "+
+			"else coalesce(ck.definition, '') end as definition "+
+			"from pg_attribute a join pg_class c on c.oid = a.attrelid "+
+			"join pg_namespace n on n.oid = c.relnamespace join pg_type t on t.oid = a.atttypid "+
+			"left join lateral (select con.conname, pg_get_constraintdef(con.oid) as definition "+
+			"from pg_constraint con where con.conrelid = c.oid and con.contype = 'c' and con.conkey = array[a.attnum] "+
+			"order by con.conname = %s desc, con.conname limit 1) ck on true "+
+			"where c.relname = %s and n.nspname = %s and a.attname = %s and a.attnum > 0 and not a.attisdropped",
+		g.QuoteString(g.enumCheckName(table, column)),
+		g.QuoteString(table),
+		g.QuoteString(schema),
+		g.QuoteString(column),
+	), nil
+}
+
 func (g *postgresGrammar) CompileColumns(schema, table string) (string, error) {
 	return fmt.Sprintf(
 		"select a.attname as name, t.typname as type_name, format_type(a.atttypid, a.atttypmod) as type, "+
@@ -63,13 +356,407 @@ func (g *postgresGrammar) CompileIndexes(schema, table string) (string, error) {
 	), nil
 }
 
+// CompileForeignKeys compiles the query for the foreign keys of a table. The actions are the codes
+// of pg_constraint, e.g. "c" for CASCADE.
+func (g *postgresGrammar) CompileForeignKeys(schema, table string) (string, error) {
+	return fmt.Sprintf(
+		"select c.conname as name, string_agg(la.attname, ',' order by conseq.ord) as columns, "+
+			"fn.nspname as foreign_schema, fc.relname as foreign_table, "+
+			"string_agg(fa.attname, ',' order by conseq.ord) as foreign_columns, "+
+			"c.confupdtype as on_update, c.confdeltype as on_delete "+
+			"from pg_constraint c "+
+			"join pg_class tc on c.conrelid = tc.oid "+
+			"join pg_namespace tn on tn.oid = tc.relnamespace "+
+			"join pg_class fc on c.confrelid = fc.oid "+
+			"join pg_namespace fn on fn.oid = fc.relnamespace "+
+			"join lateral unnest(c.conkey) with ordinality as conseq(num, ord) on true "+
+			"join pg_attribute la on la.attrelid = c.conrelid and la.attnum = conseq.num "+
+			"join pg_attribute fa on fa.attrelid = c.confrelid and fa.attnum = c.confkey[conseq.ord] "+
+			"where c.contype = 'f' and tc.relname = %s and tn.nspname = %s "+
+			"group by c.conname, fn.nspname, fc.relname, c.confupdtype, c.confdeltype "+
+			"order by c.conname",
+		g.QuoteString(table),
+		g.QuoteString(schema),
+	), nil
+}
+
+// CompileAllColumns compiles the query for the columns of every table, like CompileColumns, led by
+// the schema and the name of the table.
+func (g *postgresGrammar) CompileAllColumns() (string, error) {
+	return "select n.nspname as schema, c.relname as table_name, " +
+		"a.attname as name, t.typname as type_name, format_type(a.atttypid, a.atttypmod) as type, " +
+		"(select tc.collcollate from pg_catalog.pg_collation tc where tc.oid = a.attcollation) as collation, " +
+		"not a.attnotnull as nullable, " +
+		"(select pg_get_expr(adbin, adrelid) from pg_attrdef " +
+		"where c.oid = pg_attrdef.adrelid and pg_attrdef.adnum = a.attnum) as default, " +
+		"col_description(c.oid, a.attnum) as comment " +
+		"from pg_attribute a, pg_class c, pg_type t, pg_namespace n " +
+		"where c.relkind in ('r', 'p') and n.nspname not in ('pg_catalog', 'information_schema') " +
+		"and a.attnum > 0 and a.attrelid = c.oid and a.atttypid = t.oid and n.oid = c.relnamespace " +
+		"order by n.nspname, c.relname, a.attnum", nil
+}
+
+// CompileAllIndexes compiles the query for the indexes of every table, like CompileIndexes, led by
+// the schema and the name of the table.
+func (g *postgresGrammar) CompileAllIndexes() (string, error) {
+	return "select tn.nspname as schema, tc.relname as table_name, " +
+		"ic.relname as name, string_agg(a.attname, ',' order by indseq.ord) as columns, " +
+		"am.amname as \"type\", i.indisunique as \"unique\", i.indisprimary as \"primary\" " +
+		"from pg_index i " +
+		"join pg_class tc on tc.oid = i.indrelid " +
+		"join pg_namespace tn on tn.oid = tc.relnamespace " +
+		"join pg_class ic on ic.oid = i.indexrelid " +
+		"join pg_am am on am.oid = ic.relam " +
+		"join lateral unnest(i.indkey) with ordinality as indseq(num, ord) on true " +
+		"left join pg_attribute a on a.attrelid = i.indrelid and a.attnum = indseq.num " +
+		"where tc.relkind in ('r', 'p') and tn.nspname not in ('pg_catalog', 'information_schema') " +
+		"group by tn.nspname, tc.relname, ic.relname, am.amname, i.indisunique, i.indisprimary " +
+		"order by tn.nspname, tc.relname, ic.relname", nil
+}
+
+// CompileTableDDL reconstructs the CREATE TABLE statement of a table from the catalog, followed by
+// its standalone indexes and comments, similar to the output of pg_dump. A partitioned table keeps
+// its PARTITION BY clause, and a partition is created with PARTITION OF its parent, from which it
+// inherits the columns, constraints and indexes.
+func (g *postgresGrammar) CompileTableDDL(schema, table string) (string, error) {
+	qualified := "quote_ident(n.nspname) || '.' || quote_ident(c.relname)"
+	return fmt.Sprintf(
+		"select 'CREATE TABLE ' || "+qualified+" || case when c.relispartition then "+
+			"' PARTITION OF ' || quote_ident(pn.nspname) || '.' || quote_ident(pc.relname) || "+
+			"case when b.body <> '' then E' (\\n' || b.body || E'\\n)' else '' end || "+
+			"' ' || pg_get_expr(c.relpartbound, c.oid) "+
+			"else E' (\\n' || b.body || E'\\n)' end || "+
+			"coalesce(' PARTITION BY ' || pg_get_partkeydef(c.oid), '') || ';' || "+
+			// The indexes of a partitioned table are created on the partitions created after it.
+			"coalesce((select string_agg(E'\\n' || replace(pg_get_indexdef(i.indexrelid), ' ON ONLY ', ' ON ') || ';', "+
+			"'' order by i.indexrelid) "+
+			"from pg_index i where i.indrelid = c.oid and not exists "+
+			"(select 1 from pg_constraint con where con.conrelid = c.oid and con.conindid = i.indexrelid) "+
+			"and not exists (select 1 from pg_inherits inh where inh.inhrelid = i.indexrelid)), '') || "+
+			"coalesce(E'\\nCOMMENT ON TABLE ' || "+qualified+" || ' IS ' || "+
+			"quote_literal(obj_description(c.oid, 'pg_class')) || ';', '') || "+
+			"coalesce((select string_agg(E'\\nCOMMENT ON COLUMN ' || "+qualified+" || '.' || quote_ident(a.attname) || "+
+			"' IS ' || quote_literal(col_description(c.oid, a.attnum)) || ';', '' order by a.attnum) "+
+			"from pg_attribute a where a.attrelid = c.oid and a.attnum > 0 and not a.attisdropped "+
+			"and col_description(c.oid, a.attnum) is not null), '') as ddl "+
+			"from pg_class c join pg_namespace n on n.oid = c.relnamespace "+
+			"left join pg_inherits pi on pi.inhrelid = c.oid and c.relispartition "+
+			"left join pg_class pc on pc.oid = pi.inhparent "+
+			"left join pg_namespace pn on pn.oid = pc.relnamespace "+
+			"cross join lateral (select array_to_string("+
+			"array(select '    ' || quote_ident(a.attname) || ' ' || format_type(a.atttypid, a.atttypmod) || "+
+			"case a.attidentity when 'a' then ' GENERATED ALWAYS AS IDENTITY' "+
+			"when 'd' then ' GENERATED BY DEFAULT AS IDENTITY' else '' end || "+
+			"case when a.attcollation <> t.typcollation then ' COLLATE ' || quote_ident(co.collname) else '' end || "+
+			"case when a.attnotnull then ' NOT NULL' else '' end || "+
+			"coalesce(' DEFAULT ' || pg_get_expr(d.adbin, d.adrelid), '') "+
+			"from pg_attribute a join pg_type t on t.oid = a.atttypid "+
+			"left join pg_collation co on co.oid = a.attcollation "+
+			"left join pg_attrdef d on d.adrelid = a.attrelid and d.adnum = a.attnum "+
+			"where a.attrelid = c.oid and a.attnum > 0 and not a.attisdropped and not c.relispartition "+
+			"order by a.attnum) || "+
+			"array(select '    CONSTRAINT ' || quote_ident(con.conname) || ' ' || pg_get_constraintdef(con.oid) "+
+			"from pg_constraint con where con.conrelid = c.oid and con.contype <> 'n' "+
+			"and con.conparentid = 0 and (con.conislocal or not c.relispartition) "+
+			"order by case con.contype when 'p' then 0 when 'u' then 1 when 'c' then 2 else 3 end, con.conname), "+
+			"E',\\n') as body) b "+
+			"where c.relname = %s and n.nspname = %s and c.relkind in ('r', 'p')",
+		g.QuoteString(table),
+		g.QuoteString(schema),
+	), nil
+}
+
+// CompileCloneSchema returns a query that lists the statements cloning the regular tables of the
+// source schema into the new target schema, in the order they must run: the tables, the
+// sequences of serial columns, the rows, the primary keys, unique and exclusion constraints,
+// the indexes and finally the foreign keys. The indexes and constraints keep their names, and
+// foreign keys between tables of the source schema reference the cloned tables.
+func (g *postgresGrammar) CompileCloneSchema(source, target string, options CloneOptions) (string, error) {
+	if source == "" || target == "" {
+		return "", errors.New("source and target schema names cannot be empty")
+	}
+	if source == target {
+		return "", errors.New("source and target schema names must differ")
+	}
+
+	tables := "from pg_class c join pg_namespace n on n.oid = c.relnamespace "
+	filter := "where n.nspname = :source and c.relkind = 'r' and not c.relispartition"
+	columns := "join pg_attribute a on a.attrelid = c.oid and a.attnum > 0 and not a.attisdropped " +
+		"left join pg_attrdef d on d.adrelid = a.attrelid and d.adnum = a.attnum "
+	// The defaults of serial columns call the sequence of the source table.
+	serial := "pg_get_expr(d.adbin, d.adrelid) like 'nextval(%'"
+	sequence := "c.relname || '_' || a.attname || '_seq'"
+
+	steps := []string{
+		"select 0 as step, ''::text as tbl, 0 as pos, format('CREATE SCHEMA %I', :target) as statement",
+		"select 1, c.relname::text, 0, format('CREATE TABLE %I.%I (LIKE %I.%I INCLUDING ALL EXCLUDING INDEXES)', " +
+			":target, c.relname, :source, c.relname) " + tables + filter,
+		"select 2, c.relname::text, a.attnum::int, format('CREATE SEQUENCE %I.%I OWNED BY %I.%I.%I', " +
+			":target, " + sequence + ", :target, c.relname, a.attname) " + tables + columns + filter + " and " + serial,
+		"select 3, c.relname::text, a.attnum::int, format('ALTER TABLE %I.%I ALTER COLUMN %I SET DEFAULT nextval(%L)', " +
+			":target, c.relname, a.attname, quote_ident(:target) || '.' || quote_ident(" + sequence + ")) " +
+			tables + columns + filter + " and " + serial,
+	}
+	if dataFilter, ok := g.cloneDataFilter(options); ok {
+		steps = append(steps,
+			"select 4, c.relname::text, 0, format('INSERT INTO %I.%I (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM %I.%I', "+
+				":target, c.relname, cols, cols, :source, c.relname) "+tables+
+				"cross join lateral (select string_agg(quote_ident(a.attname), ', ' order by a.attnum) as cols "+
+				"from pg_attribute a where a.attrelid = c.oid and a.attnum > 0 and not a.attisdropped "+
+				"and a.attgenerated = '') l "+filter+dataFilter,
+			"select 5, c.relname::text, a.attnum::int, "+
+				"format('SELECT setval(pg_get_serial_sequence(%L, %L), coalesce(max(%I), 0) + 1, false) FROM %I.%I', "+
+				"quote_ident(:target) || '.' || quote_ident(c.relname), a.attname, a.attname, :target, c.relname) "+
+				tables+columns+filter+dataFilter+" and ("+serial+" or a.attidentity <> '')",
+		)
+	}
+	steps = append(steps,
+		"select 6, c.relname::text, 0, format('ALTER TABLE %I.%I ADD CONSTRAINT %I %s', "+
+			":target, c.relname, con.conname, pg_get_constraintdef(con.oid)) "+tables+
+			"join pg_constraint con on con.conrelid = c.oid and con.contype in ('p', 'u', 'x') "+filter,
+		// The definition of the index is reused from the access method on.
+		"select 7, c.relname::text, 0, format('CREATE %sINDEX %I ON %I.%I', "+
+			"case when i.indisunique then 'UNIQUE ' else '' end, ic.relname, :target, c.relname) || "+
+			"substring(pg_get_indexdef(i.indexrelid) from ' USING .*$') "+tables+
+			"join pg_index i on i.indrelid = c.oid join pg_class ic on ic.oid = i.indexrelid "+filter+
+			" and not exists (select 1 from pg_constraint con where con.conrelid = c.oid "+
+			"and con.conindid = i.indexrelid and con.contype in ('p', 'u', 'x'))",
+		"select 8, c.relname::text, 0, format('ALTER TABLE %I.%I ADD CONSTRAINT %I %s', "+
+			":target, c.relname, con.conname, case when r.relnamespace = c.relnamespace then "+
+			"replace(pg_get_constraintdef(con.oid), 'REFERENCES ' || con.confrelid::regclass::text || '(', "+
+			"format('REFERENCES %I.%I(', :target, r.relname)) else pg_get_constraintdef(con.oid) end) "+tables+
+			"join pg_constraint con on con.conrelid = c.oid and con.contype = 'f' "+
+			"join pg_class r on r.oid = con.confrelid "+filter,
+	)
+
+	query := "select statement from (" + strings.Join(steps, " union all ") + ") s order by step, tbl, pos"
+	return strings.NewReplacer(
+		":source", g.quoteLiteral(source),
+		":target", g.quoteLiteral(target),
+	).Replace(query), nil
+}
+
+// cloneDataFilter returns the condition limiting the tables whose rows are cloned, and whether
+// any rows are cloned at all.
+func (g *postgresGrammar) cloneDataFilter(options CloneOptions) (string, bool) {
+	if options.WithData {
+		return "", true
+	}
+	if len(options.DataTables) == 0 {
+		return "", false
+	}
+	var names []string
+	for _, table := range options.DataTables {
+		names = append(names, g.quoteLiteral(table))
+	}
+	return fmt.Sprintf(" and c.relname in (%s)", strings.Join(names, ", ")), true
+}
+
+// CompileReorderColumns returns the statements that rebuild the table with the columns in the new
+// order from its definition returned by GetTableDDL. PostgreSQL cannot move columns, so the rows
+// are copied into a new table with the same definition, which then replaces the table: the
+// primary key, unique and exclusion constraints, indexes, foreign keys and comments are added
+// once the table has its name, the sequences of serial columns are handed over to the new table
+// and the sequences of identity columns continue after the copied values.
+func (g *postgresGrammar) CompileReorderColumns(
+	ddl string,
+	columns []string,
+	options ReorderOptions,
+) ([]string, error) {
+	statements, foreignKeys := splitTableDDL(ddl)
+	table, definitions, err := parseCreateTable(statements[0], '"')
+	if err != nil {
+		return nil, err
+	}
+	reordered, err := reorderColumns(definitions, columns)
+	if err != nil || !reordersColumns(definitions, reordered) {
+		return nil, err
+	}
+
+	schemaName, name := splitQualifiedName(table)
+	temporary := name + "_reordered"
+	if unquoted, ok := strings.CutSuffix(name, `"`); ok {
+		temporary = unquoted + `_reordered"`
+	}
+	if schemaName != "" {
+		temporary = schemaName + "." + temporary
+	}
+
+	var queries []string
+	if options.LockTimeout > 0 {
+		queries = append(queries, fmt.Sprintf("SET LOCAL lock_timeout = %d", options.LockTimeout.Milliseconds()))
+	}
+	queries = append(queries, fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", table))
+
+	// Check constraints are part of the new table, the others are added after the rename, as
+	// their indexes are named like those of the table and foreign keys may reference it.
+	lines := make([]string, 0, len(definitions))
+	var constraints, sequences, identities []string
+	for _, def := range reordered {
+		lines = append(lines, "    "+def.definition)
+		if match := sequencePattern.FindStringSubmatch(def.definition); match != nil {
+			sequences = append(sequences,
+				fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.%s", match[1], temporary, def.name))
+		}
+		if strings.Contains(def.definition, " AS IDENTITY") {
+			identities = append(identities, fmt.Sprintf(
+				"SELECT setval(pg_get_serial_sequence(%s, %s), coalesce(max(%s), 0) + 1, false) FROM %s",
+				g.quoteLiteral(table), g.quoteLiteral(def.column), def.name, table))
+		}
+	}
+	for _, def := range definitions {
+		switch {
+		case def.column != "":
+		case strings.Contains(def.definition, " CHECK "):
+			lines = append(lines, "    "+def.definition)
+		default:
+			constraints = append(constraints, fmt.Sprintf("ALTER TABLE %s ADD %s", table, def.definition))
+		}
+	}
+	names := strings.Join(columnNames(reordered), ", ")
+
+	queries = append(queries,
+		fmt.Sprintf("CREATE TABLE %s (\n%s\n)", temporary, strings.Join(lines, ",\n")),
+		fmt.Sprintf("INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM %s", temporary, names, names, table),
+	)
+	queries = append(queries, sequences...)
+	queries = append(queries,
+		fmt.Sprintf("DROP TABLE %s", table),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", temporary, name),
+	)
+	queries = append(queries, constraints...)
+	queries = append(queries, foreignKeys...)
+	queries = append(queries, statements[1:]...)
+	return append(queries, identities...), nil
+}
+
+func (g *postgresGrammar) CompileRenameSchema(from, to string) (string, error) {
+	if from == "" || to == "" {
+		return "", errors.New("schema names cannot be empty")
+	}
+	return fmt.Sprintf("ALTER SCHEMA %s RENAME TO %s", from, to), nil
+}
+
+func (g *postgresGrammar) CompileCreateSchema(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("schema name cannot be empty")
+	}
+	return fmt.Sprintf("CREATE SCHEMA %s", name), nil
+}
+
+func (g *postgresGrammar) CompileDropSchema(name string, cascade bool) (string, error) {
+	if name == "" {
+		return "", errors.New("schema name cannot be empty")
+	}
+	if cascade {
+		return fmt.Sprintf("DROP SCHEMA %s CASCADE", name), nil
+	}
+	return fmt.Sprintf("DROP SCHEMA %s", name), nil
+}
+
+func (g *postgresGrammar) CompileSchemaExists(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("schema name cannot be empty")
+	}
+	return fmt.Sprintf("SELECT 1 FROM pg_namespace WHERE nspname = %s", g.QuoteString(name)), nil
+}
+
+// CompileCreateExtension quotes the name of the extension, as names such as uuid-ossp are not
+// valid identifiers.
+func (g *postgresGrammar) CompileCreateExtension(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("extension name cannot be empty")
+	}
+	return fmt.Sprintf("CREATE EXTENSION %s", g.quoteExtension(name)), nil
+}
+
+func (g *postgresGrammar) CompileDropExtension(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("extension name cannot be empty")
+	}
+	return fmt.Sprintf("DROP EXTENSION %s", g.quoteExtension(name)), nil
+}
+
+func (g *postgresGrammar) CompileExtensionExists(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("extension name cannot be empty")
+	}
+	return fmt.Sprintf("SELECT 1 FROM pg_extension WHERE extname = %s", g.QuoteString(name)), nil
+}
+
+func (g *postgresGrammar) QuoteIdentifier(name string) string {
+	return g.quoteIdentifier(name, `"`)
+}
+
+func (g *postgresGrammar) quoteExtension(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
 func (g *postgresGrammar) CompileCreate(blueprint *Blueprint) (string, error) {
 	columns, err := g.getColumns(blueprint)
 	if err != nil {
 		return "", err
 	}
 	columns = append(columns, g.getConstraints(blueprint)...)
-	return fmt.Sprintf("CREATE TABLE %s (%s)", blueprint.name, strings.Join(columns, ", ")), nil
+	sql := fmt.Sprintf("%s %s (%s)", blueprint.createKeyword(), blueprint.name, strings.Join(columns, ", "))
+	if blueprint.partitionMethod != "" {
+		sql += fmt.Sprintf(" PARTITION BY %s (%s)", blueprint.partitionMethod, g.Columnize(blueprint.partitionColumns))
+	}
+	return sql, nil
+}
+
+// CompileAlterOptions returns the statements unchanged: PostgreSQL chooses how to alter a table
+// itself.
+func (g *postgresGrammar) CompileAlterOptions(_ *Blueprint, statements []string) ([]string, error) {
+	return statements, nil
+}
+
+// CompilePartitions creates the partitions declared with Partition, which are separate tables.
+func (g *postgresGrammar) CompilePartitions(blueprint *Blueprint) ([]string, error) {
+	statements := make([]string, 0, len(blueprint.partitions))
+	for _, partition := range blueprint.partitions {
+		sql, err := g.CompileCreatePartition(blueprint.name, partition.name, partition.bounds)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, sql)
+	}
+	return statements, nil
+}
+
+func (g *postgresGrammar) CompileCreatePartition(parent, name string, bounds PartitionBounds) (string, error) {
+	if err := checkPartitionNames(parent, name); err != nil {
+		return "", err
+	}
+	values, err := g.partitionBounds(name, bounds)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("CREATE TABLE %s PARTITION OF %s %s", name, parent, values), nil
+}
+
+// partitionBounds returns the FOR VALUES clause of a partition.
+func (g *postgresGrammar) partitionBounds(name string, bounds PartitionBounds) (string, error) {
+	switch bounds.method {
+	case PartitionRange:
+		if bounds.from == "" || bounds.to == "" {
+			return "", fmt.Errorf("range partition %s requires both bounds, use MINVALUE or MAXVALUE "+
+				"for an unbounded side", name)
+		}
+		return fmt.Sprintf("FOR VALUES FROM (%s) TO (%s)", bounds.from, bounds.to), nil
+	case PartitionList:
+		if len(bounds.values) == 0 {
+			return "", fmt.Errorf("list partition %s has no values", name)
+		}
+		return fmt.Sprintf("FOR VALUES IN (%s)", strings.Join(bounds.values, ", ")), nil
+	case PartitionHash:
+		if bounds.modulus <= 0 || bounds.remainder < 0 || bounds.remainder >= bounds.modulus {
+			return "", fmt.Errorf("hash partition %s requires a positive modulus and a remainder below it", name)
+		}
+		return fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", bounds.modulus, bounds.remainder), nil
+	default:
+		return "", fmt.Errorf("partition %s has no bounds, use RangeBounds, ListBounds or HashBounds", name)
+	}
 }
 
 func (g *postgresGrammar) CompileAdd(blueprint *Blueprint) (string, error) {
@@ -100,19 +787,84 @@ func (g *postgresGrammar) CompileChange(bp *Blueprint, command *command) (string
 		return "", errors.New("column name cannot be empty for change operation")
 	}
 
+	nativeEnum := column.columnType == columnTypeEnum && column.nativeEnum
 	var changes []string
-	changes = append(changes, fmt.Sprintf("TYPE %s", g.getType(command.column)))
+	// The values of a native enum type are changed with ALTER TYPE, see CompileEnumTypes.
+	if !nativeEnum {
+		changes = append(changes, fmt.Sprintf("TYPE %s", g.getType(command.column)))
+	}
 	for _, modifier := range g.modifiers() {
 		change := modifier(command.column)
 		if change != "" {
 			changes = append(changes, strings.TrimSpace(change))
 		}
 	}
+	changes = g.PrefixArray(fmt.Sprintf("ALTER COLUMN %s ", column.name), changes)
+	if column.columnType == columnTypeEnum && !nativeEnum {
+		checkName := g.enumCheckName(bp.name, column.name)
+		changes = append(changes,
+			fmt.Sprintf("DROP CONSTRAINT IF EXISTS %s", checkName),
+			fmt.Sprintf("ADD CONSTRAINT %s %s", checkName, g.enumCheck(column)),
+		)
+	}
+	if column.primary != nil && *column.primary {
+		changes = append(changes, fmt.Sprintf("ADD CONSTRAINT %s PRIMARY KEY (%s)",
+			g.CreateIndexName(bp, "primary"), column.name))
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
 
-	return fmt.Sprintf("ALTER TABLE %s %s",
-		bp.name,
-		strings.Join(g.PrefixArray(fmt.Sprintf("ALTER COLUMN %s ", column.name), changes), ", "),
-	), nil
+	return fmt.Sprintf("ALTER TABLE %s %s", bp.name, strings.Join(changes, ", ")), nil
+}
+
+// CompileEnumValues replaces the check constraint of the column with the new allowed values. The
+// values of a native enum type are added or renamed in the type instead; they cannot be dropped.
+func (g *postgresGrammar) CompileEnumValues(blueprint *Blueprint, command *command) ([]string, error) {
+	values, err := enumValuesAfter(command)
+	if err != nil {
+		return nil, err
+	}
+	column := *command.column
+	column.allowed = values
+	if column.nativeEnum {
+		return g.compileEnumTypeValues(&column, command)
+	}
+	checkName := column.enumCheck
+	if checkName == "" {
+		checkName = g.enumCheckName(blueprint.name, column.name)
+	}
+	drop := fmt.Sprintf("DROP CONSTRAINT IF EXISTS %s", checkName)
+	add := fmt.Sprintf("ADD CONSTRAINT %s %s", checkName, g.enumCheck(&column))
+
+	if command.name != commandRenameEnumValue {
+		return []string{fmt.Sprintf("ALTER TABLE %s %s, %s", blueprint.name, drop, add)}, nil
+	}
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s %s", blueprint.name, drop),
+		g.compileUpdateEnumValue(blueprint, command),
+		fmt.Sprintf("ALTER TABLE %s %s", blueprint.name, add),
+	}, nil
+}
+
+// compileEnumTypeValues adds or renames the values of the native enum type of the column. A
+// renamed value is renamed in the rows as well.
+func (g *postgresGrammar) compileEnumTypeValues(column *columnDefinition, command *command) ([]string, error) {
+	switch command.name {
+	case commandAddEnumValue:
+		statements := make([]string, 0, len(command.values))
+		for _, value := range command.values {
+			statements = append(statements,
+				fmt.Sprintf("ALTER TYPE %s ADD VALUE %s", column.enumType, g.QuoteString(value)))
+		}
+		return statements, nil
+	case commandRenameEnumValue:
+		return []string{fmt.Sprintf("ALTER TYPE %s RENAME VALUE %s TO %s",
+			column.enumType, g.QuoteString(command.from), g.QuoteString(command.to))}, nil
+	default:
+		return nil, fmt.Errorf("%w: values cannot be dropped from enum type %s of column %s",
+			ErrUnsupportedFeature, column.enumType, column.name)
+	}
 }
 
 func (g *postgresGrammar) CompileDrop(blueprint *Blueprint) (string, error) {
@@ -143,6 +895,12 @@ func (g *postgresGrammar) CompileRenameColumn(blueprint *Blueprint, command *com
 	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", blueprint.name, command.from, command.to), nil
 }
 
+func (g *postgresGrammar) CompileCreateRenameLog(table string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id BIGSERIAL PRIMARY KEY, "+
+		"table_name VARCHAR(255) NOT NULL, old_name VARCHAR(255) NOT NULL, new_name VARCHAR(255) NOT NULL, "+
+		"renamed_at TIMESTAMP(0) NOT NULL DEFAULT CURRENT_TIMESTAMP)", table)
+}
+
 func (g *postgresGrammar) CompileFullText(blueprint *Blueprint, command *command) (string, error) {
 	if slices.Contains(command.columns, "") {
 		return "", errors.New("fulltext index column cannot be empty")
@@ -161,10 +919,12 @@ func (g *postgresGrammar) CompileFullText(blueprint *Blueprint, command *command
 	}
 
 	return fmt.Sprintf(
-		"CREATE INDEX %s ON %s USING GIN (%s)",
+		"CREATE INDEX %s%s ON %s USING GIN (%s)%s",
+		g.concurrently(blueprint, command),
 		indexName,
 		blueprint.name,
 		strings.Join(columns, " || "),
+		g.whereClause(command),
 	), nil
 }
 
@@ -174,14 +934,136 @@ func (g *postgresGrammar) CompileIndex(blueprint *Blueprint, command *command) (
 	}
 	indexName := command.index
 	if indexName == "" {
-		indexName = g.CreateIndexName(blueprint, "index", command.columns...)
+		indexName = g.CreateIndexName(blueprint, "index", g.indexNameParts(command)...)
+	}
+
+	algorithm, err := g.normalizeAlgorithm(command.algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	// PostgreSQL requires the access method before the column list.
+	sql := fmt.Sprintf("CREATE INDEX %s%s ON %s", g.concurrently(blueprint, command), indexName, blueprint.name)
+	if algorithm != "" {
+		sql += fmt.Sprintf(" USING %s", algorithm)
+	}
+	return fmt.Sprintf("%s (%s)%s", sql, g.indexedColumns(command), g.whereClause(command)), nil
+}
+
+func (g *postgresGrammar) CompileInsert(table string, columns []string, rows int) string {
+	return g.compileInsert(table, columns, rows, func(n int) string { return "$" + strconv.Itoa(n) })
+}
+
+// CompileResetSequence moves the sequence of a serial or identity column past the highest value
+// of the column.
+func (g *postgresGrammar) CompileResetSequence(table, column string) string {
+	return fmt.Sprintf("SELECT setval(pg_get_serial_sequence(%s, %s), coalesce(max(%s), 0) + 1, false) FROM %s",
+		g.QuoteString(table), g.QuoteString(column), column, table)
+}
+
+func (g *postgresGrammar) CompileAnalyze(tables []string) (string, error) {
+	if len(tables) == 0 || slices.Contains(tables, "") {
+		return "", errors.New("tables must not be empty")
+	}
+	return "ANALYZE " + strings.Join(tables, ", "), nil
+}
+
+func (g *postgresGrammar) CompileTruncate(table string, options TruncateOptions) (string, error) {
+	if table == "" {
+		return "", errors.New("table name cannot be empty")
+	}
+	query := "TRUNCATE TABLE " + table
+	if options.RestartIdentity {
+		query += " RESTART IDENTITY"
+	}
+	if options.Cascade {
+		query += " CASCADE"
+	}
+	return query, nil
+}
+
+func (g *postgresGrammar) CompileOwner(blueprint *Blueprint, owner string) string {
+	return fmt.Sprintf("ALTER TABLE %s OWNER TO %s", blueprint.name, owner)
+}
+
+func (g *postgresGrammar) CompileDefaultPrivileges(privileges DefaultPrivileges) (string, error) {
+	if len(privileges.Privileges) == 0 || privileges.Grantee == "" {
+		return "", errors.New("privileges and grantee must not be empty")
+	}
+	objectType := privileges.ObjectType
+	if objectType == "" {
+		objectType = "TABLES"
+	}
+	sql := "ALTER DEFAULT PRIVILEGES"
+	if privileges.ForRole != "" {
+		sql += " FOR ROLE " + privileges.ForRole
+	}
+	if privileges.Schema != "" {
+		sql += " IN SCHEMA " + privileges.Schema
+	}
+	return fmt.Sprintf("%s GRANT %s ON %s TO %s",
+		sql, strings.Join(privileges.Privileges, ", "), strings.ToUpper(objectType), privileges.Grantee), nil
+}
+
+// CompileJSONIndex creates an expression index on the text of the JSON value.
+func (g *postgresGrammar) CompileJSONIndex(blueprint *Blueprint, command *command) (string, error) {
+	keys, column, err := g.jsonIndexTarget(command)
+	if err != nil {
+		return "", err
+	}
+	indexName := command.index
+	if indexName == "" {
+		indexName = g.CreateIndexName(blueprint, "index", column)
+	}
+	algorithm, err := g.normalizeAlgorithm(command.algorithm)
+	if err != nil {
+		return "", err
 	}
 
-	sql := fmt.Sprintf("CREATE INDEX %s ON %s", indexName, blueprint.name)
-	if command.algorithm != "" {
-		sql += fmt.Sprintf(" USING %s", command.algorithm)
+	sql := fmt.Sprintf("CREATE INDEX %s%s ON %s", g.concurrently(blueprint, command), indexName, blueprint.name)
+	if algorithm != "" {
+		sql += fmt.Sprintf(" USING %s", algorithm)
 	}
-	return fmt.Sprintf("%s (%s)", sql, g.Columnize(command.columns)), nil
+	return fmt.Sprintf("%s ((%s #>> '{%s}'))%s",
+		sql, command.columns[0], strings.Join(keys, ","), g.whereClause(command)), nil
+}
+
+// concurrently returns the CONCURRENTLY keyword of CREATE INDEX if the index is built without
+// blocking writes.
+func (g *postgresGrammar) concurrently(blueprint *Blueprint, command *command) string {
+	if g.buildsConcurrently(blueprint, command) {
+		return "CONCURRENTLY "
+	}
+	return ""
+}
+
+// whereClause returns the WHERE clause of a partial index.
+// nullsNotDistinct returns the NULLS NOT DISTINCT clause of a unique index (PostgreSQL 15+).
+func (g *postgresGrammar) nullsNotDistinct(command *command) string {
+	if !command.nullsNotDistinct {
+		return ""
+	}
+	return " NULLS NOT DISTINCT"
+}
+
+func (g *postgresGrammar) whereClause(command *command) string {
+	if command.where == "" {
+		return ""
+	}
+	return " WHERE " + command.where
+}
+
+// normalizeAlgorithm validates the index access method and returns it in lower case.
+func (g *postgresGrammar) normalizeAlgorithm(algorithm string) (string, error) {
+	if algorithm == "" {
+		return "", nil
+	}
+	normalized := strings.ToLower(algorithm)
+	if !slices.Contains(postgresIndexAlgorithms, normalized) {
+		return "", fmt.Errorf("invalid index algorithm %q for postgres: must be one of %s",
+			algorithm, strings.Join(postgresIndexAlgorithms, ", "))
+	}
+	return normalized, nil
 }
 
 func (g *postgresGrammar) CompileUnique(blueprint *Blueprint, command *command) (string, error) {
@@ -190,11 +1072,23 @@ func (g *postgresGrammar) CompileUnique(blueprint *Blueprint, command *command)
 	}
 	indexName := command.index
 	if indexName == "" {
-		indexName = g.CreateIndexName(blueprint, "unique", command.columns...)
+		indexName = g.CreateIndexName(blueprint, "unique", g.indexNameParts(command)...)
+	}
+	// A constraint can neither be added without blocking writes nor be partial or on expressions,
+	// so a unique index is built instead.
+	if g.buildsConcurrently(blueprint, command) || command.where != "" || command.expressions {
+		if command.deferrable != nil {
+			return "", errors.New("a unique index built concurrently, with a condition or on expressions " +
+				"cannot be deferrable")
+		}
+		return fmt.Sprintf("CREATE UNIQUE INDEX %s%s ON %s (%s)%s%s", g.concurrently(blueprint, command),
+			indexName, blueprint.name, g.indexedColumns(command), g.nullsNotDistinct(command),
+			g.whereClause(command)), nil
 	}
-	sql := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s UNIQUE (%s)",
+	sql := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s UNIQUE%s (%s)",
 		blueprint.name,
 		indexName,
+		g.nullsNotDistinct(command),
 		g.Columnize(command.columns),
 	)
 
@@ -232,11 +1126,11 @@ func (g *postgresGrammar) CompilePrimary(blueprint *Blueprint, command *command)
 	), nil
 }
 
-func (g *postgresGrammar) CompileDropIndex(_ *Blueprint, command *command) (string, error) {
+func (g *postgresGrammar) CompileDropIndex(blueprint *Blueprint, command *command) (string, error) {
 	if command.index == "" {
 		return "", errors.New("index name cannot be empty for drop operation")
 	}
-	return fmt.Sprintf("DROP INDEX %s", command.index), nil
+	return fmt.Sprintf("DROP INDEX %s", qualifyName(blueprint.name, command.index)), nil
 }
 
 func (g *postgresGrammar) CompileDropFulltext(blueprint *Blueprint, command *command) (string, error) {
@@ -244,6 +1138,9 @@ func (g *postgresGrammar) CompileDropFulltext(blueprint *Blueprint, command *com
 }
 
 func (g *postgresGrammar) CompileDropUnique(blueprint *Blueprint, command *command) (string, error) {
+	if blueprint.name == "" {
+		return "", errors.New("table name cannot be empty for drop unique operation")
+	}
 	if command.index == "" {
 		return "", errors.New("index name cannot be empty for drop operation")
 	}
@@ -258,7 +1155,7 @@ func (g *postgresGrammar) CompileDropPrimary(blueprint *Blueprint, command *comm
 	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", blueprint.name, index), nil
 }
 
-func (g *postgresGrammar) CompileRenameIndex(_ *Blueprint, command *command) (string, error) {
+func (g *postgresGrammar) CompileRenameIndex(blueprint *Blueprint, command *command) (string, error) {
 	if command.from == "" || command.to == "" {
 		return "", fmt.Errorf(
 			"index names for rename operation cannot be empty: oldName=%s, newName=%s",
@@ -266,7 +1163,7 @@ func (g *postgresGrammar) CompileRenameIndex(_ *Blueprint, command *command) (st
 			command.to,
 		)
 	}
-	return fmt.Sprintf("ALTER INDEX %s RENAME TO %s", command.from, command.to), nil
+	return fmt.Sprintf("ALTER INDEX %s RENAME TO %s", qualifyName(blueprint.name, command.from), command.to), nil
 }
 
 func (g *postgresGrammar) CompileForeign(blueprint *Blueprint, command *command) (string, error) {
@@ -300,21 +1197,103 @@ func (g *postgresGrammar) CompileDropForeign(blueprint *Blueprint, command *comm
 	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", blueprint.name, command.index), nil
 }
 
+func (g *postgresGrammar) CompileDropCheck(blueprint *Blueprint, command *command) (string, error) {
+	if command.index == "" {
+		return "", errors.New("check constraint name cannot be empty for drop operation")
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", blueprint.name, command.index), nil
+}
+
 func (g *postgresGrammar) GetFluentCommands() []func(blueprint *Blueprint, command *command) string {
 	return []func(blueprint *Blueprint, command *command) string{
 		g.CompileComment,
 	}
 }
 
+// CompileComment compiles the COMMENT ON COLUMN statement of a column. Postgres has no inline
+// column comments, so this companion statement is emitted for both added and changed columns.
 func (g *postgresGrammar) CompileComment(blueprint *Blueprint, command *command) string {
-	if command.column.comment != nil {
-		sql := fmt.Sprintf("COMMENT ON COLUMN %s.%s IS ", blueprint.name, command.column.name)
-		if command.column.comment == nil {
-			return sql + "NULL"
+	if command.column.comment == nil {
+		return ""
+	}
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s",
+		blueprint.name,
+		command.column.name,
+		g.QuoteString(strings.ReplaceAll(*command.column.comment, "'", "''")),
+	)
+}
+
+// CompileTableComment compiles the COMMENT ON TABLE statement of the table.
+func (g *postgresGrammar) CompileTableComment(blueprint *Blueprint) string {
+	if blueprint.comment == nil {
+		return ""
+	}
+	return fmt.Sprintf("COMMENT ON TABLE %s IS %s",
+		blueprint.name,
+		g.QuoteString(strings.ReplaceAll(*blueprint.comment, "'", "''")),
+	)
+}
+
+func (g *postgresGrammar) GetUnsupportedFeatures(blueprint *Blueprint) []string {
+	var features []string
+	if blueprint.engine != "" {
+		features = append(features, fmt.Sprintf("table engine %q", blueprint.engine))
+	}
+	if blueprint.charset != "" {
+		features = append(features, fmt.Sprintf("table charset %q", blueprint.charset))
+	}
+	if blueprint.collation != "" {
+		features = append(features, fmt.Sprintf("table collation %q", blueprint.collation))
+	}
+	for _, col := range blueprint.columns {
+		if col.charset != nil && *col.charset != "" {
+			features = append(features, fmt.Sprintf("charset %q on column %s", *col.charset, col.name))
+		}
+		if col.jsonSchema != nil {
+			features = append(features, fmt.Sprintf("JSON schema check on column %s", col.name))
+		}
+		if col.array && col.columnType == columnTypeEnum && !col.nativeEnum {
+			features = append(features, fmt.Sprintf("array of non-native enum column %s", col.name))
 		}
-		return sql + fmt.Sprintf("'%s'", *command.column.comment)
 	}
-	return ""
+	for _, cmd := range blueprint.commands {
+		if cmd.nullsNotDistinct && cmd.name != commandUnique {
+			features = append(features, fmt.Sprintf("NULLS NOT DISTINCT on %s index", cmd.name))
+		}
+	}
+	return append(features, g.unsupportedPartial(blueprint,
+		commandIndex, commandUnique, commandFullText, commandJSONIndex)...)
+}
+
+func (g *postgresGrammar) GetIgnoredModifiers(blueprint *Blueprint) []string {
+	var ignored []string
+	for _, col := range blueprint.columns {
+		// Increment helpers mark columns unsigned implicitly, so only report explicit usage.
+		if col.unsigned != nil && *col.unsigned && !col.implicitUnsigned &&
+			(col.autoIncrement == nil || !*col.autoIncrement) {
+			ignored = append(ignored, fmt.Sprintf("unsigned on column %s", col.name))
+		}
+		if col.hasCommand("onUpdate") {
+			ignored = append(ignored, fmt.Sprintf("on update on column %s", col.name))
+		}
+		if col.collation != nil && *col.collation != "" {
+			ignored = append(ignored, fmt.Sprintf("collation %q on column %s", *col.collation, col.name))
+		}
+		// PostgreSQL always appends new columns and cannot reorder them.
+		if col.first || col.after != "" {
+			ignored = append(ignored, fmt.Sprintf("position on column %s", col.name))
+		}
+	}
+	if blueprint.algorithm != "" {
+		ignored = append(ignored, fmt.Sprintf("algorithm %q on table %s", blueprint.algorithm, blueprint.name))
+	}
+	if blueprint.lock != "" {
+		ignored = append(ignored, fmt.Sprintf("lock %q on table %s", blueprint.lock, blueprint.name))
+	}
+	ignored = append(ignored, g.ignoredAlgorithms(blueprint, commandPrimary, commandUnique, commandFullText)...)
+	ignored = append(ignored, g.unsupportedConcurrently(blueprint,
+		commandIndex, commandUnique, commandFullText, commandJSONIndex)...)
+	return append(ignored, g.ignoredJSONChecks(blueprint)...)
 }
 
 func (g *postgresGrammar) getColumns(blueprint *Blueprint) ([]string, error) {
@@ -328,6 +1307,7 @@ func (g *postgresGrammar) getColumns(blueprint *Blueprint) ([]string, error) {
 		for _, modifier := range g.modifiers() {
 			sqlBuilder.WriteString(modifier(col))
 		}
+		sqlBuilder.WriteString(g.modifyJSONCheck(col))
 		sql += sqlBuilder.String()
 		columns = append(columns, sql)
 	}
@@ -380,14 +1360,22 @@ func (g *postgresGrammar) getType(col *columnDefinition) string {
 		columnTypeYear:          g.typeYear,
 		columnTypeBinary:        g.typeBinary,
 		columnTypeUUID:          g.typeUUID,
+		columnTypeInterval:      g.typeInterval,
+		columnTypeInet:          g.typeInet,
+		columnTypeCidr:          g.typeCidr,
+		columnTypeMacAddr:       g.typeMacAddr,
 		columnTypeGeography:     g.typeGeography,
 		columnTypeGeometry:      g.typeGeometry,
 		columnTypePoint:         g.typePoint,
 	}
+	sqlType := col.columnType
 	if fn, ok := typeMapFunc[col.columnType]; ok {
-		return fn(col)
+		sqlType = fn(col)
+	}
+	if col.array {
+		sqlType += "[]"
 	}
-	return col.columnType
+	return sqlType
 }
 
 func (g *postgresGrammar) typeChar(col *columnDefinition) string {
@@ -420,15 +1408,21 @@ func (g *postgresGrammar) typeLongText(_ *columnDefinition) string {
 	return "TEXT"
 }
 
+// isSerial reports whether the auto-incrementing column is a serial column. Identity columns take
+// the plain integer type.
+func (g *postgresGrammar) isSerial(col *columnDefinition) bool {
+	return col.autoIncrement != nil && *col.autoIncrement && col.identity == ""
+}
+
 func (g *postgresGrammar) typeBigInteger(col *columnDefinition) string {
-	if col.autoIncrement != nil && *col.autoIncrement {
+	if g.isSerial(col) {
 		return "BIGSERIAL"
 	}
 	return "BIGINT"
 }
 
 func (g *postgresGrammar) typeInteger(col *columnDefinition) string {
-	if col.autoIncrement != nil && *col.autoIncrement {
+	if g.isSerial(col) {
 		return "SERIAL"
 	}
 	return "INTEGER"
@@ -439,7 +1433,7 @@ func (g *postgresGrammar) typeMediumInteger(col *columnDefinition) string {
 }
 
 func (g *postgresGrammar) typeSmallInteger(col *columnDefinition) string {
-	if col.autoIncrement != nil && *col.autoIncrement {
+	if g.isSerial(col) {
 		return "SMALLSERIAL"
 	}
 	return "SMALLINT"
@@ -466,11 +1460,28 @@ func (g *postgresGrammar) typeBoolean(_ *columnDefinition) string {
 }
 
 func (g *postgresGrammar) typeEnum(col *columnDefinition) string {
+	if col.nativeEnum {
+		return col.enumType
+	}
+	if col.change {
+		// The check constraint of a changed column is replaced separately, see CompileChange.
+		return "VARCHAR(255)"
+	}
+	return "VARCHAR(255) " + g.enumCheck(col)
+}
+
+// enumCheckName returns the name of the check constraint of an enum column. PostgreSQL names an
+// inline column check constraint <table>_<column>_check.
+func (g *postgresGrammar) enumCheckName(table, column string) string {
+	return fmt.Sprintf("%s_%s_check", unqualifiedName(table), column)
+}
+
+func (g *postgresGrammar) enumCheck(col *columnDefinition) string {
 	enumValues := make([]string, len(col.allowed))
 	for i, v := range col.allowed {
 		enumValues[i] = g.QuoteString(v)
 	}
-	return "VARCHAR(255) CHECK (" + col.name + " IN (" + strings.Join(enumValues, ", ") + "))"
+	return "CHECK (" + col.name + " IN (" + strings.Join(enumValues, ", ") + "))"
 }
 
 func (g *postgresGrammar) typeJSON(_ *columnDefinition) string {
@@ -539,6 +1550,25 @@ func (g *postgresGrammar) typeUUID(_ *columnDefinition) string {
 	return "UUID"
 }
 
+func (g *postgresGrammar) typeInterval(col *columnDefinition) string {
+	if col.precision != nil {
+		return fmt.Sprintf("INTERVAL(%d)", *col.precision)
+	}
+	return "INTERVAL"
+}
+
+func (g *postgresGrammar) typeInet(_ *columnDefinition) string {
+	return "INET"
+}
+
+func (g *postgresGrammar) typeCidr(_ *columnDefinition) string {
+	return "CIDR"
+}
+
+func (g *postgresGrammar) typeMacAddr(_ *columnDefinition) string {
+	return "MACADDR"
+}
+
 func (g *postgresGrammar) typeGeography(col *columnDefinition) string {
 	if col.subtype != nil && col.srid != nil {
 		return fmt.Sprintf("GEOGRAPHY(%s, %d)", *col.subtype, *col.srid)
@@ -568,12 +1598,18 @@ func (g *postgresGrammar) modifiers() []func(*columnDefinition) string {
 	return []func(*columnDefinition) string{
 		g.modifyDefault,
 		g.modifyNullable,
+		g.modifyIdentity,
+		g.modifyGenerated,
 	}
 }
 
 func (g *postgresGrammar) modifyNullable(col *columnDefinition) string {
 	if col.change {
 		if col.nullable == nil {
+			// Identity columns must be NOT NULL before the identity can be added.
+			if col.identity != "" {
+				return " SET NOT NULL"
+			}
 			return ""
 		}
 		if *col.nullable {
@@ -594,5 +1630,42 @@ func (g *postgresGrammar) modifyDefault(col *columnDefinition) string {
 		}
 		return fmt.Sprintf(" DEFAULT %s", g.GetDefaultValue(col.defaultValue))
 	}
+	if col.defaultUUID {
+		if col.change {
+			return " SET DEFAULT gen_random_uuid()"
+		}
+		return " DEFAULT gen_random_uuid()"
+	}
 	return ""
 }
+
+// modifyJSONCheck compiles the JSON path checks of the column. JSON schema checks have no
+// PostgreSQL equivalent and are reported as unsupported instead.
+func (g *postgresGrammar) modifyJSONCheck(col *columnDefinition) string {
+	conditions := make([]string, len(col.jsonPaths))
+	for i, path := range col.jsonPaths {
+		conditions[i] = fmt.Sprintf("jsonb_path_exists(%s::jsonb, %s)", col.name, g.quoteLiteral(path))
+	}
+	return g.jsonCheck(conditions)
+}
+
+func (g *postgresGrammar) modifyGenerated(col *columnDefinition) string {
+	if col.generatedAs == "" {
+		return ""
+	}
+	// The storage of a generated column cannot be changed, only its expression.
+	if col.change {
+		return fmt.Sprintf(" SET EXPRESSION AS (%s)", col.generatedAs)
+	}
+	return fmt.Sprintf(" GENERATED ALWAYS AS (%s) %s", col.generatedAs, col.generatedStorage)
+}
+
+func (g *postgresGrammar) modifyIdentity(col *columnDefinition) string {
+	if col.identity == "" {
+		return ""
+	}
+	if col.change {
+		return fmt.Sprintf(" ADD GENERATED %s AS IDENTITY", col.identity)
+	}
+	return fmt.Sprintf(" GENERATED %s AS IDENTITY", col.identity)
+}
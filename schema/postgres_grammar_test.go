@@ -42,6 +42,14 @@ func TestPgGrammar_CompileCreate(t *testing.T) {
 			},
 			want: "CREATE TABLE posts (id BIGSERIAL NOT NULL, user_id INTEGER NOT NULL, title VARCHAR(255) NOT NULL, content TEXT NULL, CONSTRAINT pk_posts PRIMARY KEY (id))",
 		},
+		{
+			name:  "Create table with JSON path checks",
+			table: "events",
+			blueprint: func(table *Blueprint) {
+				table.JSONB("payload").CheckJSONPathExists("$.id", "$.type")
+			},
+			want: "CREATE TABLE events (payload JSONB NOT NULL CHECK (jsonb_path_exists(payload::jsonb, '$.id') AND jsonb_path_exists(payload::jsonb, '$.type')))",
+		},
 		{
 			name:  "Create table with column name is empty",
 			table: "empty_column_table",
@@ -107,6 +115,34 @@ func TestPgGrammar_CompileAdd(t *testing.T) {
 			want:    "ALTER TABLE users ADD COLUMN active BOOLEAN DEFAULT '1' NOT NULL",
 			wantErr: false,
 		},
+		{
+			name:  "Add interval and network address columns",
+			table: "hosts",
+			blueprint: func(table *Blueprint) {
+				table.Interval("uptime")
+				table.Interval("latency", 3).Nullable()
+				table.Inet("ip_address")
+				table.Cidr("network")
+				table.MacAddr("mac_address")
+			},
+			want: "ALTER TABLE hosts ADD COLUMN uptime INTERVAL NOT NULL, ADD COLUMN latency INTERVAL(3) NULL, " +
+				"ADD COLUMN ip_address INET NOT NULL, ADD COLUMN network CIDR NOT NULL, " +
+				"ADD COLUMN mac_address MACADDR NOT NULL",
+		},
+		{
+			name:  "Add array columns",
+			table: "posts",
+			blueprint: func(table *Blueprint) {
+				table.TextArray("tags").Default("{}")
+				table.IntegerArray("scores").Nullable()
+				table.BigIntegerArray("related_ids")
+				table.UUIDArray("editor_ids")
+				table.String("aliases", 50).Array()
+			},
+			want: "ALTER TABLE posts ADD COLUMN tags TEXT[] DEFAULT '{}' NOT NULL, " +
+				"ADD COLUMN scores INTEGER[] NULL, ADD COLUMN related_ids BIGINT[] NOT NULL, " +
+				"ADD COLUMN editor_ids UUID[] NOT NULL, ADD COLUMN aliases VARCHAR(50)[] NOT NULL",
+		},
 		{
 			name:  "Add column with comment",
 			table: "users",
@@ -116,6 +152,44 @@ func TestPgGrammar_CompileAdd(t *testing.T) {
 			want:    "ALTER TABLE users ADD COLUMN notes VARCHAR(500) NOT NULL",
 			wantErr: false,
 		},
+		{
+			name:  "Add identity column",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.BigInteger("seq").Identity()
+			},
+			want:    "ALTER TABLE users ADD COLUMN seq BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY",
+			wantErr: false,
+		},
+		{
+			name:  "Add always generated identity column",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Integer("seq").GeneratedAsIdentity(true)
+			},
+			want:    "ALTER TABLE users ADD COLUMN seq INTEGER NOT NULL GENERATED ALWAYS AS IDENTITY",
+			wantErr: false,
+		},
+		{
+			name:  "Add stored generated column",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Decimal("total", 10, 2).StoredAs("price * quantity")
+			},
+			want: "ALTER TABLE orders ADD COLUMN " +
+				"total DECIMAL(10, 2) NOT NULL GENERATED ALWAYS AS (price * quantity) STORED",
+			wantErr: false,
+		},
+		{
+			name:  "Add virtual generated column",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.String("full_name").Nullable().VirtualAs("first_name || ' ' || last_name")
+			},
+			want: "ALTER TABLE users ADD COLUMN full_name VARCHAR(255) NULL " +
+				"GENERATED ALWAYS AS (first_name || ' ' || last_name) VIRTUAL",
+			wantErr: false,
+		},
 		{
 			name:  "Add primary key column",
 			table: "categories",
@@ -296,6 +370,50 @@ func TestPgGrammar_CompileChange(t *testing.T) {
 			},
 			want: []string{"ALTER TABLE users ALTER COLUMN email TYPE VARCHAR(500), ALTER COLUMN email SET NOT NULL"},
 		},
+		{
+			name:  "Add identity to existing column",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.BigInteger("id").Identity().Change()
+			},
+			want: []string{
+				"ALTER TABLE users ALTER COLUMN id TYPE BIGINT, ALTER COLUMN id SET NOT NULL, " +
+					"ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY",
+			},
+		},
+		{
+			name:  "Change expression of generated column",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Decimal("total", 10, 2).StoredAs("price * quantity - discount").Change()
+			},
+			want: []string{
+				"ALTER TABLE orders ALTER COLUMN total TYPE DECIMAL(10, 2), " +
+					"ALTER COLUMN total SET EXPRESSION AS (price * quantity - discount)",
+			},
+		},
+		{
+			name:  "Add always generated identity and primary key",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Integer("id").GeneratedAlwaysAsIdentity().Nullable(false).Primary().Change()
+			},
+			want: []string{
+				"ALTER TABLE users ALTER COLUMN id TYPE INTEGER, ALTER COLUMN id SET NOT NULL, " +
+					"ALTER COLUMN id ADD GENERATED ALWAYS AS IDENTITY, ADD CONSTRAINT pk_users PRIMARY KEY (id)",
+			},
+		},
+		{
+			name:  "Change column to no longer be the primary key",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.BigInteger("id").Primary(false).Change()
+			},
+			want: []string{
+				"ALTER TABLE users ALTER COLUMN id TYPE BIGINT",
+				"ALTER TABLE users DROP CONSTRAINT pk_users",
+			},
+		},
 		{
 			name:  "Column name with empty string",
 			table: "users",
@@ -1030,6 +1148,50 @@ func TestPgGrammar_CompileIndex(t *testing.T) {
 			want:    "CREATE INDEX products_sku_index ON products USING btree (sku)",
 			wantErr: false,
 		},
+		{
+			name:  "Index algorithm is normalized to lower case",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.Index("tags").Algorithm("GIN")
+			},
+			want:    "CREATE INDEX idx_products_tags ON products USING gin (tags)",
+			wantErr: false,
+		},
+		{
+			name:  "Concurrent index",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.Index("customer_id").Concurrently().Algorithm("hash")
+			},
+			want:    "CREATE INDEX CONCURRENTLY idx_orders_customer_id ON orders USING hash (customer_id)",
+			wantErr: false,
+		},
+		{
+			name:  "Partial index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Index("email").Where("deleted_at IS NULL")
+			},
+			want:    "CREATE INDEX idx_users_email ON users (email) WHERE deleted_at IS NULL",
+			wantErr: false,
+		},
+		{
+			name:  "Expression index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.IndexRaw("lower(email)", "created_at::date")
+			},
+			want:    "CREATE INDEX idx_users_lower_email_created_at_date ON users ((lower(email)), (created_at::date))",
+			wantErr: false,
+		},
+		{
+			name:  "Index with invalid algorithm",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.Index("sku").Algorithm("fulltext")
+			},
+			wantErr: true,
+		},
 		{
 			name:  "Index without name (should use generated name)",
 			table: "orders",
@@ -1073,6 +1235,90 @@ func TestPgGrammar_CompileIndex(t *testing.T) {
 	}
 }
 
+func TestPgGrammar_CompileJSONIndex(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		table     string
+		blueprint func(table *Blueprint)
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "Top-level key",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.customer_id")
+			},
+			want: "CREATE INDEX idx_orders_data_customer_id ON orders ((data #>> '{customer_id}'))",
+		},
+		{
+			name:  "Nested key with array index, name and algorithm",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.items[0].sku").Name("orders_first_sku_index").Algorithm("hash")
+			},
+			want: "CREATE INDEX orders_first_sku_index ON orders USING hash ((data #>> '{items,0,sku}'))",
+		},
+		{
+			name:  "Concurrent JSON index",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.customer_id").Concurrently()
+			},
+			want: "CREATE INDEX CONCURRENTLY idx_orders_data_customer_id ON orders ((data #>> '{customer_id}'))",
+		},
+		{
+			name:  "Partial JSON index",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.customer_id").Where("archived = false")
+			},
+			want: "CREATE INDEX idx_orders_data_customer_id ON orders ((data #>> '{customer_id}')) WHERE archived = false",
+		},
+		{
+			name:  "Path without root",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "customer_id")
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Path with wildcard",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("data", "$.items[*].sku")
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Empty column",
+			table: "orders",
+			blueprint: func(table *Blueprint) {
+				table.JSONIndex("", "$.customer_id")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: tt.table}
+			tt.blueprint(bp)
+			got, err := grammar.CompileJSONIndex(bp, bp.commands[0])
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestPgGrammar_CompileUnique(t *testing.T) {
 	grammar := newPostgresGrammar()
 
@@ -1092,6 +1338,68 @@ func TestPgGrammar_CompileUnique(t *testing.T) {
 			want:    "ALTER TABLE users ADD CONSTRAINT users_email_unique UNIQUE (email)",
 			wantErr: false,
 		},
+		{
+			name:  "Concurrent unique index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Concurrently()
+			},
+			want:    "CREATE UNIQUE INDEX CONCURRENTLY uk_users_email ON users (email)",
+			wantErr: false,
+		},
+		{
+			name:  "Partial unique index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Where("deleted_at IS NULL")
+			},
+			want:    "CREATE UNIQUE INDEX uk_users_email ON users (email) WHERE deleted_at IS NULL",
+			wantErr: false,
+		},
+		{
+			name:  "Unique constraint with nulls not distinct",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Unique("tenant_id", "email").NullsNotDistinct()
+			},
+			want: "ALTER TABLE users ADD CONSTRAINT uk_users_tenant_id_email " +
+				"UNIQUE NULLS NOT DISTINCT (tenant_id, email)",
+			wantErr: false,
+		},
+		{
+			name:  "Partial unique index with nulls not distinct",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Where("deleted_at IS NULL").NullsNotDistinct()
+			},
+			want:    "CREATE UNIQUE INDEX uk_users_email ON users (email) NULLS NOT DISTINCT WHERE deleted_at IS NULL",
+			wantErr: false,
+		},
+		{
+			name:  "Expression unique index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.UniqueRaw("lower(email)")
+			},
+			want:    "CREATE UNIQUE INDEX uk_users_lower_email ON users ((lower(email)))",
+			wantErr: false,
+		},
+		{
+			name:  "Partial deferrable unique index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Where("deleted_at IS NULL").Deferrable()
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Concurrent deferrable unique index",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Concurrently().Deferrable()
+			},
+			wantErr: true,
+		},
 		{
 			name:  "Basic unique index with multiple columns",
 			table: "users",
@@ -1200,6 +1508,16 @@ func TestPgGrammar_CompileFullText(t *testing.T) {
 			want:    "CREATE INDEX articles_title_fulltext ON articles USING GIN (to_tsvector('english', title))",
 			wantErr: false,
 		},
+		{
+			name:  "Concurrent fulltext index",
+			table: "articles",
+			blueprint: func(table *Blueprint) {
+				table.FullText("title").Name("articles_title_fulltext").Language("english").Concurrently()
+			},
+			want: "CREATE INDEX CONCURRENTLY articles_title_fulltext ON articles " +
+				"USING GIN (to_tsvector('english', title))",
+			wantErr: false,
+		},
 		{
 			name:  "Fulltext index with multiple columns",
 			table: "documents",
@@ -1284,39 +1602,51 @@ func TestPgGrammar_CompileDropUnique(t *testing.T) {
 
 	tests := []struct {
 		name      string
+		table     string
 		indexName string
 		want      string
 		wantErr   bool
 	}{
 		{
 			name:      "Drop unique index with valid name",
+			table:     "users",
 			indexName: "users_email_unique",
-			want:      "ALTER TABLE  DROP CONSTRAINT users_email_unique",
+			want:      "ALTER TABLE users DROP CONSTRAINT users_email_unique",
 			wantErr:   false,
 		},
 		{
 			name:      "Drop unique index with complex name",
+			table:     "users",
 			indexName: "uk_users_email_name",
-			want:      "ALTER TABLE  DROP CONSTRAINT uk_users_email_name",
+			want:      "ALTER TABLE users DROP CONSTRAINT uk_users_email_name",
 			wantErr:   false,
 		},
 		{
 			name:      "Drop unique index with numeric suffix",
+			table:     "public.users",
 			indexName: "users_email_unique_2",
-			want:      "ALTER TABLE  DROP CONSTRAINT users_email_unique_2",
+			want:      "ALTER TABLE public.users DROP CONSTRAINT users_email_unique_2",
 			wantErr:   false,
 		},
 		{
 			name:      "Empty index name",
+			table:     "users",
 			indexName: "",
 			want:      "",
 			wantErr:   true,
 		},
+		{
+			name:      "Empty table name",
+			table:     "",
+			indexName: "users_email_unique",
+			want:      "",
+			wantErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			bp := &Blueprint{}
+			bp := &Blueprint{name: tt.table}
 			command := &command{index: tt.indexName}
 			got, err := grammar.CompileDropUnique(bp, command)
 			if tt.wantErr {
@@ -1789,3 +2119,1345 @@ func TestPgGrammar_GetType(t *testing.T) {
 		})
 	}
 }
+
+func TestPgGrammar_GetUnsupportedFeatures(t *testing.T) {
+	g := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name: "supported features only",
+			blueprint: func(table *Blueprint) {
+				table.String("name")
+				table.FullText("name").Language("english")
+				table.Unique("name").Deferrable()
+				table.Index("name").Where("deleted_at IS NULL")
+			},
+		},
+		{
+			name: "partial primary key",
+			blueprint: func(table *Blueprint) {
+				table.Primary("id").Where("id > 0")
+			},
+			want: []string{`condition "id > 0" on primary index`},
+		},
+		{
+			name: "table engine, charset and collation",
+			blueprint: func(table *Blueprint) {
+				table.Engine("InnoDB")
+				table.Charset("utf8mb4")
+				table.Collation("utf8mb4_unicode_ci")
+			},
+			want: []string{
+				`table engine "InnoDB"`,
+				`table charset "utf8mb4"`,
+				`table collation "utf8mb4_unicode_ci"`,
+			},
+		},
+		{
+			name: "column charset",
+			blueprint: func(table *Blueprint) {
+				table.String("name").Charset("latin1")
+			},
+			want: []string{`charset "latin1" on column name`},
+		},
+		{
+			name: "nulls not distinct on a non-unique index",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").NullsNotDistinct()
+				table.Index("name").NullsNotDistinct()
+			},
+			want: []string{"NULLS NOT DISTINCT on index index"},
+		},
+		{
+			name: "JSON schema check",
+			blueprint: func(table *Blueprint) {
+				table.JSON("payload").CheckJSONSchema(`{"type": "object"}`)
+			},
+			want: []string{"JSON schema check on column payload"},
+		},
+		{
+			name: "array of non-native enum",
+			blueprint: func(table *Blueprint) {
+				table.Enum("roles", []string{"admin", "editor"}).Array()
+				table.Enum("states", []string{"draft", "published"}).Native().Array()
+			},
+			want: []string{"array of non-native enum column roles"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "test_table", grammar: g}
+			tt.blueprint(bp)
+			assert.Equal(t, tt.want, g.GetUnsupportedFeatures(bp))
+		})
+	}
+}
+
+func TestPgGrammar_StrictMode(t *testing.T) {
+	g := newPostgresGrammar()
+
+	bp := &Blueprint{name: "users", grammar: g, strict: true}
+	bp.create()
+	bp.ID()
+	bp.Engine("InnoDB")
+	_, err := bp.toSQL()
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+
+	bp = &Blueprint{name: "users", grammar: g, strict: true}
+	bp.create()
+	bp.ID()
+	bp.Timestamps()
+	_, err = bp.toSQL()
+	require.NoError(t, err)
+}
+
+func TestPgGrammar_GetIgnoredModifiers(t *testing.T) {
+	g := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name: "increments and timestamps produce no warnings",
+			blueprint: func(table *Blueprint) {
+				table.ID()
+				table.Increments("counter")
+				table.Timestamps()
+			},
+		},
+		{
+			name: "unsigned column",
+			blueprint: func(table *Blueprint) {
+				table.UnsignedInteger("age")
+			},
+			want: []string{"unsigned on column age"},
+		},
+		{
+			name: "on update and collation",
+			blueprint: func(table *Blueprint) {
+				table.Timestamp("synced_at").OnUpdate(Expression("CURRENT_TIMESTAMP"))
+				table.String("name").Collation("C")
+			},
+			want: []string{"on update on column synced_at", `collation "C" on column name`},
+		},
+		{
+			name: "column position",
+			blueprint: func(table *Blueprint) {
+				table.String("middle_name").After("first_name")
+				table.UUID("uuid").First()
+			},
+			want: []string{"position on column middle_name", "position on column uuid"},
+		},
+		{
+			name: "algorithm on unique index",
+			blueprint: func(table *Blueprint) {
+				table.Unique("email").Algorithm("hash")
+				table.Index("name").Algorithm("btree")
+			},
+			want: []string{`algorithm "hash" on unique index`},
+		},
+		{
+			name: "concurrently on primary index",
+			blueprint: func(table *Blueprint) {
+				table.Primary("id").Concurrently()
+				table.Unique("email").Concurrently()
+				table.FullText("bio").Concurrently()
+			},
+			want: []string{"concurrently on primary index"},
+		},
+		{
+			name: "JSON check on changed column",
+			blueprint: func(table *Blueprint) {
+				table.JSON("payload").CheckJSONPathExists("$.id").Change()
+			},
+			want: []string{"JSON check on changed column payload"},
+		},
+		{
+			name: "algorithm and lock",
+			blueprint: func(table *Blueprint) {
+				table.Algorithm("INSTANT")
+				table.Lock("NONE")
+				table.String("note")
+			},
+			want: []string{`algorithm "INSTANT" on table test_table`, `lock "NONE" on table test_table`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "test_table", grammar: g}
+			tt.blueprint(bp)
+			assert.Equal(t, tt.want, g.GetIgnoredModifiers(bp))
+		})
+	}
+}
+
+func TestPgGrammar_Warnings(t *testing.T) {
+	g := newPostgresGrammar()
+
+	bp := &Blueprint{name: "users", grammar: g}
+	bp.create()
+	bp.ID()
+	bp.UnsignedInteger("age")
+	bp.Engine("InnoDB")
+	_, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{`table engine "InnoDB"`, "unsigned on column age"}, bp.warnings)
+}
+
+func TestPgGrammar_CompileComment(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		table     string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name:  "Add column with comment",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.String("notes", 500).Comment("User notes")
+			},
+			want: []string{
+				"ALTER TABLE users ADD COLUMN notes VARCHAR(500) NOT NULL",
+				"COMMENT ON COLUMN users.notes IS 'User notes'",
+			},
+		},
+		{
+			name:  "Add multiple columns with comments",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.String("nickname").Comment("Display name")
+				table.Integer("age")
+				table.Text("bio").Comment("Short biography")
+			},
+			want: []string{
+				"ALTER TABLE users ADD COLUMN nickname VARCHAR(255) NOT NULL, ADD COLUMN age INTEGER NOT NULL, " +
+					"ADD COLUMN bio TEXT NOT NULL",
+				"COMMENT ON COLUMN users.nickname IS 'Display name'",
+				"COMMENT ON COLUMN users.bio IS 'Short biography'",
+			},
+		},
+		{
+			name:  "Comment with quote is escaped",
+			table: "users",
+			blueprint: func(table *Blueprint) {
+				table.String("notes").Comment("User's notes")
+			},
+			want: []string{
+				"ALTER TABLE users ADD COLUMN notes VARCHAR(255) NOT NULL",
+				"COMMENT ON COLUMN users.notes IS 'User''s notes'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: tt.table, grammar: grammar}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPgGrammar_CompileTableDDL(t *testing.T) {
+	g := newPostgresGrammar()
+
+	got, err := g.CompileTableDDL("app", "users")
+	require.NoError(t, err)
+	assert.Contains(t, got, "where c.relname = 'users' and n.nspname = 'app'")
+	assert.Contains(t, got, "pg_get_constraintdef(con.oid)")
+	assert.Contains(t, got, "pg_get_indexdef(i.indexrelid)")
+	assert.Contains(t, got, "' PARTITION BY ' || pg_get_partkeydef(c.oid)")
+	assert.Contains(t, got, "pg_get_expr(c.relpartbound, c.oid)")
+}
+
+func TestPgGrammar_CompileEnumCheck(t *testing.T) {
+	g := newPostgresGrammar()
+
+	got, err := g.CompileEnumCheck("app", "orders", "status")
+	require.NoError(t, err)
+	assert.Contains(t, got, "con.conkey = array[a.attnum]")
+	assert.Contains(t, got, "order by con.conname = 'orders_status_check' desc")
+	assert.Contains(t, got, "where c.relname = 'orders' and n.nspname = 'app' and a.attname = 'status'")
+}
+
+func TestPgGrammar_CompileEnumTypeDDL(t *testing.T) {
+	g := newPostgresGrammar()
+
+	got, err := g.CompileEnumTypeDDL("app")
+	require.NoError(t, err)
+	assert.Contains(t, got, "format('CREATE TYPE %I.%I AS ENUM (%s)', n.nspname, t.typname, ")
+	assert.Contains(t, got, "order by e.enumsortorder")
+	assert.Contains(t, got, "where n.nspname = 'app'")
+}
+
+func TestPgGrammar_CompileCloneSchema(t *testing.T) {
+	g := newPostgresGrammar()
+
+	got, err := g.CompileCloneSchema("public", "green", CloneOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, got, "format('CREATE SCHEMA %I', 'green')")
+	assert.Contains(t, got, "(LIKE %I.%I INCLUDING ALL EXCLUDING INDEXES)', 'green', c.relname, 'public', c.relname)")
+	assert.Contains(t, got, "where n.nspname = 'public'")
+	assert.NotContains(t, got, "INSERT INTO")
+
+	got, err = g.CompileCloneSchema("public", "green", CloneOptions{DataTables: []string{"schema_migrations"}})
+	require.NoError(t, err)
+	assert.Contains(t, got, "OVERRIDING SYSTEM VALUE")
+	assert.Contains(t, got, "and c.relname in ('schema_migrations')")
+
+	got, err = g.CompileCloneSchema("public", "green", CloneOptions{WithData: true, DataTables: []string{"users"}})
+	require.NoError(t, err)
+	assert.Contains(t, got, "OVERRIDING SYSTEM VALUE")
+	assert.NotContains(t, got, "c.relname in")
+
+	_, err = g.CompileCloneSchema("public", "", CloneOptions{})
+	require.Error(t, err)
+	_, err = g.CompileCloneSchema("public", "public", CloneOptions{})
+	require.Error(t, err)
+}
+
+func TestPgGrammar_CompileDropAllTables(t *testing.T) {
+	g := newPostgresGrammar()
+
+	got, err := g.CompileDropAllTables([]string{"public.users", "public.posts"})
+	require.NoError(t, err)
+	assert.Equal(t, "DROP TABLE IF EXISTS public.users, public.posts CASCADE", got)
+
+	_, err = g.CompileDropAllTables([]string{""})
+	require.Error(t, err)
+}
+
+func TestPgGrammar_CompileInsert(t *testing.T) {
+	g := newPostgresGrammar()
+
+	assert.Equal(t, "INSERT INTO users (id, name) VALUES ($1, $2), ($3, $4)",
+		g.CompileInsert("users", []string{"id", "name"}, 2))
+	assert.Equal(t, "SELECT setval(pg_get_serial_sequence('users', 'id'), coalesce(max(id), 0) + 1, false) FROM users",
+		g.CompileResetSequence("users", "id"))
+}
+
+func TestPgGrammar_QuoteIdentifier(t *testing.T) {
+	g := newPostgresGrammar()
+
+	assert.Equal(t, `"order"`, g.QuoteIdentifier("order"))
+	assert.Equal(t, `"shop"."order"`, g.QuoteIdentifier("shop.order"))
+	assert.Equal(t, `"odd""name"`, g.QuoteIdentifier(`odd"name`))
+}
+
+func TestPgGrammar_CompileAnalyze(t *testing.T) {
+	g := newPostgresGrammar()
+
+	got, err := g.CompileAnalyze([]string{"users", "posts"})
+	require.NoError(t, err)
+	assert.Equal(t, "ANALYZE users, posts", got)
+
+	_, err = g.CompileAnalyze(nil)
+	require.Error(t, err)
+}
+
+func TestPgGrammar_CompileTruncate(t *testing.T) {
+	g := newPostgresGrammar()
+
+	tests := []struct {
+		name    string
+		options TruncateOptions
+		want    string
+	}{
+		{name: "plain", want: "TRUNCATE TABLE users"},
+		{
+			name:    "restart identity",
+			options: TruncateOptions{RestartIdentity: true},
+			want:    "TRUNCATE TABLE users RESTART IDENTITY",
+		},
+		{name: "cascade", options: TruncateOptions{Cascade: true}, want: "TRUNCATE TABLE users CASCADE"},
+		{
+			name:    "restart identity and cascade",
+			options: TruncateOptions{RestartIdentity: true, Cascade: true},
+			want:    "TRUNCATE TABLE users RESTART IDENTITY CASCADE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.CompileTruncate("users", tt.options)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := g.CompileTruncate("", TruncateOptions{})
+	require.Error(t, err)
+}
+
+func TestPgGrammar_CompileCheck(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		table     string
+		blueprint func(table *Blueprint)
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "Check with name",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.Check("price > 0").Name("chk_positive_price")
+			},
+			want: "ALTER TABLE products ADD CONSTRAINT chk_positive_price CHECK (price > 0)",
+		},
+		{
+			name:  "Check without name (should use generated name)",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.Check("discount_price < price")
+			},
+			want: "ALTER TABLE products ADD CONSTRAINT chk_products_discount_price_price CHECK (discount_price < price)",
+		},
+		{
+			name:  "Drop check",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.DropCheck("chk_positive_price")
+			},
+			want: "ALTER TABLE products DROP CONSTRAINT chk_positive_price",
+		},
+		{
+			name:  "Empty condition",
+			table: "products",
+			blueprint: func(table *Blueprint) {
+				table.Check("")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: tt.table, grammar: grammar}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, got)
+		})
+	}
+}
+
+func TestPgGrammar_ForeignID(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name: "Constrained to the guessed table",
+			blueprint: func(table *Blueprint) {
+				table.ForeignID("user_id").Constrained()
+			},
+			want: []string{
+				"ALTER TABLE posts ADD COLUMN user_id BIGINT NOT NULL",
+				"ALTER TABLE posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)",
+			},
+		},
+		{
+			name: "Nullable and constrained to a given table and column",
+			blueprint: func(table *Blueprint) {
+				table.ForeignID("author_id").Nullable().Constrained("accounts", "account_id").NullOnDelete()
+			},
+			want: []string{
+				"ALTER TABLE posts ADD COLUMN author_id BIGINT NULL",
+				"ALTER TABLE posts ADD CONSTRAINT fk_posts_accounts FOREIGN KEY (author_id) " +
+					"REFERENCES accounts(account_id) ON DELETE SET NULL",
+			},
+		},
+		{
+			name: "Without constraint",
+			blueprint: func(table *Blueprint) {
+				table.ForeignID("category_id")
+			},
+			want: []string{"ALTER TABLE posts ADD COLUMN category_id BIGINT NOT NULL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "posts", grammar: grammar}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Empty(t, grammar.GetIgnoredModifiers(bp), "unsigned of ForeignID is implicit")
+		})
+	}
+}
+
+func TestPgGrammar_Morphs(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name: "Morphs",
+			blueprint: func(table *Blueprint) {
+				table.Morphs("commentable")
+			},
+			want: []string{
+				"ALTER TABLE comments ADD COLUMN commentable_type VARCHAR(255) NOT NULL, " +
+					"ADD COLUMN commentable_id BIGINT NOT NULL",
+				"CREATE INDEX idx_comments_commentable_type_commentable_id " +
+					"ON comments (commentable_type, commentable_id)",
+			},
+		},
+		{
+			name: "Nullable UUID morphs with an index name",
+			blueprint: func(table *Blueprint) {
+				table.NullableUUIDMorphs("commentable", "comments_commentable_index")
+			},
+			want: []string{
+				"ALTER TABLE comments ADD COLUMN commentable_type VARCHAR(255) NULL, " +
+					"ADD COLUMN commentable_id UUID NULL",
+				"CREATE INDEX comments_commentable_index ON comments (commentable_type, commentable_id)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "comments", grammar: grammar}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Empty(t, grammar.GetIgnoredModifiers(bp))
+		})
+	}
+}
+
+func TestPgGrammar_SoftDeletes(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name: "SoftDeletes",
+			blueprint: func(table *Blueprint) {
+				table.SoftDeletes()
+			},
+			want: []string{"ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMP(0) NULL"},
+		},
+		{
+			name: "SoftDeletesTz with precision",
+			blueprint: func(table *Blueprint) {
+				table.SoftDeletesTz(6)
+			},
+			want: []string{"ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMPTZ(6) NULL"},
+		},
+		{
+			name: "DropSoftDeletes",
+			blueprint: func(table *Blueprint) {
+				table.DropSoftDeletes()
+			},
+			want: []string{"ALTER TABLE posts DROP COLUMN deleted_at"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "posts", grammar: grammar}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPgGrammar_StatementOrder(t *testing.T) {
+	bp := &Blueprint{name: "posts", grammar: newPostgresGrammar()}
+	bp.String("title").Unique().Comment("Shown in listings")
+	bp.String("slug").Index().Change()
+	bp.Index("created_at")
+	bp.ForeignID("user_id").Constrained()
+	bp.Integer("views").Change()
+
+	first, err := bp.toSQL()
+	require.NoError(t, err)
+	second, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "compiling again yields the same statements")
+	assert.Equal(t, []string{
+		"ALTER TABLE posts ALTER COLUMN slug TYPE VARCHAR(255)",
+		"ALTER TABLE posts ALTER COLUMN views TYPE INTEGER",
+		"ALTER TABLE posts ADD COLUMN title VARCHAR(255) NOT NULL, ADD COLUMN user_id BIGINT NOT NULL",
+		"CREATE INDEX idx_posts_created_at ON posts (created_at)",
+		"ALTER TABLE posts ADD CONSTRAINT uk_posts_title UNIQUE (title)",
+		"CREATE INDEX idx_posts_slug ON posts (slug)",
+		"ALTER TABLE posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)",
+		"COMMENT ON COLUMN posts.title IS 'Shown in listings'",
+	}, second)
+}
+
+func TestPgGrammar_UUIDPrimary(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(table *Blueprint)
+		want      []string
+	}{
+		{
+			name: "UUIDPrimary with RememberToken",
+			blueprint: func(table *Blueprint) {
+				table.create()
+				table.UUIDPrimary()
+				table.RememberToken()
+			},
+			want: []string{
+				"CREATE TABLE users (id UUID DEFAULT gen_random_uuid() NOT NULL, " +
+					"remember_token VARCHAR(100) NULL, CONSTRAINT pk_users PRIMARY KEY (id))",
+			},
+		},
+		{
+			name: "Explicit default takes precedence",
+			blueprint: func(table *Blueprint) {
+				table.create()
+				table.UUIDPrimary("key").Default(Expression("uuidv7()"))
+			},
+			want: []string{
+				"CREATE TABLE users (key UUID DEFAULT uuidv7() NOT NULL, CONSTRAINT pk_users PRIMARY KEY (key))",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "users", grammar: grammar}
+			tt.blueprint(bp)
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestForeignTableName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":     "users",
+		"category_id": "categories",
+		"day_id":      "days",
+		"address_id":  "addresses",
+		"box_id":      "boxes",
+		"branch_id":   "branches",
+		"owner":       "owners",
+	}
+	for column, want := range tests {
+		assert.Equal(t, want, foreignTableName(column), column)
+	}
+}
+
+func TestPgGrammar_Temporary(t *testing.T) {
+	bp := &Blueprint{name: "order_fixes", grammar: newPostgresGrammar(), defaultOwner: "app_owner"}
+	bp.create()
+	bp.Temporary()
+	bp.BigInteger("order_id")
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	// Temporary tables do not take the configured owner.
+	assert.Equal(t, []string{"CREATE TEMPORARY TABLE order_fixes (order_id BIGINT NOT NULL)"}, got)
+}
+
+func TestPgGrammar_Partitions(t *testing.T) {
+	grammar := newPostgresGrammar()
+	bp := &Blueprint{name: "events", grammar: grammar}
+	bp.create()
+	bp.BigInteger("id")
+	bp.Timestamp("created_at")
+	bp.Primary("id", "created_at")
+	bp.PartitionBy(PartitionRange, "created_at")
+	bp.Partition("events_2025_01", RangeBounds("'2025-01-01'", "'2025-02-01'"))
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE events (id BIGINT NOT NULL, created_at TIMESTAMP(0) NOT NULL) PARTITION BY RANGE (created_at)",
+		"ALTER TABLE events ADD CONSTRAINT pk_events PRIMARY KEY (id, created_at)",
+		"CREATE TABLE events_2025_01 PARTITION OF events FOR VALUES FROM ('2025-01-01') TO ('2025-02-01')",
+	}, got)
+
+	tests := []struct {
+		name    string
+		bounds  PartitionBounds
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "range",
+			bounds: RangeBounds("MINVALUE", "'2025-01-01'"),
+			want:   "CREATE TABLE events_old PARTITION OF events FOR VALUES FROM (MINVALUE) TO ('2025-01-01')",
+		},
+		{
+			name:   "list",
+			bounds: ListBounds("'eu'", "'uk'"),
+			want:   "CREATE TABLE events_old PARTITION OF events FOR VALUES IN ('eu', 'uk')",
+		},
+		{
+			name:   "hash",
+			bounds: HashBounds(4, 1),
+			want:   "CREATE TABLE events_old PARTITION OF events FOR VALUES WITH (MODULUS 4, REMAINDER 1)",
+		},
+		{
+			name:    "range without lower bound",
+			bounds:  RangeBounds("", "'2025-01-01'"),
+			wantErr: "range partition events_old requires both bounds, use MINVALUE or MAXVALUE for an unbounded side",
+		},
+		{
+			name:    "hash remainder above modulus",
+			bounds:  HashBounds(4, 4),
+			wantErr: "hash partition events_old requires a positive modulus and a remainder below it",
+		},
+		{
+			name:    "no bounds",
+			wantErr: "partition events_old has no bounds, use RangeBounds, ListBounds or HashBounds",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grammar.CompileCreatePartition("events", "events_old", tt.bounds)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPgGrammar_PartitionErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		creating  bool
+		blueprint func(table *Blueprint)
+		wantErr   string
+	}{
+		{
+			name:     "partition without key",
+			creating: true,
+			blueprint: func(table *Blueprint) {
+				table.Partition("events_eu", ListBounds("'eu'"))
+			},
+			wantErr: "table events has partitions but no partition key, call PartitionBy",
+		},
+		{
+			name:     "bounds of another method",
+			creating: true,
+			blueprint: func(table *Blueprint) {
+				table.PartitionBy(PartitionRange, "created_at")
+				table.Partition("events_eu", ListBounds("'eu'"))
+			},
+			wantErr: "partition events_eu of table events: LIST bounds on a table partitioned by RANGE",
+		},
+		{
+			name: "altering a table",
+			blueprint: func(table *Blueprint) {
+				table.PartitionBy(PartitionRange, "created_at")
+			},
+			wantErr: "table events: PartitionBy is only supported when creating a table",
+		},
+		{
+			name:     "without columns",
+			creating: true,
+			blueprint: func(table *Blueprint) {
+				table.PartitionBy(PartitionHash)
+			},
+			wantErr: "table events: PartitionBy requires at least one column",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "events", grammar: newPostgresGrammar()}
+			if tt.creating {
+				bp.create()
+			}
+			bp.Timestamp("created_at")
+			tt.blueprint(bp)
+
+			_, err := bp.toSQL()
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPgGrammar_BlueprintConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		creating  bool
+		blueprint func(table *Blueprint)
+		wantErr   string
+	}{
+		{
+			name:     "duplicate columns and indexes",
+			creating: true,
+			blueprint: func(table *Blueprint) {
+				table.String("email")
+				table.String("email").Unique()
+				table.Unique("email")
+				table.Index("name").Name("idx_users")
+				table.Index("email").Name("idx_users")
+			},
+			wantErr: "invalid blueprint: table users: column email is defined more than once\n" +
+				"invalid blueprint: table users: index idx_users is defined more than once\n" +
+				"invalid blueprint: table users: index uk_users_email is defined more than once",
+		},
+		{
+			name: "dropped and changed column",
+			blueprint: func(table *Blueprint) {
+				table.DropColumn("name")
+				table.Text("name").Change()
+			},
+			wantErr: "invalid blueprint: table users: column name is both dropped and changed",
+		},
+		{
+			name: "dropped and renamed column",
+			blueprint: func(table *Blueprint) {
+				table.DropColumn("name", "email")
+				table.RenameColumn("email", "mail")
+			},
+			wantErr: "invalid blueprint: table users: column email is both dropped and renamed",
+		},
+		{
+			name: "duplicate foreign keys",
+			blueprint: func(table *Blueprint) {
+				table.Foreign("team_id").References("id").On("teams")
+				table.Foreign("team_id").References("id").On("teams")
+			},
+			wantErr: "invalid blueprint: table users: foreign key fk_users_teams is defined more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "users", grammar: newPostgresGrammar()}
+			if tt.creating {
+				bp.create()
+			}
+			tt.blueprint(bp)
+
+			_, err := bp.toSQL()
+			require.ErrorIs(t, err, ErrInvalidBlueprint)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPgGrammar_CreateIfNotExists(t *testing.T) {
+	bp := &Blueprint{name: "sessions", grammar: newPostgresGrammar(), ifNotExists: true}
+	bp.create()
+	bp.String("id").Primary()
+	bp.Timestamp("expires_at").Index()
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS sessions (id VARCHAR(255) NOT NULL, expires_at TIMESTAMP(0) NOT NULL, " +
+			"CONSTRAINT pk_sessions PRIMARY KEY (id))",
+		"CREATE INDEX idx_sessions_expires_at ON sessions (expires_at)",
+	}, got)
+}
+
+func TestPgGrammar_View(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		view      *ViewBlueprint
+		orReplace bool
+		want      string
+		wantErr   string
+	}{
+		{
+			name: "create view",
+			view: &ViewBlueprint{name: "active_users", query: "SELECT id, email FROM users WHERE active"},
+			want: "CREATE VIEW active_users AS SELECT id, email FROM users WHERE active",
+		},
+		{
+			name: "create or replace view with columns",
+			view: &ViewBlueprint{
+				name:    "reporting.active_users",
+				columns: []string{"user_id", "user_email"},
+				query:   " SELECT id, email FROM users WHERE active; ",
+			},
+			orReplace: true,
+			want: "CREATE OR REPLACE VIEW reporting.active_users (user_id, user_email) AS " +
+				"SELECT id, email FROM users WHERE active",
+		},
+		{
+			name:    "without a query",
+			view:    &ViewBlueprint{name: "active_users"},
+			wantErr: "view active_users has no query, call As",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grammar.CompileCreateView(tt.view, tt.orReplace)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := grammar.CompileDropView("active_users")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP VIEW active_users", got)
+	_, err = grammar.CompileDropView("")
+	require.EqualError(t, err, "view name cannot be empty")
+}
+
+func TestPgGrammar_MaterializedView(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name    string
+		view    *ViewBlueprint
+		want    string
+		wantErr string
+	}{
+		{
+			name: "create materialized view",
+			view: &ViewBlueprint{
+				name:    "daily_sales",
+				columns: []string{"day", "total"},
+				query:   "SELECT created_at::date, SUM(total) FROM orders GROUP BY 1",
+			},
+			want: "CREATE MATERIALIZED VIEW daily_sales (day, total) AS " +
+				"SELECT created_at::date, SUM(total) FROM orders GROUP BY 1",
+		},
+		{
+			name: "with no data",
+			view: &ViewBlueprint{name: "daily_sales", query: "SELECT 1 AS day", withNoData: true},
+			want: "CREATE MATERIALIZED VIEW daily_sales AS SELECT 1 AS day WITH NO DATA",
+		},
+		{
+			name:    "without a query",
+			view:    &ViewBlueprint{name: "daily_sales"},
+			wantErr: "view daily_sales has no query, call As",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grammar.CompileCreateMaterializedView(tt.view)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := grammar.CompileRefreshMaterializedView("daily_sales", RefreshOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "REFRESH MATERIALIZED VIEW daily_sales", got)
+	got, err = grammar.CompileRefreshMaterializedView("daily_sales", RefreshOptions{Concurrently: true})
+	require.NoError(t, err)
+	assert.Equal(t, "REFRESH MATERIALIZED VIEW CONCURRENTLY daily_sales", got)
+	got, err = grammar.CompileDropMaterializedView("daily_sales")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP MATERIALIZED VIEW daily_sales", got)
+
+	_, err = grammar.CompileCreateView(&ViewBlueprint{name: "daily_sales", query: "SELECT 1", withNoData: true}, false)
+	require.EqualError(t, err, "view daily_sales: WithNoData is only supported by materialized views")
+}
+
+func TestPgGrammar_Sequence(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(seq *SequenceBlueprint)
+		want      string
+		wantAlter string
+		wantErr   string
+	}{
+		{
+			name:      "without options",
+			blueprint: func(*SequenceBlueprint) {},
+			want:      "CREATE SEQUENCE invoice_number_seq",
+			wantErr:   "sequence invoice_number_seq has no changes",
+		},
+		{
+			name: "with options",
+			blueprint: func(seq *SequenceBlueprint) {
+				seq.As("integer")
+				seq.Start(1000)
+				seq.IncrementBy(10)
+				seq.MinValue(1000)
+				seq.MaxValue(999999)
+				seq.Cache(20)
+				seq.Cycle(true)
+				seq.OwnedBy("invoices.number")
+			},
+			want: "CREATE SEQUENCE invoice_number_seq AS integer INCREMENT BY 10 MINVALUE 1000 MAXVALUE 999999 " +
+				"START WITH 1000 CACHE 20 CYCLE OWNED BY invoices.number",
+			wantAlter: "ALTER SEQUENCE invoice_number_seq AS integer INCREMENT BY 10 MINVALUE 1000 MAXVALUE 999999 " +
+				"RESTART WITH 1000 CACHE 20 CYCLE OWNED BY invoices.number",
+		},
+		{
+			name: "no cycle and start at zero",
+			blueprint: func(seq *SequenceBlueprint) {
+				seq.MinValue(0)
+				seq.Start(0)
+				seq.Cycle(false)
+			},
+			want:      "CREATE SEQUENCE invoice_number_seq MINVALUE 0 START WITH 0 NO CYCLE",
+			wantAlter: "ALTER SEQUENCE invoice_number_seq MINVALUE 0 RESTART WITH 0 NO CYCLE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq := &SequenceBlueprint{name: "invoice_number_seq"}
+			tt.blueprint(seq)
+
+			got, err := grammar.CompileCreateSequence(seq)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			got, err = grammar.CompileAlterSequence(seq)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlter, got)
+		})
+	}
+
+	got, err := grammar.CompileDropSequence("invoice_number_seq")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP SEQUENCE invoice_number_seq", got)
+}
+
+func TestPgGrammar_Schema(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	got, err := grammar.CompileCreateSchema("billing")
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE SCHEMA billing", got)
+
+	got, err = grammar.CompileDropSchema("billing", false)
+	require.NoError(t, err)
+	assert.Equal(t, "DROP SCHEMA billing", got)
+
+	got, err = grammar.CompileDropSchema("billing", true)
+	require.NoError(t, err)
+	assert.Equal(t, "DROP SCHEMA billing CASCADE", got)
+
+	got, err = grammar.CompileSchemaExists("billing")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 1 FROM pg_namespace WHERE nspname = 'billing'", got)
+
+	_, err = grammar.CompileCreateSchema("")
+	require.EqualError(t, err, "schema name cannot be empty")
+}
+
+func TestPgGrammar_Extension(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	got, err := grammar.CompileCreateExtension("uuid-ossp")
+	require.NoError(t, err)
+	assert.Equal(t, `CREATE EXTENSION "uuid-ossp"`, got)
+
+	got, err = grammar.CompileDropExtension("uuid-ossp")
+	require.NoError(t, err)
+	assert.Equal(t, `DROP EXTENSION "uuid-ossp"`, got)
+
+	got, err = grammar.CompileExtensionExists("uuid-ossp")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 1 FROM pg_extension WHERE extname = 'uuid-ossp'", got)
+
+	_, err = grammar.CompileCreateExtension("")
+	require.EqualError(t, err, "extension name cannot be empty")
+}
+
+func TestPgGrammar_Function(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name    string
+		fn      *FunctionBlueprint
+		want    string
+		wantErr string
+	}{
+		{
+			name: "trigger function",
+			fn: &FunctionBlueprint{
+				name:    "set_updated_at",
+				returns: "trigger",
+				body:    "BEGIN NEW.updated_at = now(); RETURN NEW; END;",
+			},
+			want: "CREATE FUNCTION set_updated_at() RETURNS trigger LANGUAGE plpgsql AS " +
+				"$function$BEGIN NEW.updated_at = now(); RETURN NEW; END;$function$",
+		},
+		{
+			name: "deterministic sql function",
+			fn: &FunctionBlueprint{
+				name:          "add_tax",
+				parameters:    []string{"amount numeric", "rate numeric"},
+				returns:       "numeric",
+				language:      "sql",
+				body:          "SELECT amount * (1 + rate)",
+				deterministic: true,
+			},
+			want: "CREATE FUNCTION add_tax(amount numeric, rate numeric) RETURNS numeric LANGUAGE sql IMMUTABLE AS " +
+				"$function$SELECT amount * (1 + rate)$function$",
+		},
+		{
+			name:    "without a return type",
+			fn:      &FunctionBlueprint{name: "set_updated_at", body: "BEGIN RETURN NEW; END;"},
+			wantErr: "function set_updated_at has no return type, call Returns",
+		},
+		{
+			name:    "without a body",
+			fn:      &FunctionBlueprint{name: "set_updated_at", returns: "trigger"},
+			wantErr: "function set_updated_at has no body, call Body",
+		},
+		{
+			name:    "body with the dollar quote",
+			fn:      &FunctionBlueprint{name: "quote", returns: "text", body: "SELECT '$function$'"},
+			wantErr: "function quote: the body cannot contain $function$",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grammar.CompileCreateFunction(tt.fn)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := grammar.CompileDropFunction("set_updated_at")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP FUNCTION set_updated_at", got)
+}
+
+func TestPgGrammar_Trigger(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(trigger *TriggerBlueprint)
+		want      string
+		wantErr   string
+	}{
+		{
+			name: "before update",
+			blueprint: func(trigger *TriggerBlueprint) {
+				trigger.Before("update")
+				trigger.Execute("set_updated_at")
+			},
+			want: "CREATE TRIGGER users_updated_at BEFORE UPDATE ON users FOR EACH ROW " +
+				"EXECUTE FUNCTION set_updated_at()",
+		},
+		{
+			name: "after several events when changed",
+			blueprint: func(trigger *TriggerBlueprint) {
+				trigger.After("INSERT", "UPDATE")
+				trigger.When("OLD.* IS DISTINCT FROM NEW.*")
+				trigger.Execute("audit_users")
+			},
+			want: "CREATE TRIGGER users_updated_at AFTER INSERT OR UPDATE ON users FOR EACH ROW " +
+				"WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_users()",
+		},
+		{
+			name: "for each statement",
+			blueprint: func(trigger *TriggerBlueprint) {
+				trigger.After("TRUNCATE")
+				trigger.ForEachStatement()
+				trigger.Execute("audit_users")
+			},
+			want: "CREATE TRIGGER users_updated_at AFTER TRUNCATE ON users FOR EACH STATEMENT " +
+				"EXECUTE FUNCTION audit_users()",
+		},
+		{
+			name:      "without events",
+			blueprint: func(trigger *TriggerBlueprint) { trigger.Execute("set_updated_at") },
+			wantErr:   "trigger users_updated_at has no events, call Before, After or InsteadOf",
+		},
+		{
+			name: "unknown event",
+			blueprint: func(trigger *TriggerBlueprint) {
+				trigger.Before("SELECT")
+				trigger.Execute("set_updated_at")
+			},
+			wantErr: "trigger users_updated_at: unknown event SELECT, expected one of INSERT, UPDATE, DELETE, TRUNCATE",
+		},
+		{
+			name:      "without a function",
+			blueprint: func(trigger *TriggerBlueprint) { trigger.Before("UPDATE") },
+			wantErr:   "trigger users_updated_at has no function, call Execute",
+		},
+		{
+			name: "with a body",
+			blueprint: func(trigger *TriggerBlueprint) {
+				trigger.Before("UPDATE")
+				trigger.Body("SET NEW.updated_at = NOW()")
+			},
+			wantErr: "trigger users_updated_at: postgres triggers execute a function, call Execute instead of Body",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := &TriggerBlueprint{name: "users_updated_at", table: "users"}
+			tt.blueprint(trigger)
+			got, err := grammar.CompileCreateTrigger(trigger)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := grammar.CompileDropTrigger("users_updated_at", "users")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP TRIGGER users_updated_at ON users", got)
+}
+
+func TestPgGrammar_EventTrigger(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	tests := []struct {
+		name      string
+		blueprint func(trigger *EventTriggerBlueprint)
+		want      string
+		wantErr   string
+	}{
+		{
+			name: "every command",
+			blueprint: func(trigger *EventTriggerBlueprint) {
+				trigger.On("ddl_command_end")
+				trigger.Execute("log_ddl")
+			},
+			want: "CREATE EVENT TRIGGER forbid_drop_table ON ddl_command_end EXECUTE FUNCTION log_ddl()",
+		},
+		{
+			name: "filtered by tags",
+			blueprint: func(trigger *EventTriggerBlueprint) {
+				trigger.On("DDL_COMMAND_START")
+				trigger.Tags("drop table", "DROP SCHEMA")
+				trigger.Execute("abort_drop")
+			},
+			want: "CREATE EVENT TRIGGER forbid_drop_table ON ddl_command_start " +
+				"WHEN TAG IN ('DROP TABLE', 'DROP SCHEMA') EXECUTE FUNCTION abort_drop()",
+		},
+		{
+			name:      "without an event",
+			blueprint: func(trigger *EventTriggerBlueprint) { trigger.Execute("abort_drop") },
+			wantErr:   "event trigger forbid_drop_table has no event, call On",
+		},
+		{
+			name: "unknown event",
+			blueprint: func(trigger *EventTriggerBlueprint) {
+				trigger.On("ddl_command")
+				trigger.Execute("abort_drop")
+			},
+			wantErr: "event trigger forbid_drop_table: unknown event ddl_command, expected one of " +
+				"ddl_command_start, ddl_command_end, table_rewrite, sql_drop, login",
+		},
+		{
+			name:      "without a function",
+			blueprint: func(trigger *EventTriggerBlueprint) { trigger.On("sql_drop") },
+			wantErr:   "event trigger forbid_drop_table has no function, call Execute",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := &EventTriggerBlueprint{name: "forbid_drop_table"}
+			tt.blueprint(trigger)
+			got, err := grammar.CompileCreateEventTrigger(trigger)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := grammar.CompileDropEventTrigger("forbid_drop_table")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP EVENT TRIGGER forbid_drop_table", got)
+	_, err = grammar.CompileDropEventTrigger("")
+	require.Error(t, err)
+}
+
+func TestPgGrammar_TableComment(t *testing.T) {
+	grammar := newPostgresGrammar()
+
+	bp := &Blueprint{name: "orders", grammar: grammar}
+	bp.create()
+	bp.Comment("Customer's orders")
+	bp.ID()
+	bp.String("status").Comment("Order status")
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE orders (id BIGSERIAL NOT NULL, status VARCHAR(255) NOT NULL, " +
+			"CONSTRAINT pk_orders PRIMARY KEY (id))",
+		"COMMENT ON TABLE orders IS 'Customer''s orders'",
+		"COMMENT ON COLUMN orders.status IS 'Order status'",
+	}, got)
+
+	bp = &Blueprint{name: "orders", grammar: grammar}
+	bp.Comment("")
+	got, err = bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"COMMENT ON TABLE orders IS ''"}, got)
+}
+
+func TestPgGrammar_IdentityID(t *testing.T) {
+	bp := &Blueprint{name: "users", grammar: newPostgresGrammar(), identityIDs: true}
+	bp.create()
+	bp.ID()
+
+	got, err := bp.toSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE users (id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY, " +
+			"CONSTRAINT pk_users PRIMARY KEY (id))",
+	}, got)
+}
@@ -0,0 +1,77 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/akfaiz/migris/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		grammar grammar
+		want    []string
+	}{
+		{
+			name:    "postgres",
+			grammar: newPostgresGrammar(),
+			want: []string{
+				"ALTER TABLE users RENAME COLUMN name TO full_name",
+				"ALTER TABLE users RENAME COLUMN mail TO email",
+				"CREATE TABLE IF NOT EXISTS column_renames (id BIGSERIAL PRIMARY KEY, " +
+					"table_name VARCHAR(255) NOT NULL, old_name VARCHAR(255) NOT NULL, new_name VARCHAR(255) NOT NULL, " +
+					"renamed_at TIMESTAMP(0) NOT NULL DEFAULT CURRENT_TIMESTAMP)",
+				"INSERT INTO column_renames (table_name, old_name, new_name) VALUES ('users', 'name', 'full_name')",
+				"INSERT INTO column_renames (table_name, old_name, new_name) VALUES ('users', 'mail', 'email')",
+			},
+		},
+		{
+			name:    "mysql",
+			grammar: newMysqlGrammar(),
+			want: []string{
+				"ALTER TABLE users RENAME COLUMN name TO full_name",
+				"ALTER TABLE users RENAME COLUMN mail TO email",
+				"CREATE TABLE IF NOT EXISTS column_renames (id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY, " +
+					"table_name VARCHAR(255) NOT NULL, old_name VARCHAR(255) NOT NULL, new_name VARCHAR(255) NOT NULL, " +
+					"renamed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)",
+				"INSERT INTO column_renames (table_name, old_name, new_name) VALUES ('users', 'name', 'full_name')",
+				"INSERT INTO column_renames (table_name, old_name, new_name) VALUES ('users', 'mail', 'email')",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := &Blueprint{name: "users", grammar: tt.grammar, renameLog: "column_renames"}
+			bp.RenameColumn("name", "full_name")
+			bp.RenameColumn("mail", "email")
+
+			got, err := bp.toSQL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		bp := newPostgresBuilder().(*postgresBuilder).newBlueprint("users")
+		bp.RenameColumn("name", "full_name")
+
+		got, err := bp.toSQL()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ALTER TABLE users RENAME COLUMN name TO full_name"}, got)
+	})
+
+	t.Run("without renamed columns", func(t *testing.T) {
+		config.SetRenameLogTable("column_renames")
+		t.Cleanup(func() { config.SetRenameLogTable("") })
+
+		bp := newPostgresBuilder().(*postgresBuilder).newBlueprint("users")
+		bp.DropColumn("name")
+
+		got, err := bp.toSQL()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ALTER TABLE users DROP COLUMN name"}, got)
+	})
+}
@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ddlDefinition is a line of the column and constraint list of a CREATE TABLE statement returned
+// by GetTableDDL.
+type ddlDefinition struct {
+	column     string // unquoted column name, empty for constraints and keys
+	name       string // column name as quoted in the statement
+	definition string // the line without indentation and trailing comma
+}
+
+// parseCreateTable returns the table name and the column and constraint definitions of a
+// CREATE TABLE statement returned by GetTableDDL, with one definition per line. Lines starting
+// with a quote character or a lowercase letter are columns.
+func parseCreateTable(statement string, quote byte) (string, []ddlDefinition, error) {
+	lines := strings.Split(strings.TrimSpace(statement), "\n")
+	table, ok := strings.CutPrefix(lines[0], "CREATE TABLE ")
+	if !ok || len(lines) < 3 {
+		return "", nil, errors.New("unexpected table definition")
+	}
+	table = strings.TrimSuffix(table, " (")
+
+	var definitions []ddlDefinition
+	for _, line := range lines[1 : len(lines)-1] {
+		definition := strings.TrimSuffix(strings.TrimSpace(line), ",")
+		if definition == "" {
+			continue
+		}
+		def := ddlDefinition{definition: definition}
+		switch {
+		case definition[0] == quote:
+			end := strings.IndexByte(definition[1:], quote)
+			if end < 0 {
+				return "", nil, fmt.Errorf("unexpected column definition %q", definition)
+			}
+			def.name = definition[:end+2]
+			def.column = definition[1 : end+1]
+		case definition[0] >= 'a' && definition[0] <= 'z' || definition[0] == '_':
+			def.name, _, _ = strings.Cut(definition, " ")
+			def.column = def.name
+		}
+		definitions = append(definitions, def)
+	}
+	return table, definitions, nil
+}
+
+// reorderColumns returns the column definitions in their new order: the given columns first,
+// followed by the remaining ones in their current order.
+func reorderColumns(definitions []ddlDefinition, columns []string) ([]ddlDefinition, error) {
+	var current []ddlDefinition
+	for _, def := range definitions {
+		if def.column != "" {
+			current = append(current, def)
+		}
+	}
+	reordered := make([]ddlDefinition, 0, len(current))
+	for i, column := range columns {
+		if slices.Contains(columns[:i], column) {
+			return nil, fmt.Errorf("column %s is listed more than once", column)
+		}
+		j := slices.IndexFunc(current, func(def ddlDefinition) bool { return def.column == column })
+		if j < 0 {
+			return nil, fmt.Errorf("column %s does not exist", column)
+		}
+		reordered = append(reordered, current[j])
+	}
+	for _, def := range current {
+		if !slices.Contains(columns, def.column) {
+			reordered = append(reordered, def)
+		}
+	}
+	return reordered, nil
+}
+
+// columnNames returns the quoted names of the column definitions.
+func columnNames(definitions []ddlDefinition) []string {
+	names := make([]string, len(definitions))
+	for i, def := range definitions {
+		names[i] = def.name
+	}
+	return names
+}
+
+// reordersColumns reports whether the new order of the columns differs from their current one.
+func reordersColumns(definitions []ddlDefinition, reordered []ddlDefinition) bool {
+	i := 0
+	for _, def := range definitions {
+		if def.column == "" {
+			continue
+		}
+		if def.column != reordered[i].column {
+			return true
+		}
+		i++
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pgUsersDDL = `CREATE TABLE public.users (
+    id bigint NOT NULL DEFAULT nextval('users_id_seq'::regclass),
+    name character varying(255) NOT NULL,
+    "Email" character varying(255) NOT NULL,
+    account_id bigint,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CONSTRAINT users_email_key UNIQUE ("Email"),
+    CONSTRAINT chk_users_name CHECK ((name)::text <> ''::text),
+    CONSTRAINT fk_users_accounts FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+CREATE INDEX idx_users_name ON public.users USING btree (name);
+COMMENT ON COLUMN public.users.name IS 'Full name';`
+
+const mysqlUsersDDL = "CREATE TABLE `users` (\n" +
+	"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `name` varchar(255) NOT NULL,\n" +
+	"  `email` varchar(255) NOT NULL COMMENT 'Login',\n" +
+	"  `account_id` bigint unsigned DEFAULT NULL,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  UNIQUE KEY `uk_users_email` (`email`)\n" +
+	") ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4"
+
+func TestPgGrammar_CompileReorderColumns(t *testing.T) {
+	g := newPostgresGrammar()
+
+	options := ReorderOptions{LockTimeout: 5 * time.Second}
+	got, err := g.CompileReorderColumns(pgUsersDDL, []string{"id", "Email"}, options)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"SET LOCAL lock_timeout = 5000",
+		"LOCK TABLE public.users IN ACCESS EXCLUSIVE MODE",
+		"CREATE TABLE public.users_reordered (\n" +
+			"    id bigint NOT NULL DEFAULT nextval('users_id_seq'::regclass),\n" +
+			"    \"Email\" character varying(255) NOT NULL,\n" +
+			"    name character varying(255) NOT NULL,\n" +
+			"    account_id bigint,\n" +
+			"    CONSTRAINT chk_users_name CHECK ((name)::text <> ''::text)\n)",
+		"INSERT INTO public.users_reordered (id, \"Email\", name, account_id) OVERRIDING SYSTEM VALUE " +
+			"SELECT id, \"Email\", name, account_id FROM public.users",
+		"ALTER SEQUENCE users_id_seq OWNED BY public.users_reordered.id",
+		"DROP TABLE public.users",
+		"ALTER TABLE public.users_reordered RENAME TO users",
+		"ALTER TABLE public.users ADD CONSTRAINT users_pkey PRIMARY KEY (id)",
+		"ALTER TABLE public.users ADD CONSTRAINT users_email_key UNIQUE (\"Email\")",
+		"ALTER TABLE public.users ADD CONSTRAINT fk_users_accounts FOREIGN KEY (account_id) REFERENCES accounts(id)",
+		"CREATE INDEX idx_users_name ON public.users USING btree (name)",
+		"COMMENT ON COLUMN public.users.name IS 'Full name'",
+	}, got)
+
+	t.Run("identity columns continue after the copied values", func(t *testing.T) {
+		ddl := "CREATE TABLE public.tags (\n" +
+			"    id bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL,\n" +
+			"    name text NOT NULL\n);"
+		got, err := g.CompileReorderColumns(ddl, []string{"name"}, ReorderOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "LOCK TABLE public.tags IN ACCESS EXCLUSIVE MODE", got[0])
+		assert.Equal(t,
+			"SELECT setval(pg_get_serial_sequence('public.tags', 'id'), coalesce(max(id), 0) + 1, false) "+
+				"FROM public.tags",
+			got[len(got)-1])
+	})
+
+	t.Run("nothing to do when the columns are in order", func(t *testing.T) {
+		got, err := g.CompileReorderColumns(pgUsersDDL, []string{"id", "name"}, ReorderOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestMysqlGrammar_CompileReorderColumns(t *testing.T) {
+	g := newMysqlGrammar()
+
+	got, err := g.CompileReorderColumns(mysqlUsersDDL, []string{"id", "email"}, ReorderOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE `users` MODIFY COLUMN `id` bigint unsigned NOT NULL AUTO_INCREMENT FIRST, " +
+			"MODIFY COLUMN `email` varchar(255) NOT NULL COMMENT 'Login' AFTER `id`",
+	}, got)
+
+	options := ReorderOptions{LockTimeout: 1500 * time.Millisecond}
+	got, err = g.CompileReorderColumns(mysqlUsersDDL, []string{"account_id"}, options)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"SET SESSION lock_wait_timeout = 2",
+		"ALTER TABLE `users` MODIFY COLUMN `account_id` bigint unsigned DEFAULT NULL FIRST",
+		"SET SESSION lock_wait_timeout = DEFAULT",
+	}, got)
+}
+
+func TestReorderColumns_InvalidColumns(t *testing.T) {
+	g := newMysqlGrammar()
+
+	_, err := g.CompileReorderColumns(mysqlUsersDDL, []string{"nickname"}, ReorderOptions{})
+	require.EqualError(t, err, "column nickname does not exist")
+	_, err = g.CompileReorderColumns(mysqlUsersDDL, []string{"email", "email"}, ReorderOptions{})
+	require.EqualError(t, err, "column email is listed more than once")
+}
+
+func TestFake_ReorderColumns(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+
+	require.NoError(t, ReorderColumns(fake, "users", []string{"email"}, ReorderOptions{}))
+	columns, err := GetColumns(fake, "users")
+	require.NoError(t, err)
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	assert.Equal(t, []string{"email", "id", "name"}, names)
+	assert.Equal(t, []FakeOperation{{Name: "ReorderColumns", Table: "users"}}, fake.Operations())
+
+	require.EqualError(t, ReorderColumns(fake, "posts", []string{"id"}, ReorderOptions{}), "table posts does not exist")
+}
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRowCountChanged is returned by AssertRowCountStable when the number of rows of a table
+// changed while the wrapped operations ran.
+var ErrRowCountChanged = errors.New("row count changed")
+
+// AssertRowCountStable counts the rows of the table before and after running fn, and fails if
+// the counts differ, e.g. because rows were lost while a risky migration restructured the table.
+// Return the error from the migration so its transaction is rolled back. Dry runs and fakes do
+// not hold rows, so fn runs without the counts being checked.
+//
+// The rows are counted with a full scan of the table, and rows written concurrently by other
+// sessions change the count as well. Run it in a transaction, or while the application does not
+// write to the table.
+//
+// Example:
+//
+//	err := schema.AssertRowCountStable(c, "orders", func() error {
+//	    return schema.Table(c, "orders", func(table *schema.Blueprint) {
+//	        table.Decimal("total", 12, 2).Change()
+//	    })
+//	})
+func AssertRowCountStable(c Context, table string, fn func() error) error {
+	if c == nil || table == "" || fn == nil {
+		return errors.New("invalid arguments: context, table, or fn is nil/empty")
+	}
+	if !countsRows(c) {
+		return fn()
+	}
+
+	before, err := countRows(c, table)
+	if err != nil {
+		return err
+	}
+	if err = fn(); err != nil {
+		return err
+	}
+	after, err := countRows(c, table)
+	if err != nil {
+		return err
+	}
+	if after != before {
+		return fmt.Errorf("%w: table %s had %d rows before and %d after", ErrRowCountChanged, table, before, after)
+	}
+	return nil
+}
+
+// countsRows reports whether the rows of the tables can be counted through the context.
+func countsRows(c Context) bool {
+	switch c.(type) {
+	case *DryRunContext, *Fake:
+		return false
+	default:
+		return true
+	}
+}
+
+// countRows returns the number of rows of the table.
+func countRows(c Context, table string) (int64, error) {
+	var count int64
+	if err := c.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+		return 0, fmt.Errorf("cannot count the rows of table %s: %w", table, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,45 @@
+package schema //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertRowCountStable_WithoutRows(t *testing.T) {
+	fake := newTestFake(t, "pgx")
+	tests := []struct {
+		name string
+		c    Context
+	}{
+		{name: "dry run", c: NewDryRunContext(context.Background())},
+		{name: "fake", c: fake},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			err := AssertRowCountStable(tt.c, "users", func() error {
+				called = true
+				return nil
+			})
+			require.NoError(t, err)
+			assert.True(t, called)
+		})
+	}
+
+	t.Run("returns the error of fn", func(t *testing.T) {
+		err := AssertRowCountStable(fake, "users", func() error {
+			return errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		require.Error(t, AssertRowCountStable(nil, "users", func() error { return nil }))
+		require.Error(t, AssertRowCountStable(fake, "", func() error { return nil }))
+		require.Error(t, AssertRowCountStable(fake, "users", nil))
+	})
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/akfaiz/migris/internal/config"
 	"github.com/akfaiz/migris/internal/dialect"
@@ -16,7 +17,7 @@ type Column struct {
 	Collation  sql.NullString // Collation is the collation of the column, if applicable.
 	Nullable   bool           // Nullable indicates whether the column can contain NULL values.
 	DefaultVal sql.NullString // DefaultVal is the default value for the column, if any.
-	Comment    sql.NullString // Comment is an optional comment for the column.
+	Comment    sql.NullString // Comment is the comment of the column, if any; an empty comment counts as none.
 	Extra      sql.NullString // Extra contains additional information about the column (e.g., "auto_increment").
 }
 
@@ -29,19 +30,46 @@ type Index struct {
 	Primary bool     // Indicates if the index is a primary key
 }
 
+// ForeignKey represents a foreign key constraint of a table with its properties.
+type ForeignKey struct {
+	Name           string   // Name is the name of the constraint.
+	Columns        []string // Columns are the columns of the table that reference the foreign table.
+	ForeignSchema  string   // ForeignSchema is the schema of the referenced table.
+	ForeignTable   string   // ForeignTable is the name of the referenced table.
+	ForeignColumns []string // ForeignColumns are the referenced columns, in the order of Columns.
+	OnUpdate       string   // e.g., "CASCADE", "SET NULL", "SET DEFAULT", "RESTRICT", "NO ACTION"
+	OnDelete       string   // e.g., "CASCADE", "SET NULL", "SET DEFAULT", "RESTRICT", "NO ACTION"
+}
+
 // TableInfo represents information about a database table.
 // It includes the table name, schema, size, and an optional comment.
 type TableInfo struct {
 	Name      string         // Name is the name of the table.
 	Schema    string         // Schema is the schema where the table resides.
 	Size      int64          // Size is the size of the table in bytes.
-	Comment   sql.NullString // Comment is an optional comment for the table.
+	Comment   sql.NullString // Comment is the comment of the table, if any; an empty comment counts as none.
 	Engine    sql.NullString // Engine is the storage engine used for the table (e.g., "InnoDB", "MyISAM").
 	Collation sql.NullString // Collation is the collation used for the table (e.g., "utf8mb4_general_ci").
 }
 
-func newBuilder() (Builder, error) {
+// ViewInfo represents a view in the database, as returned by GetViews.
+type ViewInfo struct {
+	Name       string // Name is the name of the view.
+	Schema     string // Schema is the schema where the view resides.
+	Definition string // Definition is the SELECT statement of the view, as stored by the database.
+}
+
+func newBuilder(c Context) (Builder, error) {
+	// A fake builder is its own context, so migrations written against the package functions
+	// run against its simulated catalog.
+	if builder, ok := c.(Builder); ok {
+		return builder, nil
+	}
+
 	dialectVal := config.GetDialect()
+	if drc, ok := c.(*DryRunContext); ok && drc.dialect != dialect.Unknown {
+		dialectVal = drc.dialect
+	}
 	if dialectVal == dialect.Unknown {
 		return nil, errors.New(
 			"schema dialect is not set, please call schema.SetDialect() before using schema functions",
@@ -71,7 +99,7 @@ func newBuilder() (Builder, error) {
 //	    table.Timestamp("updated_at").Default("CURRENT_TIMESTAMP").Nullable(false)
 //	})
 func Create(c Context, name string, blueprint func(table *Blueprint)) error {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return err
 	}
@@ -79,6 +107,79 @@ func Create(c Context, name string, blueprint func(table *Blueprint)) error {
 	return builder.Create(c, name, blueprint)
 }
 
+// CreateTemporary creates a temporary table with the given name and blueprint. The table is only
+// visible to the connection of the migration and is dropped at the end of the session, which
+// makes it useful to stage data in data-fix migrations.
+//
+// Example:
+//
+//	err := schema.CreateTemporary(c, "order_fixes", func(table *schema.Blueprint) {
+//	    table.BigInteger("order_id").Primary()
+//	    table.Decimal("total", 10, 2)
+//	})
+func CreateTemporary(c Context, name string, blueprint func(table *Blueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateTemporary(c, name, blueprint)
+}
+
+// CreateIfNotExists creates a new table with the given name and blueprint, unless a table with
+// that name exists already, e.g. because it was created outside of the migrations. An existing
+// table is left as it is, even if its structure differs from the blueprint.
+//
+// Example:
+//
+//	err := schema.CreateIfNotExists(c, "sessions", func(table *schema.Blueprint) {
+//	    table.String("id").Primary()
+//	    table.Text("payload")
+//	})
+func CreateIfNotExists(c Context, name string, blueprint func(table *Blueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateIfNotExists(c, name, blueprint)
+}
+
+// WhenTableExists applies the blueprint to the table with the given name if the table exists,
+// e.g. to alter a table that only some installations have. Without the table it does nothing.
+//
+// Example:
+//
+//	err := schema.WhenTableExists(c, "legacy_sessions", func(table *schema.Blueprint) {
+//	    table.DropColumn("payload")
+//	})
+func WhenTableExists(c Context, name string, blueprint func(table *Blueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.WhenTableExists(c, name, blueprint)
+}
+
+// WhenColumnMissing applies the blueprint to the table with the given name if the table has no
+// column with the given name, e.g. to add a column that was added by hand on some databases. The
+// table must exist.
+//
+// Example:
+//
+//	err := schema.WhenColumnMissing(c, "users", "phone", func(table *schema.Blueprint) {
+//	    table.String("phone").Nullable()
+//	})
+func WhenColumnMissing(c Context, tableName string, columnName string, blueprint func(table *Blueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.WhenColumnMissing(c, tableName, columnName, blueprint)
+}
+
 // Drop removes the table with the given name.
 // It returns an error if the table removal fails.
 //
@@ -86,7 +187,7 @@ func Create(c Context, name string, blueprint func(table *Blueprint)) error {
 //
 //	err := schema.Drop(ctx, tx, "users")
 func Drop(c Context, name string) error {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return err
 	}
@@ -101,7 +202,7 @@ func Drop(c Context, name string) error {
 //
 //	err := schema.DropIfExists(ctx, tx, "users")
 func DropIfExists(c Context, name string) error {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return err
 	}
@@ -109,6 +210,21 @@ func DropIfExists(c Context, name string) error {
 	return builder.DropIfExists(c, name)
 }
 
+// DropAllTables removes every table in the current schema, including the tables referenced by
+// foreign keys.
+//
+// Example:
+//
+//	err := schema.DropAllTables(ctx, tx)
+func DropAllTables(c Context) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropAllTables(c)
+}
+
 // GetColumns retrieves the columns of the specified table.
 // It returns a slice of Column structs representing the columns in the table.
 //
@@ -116,7 +232,7 @@ func DropIfExists(c Context, name string) error {
 //
 //	columns, err := schema.GetColumns(ctx, tx, "users")
 func GetColumns(c Context, tableName string) ([]*Column, error) {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +247,7 @@ func GetColumns(c Context, tableName string) ([]*Column, error) {
 //
 //	indexes, err := schema.GetIndexes(ctx, tx, "users")
 func GetIndexes(c Context, tableName string) ([]*Index, error) {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +255,54 @@ func GetIndexes(c Context, tableName string) ([]*Index, error) {
 	return builder.GetIndexes(c, tableName)
 }
 
+// GetForeignKeys retrieves the foreign keys of the specified table, e.g. to write migrations that
+// only add or fix a foreign key when it is missing or different.
+//
+// Example:
+//
+//	foreignKeys, err := schema.GetForeignKeys(c, "posts")
+func GetForeignKeys(c Context, tableName string) ([]*ForeignKey, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.GetForeignKeys(c, tableName)
+}
+
+// GetAllColumns retrieves the columns of every table in one query instead of one per table, keyed
+// by the table name GetColumns takes. Tables outside the default PostgreSQL schema are qualified
+// with their schema.
+//
+// Example:
+//
+//	columns, err := schema.GetAllColumns(c)
+//	usersColumns := columns["users"]
+func GetAllColumns(c Context) (map[string][]*Column, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.GetAllColumns(c)
+}
+
+// GetAllIndexes retrieves the indexes of every table in one query instead of one per table, keyed
+// like GetAllColumns. Tables without indexes have no entry.
+//
+// Example:
+//
+//	indexes, err := schema.GetAllIndexes(c)
+//	usersIndexes := indexes["users"]
+func GetAllIndexes(c Context) (map[string][]*Index, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.GetAllIndexes(c)
+}
+
 // GetTables retrieves all tables in the database.
 // It returns a slice of TableInfo structs containing information about each table.
 //
@@ -146,7 +310,7 @@ func GetIndexes(c Context, tableName string) ([]*Index, error) {
 //
 //	tables, err := schema.GetTables(ctx, tx)
 func GetTables(c Context) ([]*TableInfo, error) {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +318,55 @@ func GetTables(c Context) ([]*TableInfo, error) {
 	return builder.GetTables(c)
 }
 
+// GetViews retrieves all views in the database, without the materialized views of PostgreSQL.
+// It returns a slice of ViewInfo structs containing information about each view.
+//
+// Example:
+//
+//	views, err := schema.GetViews(ctx, tx)
+func GetViews(c Context) ([]*ViewInfo, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.GetViews(c)
+}
+
+// GetTableDDL retrieves the CREATE TABLE statement of the specified table.
+// On MySQL it returns the output of SHOW CREATE TABLE; on PostgreSQL the statement is
+// reconstructed from the catalog, including constraints, indexes and comments.
+// It is useful to debug schema drift or to take a baseline snapshot of an existing database.
+//
+// Example:
+//
+//	ddl, err := schema.GetTableDDL(ctx, tx, "users")
+func GetTableDDL(c Context, tableName string) (string, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return "", err
+	}
+
+	return builder.GetTableDDL(c, tableName)
+}
+
+// GetSchemaDDL retrieves the statements that recreate every table in the current schema,
+// including indexes and comments. Foreign keys are returned as ALTER TABLE statements after
+// all tables, and on PostgreSQL the enum types and the sequences used by column defaults are
+// created first.
+//
+// Example:
+//
+//	statements, err := schema.GetSchemaDDL(ctx, tx)
+func GetSchemaDDL(c Context) ([]string, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.GetSchemaDDL(c)
+}
+
 // HasColumn checks if a column with the given name exists in the specified table.
 // It returns true if the column exists, false otherwise.
 //
@@ -161,7 +374,7 @@ func GetTables(c Context) ([]*TableInfo, error) {
 //
 //	exists, err := schema.HasColumn(ctx, tx, "users", "email")
 func HasColumn(c Context, tableName string, columnName string) (bool, error) {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return false, err
 	}
@@ -178,7 +391,7 @@ func HasColumn(c Context, tableName string, columnName string) (bool, error) {
 //
 // If any of the specified columns do not exist, it returns false.
 func HasColumns(c Context, tableName string, columnNames []string) (bool, error) {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return false, err
 	}
@@ -195,7 +408,7 @@ func HasColumns(c Context, tableName string, columnNames []string) (bool, error)
 //
 //	exists, err := schema.HasIndex(ctx, tx, "users", []string{"email", "name"}) // Checks if a composite index exists on the "email" and "name" columns in the "users" table.
 func HasIndex(c Context, tableName string, indexes []string) (bool, error) {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return false, err
 	}
@@ -211,7 +424,7 @@ func HasIndex(c Context, tableName string, indexes []string) (bool, error) {
 //
 //	exists, err := schema.HasTable(ctx, tx, "users")
 func HasTable(c Context, name string) (bool, error) {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return false, err
 	}
@@ -219,6 +432,22 @@ func HasTable(c Context, name string) (bool, error) {
 	return builder.HasTable(c, name)
 }
 
+// HasView checks if a view with the given name exists in the database, e.g. to create a view
+// only if it is missing.
+// It returns true if the view exists, false otherwise.
+//
+// Example:
+//
+//	exists, err := schema.HasView(ctx, tx, "active_users")
+func HasView(c Context, name string) (bool, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return false, err
+	}
+
+	return builder.HasView(c, name)
+}
+
 // Rename changes the name of the table from name to newName.
 // It returns an error if the renaming fails.
 //
@@ -226,7 +455,7 @@ func HasTable(c Context, name string) (bool, error) {
 //
 //	err := schema.Rename(ctx, tx, "users", "people")
 func Rename(c Context, name string, newName string) error {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return err
 	}
@@ -246,10 +475,590 @@ func Rename(c Context, name string, newName string) error {
 //	    table.RenameColumn("email", "contact_email")
 //	})
 func Table(c Context, name string, blueprint func(table *Blueprint)) error {
-	builder, err := newBuilder()
+	builder, err := newBuilder(c)
 	if err != nil {
 		return err
 	}
 
 	return builder.Table(c, name, blueprint)
 }
+
+// WithoutForeignKeyConstraints runs fn with the foreign key constraints disabled, e.g. to
+// restructure data of tables with circular foreign keys. The constraints are re-enabled
+// afterwards, even when fn returns an error.
+//
+// On MySQL it sets FOREIGN_KEY_CHECKS; on PostgreSQL it sets session_replication_role to
+// replica, which requires superuser privileges and also disables triggers.
+//
+// Example:
+//
+//	err := schema.WithoutForeignKeyConstraints(c, func() error {
+//	    _, err := c.Exec("UPDATE users SET team_id = 2 WHERE team_id = 1")
+//	    return err
+//	})
+func WithoutForeignKeyConstraints(c Context, fn func() error) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.WithoutForeignKeyConstraints(c, fn)
+}
+
+// DeferConstraints defers the checks of all deferrable constraints until the end of the
+// migration transaction, so data migrations can temporarily violate the foreign key order.
+// Only constraints created as DEFERRABLE are affected.
+//
+// It is only supported by PostgreSQL; on MySQL it returns an error wrapping ErrUnsupportedFeature.
+//
+// Example:
+//
+//	table.Foreign("team_id").References("id").On("teams").Deferrable()
+//
+//	err := schema.DeferConstraints(c)
+func DeferConstraints(c Context) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DeferConstraints(c)
+}
+
+// DefaultPrivileges describes the privileges granted on objects created in the future.
+type DefaultPrivileges struct {
+	ForRole    string   // ForRole is the role whose future objects are affected; defaults to the current role.
+	Schema     string   // Schema limits the grant to objects created in the schema; defaults to all.
+	Privileges []string // Privileges are the granted privileges, e.g. "SELECT", "INSERT".
+	ObjectType string   // ObjectType is TABLES, SEQUENCES, FUNCTIONS, TYPES or SCHEMAS; defaults to TABLES.
+	Grantee    string   // Grantee is the role receiving the privileges.
+}
+
+// AlterDefaultPrivileges grants privileges on the objects that will be created in the future, so
+// tables created by later migrations are accessible to application roles without explicit grants.
+//
+// It is only supported by PostgreSQL; on MySQL it returns an error wrapping ErrUnsupportedFeature.
+//
+// Example:
+//
+//	err := schema.AlterDefaultPrivileges(c, schema.DefaultPrivileges{
+//	    ForRole:    "app_owner",
+//	    Schema:     "public",
+//	    Privileges: []string{"SELECT"},
+//	    Grantee:    "readonly",
+//	})
+func AlterDefaultPrivileges(c Context, privileges DefaultPrivileges) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.AlterDefaultPrivileges(c, privileges)
+}
+
+// CreateEnumType creates a native enum type with the given values, e.g. to share it between the
+// enum columns of several tables; see ColumnDefinition.Native. It is only supported by PostgreSQL.
+//
+// Example:
+//
+//	err := schema.CreateEnumType(c, "order_status", "pending", "paid", "shipped")
+func CreateEnumType(c Context, name string, values ...string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateEnumType(c, name, values...)
+}
+
+// DropEnumType drops a native enum type, e.g. in the down migration after the tables that use it
+// were dropped. It is only supported by PostgreSQL.
+//
+// Example:
+//
+//	err := schema.DropEnumType(c, "order_status")
+func DropEnumType(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropEnumType(c, name)
+}
+
+// CreateView creates a view with the given name from the SELECT statement set with As.
+//
+// Example:
+//
+//	err := schema.CreateView(c, "active_users", func(view *schema.ViewBlueprint) {
+//	    view.Columns("id", "email")
+//	    view.As("SELECT id, email FROM users WHERE deleted_at IS NULL")
+//	})
+func CreateView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateView(c, name, blueprint)
+}
+
+// CreateOrReplaceView creates a view like CreateView, or replaces the query of the view with the
+// given name if it exists. PostgreSQL only replaces a view whose new columns start with the
+// columns of the existing view, with the same names and types; drop and create it otherwise.
+//
+// Example:
+//
+//	err := schema.CreateOrReplaceView(c, "active_users", func(view *schema.ViewBlueprint) {
+//	    view.As("SELECT id, email, name FROM users WHERE deleted_at IS NULL")
+//	})
+func CreateOrReplaceView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateOrReplaceView(c, name, blueprint)
+}
+
+// DropView removes the view with the given name.
+//
+// Example:
+//
+//	err := schema.DropView(c, "active_users")
+func DropView(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropView(c, name)
+}
+
+// CreateMaterializedView creates a materialized view, which stores the result of its query until
+// it is refreshed. It is only supported by PostgreSQL. Indexes are added to the view like to a
+// table, with Table.
+//
+// Example:
+//
+//	err := schema.CreateMaterializedView(c, "daily_sales", func(view *schema.ViewBlueprint) {
+//	    view.As("SELECT created_at::date AS day, SUM(total) AS total FROM orders GROUP BY 1")
+//	})
+func CreateMaterializedView(c Context, name string, blueprint func(view *ViewBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateMaterializedView(c, name, blueprint)
+}
+
+// RefreshMaterializedView replaces the rows of a materialized view with the current result of
+// its query. It is only supported by PostgreSQL.
+//
+// Example:
+//
+//	err := schema.RefreshMaterializedView(c, "daily_sales", schema.RefreshOptions{Concurrently: true})
+func RefreshMaterializedView(c Context, name string, options RefreshOptions) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.RefreshMaterializedView(c, name, options)
+}
+
+// DropMaterializedView removes the materialized view with the given name. It is only supported by
+// PostgreSQL.
+//
+// Example:
+//
+//	err := schema.DropMaterializedView(c, "daily_sales")
+func DropMaterializedView(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropMaterializedView(c, name)
+}
+
+// CreateFunction creates a function with the given name from the body set with Body, e.g. a
+// PostgreSQL function executed by a trigger.
+//
+// Example:
+//
+//	err := schema.CreateFunction(c, "set_updated_at", func(fn *schema.FunctionBlueprint) {
+//	    fn.Returns("trigger")
+//	    fn.Body("BEGIN NEW.updated_at = now(); RETURN NEW; END;")
+//	})
+func CreateFunction(c Context, name string, blueprint func(fn *FunctionBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateFunction(c, name, blueprint)
+}
+
+// DropFunction removes the function with the given name.
+//
+// Example:
+//
+//	err := schema.DropFunction(c, "set_updated_at")
+func DropFunction(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropFunction(c, name)
+}
+
+// CreateTrigger creates a trigger with the given name on a table. PostgreSQL triggers Execute a
+// function created with CreateFunction, MySQL triggers run a Body.
+//
+// Example:
+//
+//	err := schema.CreateTrigger(c, "users_updated_at", "users", func(trigger *schema.TriggerBlueprint) {
+//	    trigger.Before("UPDATE")
+//	    trigger.Execute("set_updated_at")
+//	})
+func CreateTrigger(c Context, name, table string, blueprint func(trigger *TriggerBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateTrigger(c, name, table, blueprint)
+}
+
+// DropTrigger removes the trigger with the given name from a table.
+//
+// Example:
+//
+//	err := schema.DropTrigger(c, "users_updated_at", "users")
+func DropTrigger(c Context, name, table string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropTrigger(c, name, table)
+}
+
+// CreateEventTrigger creates an event trigger with the given name, which executes a function
+// created with CreateFunction and returning event_trigger on DDL commands, e.g. to enforce DDL
+// policies. Creating event triggers requires superuser privileges. It is only supported by
+// PostgreSQL; on MySQL it returns an error wrapping ErrUnsupportedFeature.
+//
+// Example:
+//
+//	err := schema.CreateEventTrigger(c, "forbid_drop_table", func(trigger *schema.EventTriggerBlueprint) {
+//	    trigger.On("ddl_command_start")
+//	    trigger.Tags("DROP TABLE")
+//	    trigger.Execute("abort_drop_table")
+//	})
+func CreateEventTrigger(c Context, name string, blueprint func(trigger *EventTriggerBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateEventTrigger(c, name, blueprint)
+}
+
+// DropEventTrigger removes the event trigger with the given name. It is only supported by
+// PostgreSQL.
+//
+// Example:
+//
+//	err := schema.DropEventTrigger(c, "forbid_drop_table")
+func DropEventTrigger(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropEventTrigger(c, name)
+}
+
+// CreateSequence creates a sequence with the given name, e.g. for invoice numbers that are not
+// the primary key. The blueprint is optional. It is only supported by PostgreSQL; on MySQL,
+// emulate a sequence with a single-row table and
+// UPDATE invoice_numbers SET value = LAST_INSERT_ID(value + 1), then read LAST_INSERT_ID().
+//
+// Example:
+//
+//	err := schema.CreateSequence(c, "invoice_number_seq", func(seq *schema.SequenceBlueprint) {
+//	    seq.Start(1000)
+//	    seq.OwnedBy("invoices.number")
+//	})
+func CreateSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateSequence(c, name, blueprint)
+}
+
+// AlterSequence changes the options of a sequence set in the blueprint; Start restarts the
+// sequence at the given value. It is only supported by PostgreSQL.
+//
+// Example:
+//
+//	err := schema.AlterSequence(c, "invoice_number_seq", func(seq *schema.SequenceBlueprint) {
+//	    seq.Start(2000)
+//	})
+func AlterSequence(c Context, name string, blueprint func(seq *SequenceBlueprint)) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.AlterSequence(c, name, blueprint)
+}
+
+// DropSequence removes the sequence with the given name. It is only supported by PostgreSQL.
+//
+// Example:
+//
+//	err := schema.DropSequence(c, "invoice_number_seq")
+func DropSequence(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropSequence(c, name)
+}
+
+// Analyze refreshes the planner statistics of the given tables, e.g. after a large column or
+// index change, by running ANALYZE on PostgreSQL or ANALYZE TABLE on MySQL.
+//
+// Example:
+//
+//	err := schema.Analyze(c, "users", "orders")
+func Analyze(c Context, tables ...string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.Analyze(c, tables...)
+}
+
+// TruncateOptions configures Truncate.
+type TruncateOptions struct {
+	// RestartIdentity resets the sequences of the serial and identity columns of the table on
+	// PostgreSQL. MySQL always resets the AUTO_INCREMENT counter.
+	RestartIdentity bool
+	// Cascade also truncates the tables with foreign keys referencing the table on PostgreSQL. On
+	// MySQL it returns an error wrapping ErrUnsupportedFeature.
+	Cascade bool
+}
+
+// Truncate removes every row of the given table with TRUNCATE TABLE, e.g. in data-reset
+// migrations and seeders. MySQL refuses to truncate a table referenced by foreign keys; wrap the
+// call in WithoutForeignKeyConstraints there.
+//
+// Example:
+//
+//	err := schema.Truncate(c, "sessions", schema.TruncateOptions{RestartIdentity: true})
+func Truncate(c Context, table string, options TruncateOptions) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.Truncate(c, table, options)
+}
+
+// ReorderOptions configures ReorderColumns.
+type ReorderOptions struct {
+	// LockTimeout limits how long the rebuild waits for the lock on the table before it fails,
+	// with lock_timeout on PostgreSQL and lock_wait_timeout on MySQL, which rounds it up to whole
+	// seconds. A timeout of 0 keeps the default of the session.
+	LockTimeout time.Duration
+}
+
+// ReorderColumns moves the columns of a table to a new physical order: the given columns first,
+// in the given order, followed by the remaining columns in their current order. Nothing happens
+// if the columns are in that order already.
+//
+// MySQL moves the columns with MODIFY COLUMN ... AFTER. PostgreSQL cannot move columns, so the
+// table is rebuilt: it is locked, its rows are copied into a new table with the columns in the
+// new order, and the new table replaces it along with the constraints, indexes, comments and
+// sequences of the table. Run it in a transaction on PostgreSQL, so a failed rebuild leaves the
+// table untouched; the table is locked against reads and writes until the transaction ends.
+// The rebuild fails for tables referenced by foreign keys of other tables or by views, and it
+// does not keep the grants, triggers and row security policies of the table.
+//
+// Example:
+//
+//	err := schema.ReorderColumns(c, "users", []string{"id", "email", "name"},
+//	    schema.ReorderOptions{LockTimeout: 5 * time.Second})
+func ReorderColumns(c Context, table string, columns []string, options ReorderOptions) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.ReorderColumns(c, table, columns, options)
+}
+
+// CloneOptions controls which rows CloneSchema copies into the clone.
+type CloneOptions struct {
+	WithData   bool     // WithData copies the rows of every table.
+	DataTables []string // DataTables copies the rows of the given tables only, e.g. the migration version table.
+}
+
+// CloneSchema creates the target schema with a copy of every table of the source schema,
+// including the columns, defaults, check constraints, indexes, comments and foreign keys, and
+// optionally the rows. Indexes and constraints keep their names, serial columns get their own
+// sequences, and foreign keys between tables of the source schema reference the cloned tables.
+// Together with SwapSchemas it supports blue-green deployments: migrate the clone, then swap it
+// with the live schema.
+//
+// Views, functions, triggers, types and partitioned tables are not cloned. It is only supported
+// by PostgreSQL; on MySQL it returns an error wrapping ErrUnsupportedFeature.
+//
+// Example:
+//
+//	err := schema.CloneSchema(c, "public", "green", schema.CloneOptions{WithData: true})
+func CloneSchema(c Context, source, target string, options CloneOptions) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CloneSchema(c, source, target, options)
+}
+
+// SwapSchemas exchanges the names of two schemas, e.g. to promote a migrated clone created with
+// CloneSchema to the live schema. Run it in a transaction to swap the names atomically; swapping
+// again restores the previous live schema. Connections that have already resolved a table, e.g.
+// in a prepared statement, keep using the table of the renamed schema.
+//
+// It is only supported by PostgreSQL; on MySQL it returns an error wrapping ErrUnsupportedFeature.
+//
+// Example:
+//
+//	err := schema.SwapSchemas(c, "public", "green")
+func SwapSchemas(c Context, schema, otherSchema string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.SwapSchemas(c, schema, otherSchema)
+}
+
+// CreateSchema creates a schema, e.g. to hold the tables of a tenant or a module. On MySQL a
+// schema is a database.
+//
+// Example:
+//
+//	err := schema.CreateSchema(c, "billing")
+func CreateSchema(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateSchema(c, name)
+}
+
+// DropSchema drops a schema. Without cascade, PostgreSQL refuses to drop a schema that still
+// contains objects; with it, the objects are dropped too. MySQL always drops the tables of a
+// database with it, so it requires cascade and otherwise returns an error wrapping
+// ErrUnsupportedFeature.
+//
+// Example:
+//
+//	err := schema.DropSchema(c, "billing", true)
+func DropSchema(c Context, name string, cascade bool) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropSchema(c, name, cascade)
+}
+
+// HasSchema checks if a schema exists.
+//
+// Example:
+//
+//	exists, err := schema.HasSchema(c, "billing")
+func HasSchema(c Context, name string) (bool, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return false, err
+	}
+
+	return builder.HasSchema(c, name)
+}
+
+// CreateExtension enables a PostgreSQL extension, e.g. pgcrypto, uuid-ossp or postgis. Enabling
+// an extension usually requires superuser rights or the CREATE privilege on the database. It is
+// only supported by PostgreSQL; on MySQL it returns an error wrapping ErrUnsupportedFeature.
+//
+// Example:
+//
+//	err := schema.CreateExtension(c, "uuid-ossp")
+func CreateExtension(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreateExtension(c, name)
+}
+
+// DropExtension removes a PostgreSQL extension. It fails while columns, indexes or functions
+// still depend on the extension.
+//
+// Example:
+//
+//	err := schema.DropExtension(c, "uuid-ossp")
+func DropExtension(c Context, name string) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.DropExtension(c, name)
+}
+
+// HasExtension checks if a PostgreSQL extension is enabled in the database.
+//
+// Example:
+//
+//	enabled, err := schema.HasExtension(c, "postgis")
+func HasExtension(c Context, name string) (bool, error) {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return false, err
+	}
+
+	return builder.HasExtension(c, name)
+}
+
+// CreatePartition creates a partition of a table created with Blueprint.PartitionBy, e.g. the
+// partition of the next month of an event table. On PostgreSQL the partition is a table of its
+// own; on MySQL it is added to the table with ALTER TABLE, which cannot add hash partitions by
+// name.
+//
+// Example:
+//
+//	err := schema.CreatePartition(c, "events", "events_2025_02", schema.RangeBounds("'2025-02-01'", "'2025-03-01'"))
+func CreatePartition(c Context, parent, name string, bounds PartitionBounds) error {
+	builder, err := newBuilder(c)
+	if err != nil {
+		return err
+	}
+
+	return builder.CreatePartition(c, parent, name, bounds)
+}
@@ -506,3 +506,277 @@ func (s *schemaTestSuite) TestTable() {
 		s.Require().Error(err)
 	})
 }
+
+func (s *schemaTestSuite) TestAssertRowCountStable() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	err = schema.Create(c, "orders", func(table *schema.Blueprint) {
+		table.ID()
+		table.Integer("total")
+	})
+	s.Require().NoError(err)
+	_, err = c.Exec("INSERT INTO orders (total) VALUES (10), (20)")
+	s.Require().NoError(err)
+
+	s.Run("when the rows are kept should succeed", func() {
+		err := schema.AssertRowCountStable(c, "orders", func() error {
+			return schema.Table(c, "orders", func(table *schema.Blueprint) {
+				table.BigInteger("total").Change()
+			})
+		})
+		s.Require().NoError(err)
+	})
+
+	s.Run("when rows are lost should return error", func() {
+		err := schema.AssertRowCountStable(c, "orders", func() error {
+			_, err := c.Exec("DELETE FROM orders WHERE total = 10")
+			return err
+		})
+		s.Require().ErrorIs(err, schema.ErrRowCountChanged)
+		s.Require().EqualError(err, "row count changed: table orders had 2 rows before and 1 after")
+	})
+}
+
+func (s *schemaTestSuite) TestCreateIfNotExists() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	sessions := func(table *schema.Blueprint) {
+		table.String("id").Primary()
+		table.Timestamp("expires_at").Index()
+	}
+	s.Require().NoError(schema.CreateIfNotExists(c, "sessions", sessions))
+	s.Require().NoError(schema.CreateIfNotExists(c, "sessions", sessions), "the existing table is kept")
+
+	exists, err := schema.HasTable(c, "sessions")
+	s.Require().NoError(err)
+	s.True(exists)
+}
+
+func (s *schemaTestSuite) TestConditionalTable() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	addPhone := func(table *schema.Blueprint) {
+		table.String("phone").Nullable()
+	}
+	s.Require().NoError(schema.WhenTableExists(c, "users", addPhone), "a missing table is skipped")
+	s.Require().NoError(schema.Create(c, "users", func(table *schema.Blueprint) {
+		table.ID()
+	}))
+	s.Require().NoError(schema.WhenColumnMissing(c, "users", "phone", addPhone))
+	s.Require().NoError(schema.WhenColumnMissing(c, "users", "phone", addPhone), "an existing column is skipped")
+
+	exists, err := schema.HasColumn(c, "users", "phone")
+	s.Require().NoError(err)
+	s.True(exists)
+}
+
+func (s *schemaTestSuite) TestViews() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	err = schema.Create(c, "users", func(table *schema.Blueprint) {
+		table.ID()
+		table.String("email")
+		table.Boolean("active")
+	})
+	s.Require().NoError(err)
+
+	err = schema.CreateView(c, "active_users", func(view *schema.ViewBlueprint) {
+		view.Columns("user_id")
+		view.As("SELECT id FROM users WHERE active")
+	})
+	s.Require().NoError(err)
+	err = schema.CreateOrReplaceView(c, "active_users", func(view *schema.ViewBlueprint) {
+		view.Columns("user_id", "email")
+		view.As("SELECT id, email FROM users WHERE active")
+	})
+	s.Require().NoError(err)
+
+	var count int
+	s.Require().NoError(c.QueryRow("SELECT COUNT(*) FROM active_users").Scan(&count))
+	s.Zero(count)
+
+	s.Require().NoError(schema.DropView(c, "active_users"))
+}
+
+func (s *schemaTestSuite) TestMaterializedViews() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	err = schema.Create(c, "orders", func(table *schema.Blueprint) {
+		table.ID()
+		table.Integer("total")
+	})
+	s.Require().NoError(err)
+	_, err = c.Exec("INSERT INTO orders (total) VALUES (10), (20)")
+	s.Require().NoError(err)
+
+	err = schema.CreateMaterializedView(c, "order_totals", func(view *schema.ViewBlueprint) {
+		view.Columns("total")
+		view.As("SELECT SUM(total) FROM orders")
+		view.WithNoData()
+	})
+	s.Require().NoError(err)
+	err = schema.Table(c, "order_totals", func(table *schema.Blueprint) {
+		table.Index("total")
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(schema.RefreshMaterializedView(c, "order_totals", schema.RefreshOptions{}))
+
+	var total int
+	s.Require().NoError(c.QueryRow("SELECT total FROM order_totals").Scan(&total))
+	s.Equal(30, total)
+
+	s.Require().NoError(schema.DropMaterializedView(c, "order_totals"))
+}
+
+func (s *schemaTestSuite) TestSequences() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	err = schema.CreateSequence(c, "invoice_number_seq", func(seq *schema.SequenceBlueprint) {
+		seq.Start(1000)
+		seq.IncrementBy(10)
+	})
+	s.Require().NoError(err)
+
+	var value int64
+	s.Require().NoError(c.QueryRow("SELECT nextval('invoice_number_seq')").Scan(&value))
+	s.Equal(int64(1000), value)
+	s.Require().NoError(c.QueryRow("SELECT nextval('invoice_number_seq')").Scan(&value))
+	s.Equal(int64(1010), value)
+
+	err = schema.AlterSequence(c, "invoice_number_seq", func(seq *schema.SequenceBlueprint) {
+		seq.Start(5000)
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(c.QueryRow("SELECT nextval('invoice_number_seq')").Scan(&value))
+	s.Equal(int64(5000), value)
+
+	s.Require().NoError(schema.DropSequence(c, "invoice_number_seq"))
+}
+
+func (s *schemaTestSuite) TestSchemas() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	s.Require().NoError(schema.CreateSchema(c, "billing"))
+	exists, err := schema.HasSchema(c, "billing")
+	s.Require().NoError(err)
+	s.True(exists)
+
+	err = schema.Create(c, "billing.invoices", func(table *schema.Blueprint) {
+		table.ID()
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(schema.DropSchema(c, "billing", true))
+	exists, err = schema.HasSchema(c, "billing")
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *schemaTestSuite) TestExtensions() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	enabled, err := schema.HasExtension(c, "pg_trgm")
+	s.Require().NoError(err)
+	s.False(enabled)
+
+	s.Require().NoError(schema.CreateExtension(c, "pg_trgm"))
+	enabled, err = schema.HasExtension(c, "pg_trgm")
+	s.Require().NoError(err)
+	s.True(enabled)
+
+	s.Require().NoError(schema.DropExtension(c, "pg_trgm"))
+}
+
+func (s *schemaTestSuite) TestPartitions() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	err = schema.Create(c, "events", func(table *schema.Blueprint) {
+		table.BigInteger("id")
+		table.Timestamp("created_at")
+		table.Primary("id", "created_at")
+		table.PartitionBy(schema.PartitionRange, "created_at")
+		table.Partition("events_2025_01", schema.RangeBounds("'2025-01-01'", "'2025-02-01'"))
+	})
+	s.Require().NoError(err)
+	err = schema.CreatePartition(c, "events", "events_2025_02", schema.RangeBounds("'2025-02-01'", "'2025-03-01'"))
+	s.Require().NoError(err)
+
+	_, err = c.Exec("INSERT INTO events (id, created_at) VALUES (1, '2025-01-15'), (2, '2025-02-15')")
+	s.Require().NoError(err)
+	var count int
+	s.Require().NoError(c.QueryRow("SELECT COUNT(*) FROM events_2025_02").Scan(&count))
+	s.Equal(1, count)
+
+	_, err = c.Exec("INSERT INTO events (id, created_at) VALUES (3, '2025-03-15')")
+	s.Require().Error(err, "expected no partition to hold the row")
+}
+
+func (s *schemaTestSuite) TestTriggers() {
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	c := schema.NewContext(s.ctx, tx)
+
+	err = schema.Create(c, "orders", func(table *schema.Blueprint) {
+		table.ID()
+		table.Integer("total")
+		table.Timestamp("updated_at").Nullable()
+	})
+	s.Require().NoError(err)
+	err = schema.CreateFunction(c, "set_updated_at", func(fn *schema.FunctionBlueprint) {
+		fn.Returns("trigger")
+		fn.Body("BEGIN NEW.updated_at = now(); RETURN NEW; END;")
+	})
+	s.Require().NoError(err)
+	err = schema.CreateTrigger(c, "orders_updated_at", "orders", func(trigger *schema.TriggerBlueprint) {
+		trigger.Before("INSERT", "UPDATE")
+		trigger.Execute("set_updated_at")
+	})
+	s.Require().NoError(err)
+
+	_, err = c.Exec("INSERT INTO orders (total) VALUES (10)")
+	s.Require().NoError(err)
+	var updated bool
+	s.Require().NoError(c.QueryRow("SELECT updated_at IS NOT NULL FROM orders").Scan(&updated))
+	s.True(updated)
+
+	s.Require().NoError(schema.DropTrigger(c, "orders_updated_at", "orders"))
+	s.Require().NoError(schema.DropFunction(c, "set_updated_at"))
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"errors"
+)
+
+// SequenceBlueprint defines a sequence created with CreateSequence or changed with AlterSequence.
+// Options that are not set keep their default, or their current value when altering.
+type SequenceBlueprint struct {
+	name      string
+	dataType  string
+	start     *int64
+	increment *int64
+	minValue  *int64
+	maxValue  *int64
+	cache     *int64
+	cycle     *bool
+	ownedBy   string
+}
+
+// As sets the data type of the sequence: smallint, integer or bigint (the default).
+func (s *SequenceBlueprint) As(dataType string) {
+	s.dataType = dataType
+}
+
+// Start sets the first value of the sequence. AlterSequence restarts the sequence at it.
+func (s *SequenceBlueprint) Start(value int64) {
+	s.start = &value
+}
+
+// IncrementBy sets the value added to the current value to get the next one. A negative increment
+// makes a descending sequence.
+func (s *SequenceBlueprint) IncrementBy(value int64) {
+	s.increment = &value
+}
+
+// MinValue sets the lowest value of the sequence.
+func (s *SequenceBlueprint) MinValue(value int64) {
+	s.minValue = &value
+}
+
+// MaxValue sets the highest value of the sequence.
+func (s *SequenceBlueprint) MaxValue(value int64) {
+	s.maxValue = &value
+}
+
+// Cache sets how many values are allocated in advance, for faster access. Cached values that are
+// not used are lost, leaving gaps.
+func (s *SequenceBlueprint) Cache(value int64) {
+	s.cache = &value
+}
+
+// Cycle sets whether the sequence wraps around when it reaches its maximum (or minimum) value,
+// instead of failing.
+func (s *SequenceBlueprint) Cycle(cycle bool) {
+	s.cycle = &cycle
+}
+
+// OwnedBy ties the sequence to a column, e.g. "invoices.number", so it is dropped with the column
+// or its table. "NONE" removes the association.
+func (s *SequenceBlueprint) OwnedBy(column string) {
+	s.ownedBy = column
+}
+
+// newSequenceBlueprint returns the sequence defined by blueprint. The blueprint is optional.
+func newSequenceBlueprint(c Context, name string, blueprint func(seq *SequenceBlueprint)) (*SequenceBlueprint, error) {
+	if c == nil || name == "" {
+		return nil, errors.New("invalid arguments: context is nil or sequence name is empty")
+	}
+
+	seq := &SequenceBlueprint{name: name}
+	if blueprint != nil {
+		blueprint(seq)
+	}
+	return seq, nil
+}
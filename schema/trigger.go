@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FunctionBlueprint defines a function created with CreateFunction.
+type FunctionBlueprint struct {
+	name          string
+	parameters    []string
+	returns       string
+	language      string
+	body          string
+	deterministic bool
+}
+
+// TriggerBlueprint defines a trigger created with CreateTrigger.
+type TriggerBlueprint struct {
+	name             string
+	table            string
+	timing           string
+	events           []string
+	forEachStatement bool
+	when             string
+	function         string
+	body             string
+}
+
+// triggerEventNames are the events a trigger can fire on.
+var triggerEventNames = []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE"}
+
+// Parameters declares the parameters of the function, each as its name and type, e.g. "amount
+// numeric".
+func (f *FunctionBlueprint) Parameters(parameters ...string) {
+	f.parameters = parameters
+}
+
+// Returns sets the return type of the function, e.g. "integer", or "trigger" for a PostgreSQL
+// function executed by a trigger.
+func (f *FunctionBlueprint) Returns(typ string) {
+	f.returns = typ
+}
+
+// Language sets the language of the body. PostgreSQL defaults to plpgsql; MySQL only supports SQL.
+func (f *FunctionBlueprint) Language(language string) {
+	f.language = language
+}
+
+// Body sets the body of the function, e.g. "BEGIN NEW.updated_at = now(); RETURN NEW; END;" in
+// PostgreSQL or "RETURN amount * 1.2" in MySQL.
+func (f *FunctionBlueprint) Body(body string) {
+	f.body = body
+}
+
+// Deterministic declares that the function always returns the same result for the same
+// arguments: IMMUTABLE in PostgreSQL, DETERMINISTIC in MySQL.
+func (f *FunctionBlueprint) Deterministic() {
+	f.deterministic = true
+}
+
+// Before fires the trigger before the given events: INSERT, UPDATE, DELETE or TRUNCATE.
+func (t *TriggerBlueprint) Before(events ...string) {
+	t.timing, t.events = "BEFORE", events
+}
+
+// After fires the trigger after the given events: INSERT, UPDATE, DELETE or TRUNCATE.
+func (t *TriggerBlueprint) After(events ...string) {
+	t.timing, t.events = "AFTER", events
+}
+
+// InsteadOf fires the trigger instead of the given events on a view. It is only supported by
+// PostgreSQL.
+func (t *TriggerBlueprint) InsteadOf(events ...string) {
+	t.timing, t.events = "INSTEAD OF", events
+}
+
+// ForEachStatement fires the trigger once per statement instead of once per row. It is only
+// supported by PostgreSQL.
+func (t *TriggerBlueprint) ForEachStatement() {
+	t.forEachStatement = true
+}
+
+// When fires the trigger only for the rows matching the condition, e.g. "OLD.* IS DISTINCT FROM
+// NEW.*". It is only supported by PostgreSQL.
+func (t *TriggerBlueprint) When(condition string) {
+	t.when = condition
+}
+
+// Execute sets the function the trigger executes, created with CreateFunction and returning
+// trigger. It is only supported by PostgreSQL; MySQL triggers run a Body.
+func (t *TriggerBlueprint) Execute(function string) {
+	t.function = function
+}
+
+// Body sets the statement the trigger runs, e.g. "SET NEW.updated_at = NOW()". It is only
+// supported by MySQL; PostgreSQL triggers Execute a function.
+func (t *TriggerBlueprint) Body(body string) {
+	t.body = body
+}
+
+func (g *baseGrammar) CompileDropFunction(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("function name cannot be empty")
+	}
+	return fmt.Sprintf("DROP FUNCTION %s", name), nil
+}
+
+// functionSignature returns the name, the parameters and the return type of the function.
+func (g *baseGrammar) functionSignature(fn *FunctionBlueprint) (string, error) {
+	if fn.returns == "" {
+		return "", fmt.Errorf("function %s has no return type, call Returns", fn.name)
+	}
+	if strings.TrimSpace(fn.body) == "" {
+		return "", fmt.Errorf("function %s has no body, call Body", fn.name)
+	}
+	return fmt.Sprintf("%s(%s) RETURNS %s", fn.name, strings.Join(fn.parameters, ", "), fn.returns), nil
+}
+
+// triggerEvents returns the events the trigger fires on, upper-cased.
+func (g *baseGrammar) triggerEvents(trigger *TriggerBlueprint) ([]string, error) {
+	if trigger.timing == "" || len(trigger.events) == 0 {
+		return nil, fmt.Errorf("trigger %s has no events, call Before, After or InsteadOf", trigger.name)
+	}
+	events := make([]string, len(trigger.events))
+	for i, event := range trigger.events {
+		events[i] = strings.ToUpper(strings.TrimSpace(event))
+		if !slices.Contains(triggerEventNames, events[i]) {
+			return nil, fmt.Errorf("trigger %s: unknown event %s, expected one of %s",
+				trigger.name, event, strings.Join(triggerEventNames, ", "))
+		}
+	}
+	return events, nil
+}
+
+// newFunctionBlueprint returns the function defined by blueprint.
+func newFunctionBlueprint(
+	c Context,
+	name string,
+	blueprint func(fn *FunctionBlueprint),
+) (*FunctionBlueprint, error) {
+	if c == nil || name == "" || blueprint == nil {
+		return nil, errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+
+	fn := &FunctionBlueprint{name: name}
+	blueprint(fn)
+	return fn, nil
+}
+
+// newTriggerBlueprint returns the trigger on table defined by blueprint.
+func newTriggerBlueprint(
+	c Context,
+	name, table string,
+	blueprint func(trigger *TriggerBlueprint),
+) (*TriggerBlueprint, error) {
+	if c == nil || name == "" || table == "" || blueprint == nil {
+		return nil, errors.New("invalid arguments: context, name, table, or blueprint is nil/empty")
+	}
+
+	trigger := &TriggerBlueprint{name: name, table: table}
+	blueprint(trigger)
+	return trigger, nil
+}
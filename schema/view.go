@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ViewBlueprint defines a view created with CreateView, CreateOrReplaceView or
+// CreateMaterializedView.
+type ViewBlueprint struct {
+	name       string
+	columns    []string
+	query      string
+	withNoData bool
+}
+
+// RefreshOptions configures RefreshMaterializedView.
+type RefreshOptions struct {
+	// Concurrently refreshes the materialized view without blocking reads of it. It requires a
+	// unique index on the view, and the view must have been populated before.
+	Concurrently bool
+}
+
+// Columns names the columns of the view. Without it the columns are named after the select list
+// of the query.
+func (v *ViewBlueprint) Columns(columns ...string) {
+	v.columns = columns
+}
+
+// As sets the SELECT statement of the view.
+func (v *ViewBlueprint) As(query string) {
+	v.query = query
+}
+
+// WithNoData creates a materialized view without running its query, e.g. to create its indexes
+// first. The view cannot be read before it is populated with RefreshMaterializedView.
+func (v *ViewBlueprint) WithNoData() {
+	v.withNoData = true
+}
+
+// CompileCreateView compiles the statement creating the view, or replacing it if orReplace is set.
+func (g *baseGrammar) CompileCreateView(view *ViewBlueprint, orReplace bool) (string, error) {
+	if view.withNoData {
+		return "", fmt.Errorf("view %s: WithNoData is only supported by materialized views", view.name)
+	}
+	definition, err := g.viewDefinition(view)
+	if err != nil {
+		return "", err
+	}
+	keyword := "CREATE VIEW"
+	if orReplace {
+		keyword = "CREATE OR REPLACE VIEW"
+	}
+	return fmt.Sprintf("%s %s", keyword, definition), nil
+}
+
+func (g *baseGrammar) CompileDropView(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("view name cannot be empty")
+	}
+	return fmt.Sprintf("DROP VIEW %s", name), nil
+}
+
+// viewDefinition returns the name, the columns and the query of the view.
+func (g *baseGrammar) viewDefinition(view *ViewBlueprint) (string, error) {
+	query := strings.TrimSuffix(strings.TrimSpace(view.query), ";")
+	if query == "" {
+		return "", fmt.Errorf("view %s has no query, call As", view.name)
+	}
+	columns := ""
+	if len(view.columns) > 0 {
+		columns = fmt.Sprintf(" (%s)", g.Columnize(view.columns))
+	}
+	return fmt.Sprintf("%s%s AS %s", view.name, columns, query), nil
+}
+
+// newViewBlueprint returns the view defined by blueprint.
+func newViewBlueprint(c Context, name string, blueprint func(view *ViewBlueprint)) (*ViewBlueprint, error) {
+	if c == nil || name == "" || blueprint == nil {
+		return nil, errors.New("invalid arguments: context, name, or blueprint is nil/empty")
+	}
+
+	view := &ViewBlueprint{name: name}
+	blueprint(view)
+	return view, nil
+}
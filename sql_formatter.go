@@ -0,0 +1,306 @@
+package migris
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SQLFormatter formats the generated statements the migrator shows to people: the statements
+// printed in dry-run mode and those written by UpToSQL and DownToSQL. The statements run against
+// the database are never formatted.
+type SQLFormatter interface {
+	Format(statement string) string
+}
+
+// SQLFormatterFunc is an adapter to allow the use of ordinary functions as a SQLFormatter.
+type SQLFormatterFunc func(statement string) string
+
+// Format returns f(statement).
+func (f SQLFormatterFunc) Format(statement string) string {
+	return f(statement)
+}
+
+// WithSQLFormatter sets the formatter of the statements printed in dry-run mode and written by
+// UpToSQL and DownToSQL, e.g. PrettySQL.
+func WithSQLFormatter(formatter SQLFormatter) Option {
+	return func(m *Migrate) {
+		m.sqlFormatter = formatter
+	}
+}
+
+// formatSQL formats a statement shown to people with the SQL formatter, if any.
+func (m *Migrate) formatSQL(statement string) string {
+	if m.sqlFormatter == nil {
+		return statement
+	}
+	return m.sqlFormatter.Format(statement)
+}
+
+// KeywordCase is the case PrettySQL writes SQL keywords in.
+type KeywordCase string
+
+const (
+	// KeywordCaseUnchanged keeps the keywords as they were generated, which is upper case.
+	KeywordCaseUnchanged KeywordCase = ""
+	// KeywordCaseUpper writes the keywords in upper case.
+	KeywordCaseUpper KeywordCase = "upper"
+	// KeywordCaseLower writes the keywords in lower case.
+	KeywordCaseLower KeywordCase = "lower"
+)
+
+// PrettySQL is a SQLFormatter that puts every column and constraint of a long CREATE TABLE
+// statement, and every change of a long ALTER TABLE statement, on its own indented line, so
+// reviewers can read the statements of wide tables. Other statements are kept on one line.
+type PrettySQL struct {
+	// Width is the length from which statements are broken over lines. Defaults to 80.
+	Width int
+	// Indent is the indentation of the broken lines. Defaults to four spaces.
+	Indent string
+	// KeywordCase is the case of the SQL keywords.
+	KeywordCase KeywordCase
+}
+
+var _ SQLFormatter = PrettySQL{}
+
+// sqlKeywords are the keywords whose case PrettySQL changes.
+var sqlKeywords = map[string]bool{
+	"ADD": true, "AFTER": true, "ALTER": true, "ALWAYS": true, "AND": true, "AS": true, "ASC": true,
+	"AUTO_INCREMENT": true, "BEFORE": true, "BEGIN": true, "BY": true, "CASCADE": true, "CASE": true,
+	"CHECK": true, "COLLATE": true, "COLUMN": true, "COMMENT": true, "COMMIT": true,
+	"CONCURRENTLY": true, "CONSTRAINT": true, "CREATE": true, "CYCLE": true, "DEFAULT": true,
+	"DEFERRABLE": true, "DELETE": true, "DESC": true, "DROP": true, "EACH": true, "ELSE": true,
+	"END": true, "EXECUTE": true, "EXISTS": true, "FOR": true, "FOREIGN": true, "FROM": true,
+	"FUNCTION": true, "GENERATED": true, "GROUP": true, "IDENTITY": true, "IF": true, "IN": true,
+	"INCREMENT": true, "INDEX": true, "INITIALLY": true, "INSERT": true, "INTO": true, "IS": true,
+	"JOIN": true, "KEY": true, "LANGUAGE": true, "LEFT": true, "LIKE": true, "MATERIALIZED": true,
+	"MODIFY": true, "NOT": true, "NULL": true, "ON": true, "ONLY": true, "OR": true, "ORDER": true,
+	"OWNED": true, "PRIMARY": true, "REFERENCES": true, "REFRESH": true, "RENAME": true,
+	"REPLACE": true, "RESTART": true, "RETURNS": true, "ROW": true, "SELECT": true, "SEQUENCE": true,
+	"SET": true, "START": true, "STATEMENT": true, "TABLE": true, "TEMPORARY": true, "THEN": true,
+	"TO": true, "TRIGGER": true, "TYPE": true, "UNIQUE": true, "UPDATE": true, "USING": true,
+	"VALUES": true, "VIEW": true, "WHEN": true, "WHERE": true, "WITH": true,
+}
+
+// Format implements SQLFormatter.
+func (p PrettySQL) Format(statement string) string {
+	tokens := tokenizeSQL(strings.TrimSpace(statement))
+	for i, token := range tokens {
+		if token.kind == sqlWord && sqlKeywords[strings.ToUpper(token.text)] {
+			switch p.KeywordCase {
+			case KeywordCaseUpper:
+				tokens[i].text = strings.ToUpper(token.text)
+			case KeywordCaseLower:
+				tokens[i].text = strings.ToLower(token.text)
+			}
+		}
+	}
+
+	width, indent := p.Width, p.Indent
+	if width <= 0 {
+		width = 80
+	}
+	if indent == "" {
+		indent = "    "
+	}
+	if len(statement) < width {
+		return joinTokens(tokens)
+	}
+	switch leadingWords(tokens, 2) {
+	case "CREATE TABLE", "CREATE TEMPORARY":
+		return breakColumnList(tokens, indent)
+	case "ALTER TABLE":
+		return breakAlterClauses(tokens, indent)
+	}
+	return joinTokens(tokens)
+}
+
+// breakColumnList puts the items of the first parenthesized list on their own lines.
+func breakColumnList(tokens []sqlToken, indent string) string {
+	var b strings.Builder
+	depth, done := 0, false
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case done:
+			b.WriteString(token.text)
+		case token.text == "(":
+			depth++
+			if depth > 1 {
+				b.WriteString(token.text)
+				continue
+			}
+			b.WriteString("(\n" + indent)
+			i = skipSpace(tokens, i)
+		case token.text == ")":
+			depth--
+			if depth > 0 {
+				b.WriteString(token.text)
+				continue
+			}
+			b.WriteString("\n)")
+			done = true
+		case token.text == "," && depth == 1:
+			b.WriteString(",\n" + indent)
+			i = skipSpace(tokens, i)
+		default:
+			b.WriteString(token.text)
+		}
+	}
+	return b.String()
+}
+
+// breakAlterClauses puts every change of an ALTER TABLE statement on its own line.
+func breakAlterClauses(tokens []sqlToken, indent string) string {
+	var b strings.Builder
+	words, depth := 0, 0
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case token.kind == sqlSpace && words == 3:
+			// The space after ALTER TABLE and the table name starts the first change.
+			words++
+			b.WriteString("\n" + indent)
+		case token.text == "(":
+			depth++
+			b.WriteString(token.text)
+		case token.text == ")":
+			depth--
+			b.WriteString(token.text)
+		case token.text == "," && depth == 0:
+			b.WriteString(",\n" + indent)
+			i = skipSpace(tokens, i)
+		default:
+			if token.kind != sqlSpace && words < 3 && !isTableModifier(token, words) {
+				words++
+			}
+			b.WriteString(token.text)
+		}
+	}
+	return b.String()
+}
+
+// isTableModifier reports whether the token is IF EXISTS or ONLY between ALTER TABLE and the
+// table name.
+func isTableModifier(token sqlToken, words int) bool {
+	if words != 2 || token.kind != sqlWord {
+		return false
+	}
+	switch strings.ToUpper(token.text) {
+	case "IF", "EXISTS", "ONLY":
+		return true
+	}
+	return false
+}
+
+// skipSpace returns the index of the last space token following i, or i.
+func skipSpace(tokens []sqlToken, i int) int {
+	for i+1 < len(tokens) && tokens[i+1].kind == sqlSpace {
+		i++
+	}
+	return i
+}
+
+// leadingWords returns the first n words of the statement in upper case, separated by a space.
+func leadingWords(tokens []sqlToken, n int) string {
+	var words []string
+	for _, token := range tokens {
+		if len(words) == n {
+			break
+		}
+		if token.kind == sqlWord {
+			words = append(words, strings.ToUpper(token.text))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func joinTokens(tokens []sqlToken) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString(token.text)
+	}
+	return b.String()
+}
+
+type sqlTokenKind int
+
+const (
+	sqlWord sqlTokenKind = iota
+	sqlSpace
+	sqlQuoted // a string, a quoted identifier or a dollar-quoted body
+	sqlSymbol
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+// tokenizeSQL splits a statement into words, runs of whitespace, quoted text and symbols, so
+// keywords and separators inside strings, quoted identifiers and function bodies are left alone.
+func tokenizeSQL(statement string) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		end := i + 1
+		kind := sqlSymbol
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			kind, end = sqlQuoted, quotedEnd(statement, i, string(c))
+		case c == '$':
+			if tag := dollarTag(statement[i:]); tag != "" {
+				kind = sqlQuoted
+				end = quotedEnd(statement, i+len(tag)-1, tag)
+			}
+		case unicode.IsSpace(rune(c)):
+			kind = sqlSpace
+			for end < len(statement) && unicode.IsSpace(rune(statement[end])) {
+				end++
+			}
+		case isWordByte(c):
+			kind = sqlWord
+			for end < len(statement) && isWordByte(statement[end]) {
+				end++
+			}
+		}
+		tokens = append(tokens, sqlToken{kind: kind, text: statement[i:end]})
+		i = end
+	}
+	return tokens
+}
+
+// quotedEnd returns the index after the quote closing the one at start. A doubled quote is an
+// escaped quote.
+func quotedEnd(statement string, start int, quote string) int {
+	i := start + 1
+	for {
+		j := strings.Index(statement[i:], quote)
+		if j < 0 {
+			return len(statement)
+		}
+		i += j + len(quote)
+		if len(quote) > 1 || !strings.HasPrefix(statement[i:], quote) {
+			return i
+		}
+		i += len(quote)
+	}
+}
+
+// dollarTag returns the $tag$ that opens a dollar-quoted string at the start of s, or "" if
+// there is none.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '$' {
+			return s[:i+1]
+		}
+		if !isWordByte(s[i]) || (i == 1 && s[i] >= '0' && s[i] <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c >= 0x80
+}
@@ -0,0 +1,68 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettySQL_Format(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter PrettySQL
+		statement string
+		want      string
+	}{
+		{
+			name:      "short statement",
+			statement: "CREATE TABLE tags (id BIGINT NOT NULL)",
+			want:      "CREATE TABLE tags (id BIGINT NOT NULL)",
+		},
+		{
+			name:      "create table",
+			formatter: PrettySQL{Width: 40},
+			statement: "CREATE TABLE users (id BIGSERIAL NOT NULL, price DECIMAL(10, 2) NOT NULL, " +
+				"CONSTRAINT pk_users PRIMARY KEY (id)) PARTITION BY RANGE (id)",
+			want: "CREATE TABLE users (\n" +
+				"    id BIGSERIAL NOT NULL,\n" +
+				"    price DECIMAL(10, 2) NOT NULL,\n" +
+				"    CONSTRAINT pk_users PRIMARY KEY (id)\n" +
+				") PARTITION BY RANGE (id)",
+		},
+		{
+			name:      "alter table",
+			formatter: PrettySQL{Width: 40, Indent: "\t"},
+			statement: "ALTER TABLE IF EXISTS ONLY users ADD COLUMN note TEXT DEFAULT 'a, b', DROP COLUMN age",
+			want:      "ALTER TABLE IF EXISTS ONLY users\n\tADD COLUMN note TEXT DEFAULT 'a, b',\n\tDROP COLUMN age",
+		},
+		{
+			name:      "lower keywords",
+			formatter: PrettySQL{KeywordCase: KeywordCaseLower},
+			statement: `CREATE INDEX idx_users_email ON users (email) WHERE "NOT" IS NOT NULL AND note <> 'NULL'`,
+			want:      `create index idx_users_email on users (email) where "NOT" is not null and note <> 'NULL'`,
+		},
+		{
+			name:      "dollar-quoted body",
+			formatter: PrettySQL{KeywordCase: KeywordCaseLower},
+			statement: "CREATE FUNCTION f() RETURNS trigger AS $function$BEGIN RETURN NULL; END;$function$",
+			want:      "create function f() returns trigger as $function$BEGIN RETURN NULL; END;$function$",
+		},
+		{
+			name:      "anonymous dollar-quoted body",
+			formatter: PrettySQL{KeywordCase: KeywordCaseLower},
+			statement: "DO $$BEGIN PERFORM 1; END$$",
+			want:      "DO $$BEGIN PERFORM 1; END$$",
+		},
+		{
+			name:      "escaped quote",
+			formatter: PrettySQL{KeywordCase: KeywordCaseLower},
+			statement: "COMMENT ON TABLE orders IS 'Customer''s orders, NOT NULL'",
+			want:      "comment on table orders is 'Customer''s orders, NOT NULL'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.formatter.Format(tt.statement))
+		})
+	}
+}
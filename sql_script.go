@@ -0,0 +1,162 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/akfaiz/migris/internal/sqlfile"
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+)
+
+// UpToSQL renders all pending migrations into a single SQL script written to w, without
+// applying them. The script includes the statements that record each migration in the
+// version table, so it can be applied manually (e.g. by a DBA) and leave the database in
+// the same state as Up.
+//
+// The database connection is only used to read the applied migrations. Go migrations that run
+// parameterized statements cannot be rendered and cause an error. On PostgreSQL every migration
+// is wrapped in a transaction, except SQL migrations annotated with -- +goose NO TRANSACTION, Go
+// migrations added with NoTransaction and the Go migrations TransactionPolicyAuto routes outside
+// of a transaction.
+func (m *Migrate) UpToSQL(ctx context.Context, w io.Writer) error {
+	provider, err := m.newProvider()
+	if err != nil {
+		return err
+	}
+	pending, err := m.pendingSources(ctx, provider, goose.MaxVersion)
+	if err != nil {
+		return fmt.Errorf("cannot get pending migrations: %w", err)
+	}
+	return m.writeSQLScript(ctx, w, pending, true)
+}
+
+// DownToSQL renders the rollback of the applied migrations down to the specified version
+// into a single SQL script written to w, without applying it. Like UpToSQL, the script
+// includes the statements that remove each migration from the version table.
+func (m *Migrate) DownToSQL(ctx context.Context, w io.Writer, version int64) error {
+	provider, err := m.newProvider()
+	if err != nil {
+		return err
+	}
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get applied migrations: %w", err)
+	}
+
+	var applied []*goose.Source
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if status := statuses[i]; status.State == goose.StateApplied && status.Source.Version > version {
+			applied = append(applied, status.Source)
+		}
+	}
+	return m.writeSQLScript(ctx, w, applied, false)
+}
+
+// writeSQLScript renders the statements of the migrations of the given sources and writes them
+// to w, followed by the version table bookkeeping of each migration.
+func (m *Migrate) writeSQLScript(ctx context.Context, w io.Writer, sources []*goose.Source, isUp bool) error {
+	registered := make(map[int64]*Migration, len(registeredMigrations))
+	for _, migration := range registeredMigrations {
+		registered[migration.version] = migration
+	}
+
+	for _, source := range sources {
+		var statements []string
+		var noTransaction bool
+		var err error
+		path := source.Path
+		switch source.Type {
+		case goose.TypeSQL:
+			statements, noTransaction, err = m.renderSQLMigration(source, isUp)
+		default:
+			migration, ok := registered[source.Version]
+			if !ok {
+				return fmt.Errorf("failed to render migration %d: it is not registered", source.Version)
+			}
+			path = migration.source
+			statements, noTransaction, err = m.renderGoMigration(ctx, migration, isUp)
+		}
+		if err != nil {
+			return err
+		}
+
+		if isUp {
+			statements = append(statements, fmt.Sprintf(
+				"INSERT INTO %s (version_id, is_applied) VALUES (%d, true)", m.tableName, source.Version))
+		} else {
+			statements = append(statements, fmt.Sprintf(
+				"DELETE FROM %s WHERE version_id=%d", m.tableName, source.Version))
+		}
+		if m.dialect == dialect.Postgres && !noTransaction {
+			statements = append([]string{"BEGIN"}, append(statements, "COMMIT")...)
+		}
+
+		if _, err = fmt.Fprintf(w, "-- %s (version %d)\n", pathutil.Base(path), source.Version); err != nil {
+			return err
+		}
+		for _, statement := range statements {
+			if _, err = fmt.Fprintf(w, "%s;\n", m.formatSQL(statement)); err != nil {
+				return err
+			}
+		}
+		if _, err = fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderGoMigration captures the statements of a Go migration. It also reports whether the
+// migration runs outside of a transaction, because it was added with NoTransaction or the
+// transaction policy routes it.
+func (m *Migrate) renderGoMigration(ctx context.Context, migration *Migration, isUp bool) ([]string, bool, error) {
+	migrationFunc := migration.downFunc(m.dependencies)
+	if isUp {
+		migrationFunc = migration.upFunc(m.dependencies)
+	}
+	dryRunCtx := schema.NewDryRunContext(ctx)
+	if migrationFunc != nil {
+		if err := migrationFunc(dryRunCtx); err != nil {
+			return nil, false, fmt.Errorf("failed to render migration %s: %w", migration.source, err)
+		}
+	}
+
+	noTransaction := migration.noTransaction || m.noTransaction[migration.version]
+	queries := dryRunCtx.GetPendingQueries()
+	statements := make([]string, 0, len(queries))
+	for _, q := range queries {
+		if len(q.Args) > 0 {
+			return nil, false, fmt.Errorf("failed to render migration %s: %w", migration.source,
+				errors.New("parameterized statements cannot be written to a SQL script"))
+		}
+		if m.transactionPolicy == TransactionPolicyAuto && isNonTransactional(q.Query) {
+			noTransaction = true
+		}
+		statements = append(statements, q.Query)
+	}
+	return statements, noTransaction, nil
+}
+
+// renderSQLMigration parses the statements of the up or down section of a SQL migration. It
+// also reports whether the migration is annotated with -- +goose NO TRANSACTION.
+func (m *Migrate) renderSQLMigration(source *goose.Source, isUp bool) ([]string, bool, error) {
+	content, err := fs.ReadFile(m.migrationsFS(), source.Path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read migration %s: %w", source.Path, err)
+	}
+	migration, err := sqlfile.Parse(content)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to render migration %s: %w", source.Path, err)
+	}
+	if isUp {
+		return migration.Up, migration.NoTransaction, nil
+	}
+	return migration.Down, migration.NoTransaction, nil
+}
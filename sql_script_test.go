@@ -0,0 +1,208 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_WriteSQLScript(t *testing.T) {
+	createUsers := &Migration{
+		version: 20250904164848,
+		source:  "migrations/20250904164848_create_users_table.go",
+		upFnContext: func(c schema.Context) error {
+			_, err := c.Exec("CREATE TABLE users (id bigint)")
+			return err
+		},
+		downFnContext: func(c schema.Context) error {
+			_, err := c.Exec("DROP TABLE users")
+			return err
+		},
+	}
+
+	withRegisteredMigrations(t, createUsers)
+	createUsersSource := &goose.Source{Type: goose.TypeGo, Version: createUsers.version}
+
+	t.Run("up on postgres", func(t *testing.T) {
+		m, err := New("postgres")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, m.writeSQLScript(context.Background(), &buf, []*goose.Source{createUsersSource}, true))
+		assert.Equal(t, "-- 20250904164848_create_users_table.go (version 20250904164848)\n"+
+			"BEGIN;\n"+
+			"CREATE TABLE users (id bigint);\n"+
+			"INSERT INTO schema_migrations (version_id, is_applied) VALUES (20250904164848, true);\n"+
+			"COMMIT;\n\n", buf.String())
+	})
+
+	t.Run("down on mysql", func(t *testing.T) {
+		m, err := New("mysql", WithTableName("migrations"))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, m.writeSQLScript(context.Background(), &buf, []*goose.Source{createUsersSource}, false))
+		assert.Equal(t, "-- 20250904164848_create_users_table.go (version 20250904164848)\n"+
+			"DROP TABLE users;\n"+
+			"DELETE FROM migrations WHERE version_id=20250904164848;\n\n", buf.String())
+	})
+
+	t.Run("formatted", func(t *testing.T) {
+		m, err := New("mysql", WithSQLFormatter(SQLFormatterFunc(func(statement string) string {
+			return "/* reviewed */ " + statement
+		})))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, m.writeSQLScript(context.Background(), &buf, []*goose.Source{createUsersSource}, true))
+		assert.Equal(t, "-- 20250904164848_create_users_table.go (version 20250904164848)\n"+
+			"/* reviewed */ CREATE TABLE users (id bigint);\n"+
+			"/* reviewed */ INSERT INTO schema_migrations (version_id, is_applied) VALUES (20250904164848, true);\n\n",
+			buf.String())
+	})
+
+	t.Run("parameterized statements are rejected", func(t *testing.T) {
+		m, err := New("postgres")
+		require.NoError(t, err)
+
+		seed := &Migration{
+			version: 20250904164849,
+			source:  "20250904164849_seed_users.go",
+			upFnContext: func(c schema.Context) error {
+				_, err := c.Exec("INSERT INTO users (id) VALUES ($1)", 1)
+				return err
+			},
+		}
+		withRegisteredMigrations(t, seed)
+		var buf bytes.Buffer
+		sources := []*goose.Source{{Type: goose.TypeGo, Version: seed.version}}
+		err = m.writeSQLScript(context.Background(), &buf, sources, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parameterized statements")
+	})
+}
+
+func TestMigrate_WriteSQLScriptMixed(t *testing.T) {
+	createUsers := &Migration{
+		version: 20250904164848,
+		source:  "migrations/20250904164848_create_users_table.go",
+		upFnContext: func(c schema.Context) error {
+			_, err := c.Exec("CREATE TABLE users (id bigint)")
+			return err
+		},
+		downFnContext: func(c schema.Context) error {
+			_, err := c.Exec("DROP TABLE users")
+			return err
+		},
+	}
+	withRegisteredMigrations(t, createUsers)
+	fsys := fstest.MapFS{
+		"20250904164849_add_touch_function.up.sql": {Data: []byte("-- +goose StatementBegin\r\n" +
+			"CREATE FUNCTION touch() RETURNS trigger AS $$\r\nBEGIN\r\n  RETURN NEW;\r\nEND;\r\n" +
+			"$$ LANGUAGE plpgsql;\r\n-- +goose StatementEnd\r\n")},
+		"20250904164849_add_touch_function.down.sql": {Data: []byte("DROP FUNCTION touch();\n")},
+	}
+	m, err := New("postgres", WithFS(fsys))
+	require.NoError(t, err)
+
+	sources := []*goose.Source{
+		{Type: goose.TypeGo, Version: 20250904164848},
+		{Type: goose.TypeSQL, Path: "20250904164849_add_touch_function.sql", Version: 20250904164849},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, m.writeSQLScript(t.Context(), &buf, sources, true))
+	assert.Equal(t, "-- 20250904164848_create_users_table.go (version 20250904164848)\n"+
+		"BEGIN;\n"+
+		"CREATE TABLE users (id bigint);\n"+
+		"INSERT INTO schema_migrations (version_id, is_applied) VALUES (20250904164848, true);\n"+
+		"COMMIT;\n\n"+
+		"-- 20250904164849_add_touch_function.sql (version 20250904164849)\n"+
+		"BEGIN;\n"+
+		"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;\n"+
+		"INSERT INTO schema_migrations (version_id, is_applied) VALUES (20250904164849, true);\n"+
+		"COMMIT;\n\n", buf.String())
+
+	buf.Reset()
+	slices.Reverse(sources)
+	require.NoError(t, m.writeSQLScript(t.Context(), &buf, sources, false))
+	assert.Equal(t, "-- 20250904164849_add_touch_function.sql (version 20250904164849)\n"+
+		"BEGIN;\n"+
+		"DROP FUNCTION touch();\n"+
+		"DELETE FROM schema_migrations WHERE version_id=20250904164849;\n"+
+		"COMMIT;\n\n"+
+		"-- 20250904164848_create_users_table.go (version 20250904164848)\n"+
+		"BEGIN;\n"+
+		"DROP TABLE users;\n"+
+		"DELETE FROM schema_migrations WHERE version_id=20250904164848;\n"+
+		"COMMIT;\n\n", buf.String())
+}
+
+func TestMigrate_WriteSQLScriptNoTransaction(t *testing.T) {
+	createIndex := func(c schema.Context) error {
+		_, err := c.Exec("CREATE INDEX CONCURRENTLY idx_users_email ON users (email)")
+		return err
+	}
+	script := func(version int64, file string) string {
+		return "-- " + file + " (version " + strconv.FormatInt(version, 10) + ")\n" +
+			"CREATE INDEX CONCURRENTLY idx_users_email ON users (email);\n" +
+			"INSERT INTO schema_migrations (version_id, is_applied) VALUES (" +
+			strconv.FormatInt(version, 10) + ", true);\n\n"
+	}
+
+	t.Run("added with NoTransaction", func(t *testing.T) {
+		migration := &Migration{
+			version:       20250904164848,
+			source:        "20250904164848_index_users_email.go",
+			upFnContext:   createIndex,
+			noTransaction: true,
+		}
+		withRegisteredMigrations(t, migration)
+		m, err := New("postgres")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		sources := []*goose.Source{{Type: goose.TypeGo, Version: migration.version}}
+		require.NoError(t, m.writeSQLScript(t.Context(), &buf, sources, true))
+		assert.Equal(t, script(migration.version, "20250904164848_index_users_email.go"), buf.String())
+	})
+
+	t.Run("routed by the transaction policy", func(t *testing.T) {
+		migration := &Migration{
+			version:     20250904164849,
+			source:      "20250904164849_index_users_email.go",
+			upFnContext: createIndex,
+		}
+		withRegisteredMigrations(t, migration)
+		m, err := New("postgres", WithTransactionPolicy(TransactionPolicyAuto))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		sources := []*goose.Source{{Type: goose.TypeGo, Version: migration.version}}
+		require.NoError(t, m.writeSQLScript(t.Context(), &buf, sources, true))
+		assert.Equal(t, script(migration.version, "20250904164849_index_users_email.go"), buf.String())
+	})
+
+	t.Run("annotated SQL migration", func(t *testing.T) {
+		withRegisteredMigrations(t)
+		m, err := New("postgres", WithFS(fstest.MapFS{
+			"20250904164850_index_users_email.up.sql": {Data: []byte("-- +goose NO TRANSACTION\n" +
+				"CREATE INDEX CONCURRENTLY idx_users_email ON users (email);\n")},
+		}))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		sources := []*goose.Source{
+			{Type: goose.TypeSQL, Path: "20250904164850_index_users_email.sql", Version: 20250904164850},
+		}
+		require.NoError(t, m.writeSQLScript(t.Context(), &buf, sources, true))
+		assert.Equal(t, script(20250904164850, "20250904164850_index_users_email.sql"), buf.String())
+	})
+}
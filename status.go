@@ -2,10 +2,35 @@ package migris
 
 import (
 	"context"
+	"time"
 
 	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/pressly/goose/v3"
 )
 
+// MigrationState is the state of a migration reported by StatusInfo.
+type MigrationState string
+
+const (
+	// StatePending is a migration that has not been applied yet.
+	StatePending MigrationState = "pending"
+	// StateApplied is a migration that has been applied.
+	StateApplied MigrationState = "applied"
+	// StateDirty is an applied migration whose file has changed since it ran; see Validate.
+	StateDirty MigrationState = "dirty"
+)
+
+// MigrationStatus is the status of a single migration.
+type MigrationStatus struct {
+	Version   int64          `json:"version"`
+	Name      string         `json:"name"`
+	State     MigrationState `json:"state"`
+	AppliedAt *time.Time     `json:"applied_at"` // nil when the migration is pending
+	Author    string         `json:"author,omitempty"`
+	Ticket    string         `json:"ticket,omitempty"`
+}
+
 // Status returns the status of the migrations.
 func (m *Migrate) Status() error {
 	ctx := context.Background()
@@ -25,3 +50,91 @@ func (m *Migrate) StatusContext(ctx context.Context) error {
 	logger.PrintStatuses(migrations)
 	return nil
 }
+
+// PendingStatusContext prints the status of the migrations that have not been applied yet.
+func (m *Migrate) PendingStatusContext(ctx context.Context) error {
+	provider, err := m.newProvider()
+	if err != nil {
+		return err
+	}
+	migrations, err := provider.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, migration := range migrations {
+		if migration.State == goose.StatePending {
+			logger.PrintStatus(migration)
+		}
+	}
+	return nil
+}
+
+// StatusInfo returns the status of every migration ordered by version, for tooling and
+// dashboards that consume the status instead of printing it.
+func (m *Migrate) StatusInfo(ctx context.Context) ([]MigrationStatus, error) {
+	provider, err := m.newProvider()
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := m.recordedChecksums(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sources, fsys := provider.ListSources(), m.migrationsFS()
+	checksums, metadata := migrationChecksums(sources, fsys), migrationsMetadata(sources, fsys)
+	return migrationStatuses(statuses, checksums, recorded, metadata), nil
+}
+
+// PendingStatuses returns the statuses of the migrations that have not been applied yet.
+func PendingStatuses(statuses []MigrationStatus) []MigrationStatus {
+	pending := make([]MigrationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.State == StatePending {
+			pending = append(pending, status)
+		}
+	}
+	return pending
+}
+
+// migrationStatuses converts the goose statuses, marking applied migrations whose current
+// checksum differs from the recorded one as dirty.
+func migrationStatuses(
+	statuses []*goose.MigrationStatus,
+	current, recorded map[int64]string,
+	metadata map[int64]migrationMetadata,
+) []MigrationStatus {
+	result := make([]MigrationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		version := status.Source.Version
+		info := MigrationStatus{
+			Version: version,
+			Name:    migrationName(status.Source),
+			State:   StatePending,
+			Author:  metadata[version].author,
+			Ticket:  metadata[version].ticket,
+		}
+		if status.State == goose.StateApplied {
+			appliedAt := status.AppliedAt
+			info.AppliedAt = &appliedAt
+			info.State = StateApplied
+			if checksum, ok := recorded[version]; ok && current[version] != "" && current[version] != checksum {
+				info.State = StateDirty
+			}
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// migrationName returns the file name of the migration.
+func migrationName(source *goose.Source) string {
+	path := source.Path
+	if path == "" {
+		path = registeredVersions[source.Version]
+	}
+	return pathutil.Base(path)
+}
@@ -0,0 +1,66 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationStatuses(t *testing.T) {
+	appliedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	statuses := []*goose.MigrationStatus{
+		{
+			Source:    &goose.Source{Type: goose.TypeSQL, Path: "20250101000000_create_users.sql", Version: 20250101000000},
+			State:     goose.StateApplied,
+			AppliedAt: appliedAt,
+		},
+		{
+			Source:    &goose.Source{Type: goose.TypeSQL, Path: "20250102000000_create_posts.sql", Version: 20250102000000},
+			State:     goose.StateApplied,
+			AppliedAt: appliedAt,
+		},
+		{
+			Source: &goose.Source{Type: goose.TypeSQL, Path: "20250103000000_add_tags.sql", Version: 20250103000000},
+			State:  goose.StatePending,
+		},
+	}
+	current := map[int64]string{20250101000000: "aaa", 20250102000000: "bbb", 20250103000000: "ccc"}
+	recorded := map[int64]string{20250101000000: "aaa", 20250102000000: "old"}
+
+	metadata := map[int64]migrationMetadata{20250101000000: {author: "jane", ticket: "SHOP-42"}}
+
+	got := migrationStatuses(statuses, current, recorded, metadata)
+	assert.Equal(t, []MigrationStatus{
+		{
+			Version:   20250101000000,
+			Name:      "20250101000000_create_users.sql",
+			State:     StateApplied,
+			AppliedAt: &appliedAt,
+			Author:    "jane",
+			Ticket:    "SHOP-42",
+		},
+		{Version: 20250102000000, Name: "20250102000000_create_posts.sql", State: StateDirty, AppliedAt: &appliedAt},
+		{Version: 20250103000000, Name: "20250103000000_add_tags.sql", State: StatePending},
+	}, got)
+
+	data, err := json.Marshal(got[2])
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"version":20250103000000,"name":"20250103000000_add_tags.sql","state":"pending","applied_at":null}`,
+		string(data))
+}
+
+func TestPendingStatuses(t *testing.T) {
+	appliedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	statuses := []MigrationStatus{
+		{Version: 20250101000000, Name: "20250101000000_create_users.sql", State: StateApplied, AppliedAt: &appliedAt},
+		{Version: 20250102000000, Name: "20250102000000_create_posts.sql", State: StateDirty, AppliedAt: &appliedAt},
+		{Version: 20250103000000, Name: "20250103000000_add_tags.sql", State: StatePending},
+	}
+	assert.Equal(t, statuses[2:], PendingStatuses(statuses))
+	assert.Empty(t, PendingStatuses(statuses[:2]))
+}
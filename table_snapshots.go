@@ -0,0 +1,132 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/schema"
+)
+
+// TableDDLSnapshot is the definition of a table captured with WithTableSnapshots before a
+// migration altered, renamed or dropped it.
+type TableDDLSnapshot struct {
+	Version    int64     // Version is the version of the migration that changed the table.
+	Direction  string    // Direction is "up" or "down".
+	Table      string    // Table is the name of the table.
+	DDL        string    // DDL is the CREATE TABLE statement of the table, as returned by GetTableDDL.
+	CapturedAt time.Time // CapturedAt is when the definition was captured.
+}
+
+// tableSnapshots records the definition of the tables changed by Go migrations in a table.
+type tableSnapshots struct {
+	dialect   dialect.Dialect
+	table     string
+	clock     Clock
+	encryptor Encryptor
+}
+
+func (m *Migrate) newTableSnapshots() *tableSnapshots {
+	if m.tableSnapshots == "" {
+		return nil
+	}
+	return &tableSnapshots{dialect: m.dialect, table: m.tableSnapshots, clock: m.clock, encryptor: m.encryptor}
+}
+
+func (s *tableSnapshots) createTableSQL() string {
+	if s.dialect == dialect.MySQL {
+		return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY, "+
+			"version_id BIGINT NOT NULL, direction VARCHAR(4) NOT NULL, table_name VARCHAR(255) NOT NULL, "+
+			"ddl LONGBLOB NOT NULL, captured_at DATETIME NOT NULL)", s.table)
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id BIGSERIAL PRIMARY KEY, "+
+		"version_id BIGINT NOT NULL, direction VARCHAR(4) NOT NULL, table_name VARCHAR(255) NOT NULL, "+
+		"ddl BYTEA NOT NULL, captured_at TIMESTAMP NOT NULL)", s.table)
+}
+
+func (s *tableSnapshots) insertSQL() string {
+	if s.dialect == dialect.MySQL {
+		return fmt.Sprintf("INSERT INTO %s (version_id, direction, table_name, ddl, captured_at) "+
+			"VALUES (?, ?, ?, ?, ?)", s.table)
+	}
+	return fmt.Sprintf("INSERT INTO %s (version_id, direction, table_name, ddl, captured_at) "+
+		"VALUES ($1, $2, $3, $4, $5)", s.table)
+}
+
+func (s *tableSnapshots) selectSQL() string {
+	placeholder := "$1"
+	if s.dialect == dialect.MySQL {
+		placeholder = "?"
+	}
+	return fmt.Sprintf("SELECT direction, table_name, ddl, captured_at FROM %s WHERE version_id = %s ORDER BY id",
+		s.table, placeholder)
+}
+
+// capture records the definition of the table, read through c, before the migration changes it.
+// Tables that do not exist, e.g. dropped with DropIfExists, are skipped.
+func (s *tableSnapshots) capture(
+	ctx context.Context,
+	c schema.Context,
+	conn migrationConn,
+	version int64,
+	direction, table string,
+) error {
+	exists, err := schema.HasTable(c, table)
+	if err != nil || !exists {
+		return err
+	}
+	ddl, err := schema.GetTableDDL(c, table)
+	if err != nil {
+		return fmt.Errorf("failed to capture the definition of table %s: %w", table, err)
+	}
+	data := []byte(ddl)
+	if s.encryptor != nil {
+		if data, err = s.encryptor.Encrypt(ctx, data); err != nil {
+			return fmt.Errorf("failed to encrypt the definition of table %s: %w", table, err)
+		}
+	}
+	if _, err = conn.ExecContext(ctx, s.createTableSQL()); err != nil {
+		return fmt.Errorf("failed to create table snapshots table: %w", err)
+	}
+	if _, err = conn.ExecContext(ctx, s.insertSQL(), version, direction, table, data, s.clock.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record the definition of table %s: %w", table, err)
+	}
+	return nil
+}
+
+// TableDDLSnapshots returns the definitions of the tables captured with WithTableSnapshots before
+// the migration with the given version changed them, in the order they were captured.
+func (m *Migrate) TableDDLSnapshots(ctx context.Context, version int64) ([]TableDDLSnapshot, error) {
+	snapshots := m.newTableSnapshots()
+	if snapshots == nil {
+		return nil, errors.New("table snapshots are not enabled, please call WithTableSnapshots option")
+	}
+	if m.db == nil {
+		return nil, errors.New("database connection is not set, please call WithDB option")
+	}
+
+	rows, err := m.db.QueryContext(ctx, snapshots.selectSQL(), version)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []TableDDLSnapshot
+	for rows.Next() {
+		snapshot := TableDDLSnapshot{Version: version}
+		var data []byte
+		if err = rows.Scan(&snapshot.Direction, &snapshot.Table, &data, &snapshot.CapturedAt); err != nil {
+			return nil, err
+		}
+		if snapshots.encryptor != nil {
+			if data, err = snapshots.encryptor.Decrypt(ctx, data); err != nil {
+				return nil, fmt.Errorf("failed to decrypt the definition of table %s: %w", snapshot.Table, err)
+			}
+		}
+		snapshot.DDL = string(data)
+		result = append(result, snapshot)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,37 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akfaiz/migris/internal/dialect"
+)
+
+func TestTableSnapshotsQueries(t *testing.T) {
+	pg := &tableSnapshots{dialect: dialect.Postgres, table: "table_snapshots"}
+	assert.Contains(t, pg.createTableSQL(), "ddl BYTEA NOT NULL")
+	assert.Equal(t, "INSERT INTO table_snapshots (version_id, direction, table_name, ddl, captured_at) "+
+		"VALUES ($1, $2, $3, $4, $5)", pg.insertSQL())
+	assert.Equal(t, "SELECT direction, table_name, ddl, captured_at FROM table_snapshots WHERE version_id = $1 "+
+		"ORDER BY id", pg.selectSQL())
+
+	mysql := &tableSnapshots{dialect: dialect.MySQL, table: "table_snapshots"}
+	assert.Contains(t, mysql.createTableSQL(), "ddl LONGBLOB NOT NULL")
+	assert.Equal(t, "INSERT INTO table_snapshots (version_id, direction, table_name, ddl, captured_at) "+
+		"VALUES (?, ?, ?, ?, ?)", mysql.insertSQL())
+}
+
+func TestTableDDLSnapshotsRequiresOption(t *testing.T) {
+	m, err := New("pgx")
+	require.NoError(t, err)
+
+	_, err = m.TableDDLSnapshots(t.Context(), 1)
+	require.Error(t, err)
+
+	m, err = New("pgx", WithTableSnapshots("table_snapshots"))
+	require.NoError(t, err)
+	_, err = m.TableDDLSnapshots(t.Context(), 1)
+	require.EqualError(t, err, "database connection is not set, please call WithDB option")
+}
@@ -0,0 +1,113 @@
+package migris
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DefaultProductionHosts are the host patterns of production databases used when
+// WithProductionHosts is not set.
+var DefaultProductionHosts = []string{"*prod*"}
+
+// WithDSN sets the data source name the database connection was opened with. It is only used to
+// show the target host and database before destructive commands and to detect production
+// databases with the patterns of WithProductionHosts; the statements still run on the
+// connection set with WithDB. The password of the DSN is never printed.
+func WithDSN(dsn string) Option {
+	return func(m *Migrate) {
+		m.dsn = dsn
+	}
+}
+
+// WithProductionHosts sets the host patterns of production databases, in the syntax of
+// path.Match, e.g. "*.prod.example.com" or "10.0.1.*". The patterns are matched case-insensitively
+// against the host of the DSN set with WithDSN and replace DefaultProductionHosts.
+func WithProductionHosts(patterns ...string) Option {
+	return func(m *Migrate) {
+		m.productionHosts = patterns
+	}
+}
+
+// WithEnvironmentLabel sets the name of the environment the migrator changes, e.g. "staging".
+// The label is shown in the banner printed before destructive commands, so operators instantly
+// see which environment they are about to modify.
+func WithEnvironmentLabel(label string) Option {
+	return func(m *Migrate) {
+		m.environment = label
+	}
+}
+
+// target is the database a migrator changes, as parsed from its DSN.
+type target struct {
+	host     string // host and port
+	database string
+}
+
+// parseTarget returns the host and database of the DSN in the format of the dialect's driver.
+func parseTarget(d dialect.Dialect, dsn string) (target, error) {
+	if dsn == "" {
+		return target{}, nil
+	}
+	switch d {
+	case dialect.MySQL:
+		cfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return target{}, fmt.Errorf("invalid DSN: %w", err)
+		}
+		return target{host: cfg.Addr, database: cfg.DBName}, nil
+	case dialect.Postgres:
+		cfg, err := pgconn.ParseConfig(dsn)
+		if err != nil {
+			return target{}, fmt.Errorf("invalid DSN: %w", err)
+		}
+		host := cfg.Host
+		if !strings.HasPrefix(host, "/") {
+			host = net.JoinHostPort(host, fmt.Sprint(cfg.Port))
+		}
+		return target{host: host, database: cfg.Database}, nil
+	case dialect.Unknown:
+		return target{}, nil
+	default:
+		return target{}, nil
+	}
+}
+
+// hostname returns the host without the port.
+func (t target) hostname() string {
+	host, _, err := net.SplitHostPort(t.host)
+	if err != nil {
+		return t.host
+	}
+	return host
+}
+
+// isProduction reports whether the host matches one of the patterns.
+func (t target) isProduction(patterns []string) bool {
+	host := strings.ToLower(t.hostname())
+	if host == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// warnTarget prints the banner with the target of a destructive command if the target is a
+// production database or the environment is labeled.
+func (m *Migrate) warnTarget() {
+	production := m.target.isProduction(m.productionHosts)
+	if !production && m.environment == "" {
+		return
+	}
+	logger.TargetBanner(m.environment, m.target.host, m.target.database, production)
+}
@@ -0,0 +1,218 @@
+package migris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TenantMigrator returns the migrator of a tenant, e.g. one whose connection uses the schema of
+// the tenant as its search_path, and a function releasing its resources, e.g. closing the
+// connection pool opened for the tenant. The release function may be nil; it is called once the
+// tenant has been migrated.
+//
+// Some settings of a migrator are process-wide rather than per migrator: the dialect, the options
+// of the schema builder (WithStrict, WithInlineIndexes, WithIdentityColumns, WithRenameLog,
+// WithObjectOwner, WithNamingStrategy) and the logging options (WithQuiet, WithLogger,
+// WithNoColor, WithMessages). Every migrator New creates replaces them, also for the tenants
+// already running, so the migrators of all tenants must be created with the same values of these
+// options; only the connection and the other options may differ between tenants.
+type TenantMigrator func(ctx context.Context, tenant string) (*Migrate, func(), error)
+
+// TenantStatus is the outcome of the run of a tenant.
+type TenantStatus string
+
+const (
+	// TenantPending is a tenant that was not run, e.g. because the run was canceled first.
+	TenantPending TenantStatus = "pending"
+	// TenantSucceeded is a tenant whose migrations were all applied.
+	TenantSucceeded TenantStatus = "succeeded"
+	// TenantFailed is a tenant whose migrator could not be created or whose migrations failed.
+	TenantFailed TenantStatus = "failed"
+)
+
+// TenantResult is the outcome of the run of a single tenant.
+type TenantResult struct {
+	Tenant   string        `json:"tenant"`
+	Status   TenantStatus  `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// TenantReport is the result of a TenantRunner run, with one result per tenant in the order the
+// tenants were given.
+type TenantReport struct {
+	Results []TenantResult `json:"results"`
+}
+
+// Count returns the number of tenants with the given status.
+func (r *TenantReport) Count(status TenantStatus) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Unfinished returns the tenants that failed or were not run, which Resume runs again.
+func (r *TenantReport) Unfinished() []string {
+	var tenants []string
+	for _, result := range r.Results {
+		if result.Status != TenantSucceeded {
+			tenants = append(tenants, result.Tenant)
+		}
+	}
+	return tenants
+}
+
+// Err returns an error listing the tenants that failed or were not run, or nil if every tenant
+// succeeded.
+func (r *TenantReport) Err() error {
+	unfinished := r.Unfinished()
+	if len(unfinished) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d tenants did not finish: %s", len(unfinished), len(r.Results),
+		strings.Join(unfinished, ", "))
+}
+
+// TenantRunner applies the pending migrations of many tenants, e.g. one schema per tenant,
+// running several tenants at once. A failing tenant does not stop the others: every tenant gets
+// a result in the report, and Resume runs only the tenants that did not succeed. The migrators
+// of all tenants must share the process-wide settings, see TenantMigrator.
+//
+// Example:
+//
+//	runner := &migris.TenantRunner{
+//	    Migrator: func(ctx context.Context, tenant string) (*migris.Migrate, func(), error) {
+//	        db, err := sql.Open("pgx", dsn+"&search_path="+tenant)
+//	        if err != nil {
+//	            return nil, nil, err
+//	        }
+//	        migrator, err := migris.New("pgx", migris.WithDB(db), migris.WithQuiet(true))
+//	        return migrator, func() { db.Close() }, err
+//	    },
+//	    Concurrency: 16,
+//	}
+//	report, err := runner.Up(ctx, tenants)
+type TenantRunner struct {
+	// Migrator returns the migrator of a tenant.
+	Migrator TenantMigrator
+	// Concurrency is the number of tenants migrated at once. Defaults to 1.
+	Concurrency int
+	// Progress is called with the result of every tenant once it has run, one call at a time.
+	Progress func(result TenantResult)
+}
+
+// Up applies the pending migrations of the given tenants.
+func (r *TenantRunner) Up(ctx context.Context, tenants []string) (*TenantReport, error) {
+	if r.Migrator == nil {
+		return nil, errors.New("tenant migrator is not set")
+	}
+
+	report := &TenantReport{Results: make([]TenantResult, len(tenants))}
+	for i, tenant := range tenants {
+		report.Results[i] = TenantResult{Tenant: tenant, Status: TenantPending}
+	}
+	r.run(ctx, report.Results, (*Migrate).UpContext)
+	return report, nil
+}
+
+// Resume runs the tenants of a previous report that failed or were not run again, and returns the
+// previous report updated with their new results.
+func (r *TenantRunner) Resume(ctx context.Context, previous *TenantReport) (*TenantReport, error) {
+	if r.Migrator == nil {
+		return nil, errors.New("tenant migrator is not set")
+	}
+	if previous == nil {
+		return nil, errors.New("previous tenant report is nil")
+	}
+
+	report := &TenantReport{Results: make([]TenantResult, len(previous.Results))}
+	copy(report.Results, previous.Results)
+	var unfinished []TenantResult
+	var indexes []int
+	for i, result := range report.Results {
+		if result.Status != TenantSucceeded {
+			unfinished = append(unfinished, TenantResult{Tenant: result.Tenant, Status: TenantPending})
+			indexes = append(indexes, i)
+		}
+	}
+	r.run(ctx, unfinished, (*Migrate).UpContext)
+	for i, result := range unfinished {
+		report.Results[indexes[i]] = result
+	}
+	return report, nil
+}
+
+// run runs op with the migrator of every tenant of results, at most Concurrency at once, and
+// stores their outcome in results. Tenants not started before ctx is canceled stay pending.
+func (r *TenantRunner) run(ctx context.Context, results []TenantResult, op func(*Migrate, context.Context) error) {
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg         sync.WaitGroup
+		createMu   sync.Mutex // New sets process-wide settings, so migrators are created one at a time
+		progressMu sync.Mutex
+	)
+	slots := make(chan struct{}, concurrency)
+	for i := range results {
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(result *TenantResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			start := time.Now()
+			err := r.migrateTenant(ctx, result.Tenant, op, &createMu)
+			result.Duration = time.Since(start)
+			if err != nil {
+				result.Status, result.Error = TenantFailed, err.Error()
+			} else {
+				result.Status = TenantSucceeded
+			}
+			if r.Progress != nil {
+				progressMu.Lock()
+				defer progressMu.Unlock()
+				r.Progress(*result)
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+}
+
+// migrateTenant runs op with the migrator of a tenant.
+func (r *TenantRunner) migrateTenant(
+	ctx context.Context,
+	tenant string,
+	op func(*Migrate, context.Context) error,
+	createMu *sync.Mutex,
+) error {
+	createMu.Lock()
+	migrator, release, err := r.Migrator(ctx, tenant)
+	createMu.Unlock()
+	if release != nil {
+		defer release()
+	}
+	if err != nil {
+		return err
+	}
+	if migrator == nil {
+		return errors.New("tenant migrator returned no migrator")
+	}
+	return op(migrator, ctx)
+}
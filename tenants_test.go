@@ -0,0 +1,109 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantRunnerRun(t *testing.T) {
+	var released atomic.Int32
+	runner := &TenantRunner{
+		Migrator: func(_ context.Context, tenant string) (*Migrate, func(), error) {
+			if tenant == "broken" {
+				return nil, nil, errors.New("cannot connect")
+			}
+			m, err := New("pgx")
+			return m, func() { released.Add(1) }, err
+		},
+		Concurrency: 2,
+	}
+	var mu sync.Mutex
+	var progress []string
+	runner.Progress = func(result TenantResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress = append(progress, result.Tenant)
+	}
+
+	var running, maxRunning atomic.Int32
+	results := []TenantResult{
+		{Tenant: "acme", Status: TenantPending},
+		{Tenant: "broken", Status: TenantPending},
+		{Tenant: "globex", Status: TenantPending},
+		{Tenant: "initech", Status: TenantPending},
+	}
+	runner.run(t.Context(), results, func(_ *Migrate, _ context.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		return nil
+	})
+
+	report := &TenantReport{Results: results}
+	assert.Equal(t, 3, report.Count(TenantSucceeded))
+	assert.Equal(t, TenantFailed, results[1].Status)
+	assert.Equal(t, "cannot connect", results[1].Error)
+	assert.Equal(t, []string{"broken"}, report.Unfinished())
+	require.EqualError(t, report.Err(), "1 of 4 tenants did not finish: broken")
+	assert.ElementsMatch(t, []string{"acme", "broken", "globex", "initech"}, progress)
+	assert.Equal(t, int32(3), released.Load())
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestTenantRunnerCanceled(t *testing.T) {
+	runner := &TenantRunner{
+		Migrator: func(_ context.Context, _ string) (*Migrate, func(), error) {
+			m, err := New("pgx")
+			return m, nil, err
+		},
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	results := []TenantResult{
+		{Tenant: "acme", Status: TenantPending},
+		{Tenant: "globex", Status: TenantPending},
+	}
+	runner.run(ctx, results, func(_ *Migrate, _ context.Context) error {
+		cancel()
+		return nil
+	})
+
+	assert.Equal(t, TenantSucceeded, results[0].Status)
+	assert.Equal(t, TenantPending, results[1].Status)
+}
+
+func TestTenantRunnerResume(t *testing.T) {
+	var migrated []string
+	runner := &TenantRunner{
+		Migrator: func(_ context.Context, tenant string) (*Migrate, func(), error) {
+			migrated = append(migrated, tenant)
+			return nil, nil, errors.New("still broken")
+		},
+	}
+	previous := &TenantReport{Results: []TenantResult{
+		{Tenant: "acme", Status: TenantSucceeded},
+		{Tenant: "broken", Status: TenantFailed, Error: "cannot connect"},
+		{Tenant: "globex", Status: TenantPending},
+	}}
+
+	report, err := runner.Resume(t.Context(), previous)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"broken", "globex"}, migrated)
+	assert.Equal(t, TenantSucceeded, report.Results[0].Status)
+	assert.Equal(t, "still broken", report.Results[1].Error)
+	assert.Equal(t, "still broken", report.Results[2].Error)
+	assert.Equal(t, "cannot connect", previous.Results[1].Error, "the previous report is not changed")
+
+	_, err = (&TenantRunner{}).Up(t.Context(), []string{"acme"})
+	require.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package migris
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/pressly/goose/v3"
+)
+
+// WithStatementTimeout limits how long a statement of a migration may run, so a long-running
+// DDL statement, e.g. an index build waiting for a lock, cannot hang a deployment forever.
+//
+// The Go migrations set the timeout on the database session, with statement_timeout on
+// PostgreSQL, max_execution_time on MySQL and max_statement_time on MariaDB, and additionally
+// cancel statements through their context. Note that MySQL only enforces max_execution_time for
+// SELECT statements. SQL migrations are executed by goose, so the timeout applies to each SQL
+// migration as a whole instead.
+//
+// Use StatementTimeout to override the timeout of a single Go migration.
+func WithStatementTimeout(timeout time.Duration) Option {
+	return func(m *Migrate) {
+		m.statementTimeout = timeout
+	}
+}
+
+// StatementTimeout overrides the timeout set with WithStatementTimeout for a single Go
+// migration, e.g. one that builds a large index. A timeout of 0 disables the limit.
+//
+// Example:
+//
+//	func init() {
+//	    migris.AddMigrationContext(upIndexOrders, downIndexOrders, migris.StatementTimeout(time.Hour))
+//	}
+func StatementTimeout(timeout time.Duration) MigrationOption {
+	return func(m *Migration) {
+		m.timeout = &timeout
+	}
+}
+
+// statementTimeout returns the statement timeout of the migration, falling back to the timeout
+// of the migrator.
+func (m *Migration) statementTimeout(fallback time.Duration) time.Duration {
+	if m.timeout != nil {
+		return *m.timeout
+	}
+	return fallback
+}
+
+// setStatementTimeout sets the statement timeout of the session of the migration. It returns
+// the statement that restores the session default, if the setting outlives the migration.
+func setStatementTimeout(
+	ctx context.Context,
+	conn migrationConn,
+	d dialect.Dialect,
+	timeout time.Duration,
+	inTx bool,
+) (string, error) {
+	millis := timeout.Milliseconds()
+	switch d {
+	case dialect.Postgres:
+		if inTx {
+			_, err := conn.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", millis))
+			return "", err
+		}
+		_, err := conn.ExecContext(ctx, fmt.Sprintf("SET statement_timeout = %d", millis))
+		return "RESET statement_timeout", err
+	case dialect.MySQL:
+		var version string
+		if err := conn.QueryRowContext(ctx, "SELECT VERSION()").Scan(&version); err != nil {
+			return "", err
+		}
+		variable, value := "max_execution_time", strconv.FormatInt(millis, 10)
+		if strings.Contains(strings.ToLower(version), "mariadb") {
+			variable, value = "max_statement_time", fmt.Sprintf("%.3f", timeout.Seconds())
+		}
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("SET SESSION %s = %s", variable, value)); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("SET SESSION %s = DEFAULT", variable), nil
+	case dialect.Unknown:
+		return "", nil
+	default:
+		return "", nil
+	}
+}
+
+// migrationContext bounds the context of a SQL migration by the statement timeout. Goose runs
+// SQL migrations on its own connection, so the timeout cannot be set on the session.
+func (m *Migrate) migrationContext(ctx context.Context, source *goose.Source) (context.Context, context.CancelFunc) {
+	if source.Type != goose.TypeSQL || m.statementTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, m.statementTimeout)
+}
@@ -0,0 +1,47 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationStatementTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []MigrationOption
+		want time.Duration
+	}{
+		{name: "falls back to the migrator", want: time.Minute},
+		{name: "overrides the migrator", opts: []MigrationOption{StatementTimeout(time.Hour)}, want: time.Hour},
+		{name: "disables the limit", opts: []MigrationOption{StatementTimeout(0)}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newMigration("20250101000000_index_orders.go", nil, nil, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m.statementTimeout(time.Minute))
+		})
+	}
+}
+
+func TestMigrationContext(t *testing.T) {
+	m, err := New("pgx", WithStatementTimeout(time.Minute))
+	require.NoError(t, err)
+
+	ctx, cancel := m.migrationContext(context.Background(), &goose.Source{Type: goose.TypeSQL})
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	// Go migrations limit every statement instead.
+	ctx, cancel = m.migrationContext(context.Background(), &goose.Source{Type: goose.TypeGo})
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+}
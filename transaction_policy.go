@@ -0,0 +1,203 @@
+package migris
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/akfaiz/migris/internal/dialect"
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/internal/pathutil"
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+)
+
+// ErrNonTransactionalStatement is returned before running pending migrations that contain a
+// statement PostgreSQL cannot run in a transaction, unless the transaction policy routes them
+// outside of one.
+var ErrNonTransactionalStatement = errors.New("migration contains a statement that cannot run in a transaction")
+
+// TransactionPolicy decides how pending PostgreSQL migrations are checked for statements that
+// cannot run in a transaction, such as CREATE INDEX CONCURRENTLY, ALTER TYPE ... ADD VALUE or
+// VACUUM, before Up runs them. Without the check PostgreSQL rejects such a statement only when
+// its migration runs, after the migrations before it have been applied.
+type TransactionPolicy string
+
+const (
+	// TransactionPolicyNone runs the migrations as they were added, without checking them.
+	TransactionPolicyNone TransactionPolicy = ""
+	// TransactionPolicyStrict fails before any migration runs, naming the migration and the
+	// statement that has to run outside of a transaction.
+	TransactionPolicyStrict TransactionPolicy = "strict"
+	// TransactionPolicyAuto runs the Go migrations containing such statements outside of a
+	// transaction, as if they were added with NoTransaction. SQL migrations cannot be routed and
+	// have to be annotated with -- +goose NO TRANSACTION.
+	TransactionPolicyAuto TransactionPolicy = "auto"
+)
+
+// WithTransactionPolicy sets how pending PostgreSQL migrations are checked for statements that
+// cannot run in a transaction, see TransactionPolicy. The statements of Go migrations are
+// captured like in dry-run mode, so the migrations must not depend on the results of queries to
+// decide which statements they run.
+func WithTransactionPolicy(policy TransactionPolicy) Option {
+	return func(m *Migrate) {
+		m.transactionPolicy = policy
+	}
+}
+
+// nonTransactionalPatterns match the PostgreSQL statements that cannot run in a transaction.
+// ALTER TYPE ... ADD VALUE runs in one from PostgreSQL 12, but the added value cannot be used
+// before the transaction is committed.
+var nonTransactionalPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(CREATE\s+(UNIQUE\s+)?INDEX|DROP\s+INDEX|` +
+		`REINDEX\s+(\(.*?\)\s*)?(INDEX|TABLE|SCHEMA|DATABASE|SYSTEM))\s+CONCURRENTLY\b`),
+	regexp.MustCompile(`(?i)^ALTER\s+TYPE\s+\S+\s+ADD\s+VALUE\b`),
+	regexp.MustCompile(`(?i)^VACUUM\b`),
+	regexp.MustCompile(`(?i)^(CREATE|DROP)\s+(DATABASE|TABLESPACE)\b`),
+	regexp.MustCompile(`(?i)^ALTER\s+SYSTEM\b`),
+}
+
+var (
+	noTransactionAnnotation = regexp.MustCompile(`(?m)^\s*--\s*\+goose\s+NO\s+TRANSACTION\b`)
+	downAnnotation          = regexp.MustCompile(`(?m)^\s*--\s*\+goose\s+Down\b`)
+	sqlComment              = regexp.MustCompile(`(?m)--.*$`)
+)
+
+// isNonTransactional reports whether PostgreSQL cannot run the statement in a transaction.
+func isNonTransactional(statement string) bool {
+	statement = strings.TrimSpace(statement)
+	for _, pattern := range nonTransactionalPatterns {
+		if pattern.MatchString(statement) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTransactionPolicy checks the pending migrations for statements that cannot run in a
+// transaction. It returns the provider to run the migrations with, which is rebuilt if the
+// policy routed migrations outside of a transaction.
+func (m *Migrate) checkTransactionPolicy(
+	ctx context.Context,
+	provider *goose.Provider,
+	pending []*goose.Source,
+) (*goose.Provider, error) {
+	if m.transactionPolicy == TransactionPolicyNone || m.dialect != dialect.Postgres {
+		return provider, nil
+	}
+	if err := checkSQLTransactions(m.migrationsFS(), pending); err != nil {
+		return nil, err
+	}
+
+	routed, err := m.routeTransactions(ctx, registeredPending(pending))
+	if err != nil || len(routed) == 0 {
+		return provider, err
+	}
+	m.noTransaction = routed
+	defer func() { m.noTransaction = nil }()
+	return m.newProvider()
+}
+
+// routeTransactions checks the Go migrations for statements that cannot run in a transaction and
+// returns the versions of the migrations to run outside of one.
+func (m *Migrate) routeTransactions(ctx context.Context, migrations []*Migration) (map[int64]bool, error) {
+	routed := make(map[int64]bool)
+	for _, migration := range migrations {
+		if migration.noTransaction {
+			continue
+		}
+		statement, err := migration.nonTransactionalStatement(ctx, m.dependencies)
+		if err != nil {
+			return nil, err
+		}
+		if statement == "" {
+			continue
+		}
+		name := pathutil.Base(migration.source)
+		if m.transactionPolicy != TransactionPolicyAuto {
+			return nil, fmt.Errorf("%w: %s: %s; add the migration with migris.NoTransaction()",
+				ErrNonTransactionalStatement, name, statement)
+		}
+		logger.WarnMsg(logger.MessageNoTransactionRouted, name, statement)
+		routed[migration.version] = true
+	}
+	return routed, nil
+}
+
+// nonTransactionalStatement returns the first statement of the up migration that cannot run in a
+// transaction, or an empty string if there is none.
+func (m *Migration) nonTransactionalStatement(ctx context.Context, deps *Dependencies) (string, error) {
+	fn := m.upFunc(deps)
+	if fn == nil {
+		return "", nil
+	}
+	dryRunCtx := schema.NewDryRunContext(ctx)
+	if err := fn(dryRunCtx); err != nil {
+		return "", fmt.Errorf("failed to check migration %s: %w", m.source, err)
+	}
+	for _, statement := range dryRunCtx.GetCapturedSQL() {
+		if isNonTransactional(statement) {
+			return statement, nil
+		}
+	}
+	return "", nil
+}
+
+// checkSQLTransactions checks the up sections of the pending SQL migrations that are not
+// annotated with -- +goose NO TRANSACTION for statements that cannot run in a transaction.
+func checkSQLTransactions(fsys fs.FS, pending []*goose.Source) error {
+	for _, source := range pending {
+		if source.Type != goose.TypeSQL {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, source.Path)
+		if err != nil {
+			return err
+		}
+		if noTransactionAnnotation.Match(content) {
+			continue
+		}
+		for _, statement := range upStatements(content) {
+			if isNonTransactional(statement) {
+				return fmt.Errorf("%w: %s: %s; annotate the migration with -- +goose NO TRANSACTION",
+					ErrNonTransactionalStatement, pathutil.Base(source.Path), statement)
+			}
+		}
+	}
+	return nil
+}
+
+// upStatements returns the statements of the up section of a SQL migration. The statements are
+// split at every semicolon, so statements containing one, e.g. function bodies, are split too.
+func upStatements(content []byte) []string {
+	if loc := downAnnotation.FindIndex(content); loc != nil {
+		content = content[:loc[0]]
+	}
+	return splitStatements(content)
+}
+
+// downStatements returns the statements of the down section of a SQL migration, split like
+// upStatements.
+func downStatements(content []byte) []string {
+	loc := downAnnotation.FindIndex(content)
+	if loc == nil {
+		return nil
+	}
+	return splitStatements(content[loc[1]:])
+}
+
+// splitStatements splits the SQL at every semicolon and returns the trimmed, non-empty
+// statements without comments.
+func splitStatements(content []byte) []string {
+	var statements []string
+	for _, statement := range bytes.Split(sqlComment.ReplaceAll(content, nil), []byte(";")) {
+		if trimmed := strings.TrimSpace(string(statement)); trimmed != "" {
+			statements = append(statements, trimmed)
+		}
+	}
+	return statements
+}
@@ -0,0 +1,108 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/akfaiz/migris/schema"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNonTransactional(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{statement: "CREATE INDEX CONCURRENTLY idx_orders_customer_id ON orders (customer_id)", want: true},
+		{statement: "create unique index concurrently idx_users_email on users (email)", want: true},
+		{statement: "DROP INDEX CONCURRENTLY IF EXISTS idx_orders_customer_id", want: true},
+		{statement: "REINDEX (VERBOSE) TABLE CONCURRENTLY orders", want: true},
+		{statement: "ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'shipped'", want: true},
+		{statement: "  VACUUM ANALYZE orders", want: true},
+		{statement: "CREATE DATABASE reports", want: true},
+		{statement: "ALTER SYSTEM SET work_mem = '64MB'", want: true},
+		{statement: "CREATE INDEX idx_orders_concurrently ON orders (concurrently)"},
+		{statement: "ALTER TYPE order_status RENAME VALUE 'paid' TO 'settled'"},
+		{statement: "REFRESH MATERIALIZED VIEW CONCURRENTLY order_totals"},
+		{statement: "ALTER TABLE orders ADD COLUMN note TEXT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			assert.Equal(t, tt.want, isNonTransactional(tt.statement))
+		})
+	}
+}
+
+func TestMigrate_RouteTransactions(t *testing.T) {
+	indexOrders := func(c schema.Context) error {
+		return schema.Table(c, "orders", func(table *schema.Blueprint) {
+			table.Index("customer_id").Concurrently()
+		})
+	}
+	addNote := func(c schema.Context) error {
+		return schema.Table(c, "orders", func(table *schema.Blueprint) {
+			table.Text("note").Nullable()
+		})
+	}
+	concurrent, err := newMigration("20250101000000_index_orders.go", indexOrders, nil)
+	require.NoError(t, err)
+	declared, err := newMigration("20250102000000_index_orders_again.go", indexOrders, nil, NoTransaction())
+	require.NoError(t, err)
+	transactional, err := newMigration("20250103000000_add_note.go", addNote, nil)
+	require.NoError(t, err)
+	migrations := []*Migration{concurrent, declared, transactional}
+
+	t.Run("strict fails", func(t *testing.T) {
+		m, err := New("pgx", WithTransactionPolicy(TransactionPolicyStrict))
+		require.NoError(t, err)
+		_, err = m.routeTransactions(t.Context(), migrations)
+		require.ErrorIs(t, err, ErrNonTransactionalStatement)
+		assert.ErrorContains(t, err, "20250101000000_index_orders.go: CREATE INDEX CONCURRENTLY")
+	})
+
+	t.Run("auto routes the migration outside of a transaction", func(t *testing.T) {
+		m, err := New("pgx", WithTransactionPolicy(TransactionPolicyAuto))
+		require.NoError(t, err)
+		routed, err := m.routeTransactions(t.Context(), migrations)
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]bool{20250101000000: true}, routed)
+
+		fn := concurrent.goFunc(nil, directionUp, &migrationRun{noTransaction: routed})
+		assert.Equal(t, goose.TransactionDisabled, fn.Mode)
+		fn = transactional.goFunc(nil, directionUp, &migrationRun{noTransaction: routed})
+		assert.Equal(t, goose.TransactionEnabled, fn.Mode)
+	})
+}
+
+func TestCheckSQLTransactions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"20250101000000_index_orders.sql": {Data: []byte(
+			"-- +goose Up\n-- Build the index without blocking writes\n" +
+				"CREATE INDEX CONCURRENTLY idx_orders_customer_id ON orders (customer_id);\n" +
+				"-- +goose Down\nDROP INDEX idx_orders_customer_id;\n")},
+		"20250102000000_index_users.sql": {Data: []byte(
+			"-- +goose NO TRANSACTION\n-- +goose Up\n" +
+				"CREATE INDEX CONCURRENTLY idx_users_email ON users (email);\n")},
+		"20250103000000_drop_index.sql": {Data: []byte(
+			"-- +goose Up\nCREATE TABLE tags (id BIGINT);\n" +
+				"-- +goose Down\nDROP INDEX CONCURRENTLY idx_tags_id;\n")},
+	}
+	source := func(path string, version int64) *goose.Source {
+		return &goose.Source{Type: goose.TypeSQL, Path: path, Version: version}
+	}
+	sources := []*goose.Source{
+		source("20250101000000_index_orders.sql", 20250101000000),
+		source("20250102000000_index_users.sql", 20250102000000),
+		source("20250103000000_drop_index.sql", 20250103000000),
+	}
+
+	err := checkSQLTransactions(fsys, sources)
+	require.ErrorIs(t, err, ErrNonTransactionalStatement)
+	assert.ErrorContains(t, err,
+		"20250101000000_index_orders.sql: CREATE INDEX CONCURRENTLY idx_orders_customer_id ON orders (customer_id)")
+
+	require.NoError(t, checkSQLTransactions(fsys, sources[1:]),
+		"annotated migrations and down sections are not checked")
+}
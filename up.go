@@ -4,10 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
-	"time"
 
 	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/internal/pathutil"
 	"github.com/akfaiz/migris/schema"
 	"github.com/pressly/goose/v3"
 )
@@ -48,12 +47,25 @@ func (m *Migrate) UpToContext(ctx context.Context, version int64) error {
 		return err
 	}
 	if !hasPending {
-		logger.Info("Nothing to migrate.")
+		logger.InfoMsg(logger.MessageNothingToMigrate)
 		return nil
 	}
+	pending, err := m.pendingSources(ctx, provider, version)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		// The target version is already applied, or the class policy skips the pending migrations.
+		logger.InfoMsg(logger.MessageNothingToMigrate)
+		return nil
+	}
+	provider, err = m.checkTransactionPolicy(ctx, provider, pending)
+	if err != nil {
+		return err
+	}
 
-	logger.Infof("Running migrations.\n")
-	results, err := provider.UpTo(ctx, version)
+	logger.InfoMsg(logger.MessageRunningMigrations)
+	results, err := m.upTo(ctx, provider, pending)
 	if err != nil {
 		var partialErr *goose.PartialError
 		if errors.As(err, &partialErr) {
@@ -65,7 +77,7 @@ func (m *Migrate) UpToContext(ctx context.Context, version int64) error {
 	}
 	logger.PrintResults(results)
 
-	return nil
+	return m.runPostMigrationHooks(ctx)
 }
 
 // executeDryRunUp executes migrations in dry-run mode.
@@ -82,20 +94,18 @@ func (m *Migrate) executeDryRunUp(ctx context.Context, version int64) error {
 		return fmt.Errorf("cannot check pending migrations: %w", err)
 	}
 	if !hasPending {
-		logger.Info("Nothing to migrate.")
+		logger.InfoMsg(logger.MessageNothingToMigrate)
 		return nil
 	}
 
 	logger.DryRunStart(version)
 
-	// Get current database version
-	currentVersion, err := provider.GetDBVersion(ctx)
+	// Get migrations to apply
+	pending, err := m.pendingSources(ctx, provider, version)
 	if err != nil {
-		return fmt.Errorf("cannot get current database version: %w", err)
+		return fmt.Errorf("cannot get pending migrations: %w", err)
 	}
-
-	// Get migrations to apply
-	migrationsToApply := m.determineMigrationsToApply(version, currentVersion)
+	migrationsToApply := registeredPending(pending)
 
 	// Process migrations in dry-run mode
 	totalMigrations, totalStatements, duration, err := m.processDryRunUpMigrations(ctx, migrationsToApply)
@@ -136,15 +146,15 @@ func (m *Migrate) processDryRunMigrations(
 	migrations []*Migration,
 	isUp bool,
 ) (int, int, float64, error) {
-	startTime := time.Now()
+	startTime := m.clock.Now()
 	totalStatements := 0
 	totalMigrations := 0
 
 	for _, migration := range migrations {
-		migrationStartTime := time.Now()
+		migrationStartTime := m.clock.Now()
 		totalMigrations++
 
-		logger.DryRunMigrationStart(filepath.Base(migration.source), migration.version)
+		logger.DryRunMigrationStart(pathutil.Base(migration.source), migration.version)
 
 		// Create dry-run context for this migration
 		dryRunCtx := schema.NewDryRunContext(ctx)
@@ -153,10 +163,10 @@ func (m *Migrate) processDryRunMigrations(
 		var migrationFunc MigrationContext
 		var direction string
 		if isUp {
-			migrationFunc = migration.upFnContext
+			migrationFunc = migration.upFunc(m.dependencies)
 			direction = "up"
 		} else {
-			migrationFunc = migration.downFnContext
+			migrationFunc = migration.downFunc(m.dependencies)
 			direction = "down"
 		}
 
@@ -173,16 +183,16 @@ func (m *Migrate) processDryRunMigrations(
 			if dryRunCtx.HasPendingQuery() {
 				queries := dryRunCtx.GetPendingQueries()
 				for _, q := range queries {
-					logger.DryRunSQL(q.Query, q.Args...)
+					logger.DryRunSQL(m.formatSQL(q.Query), q.Args...)
 				}
 			}
 		}
 
-		migrationDuration := time.Since(migrationStartTime).Seconds() * 1000
-		logger.DryRunMigrationComplete(filepath.Base(migration.source), migrationDuration)
+		migrationDuration := m.clock.Now().Sub(migrationStartTime).Seconds() * 1000
+		logger.DryRunMigrationComplete(pathutil.Base(migration.source), migrationDuration)
 	}
 
-	duration := time.Since(startTime).Seconds() * 1000
+	duration := m.clock.Now().Sub(startTime).Seconds() * 1000
 	return totalMigrations, totalStatements, duration, nil
 }
 
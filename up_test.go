@@ -0,0 +1,127 @@
+package migris //nolint:testpackage // Need to access unexported members for testing
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/akfaiz/migris/internal/logger"
+	"github.com/akfaiz/migris/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionDB is a database/sql driver answering the reads of the postgres version table with the
+// applied versions. Any other statement fails, so running a migration against it is an error.
+type versionDB struct {
+	applied []int64
+}
+
+func (d *versionDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *versionDB) Driver() driver.Driver                        { return d }
+func (d *versionDB) Open(string) (driver.Conn, error)             { return d, nil }
+func (d *versionDB) Close() error                                 { return nil }
+
+func (d *versionDB) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("unexpected statement %q", query)
+}
+
+func (d *versionDB) Begin() (driver.Tx, error) {
+	return nil, errors.New("unexpected transaction")
+}
+
+func (d *versionDB) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	return nil, fmt.Errorf("unexpected statement %q", query)
+}
+
+func (d *versionDB) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	switch {
+	case strings.Contains(query, "FROM pg_tables"):
+		return &versionRows{columns: []string{"exists"}, values: [][]driver.Value{{true}}}, nil
+	case strings.Contains(query, "FROM information_schema.columns"):
+		return &versionRows{columns: []string{"count"}, values: [][]driver.Value{{int64(1)}}}, nil
+	case strings.HasPrefix(query, "SELECT version_id, is_applied"):
+		rows := &versionRows{columns: []string{"version_id", "is_applied"}}
+		for _, version := range slices.Backward(append([]int64{0}, d.applied...)) {
+			rows.values = append(rows.values, []driver.Value{version, true})
+		}
+		return rows, nil
+	case strings.HasPrefix(query, "SELECT max(version_id)"):
+		return &versionRows{columns: []string{"max"}, values: [][]driver.Value{{slices.Max(d.applied)}}}, nil
+	case strings.HasPrefix(query, "SELECT tstamp, is_applied"):
+		rows := &versionRows{columns: []string{"tstamp", "is_applied"}}
+		if slices.Contains(d.applied, args[0].Value.(int64)) {
+			rows.values = append(rows.values, []driver.Value{time.Now(), true})
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("unexpected query %q", query)
+}
+
+type versionRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *versionRows) Columns() []string { return r.columns }
+func (r *versionRows) Close() error      { return nil }
+
+func (r *versionRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// newVersionMigrate returns a postgres Migrate with three registered Go migrations, whose
+// versions up to applied are recorded in the version table. Running a migration fails the test.
+// The returned buffer holds the log output.
+func newVersionMigrate(t *testing.T, applied int64) (*Migrate, *bytes.Buffer) {
+	t.Helper()
+	run := func(schema.Context) error {
+		t.Errorf("migration must not run")
+		return nil
+	}
+	var migrations []*Migration
+	var versions []int64
+	for version := int64(1); version <= 3; version++ {
+		migration, err := newMigration(fmt.Sprintf("%d_step.go", version), run, run)
+		require.NoError(t, err)
+		migrations = append(migrations, migration)
+		if version <= applied {
+			versions = append(versions, version)
+		}
+	}
+	withRegisteredMigrations(t, migrations...)
+
+	db := sql.OpenDB(&versionDB{applied: versions})
+	t.Cleanup(func() { db.Close() })
+	var buf bytes.Buffer
+	m, err := New("postgres", WithDB(db), WithFS(fstest.MapFS{}),
+		WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	require.NoError(t, err)
+	t.Cleanup(func() { logger.SetLogger(nil) })
+	return m, &buf
+}
+
+func TestMigrate_UpToReachedTarget(t *testing.T) {
+	for _, target := range []int64{1, 2} {
+		t.Run(fmt.Sprintf("target %d", target), func(t *testing.T) {
+			m, buf := newVersionMigrate(t, 2)
+			require.NoError(t, m.UpToContext(t.Context(), target))
+			assert.Contains(t, buf.String(), `msg="Nothing to migrate."`)
+		})
+	}
+}